@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// anchorTotals is a player's accumulated site holds on one map.
+type anchorTotals struct {
+	anchorRounds   int
+	survived       int
+	multiKills     int
+	siteContacts   int
+	retakeDelaySum float64
+}
+
+// AnchorEntry is a detected anchor's row in a map's anchoring leaderboard.
+type AnchorEntry struct {
+	Map     string
+	Rank    int
+	SteamID string
+	Name    string
+	Games   int
+	Rounds  int
+	// AnchorScore is the average of the player's hold survival, multi-kill
+	// and retake delay numbers, each over the map's average among anchors
+	// (1 = average).
+	AnchorScore float64
+
+	AnchorRounds       int     // CT rounds spent mostly on a bomb site
+	HoldsSurvived      int     // Anchor rounds survived
+	HoldSurvivalPct    float64 // Share of anchor rounds survived
+	AnchorMultiKills   int     // Anchor rounds with 2+ kills
+	AnchorMultiKillPct float64 // Share of anchor rounds with 2+ kills
+	SiteContacts       int     // Anchor rounds where a T reached the player's site before a plant
+	AvgRetakeDelay     float64 // Seconds from first contact to the plant, or the round being decided without one
+	CTBombZonePct      float64 // Share of CT time spent in a bomb site, as in role detection
+	Rating             float64
+}
+
+// AnchorLeaderboard accumulates players' site holds per map and ranks the
+// players detected as anchors (see RoleDetector) on each one.
+type AnchorLeaderboard struct {
+	roles  *RoleDetector
+	totals map[roleKey]*anchorTotals
+}
+
+// NewAnchorLeaderboard creates an empty anchoring leaderboard.
+func NewAnchorLeaderboard() *AnchorLeaderboard {
+	return &AnchorLeaderboard{
+		roles:  NewRoleDetector(),
+		totals: make(map[roleKey]*anchorTotals),
+	}
+}
+
+// AddGame incorporates a parsed game on mapName.
+func (l *AnchorLeaderboard) AddGame(mapName string, players map[uint64]*model.PlayerStats) {
+	l.roles.AddGame(mapName, players)
+	for _, p := range players {
+		if p.RoundsPlayed == 0 {
+			continue
+		}
+		key := roleKey{steamID: p.SteamID, mapName: mapName}
+		t := l.totals[key]
+		if t == nil {
+			t = &anchorTotals{}
+			l.totals[key] = t
+		}
+		t.anchorRounds += p.AnchorRounds
+		t.survived += p.AnchorRoundsSurvived
+		t.multiKills += p.AnchorMultiKills
+		t.siteContacts += p.AnchorSiteContacts
+		t.retakeDelaySum += p.AnchorRetakeDelay
+	}
+}
+
+// Results ranks the anchors on every map, ordered by map then rank. Only
+// players detected as the anchor on a map with at least one anchor round
+// there are listed.
+func (l *AnchorLeaderboard) Results() []AnchorEntry {
+	var entries []AnchorEntry
+	for _, r := range l.roles.Results() {
+		t := l.totals[roleKey{steamID: r.SteamID, mapName: r.Map}]
+		if r.Role != RoleAnchor || t == nil || t.anchorRounds == 0 {
+			continue
+		}
+		e := AnchorEntry{
+			Map:              r.Map,
+			SteamID:          r.SteamID,
+			Name:             r.Name,
+			Games:            r.Games,
+			Rounds:           r.Rounds,
+			AnchorRounds:     t.anchorRounds,
+			HoldsSurvived:    t.survived,
+			AnchorMultiKills: t.multiKills,
+			SiteContacts:     t.siteContacts,
+			CTBombZonePct:    r.CTBombZonePct,
+			Rating:           r.Rating,
+		}
+		e.HoldSurvivalPct = float64(t.survived) / float64(t.anchorRounds)
+		e.AnchorMultiKillPct = float64(t.multiKills) / float64(t.anchorRounds)
+		if t.siteContacts > 0 {
+			e.AvgRetakeDelay = t.retakeDelaySum / float64(t.siteContacts)
+		}
+		entries = append(entries, e)
+	}
+
+	byMap := make(map[string][]*AnchorEntry)
+	for i := range entries {
+		byMap[entries[i].Map] = append(byMap[entries[i].Map], &entries[i])
+	}
+	for _, group := range byMap {
+		setAnchorScores(group)
+	}
+
+	output.RankWithinGroups(entries,
+		func(e *AnchorEntry) (string, float64, string) { return e.Map, e.AnchorScore, e.SteamID },
+		func(e *AnchorEntry) *int { return &e.Rank })
+	return entries
+}
+
+// setAnchorScores scores one map's anchors on hold survival, multi-kills
+// and retake delay, each over the map's average among them. A metric every
+// anchor on the map has at zero is left out.
+func setAnchorScores(group []*AnchorEntry) {
+	metrics := []func(*AnchorEntry) float64{
+		func(e *AnchorEntry) float64 { return e.HoldSurvivalPct },
+		func(e *AnchorEntry) float64 { return e.AnchorMultiKillPct },
+		func(e *AnchorEntry) float64 { return e.AvgRetakeDelay },
+	}
+	used := 0
+	for _, metric := range metrics {
+		var sum float64
+		for _, e := range group {
+			sum += metric(e)
+		}
+		if sum == 0 {
+			continue
+		}
+		used++
+		avg := sum / float64(len(group))
+		for _, e := range group {
+			e.AnchorScore += metric(e) / avg
+		}
+	}
+	if used == 0 {
+		return
+	}
+	for _, e := range group {
+		e.AnchorScore /= float64(used)
+	}
+}
@@ -0,0 +1,180 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// MoneyBands are the lower bounds of the start-of-round money bands AWP
+// frequency is reported in. Like ArmorSkipMinMoney they are starting
+// estimates around CS2 prices ($4750 buys an AWP), to be revisited once
+// profiles are checked against how teams describe their economy.
+var MoneyBands = []int{0, 2000, 4750, 6000}
+
+const (
+	// ArmorSkipMinMoney is the start-of-round money at or above which a
+	// player without armor at the end of freeze time counts as skipping it.
+	ArmorSkipMinMoney = 1000
+	// awpWeapon is the AWP's equipment name.
+	awpWeapon = "AWP"
+	// noWeapon names a loadout with no gun at all.
+	noWeapon = "None"
+)
+
+// BuyProfile is a player's buy tendencies, from their loadout at the end of
+// each round's freeze time.
+type BuyProfile struct {
+	SteamID string
+	Name    string
+	Rounds  int // Non-pistol rounds with a loadout snapshot
+
+	// Per money band (see MoneyBands): rounds starting in the band and the
+	// share of them the player held an AWP.
+	BandRounds []int
+	BandAWPPct []float64
+
+	ArmorRounds  int     // Rounds starting with at least ArmorSkipMinMoney
+	ArmorSkipPct float64 // Share of ArmorRounds without armor
+
+	ForceRounds  int           // Rounds the player's team force-bought
+	ForceWeapons []WeaponShare // Guns held on force buys, most common first
+}
+
+// WeaponShare is how often a gun was held among a set of rounds.
+type WeaponShare struct {
+	Weapon string
+	Rounds int
+	Pct    float64
+}
+
+// buyTotals is a player's accumulated loadout counts.
+type buyTotals struct {
+	name         string
+	rounds       int
+	bandRounds   []int
+	bandAWP      []int
+	armorRounds  int
+	armorSkips   int
+	forceRounds  int
+	forceWeapons map[string]int
+}
+
+// BuyTendencies accumulates players' loadouts into buy tendency profiles.
+type BuyTendencies struct {
+	totals map[string]*buyTotals
+}
+
+// NewBuyTendencies creates an empty buy tendency accumulator.
+func NewBuyTendencies() *BuyTendencies {
+	return &BuyTendencies{totals: make(map[string]*buyTotals)}
+}
+
+// AddGame incorporates a parsed game. Pistol rounds are skipped, since
+// every loadout in them is forced by the starting money.
+func (b *BuyTendencies) AddGame(players map[uint64]*model.PlayerStats) {
+	for _, p := range players {
+		t := b.totals[p.SteamID]
+		if t == nil {
+			t = &buyTotals{
+				bandRounds:   make([]int, len(MoneyBands)),
+				bandAWP:      make([]int, len(MoneyBands)),
+				forceWeapons: make(map[string]int),
+			}
+			b.totals[p.SteamID] = t
+		}
+		t.name = p.Name
+		for _, rb := range p.RoundBreakdowns {
+			if rb.IsPistolRound || rb.BuyType == "" {
+				continue
+			}
+			t.rounds++
+			band := moneyBand(rb.StartMoney)
+			t.bandRounds[band]++
+			if rb.PrimaryWeapon == awpWeapon {
+				t.bandAWP[band]++
+			}
+			if rb.StartMoney >= ArmorSkipMinMoney {
+				t.armorRounds++
+				if rb.Armor == 0 {
+					t.armorSkips++
+				}
+			}
+			if rb.BuyType == "force" {
+				t.forceRounds++
+				weapon := rb.PrimaryWeapon
+				if weapon == "" {
+					weapon = noWeapon
+				}
+				t.forceWeapons[weapon]++
+			}
+		}
+	}
+}
+
+// moneyBand returns the index of the MoneyBands band money falls in.
+func moneyBand(money int) int {
+	band := 0
+	for i, lower := range MoneyBands {
+		if money >= lower {
+			band = i
+		}
+	}
+	return band
+}
+
+// Results returns every player's profile, ordered by Steam ID. Players with
+// no non-pistol rounds are left out.
+func (b *BuyTendencies) Results() []BuyProfile {
+	results := make([]BuyProfile, 0, len(b.totals))
+	for steamID, t := range b.totals {
+		if t.rounds == 0 {
+			continue
+		}
+		profile := BuyProfile{
+			SteamID:     steamID,
+			Name:        t.name,
+			Rounds:      t.rounds,
+			BandRounds:  t.bandRounds,
+			BandAWPPct:  make([]float64, len(MoneyBands)),
+			ArmorRounds: t.armorRounds,
+			ForceRounds: t.forceRounds,
+		}
+		for i, n := range t.bandRounds {
+			if n > 0 {
+				profile.BandAWPPct[i] = float64(t.bandAWP[i]) / float64(n)
+			}
+		}
+		if t.armorRounds > 0 {
+			profile.ArmorSkipPct = float64(t.armorSkips) / float64(t.armorRounds)
+		}
+		for weapon, n := range t.forceWeapons {
+			profile.ForceWeapons = append(profile.ForceWeapons, WeaponShare{
+				Weapon: weapon,
+				Rounds: n,
+				Pct:    float64(n) / float64(t.forceRounds),
+			})
+		}
+		sort.Slice(profile.ForceWeapons, func(i, j int) bool {
+			a, c := profile.ForceWeapons[i], profile.ForceWeapons[j]
+			if a.Rounds != c.Rounds {
+				return a.Rounds > c.Rounds
+			}
+			return a.Weapon < c.Weapon
+		})
+		results = append(results, profile)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].SteamID < results[j].SteamID
+	})
+	return results
+}
+
+// MoneyBandLabel names MoneyBands band i, e.g. "$2000-4749" or "$6000+".
+func MoneyBandLabel(i int) string {
+	if i == len(MoneyBands)-1 {
+		return fmt.Sprintf("$%d+", MoneyBands[i])
+	}
+	return fmt.Sprintf("$%d-%d", MoneyBands[i], MoneyBands[i+1]-1)
+}
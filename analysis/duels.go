@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// duelKey identifies a killer and victim pair by Steam ID.
+type duelKey struct {
+	killer string
+	victim string
+}
+
+// Duel is a player's head-to-head record against one opponent.
+type Duel struct {
+	SteamID      string `json:"steam_id"`
+	Name         string `json:"name"`
+	OpponentID   string `json:"opponent_id"`
+	OpponentName string `json:"opponent_name"`
+	Kills        int    `json:"kills"`  // Times the player killed the opponent
+	Deaths       int    `json:"deaths"` // Times the opponent killed the player
+}
+
+// MatchDuels is the duel matrix of one match.
+type MatchDuels struct {
+	MatchID string `json:"match_id"`
+	Map     string `json:"map"`
+	Duels   []Duel `json:"duels"`
+}
+
+// DuelMatrix accumulates who killed whom, per match and across the season.
+type DuelMatrix struct {
+	names   map[string]string
+	season  map[duelKey]int
+	matches []MatchDuels
+}
+
+// NewDuelMatrix creates an empty duel matrix.
+func NewDuelMatrix() *DuelMatrix {
+	return &DuelMatrix{
+		names:  make(map[string]string),
+		season: make(map[duelKey]int),
+	}
+}
+
+// AddGame incorporates a parsed game.
+func (m *DuelMatrix) AddGame(matchID, mapName string, players map[uint64]*model.PlayerStats) {
+	kills := make(map[duelKey]int)
+	names := make(map[string]string, len(players))
+	for id, p := range players {
+		names[strconv.FormatUint(id, 10)] = p.Name
+	}
+	for id, p := range players {
+		killer := strconv.FormatUint(id, 10)
+		for victimID, n := range p.KillsByVictim {
+			kills[duelKey{killer: killer, victim: strconv.FormatUint(victimID, 10)}] += n
+		}
+	}
+	for key, n := range kills {
+		m.season[key] += n
+	}
+	for id, name := range names {
+		m.names[id] = name
+	}
+	m.matches = append(m.matches, MatchDuels{
+		MatchID: matchID,
+		Map:     mapName,
+		Duels:   duels(kills, names),
+	})
+}
+
+// Season returns every pair's record across all games, ordered by Steam ID
+// then opponent Steam ID.
+func (m *DuelMatrix) Season() []Duel {
+	return duels(m.season, m.names)
+}
+
+// Matches returns each game's duel matrix, in the order the games were added.
+func (m *DuelMatrix) Matches() []MatchDuels {
+	return m.matches
+}
+
+// duels turns killer-victim counts into records from both players' sides,
+// so every player who met an opponent has a row against them.
+func duels(kills map[duelKey]int, names map[string]string) []Duel {
+	pairs := make(map[duelKey]bool)
+	for key := range kills {
+		pairs[key] = true
+		pairs[duelKey{killer: key.victim, victim: key.killer}] = true
+	}
+	result := make([]Duel, 0, len(pairs))
+	for key := range pairs {
+		result = append(result, Duel{
+			SteamID:      key.killer,
+			Name:         names[key.killer],
+			OpponentID:   key.victim,
+			OpponentName: names[key.victim],
+			Kills:        kills[key],
+			Deaths:       kills[duelKey{killer: key.victim, victim: key.killer}],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SteamID != result[j].SteamID {
+			return result[i].SteamID < result[j].SteamID
+		}
+		return result[i].OpponentID < result[j].OpponentID
+	})
+	return result
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// duoKey identifies a player and a teammate by Steam ID.
+type duoKey struct {
+	player  string
+	partner string
+}
+
+// duoTotals is a player's accumulated numbers alongside one teammate.
+type duoTotals struct {
+	rounds          int
+	openingDeaths   int // The player's opening deaths with the partner on the team
+	tradedByPartner int // Of those, traded by the partner
+	entryRounds     int // Rounds both had entry contact
+	entryWins       int
+}
+
+// EntryDuo is a player's entry synergy with one teammate.
+type EntryDuo struct {
+	SteamID     string
+	Name        string
+	PartnerID   string
+	PartnerName string
+	Rounds      int // Rounds played on the same team
+
+	OpeningDeaths   int     // The player's opening deaths in those rounds
+	TradedByPartner int     // Opening deaths the partner traded
+	DuoTradeRate    float64 // TradedByPartner over OpeningDeaths
+
+	// Rounds both took part in the round's first kills, and the share of
+	// them their team won.
+	DuoEntryRounds  int
+	DuoEntryWinRate float64
+}
+
+// EntryDuos accumulates pair-level entry synergy between teammates: who
+// trades a player's opening deaths, and how rounds go when both take the
+// first fights.
+type EntryDuos struct {
+	names  map[string]string
+	totals map[duoKey]*duoTotals
+}
+
+// NewEntryDuos creates an empty entry duo accumulator.
+func NewEntryDuos() *EntryDuos {
+	return &EntryDuos{
+		names:  make(map[string]string),
+		totals: make(map[duoKey]*duoTotals),
+	}
+}
+
+// AddGame incorporates a parsed game. Teammates are players on the same
+// side in the same round.
+func (e *EntryDuos) AddGame(players map[uint64]*model.PlayerStats) {
+	type entry struct {
+		id      uint64
+		steamID string
+		round   model.RoundSwingBreakdown
+	}
+	byRound := make(map[int][]entry)
+	for id, p := range players {
+		steamID := strconv.FormatUint(id, 10)
+		e.names[steamID] = p.Name
+		for _, rb := range p.RoundBreakdowns {
+			byRound[rb.RoundNumber] = append(byRound[rb.RoundNumber], entry{id: id, steamID: steamID, round: rb})
+		}
+	}
+	for _, round := range byRound {
+		for _, a := range round {
+			for _, b := range round {
+				if a.id == b.id || a.round.PlayerSide != b.round.PlayerSide {
+					continue
+				}
+				key := duoKey{player: a.steamID, partner: b.steamID}
+				t := e.totals[key]
+				if t == nil {
+					t = &duoTotals{}
+					e.totals[key] = t
+				}
+				t.rounds++
+				if a.round.OpeningDeath {
+					t.openingDeaths++
+					if a.round.TradedBy == b.id {
+						t.tradedByPartner++
+					}
+				}
+				if a.round.EntryContact && b.round.EntryContact {
+					t.entryRounds++
+					if a.round.TeamWon {
+						t.entryWins++
+					}
+				}
+			}
+		}
+	}
+}
+
+// Results returns every player's synergy with each teammate, ordered by
+// Steam ID then by the partner's trades of the player's opening deaths,
+// most first.
+func (e *EntryDuos) Results() []EntryDuo {
+	results := make([]EntryDuo, 0, len(e.totals))
+	for key, t := range e.totals {
+		d := EntryDuo{
+			SteamID:         key.player,
+			Name:            e.names[key.player],
+			PartnerID:       key.partner,
+			PartnerName:     e.names[key.partner],
+			Rounds:          t.rounds,
+			OpeningDeaths:   t.openingDeaths,
+			TradedByPartner: t.tradedByPartner,
+			DuoEntryRounds:  t.entryRounds,
+		}
+		if t.openingDeaths > 0 {
+			d.DuoTradeRate = float64(t.tradedByPartner) / float64(t.openingDeaths)
+		}
+		if t.entryRounds > 0 {
+			d.DuoEntryWinRate = float64(t.entryWins) / float64(t.entryRounds)
+		}
+		results = append(results, d)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.SteamID != b.SteamID {
+			return a.SteamID < b.SteamID
+		}
+		if a.TradedByPartner != b.TradedByPartner {
+			return a.TradedByPartner > b.TradedByPartner
+		}
+		return a.PartnerID < b.PartnerID
+	})
+	return results
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// openingKey identifies a player's opening duels in one weapon matchup.
+type openingKey struct {
+	steamID string
+	matchup string
+}
+
+// OpeningMatchup is a player's opening duel record in one weapon matchup,
+// seen from the player's side: "awp_vs_rifle" counts their opening duels
+// holding the AWP against a rifle.
+type OpeningMatchup struct {
+	SteamID        string
+	Name           string
+	Matchup        string
+	Wins           int
+	Losses         int
+	WinPct         float64
+	PistolInvolved bool // Either player held a pistol
+}
+
+// OpeningMatchups accumulates players' opening duels by weapon matchup.
+type OpeningMatchups struct {
+	names  map[string]string
+	wins   map[openingKey]int
+	losses map[openingKey]int
+}
+
+// NewOpeningMatchups creates an empty opening duel matchup accumulator.
+func NewOpeningMatchups() *OpeningMatchups {
+	return &OpeningMatchups{
+		names:  make(map[string]string),
+		wins:   make(map[openingKey]int),
+		losses: make(map[openingKey]int),
+	}
+}
+
+// AddGame incorporates a parsed game.
+func (o *OpeningMatchups) AddGame(players map[uint64]*model.PlayerStats) {
+	for _, p := range players {
+		o.names[p.SteamID] = p.Name
+		for matchup, n := range p.OpeningWinsByMatchup {
+			o.wins[openingKey{steamID: p.SteamID, matchup: matchup}] += n
+		}
+		for matchup, n := range p.OpeningLossesByMatchup {
+			o.losses[openingKey{steamID: p.SteamID, matchup: matchup}] += n
+		}
+	}
+}
+
+// Results returns every player's record in each matchup they took an
+// opening duel in, ordered by Steam ID then by duels, most first.
+func (o *OpeningMatchups) Results() []OpeningMatchup {
+	keys := make(map[openingKey]bool, len(o.wins)+len(o.losses))
+	for key := range o.wins {
+		keys[key] = true
+	}
+	for key := range o.losses {
+		keys[key] = true
+	}
+	results := make([]OpeningMatchup, 0, len(keys))
+	for key := range keys {
+		wins, losses := o.wins[key], o.losses[key]
+		results = append(results, OpeningMatchup{
+			SteamID:        key.steamID,
+			Name:           o.names[key.steamID],
+			Matchup:        key.matchup,
+			Wins:           wins,
+			Losses:         losses,
+			WinPct:         float64(wins) / float64(wins+losses),
+			PistolInvolved: strings.Contains(key.matchup, "pistol"),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.SteamID != b.SteamID {
+			return a.SteamID < b.SteamID
+		}
+		if a.Wins+a.Losses != b.Wins+b.Losses {
+			return a.Wins+a.Losses > b.Wins+b.Losses
+		}
+		return a.Matchup < b.Matchup
+	})
+	return results
+}
@@ -0,0 +1,275 @@
+// Package analysis derives higher-level descriptions of players from their
+// parsed games, such as the role each one plays on each map.
+package analysis
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// Role is the in-game role a player is detected to play on a map.
+type Role string
+
+// Detected roles.
+const (
+	RoleEntry   Role = "entry"   // Takes the T side's opening duels
+	RoleLurker  Role = "lurker"  // Plays T rounds away from the team
+	RoleAWPer   Role = "awper"   // Gets a large share of kills with the AWP
+	RoleSupport Role = "support" // Throws the most utility
+	RoleAnchor  Role = "anchor"  // Holds a bomb site on the CT side
+)
+
+// AWPerKillShare is the share of kills with the AWP at or above which a
+// player is the AWPer, whatever else they do. It is a starting estimate for
+// league play, to be revisited as detected roles are checked against teams'
+// own descriptions.
+const AWPerKillShare = 0.35
+
+// roleOrder is the order roles other than AWPer are scored in; an earlier
+// role wins a tie.
+var roleOrder = []Role{RoleEntry, RoleLurker, RoleAnchor, RoleSupport}
+
+// roleKey identifies a player on a map.
+type roleKey struct {
+	steamID string
+	mapName string
+}
+
+// roleTotals is a player's accumulated numbers on one map.
+type roleTotals struct {
+	name              string
+	games             int
+	rounds            int
+	tRounds           int
+	kills             int
+	awpKills          int
+	tOpeningAttempts  int
+	nadesThrown       int
+	damage            int
+	kastRounds        float64
+	ratingRounds      float64 // Final rating times rounds, for a round-weighted average
+	tDistanceTotal    float64
+	tSamples          int
+	ctSamples         int
+	ctBombZoneSamples int
+}
+
+// RoleAssignment is a player's detected role on a map, the numbers it was
+// detected from, and how the player compares with others detected in the
+// same role.
+type RoleAssignment struct {
+	SteamID string
+	Name    string
+	Map     string
+	Role    Role
+	// RoleScore is how strongly the player's numbers point to the role: for
+	// the AWPer their AWP kill share over AWPerKillShare, otherwise the
+	// deciding metric over the map's average (1 = average).
+	RoleScore float64
+	Games     int
+	Rounds    int
+
+	TOpeningAttemptsPerRound float64 // T-side opening duels per T round
+	AWPKillShare             float64 // Share of kills with the AWP
+	UtilityPerRound          float64 // Grenades thrown per round
+	AvgTTeammateDistance     float64 // Distance to the nearest teammate on the T side
+	CTBombZonePct            float64 // Share of CT time spent in a bomb site
+
+	Rating float64
+	ADR    float64
+	KPR    float64
+	KAST   float64
+
+	// Percentiles (0-100) among every player-map detected in the same role,
+	// across all maps.
+	RatingPercentile float64
+	ADRPercentile    float64
+	KPRPercentile    float64
+	KASTPercentile   float64
+}
+
+// RoleDetector accumulates players' games per map and detects the role each
+// player plays on each one.
+type RoleDetector struct {
+	totals map[roleKey]*roleTotals
+}
+
+// NewRoleDetector creates an empty role detector.
+func NewRoleDetector() *RoleDetector {
+	return &RoleDetector{totals: make(map[roleKey]*roleTotals)}
+}
+
+// AddGame incorporates a parsed game on mapName.
+func (d *RoleDetector) AddGame(mapName string, players map[uint64]*model.PlayerStats) {
+	for _, p := range players {
+		if p.RoundsPlayed == 0 {
+			continue
+		}
+		key := roleKey{steamID: p.SteamID, mapName: mapName}
+		t := d.totals[key]
+		if t == nil {
+			t = &roleTotals{}
+			d.totals[key] = t
+		}
+		t.name = p.Name
+		t.games++
+		t.rounds += p.RoundsPlayed
+		t.tRounds += p.TRoundsPlayed
+		t.kills += p.Kills
+		t.awpKills += p.AWPKills
+		t.tOpeningAttempts += p.TOpeningKills + p.TOpeningDeaths
+		t.nadesThrown += p.TotalNadesThrown
+		t.damage += p.Damage
+		t.kastRounds += p.KAST * float64(p.RoundsPlayed)
+		t.ratingRounds += p.FinalRating * float64(p.RoundsPlayed)
+		t.tDistanceTotal += p.TTeammateDistanceTotal
+		t.tSamples += p.TPositionSamples
+		t.ctSamples += p.CTPositionSamples
+		t.ctBombZoneSamples += p.CTBombZoneSamples
+	}
+}
+
+// Results detects every player's role on every map they played, ordered by
+// map then Steam ID.
+//
+// A player whose AWP kill share reaches AWPerKillShare is the AWPer. Anyone
+// else is scored on each remaining role's metric relative to the average on
+// the map (T opening duels per T round for entry, distance to the nearest
+// teammate on T for lurker, CT time in a bomb site for anchor, grenades per
+// round for support) and given the role they score highest on.
+func (d *RoleDetector) Results() []RoleAssignment {
+	results := make([]RoleAssignment, 0, len(d.totals))
+	for key, t := range d.totals {
+		results = append(results, newRoleAssignment(key, t))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Map != results[j].Map {
+			return results[i].Map < results[j].Map
+		}
+		return results[i].SteamID < results[j].SteamID
+	})
+
+	// Map averages of each role metric, over the players on the map.
+	type sums struct {
+		n       int
+		metrics map[Role]float64
+	}
+	byMap := make(map[string]*sums)
+	for _, r := range results {
+		s := byMap[r.Map]
+		if s == nil {
+			s = &sums{metrics: make(map[Role]float64)}
+			byMap[r.Map] = s
+		}
+		s.n++
+		for _, role := range roleOrder {
+			s.metrics[role] += r.roleMetric(role)
+		}
+	}
+
+	for i := range results {
+		r := &results[i]
+		if r.AWPKillShare >= AWPerKillShare {
+			r.Role = RoleAWPer
+			r.RoleScore = r.AWPKillShare / AWPerKillShare
+			continue
+		}
+		s := byMap[r.Map]
+		for _, role := range roleOrder {
+			avg := s.metrics[role] / float64(s.n)
+			if avg == 0 {
+				continue
+			}
+			if score := r.roleMetric(role) / avg; r.Role == "" || score > r.RoleScore {
+				r.Role = role
+				r.RoleScore = score
+			}
+		}
+		if r.Role == "" {
+			r.Role = RoleSupport
+		}
+	}
+
+	setRolePercentiles(results)
+	return results
+}
+
+// newRoleAssignment turns a player's totals on a map into per-round numbers.
+func newRoleAssignment(key roleKey, t *roleTotals) RoleAssignment {
+	r := RoleAssignment{
+		SteamID: key.steamID,
+		Name:    t.name,
+		Map:     key.mapName,
+		Games:   t.games,
+		Rounds:  t.rounds,
+	}
+	rounds := float64(t.rounds)
+	r.UtilityPerRound = float64(t.nadesThrown) / rounds
+	r.Rating = t.ratingRounds / rounds
+	r.ADR = float64(t.damage) / rounds
+	r.KPR = float64(t.kills) / rounds
+	r.KAST = t.kastRounds / rounds
+	if t.tRounds > 0 {
+		r.TOpeningAttemptsPerRound = float64(t.tOpeningAttempts) / float64(t.tRounds)
+	}
+	if t.kills > 0 {
+		r.AWPKillShare = float64(t.awpKills) / float64(t.kills)
+	}
+	if t.tSamples > 0 {
+		r.AvgTTeammateDistance = t.tDistanceTotal / float64(t.tSamples)
+	}
+	if t.ctSamples > 0 {
+		r.CTBombZonePct = float64(t.ctBombZoneSamples) / float64(t.ctSamples)
+	}
+	return r
+}
+
+// roleMetric returns the metric a role is detected from.
+func (r *RoleAssignment) roleMetric(role Role) float64 {
+	switch role {
+	case RoleEntry:
+		return r.TOpeningAttemptsPerRound
+	case RoleLurker:
+		return r.AvgTTeammateDistance
+	case RoleAnchor:
+		return r.CTBombZonePct
+	case RoleSupport:
+		return r.UtilityPerRound
+	}
+	return 0
+}
+
+// setRolePercentiles ranks each assignment's rating, ADR, KPR and KAST among
+// the assignments with the same role.
+func setRolePercentiles(results []RoleAssignment) {
+	byRole := make(map[Role][]*RoleAssignment)
+	for i := range results {
+		byRole[results[i].Role] = append(byRole[results[i].Role], &results[i])
+	}
+	for _, group := range byRole {
+		rank := func(value func(*RoleAssignment) float64, set func(*RoleAssignment, float64)) {
+			for _, r := range group {
+				set(r, percentile(group, value, value(r)))
+			}
+		}
+		rank(func(r *RoleAssignment) float64 { return r.Rating }, func(r *RoleAssignment, p float64) { r.RatingPercentile = p })
+		rank(func(r *RoleAssignment) float64 { return r.ADR }, func(r *RoleAssignment, p float64) { r.ADRPercentile = p })
+		rank(func(r *RoleAssignment) float64 { return r.KPR }, func(r *RoleAssignment, p float64) { r.KPRPercentile = p })
+		rank(func(r *RoleAssignment) float64 { return r.KAST }, func(r *RoleAssignment, p float64) { r.KASTPercentile = p })
+	}
+}
+
+// percentile returns the percentage of group below v, counting ties as half.
+func percentile(group []*RoleAssignment, value func(*RoleAssignment) float64, v float64) float64 {
+	var below, equal int
+	for _, r := range group {
+		switch x := value(r); {
+		case x < v:
+			below++
+		case x == v:
+			equal++
+		}
+	}
+	return 100 * (float64(below) + 0.5*float64(equal)) / float64(len(group))
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// zoneKey identifies a player's side on a map.
+type zoneKey struct {
+	steamID string
+	mapName string
+	side    string
+}
+
+// ZoneTendency is the share of a player's live round time on one side of a
+// map spent in one named zone.
+type ZoneTendency struct {
+	SteamID string
+	Name    string
+	Map     string
+	Side    string // "T" or "CT"
+	Zone    string // The map's callout name, e.g. "BombsiteA"
+	Seconds float64
+	Pct     float64 // Share of the player's sampled time on this side of this map, 0-1
+}
+
+// ZoneTendencies accumulates the time players spend in each named zone, per
+// map and side, into positional tendency profiles.
+type ZoneTendencies struct {
+	names map[string]string
+	time  map[zoneKey]map[string]float64
+}
+
+// NewZoneTendencies creates an empty zone tendency accumulator.
+func NewZoneTendencies() *ZoneTendencies {
+	return &ZoneTendencies{
+		names: make(map[string]string),
+		time:  make(map[zoneKey]map[string]float64),
+	}
+}
+
+// AddGame incorporates a parsed game on mapName.
+func (z *ZoneTendencies) AddGame(mapName string, players map[uint64]*model.PlayerStats) {
+	for _, p := range players {
+		z.names[p.SteamID] = p.Name
+		z.add(zoneKey{steamID: p.SteamID, mapName: mapName, side: "T"}, p.TZoneTime)
+		z.add(zoneKey{steamID: p.SteamID, mapName: mapName, side: "CT"}, p.CTZoneTime)
+	}
+}
+
+// add sums zone times into key's profile.
+func (z *ZoneTendencies) add(key zoneKey, zoneTime map[string]float64) {
+	if len(zoneTime) == 0 {
+		return
+	}
+	profile := z.time[key]
+	if profile == nil {
+		profile = make(map[string]float64)
+		z.time[key] = profile
+	}
+	for zone, seconds := range zoneTime {
+		profile[zone] += seconds
+	}
+}
+
+// Results returns every player's zone shares, ordered by map, Steam ID and
+// side, then by share, largest first.
+func (z *ZoneTendencies) Results() []ZoneTendency {
+	var results []ZoneTendency
+	for key, profile := range z.time {
+		zones := make([]string, 0, len(profile))
+		for zone := range profile {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		var total float64
+		for _, zone := range zones {
+			total += profile[zone]
+		}
+		if total == 0 {
+			continue
+		}
+		for _, zone := range zones {
+			results = append(results, ZoneTendency{
+				SteamID: key.steamID,
+				Name:    z.names[key.steamID],
+				Map:     key.mapName,
+				Side:    key.side,
+				Zone:    zone,
+				Seconds: profile[zone],
+				Pct:     profile[zone] / total,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Map != b.Map {
+			return a.Map < b.Map
+		}
+		if a.SteamID != b.SteamID {
+			return a.SteamID < b.SteamID
+		}
+		if a.Side != b.Side {
+			return a.Side > b.Side // T before CT
+		}
+		if a.Seconds != b.Seconds {
+			return a.Seconds > b.Seconds
+		}
+		return a.Zone < b.Zone
+	})
+	return results
+}
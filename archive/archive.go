@@ -0,0 +1,317 @@
+// Package archive stores per-game player results across parsing runs.
+// Cumulative mode only keeps season totals in memory; the archive keeps each
+// game's box score so later features (streaks, head-to-head history, map
+// records) can be computed from stored data.
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// weekPattern matches match-week identifiers such as "M01" in bucket keys.
+var weekPattern = regexp.MustCompile(`(?i)(?:^|[/_-])(M\d+)(?:[/_-]|$)`)
+
+// seasonPattern matches season prefixes such as "s19/" in bucket keys.
+var seasonPattern = regexp.MustCompile(`(?i)(?:^|/)s(\d+)/`)
+
+// PlayerLine is one player's box score for a single game.
+type PlayerLine struct {
+	SteamID       string  `json:"steam_id"`
+	Name          string  `json:"name"`
+	Team          string  `json:"team"`
+	Rating        float64 `json:"rating"`
+	HLTVRating    float64 `json:"hltv_rating"`
+	RoundsPlayed  int     `json:"rounds_played"`
+	RoundsWon     int     `json:"rounds_won"`
+	Kills         int     `json:"kills"`
+	Deaths        int     `json:"deaths"`
+	Assists       int     `json:"assists"`
+	ADR           float64 `json:"adr"`
+	KAST          float64 `json:"kast"`
+	OpeningKills  int     `json:"opening_kills"`
+	ClutchRounds  int     `json:"clutch_rounds"`
+	ClutchWins    int     `json:"clutch_wins"`
+	BigClutchWins int     `json:"big_clutch_wins"` // 1v3 or larger clutches won
+	BiggestClutch int     `json:"biggest_clutch"`  // Largest clutch won (N in 1vN), 0 if none
+	Aces          int     `json:"aces"`
+	AWPKills      int     `json:"awp_kills"`
+	Won           bool    `json:"won"`
+	SwingPerRound float64 `json:"probability_swing_per_round"`
+}
+
+// GameRecord is a stored game with its final score and player lines.
+type GameRecord struct {
+	MatchID  string         `json:"match_id"`
+	Map      string         `json:"map"`
+	Tier     string         `json:"tier"`
+	Week     string         `json:"week,omitempty"`
+	PlayedAt time.Time      `json:"played_at"`
+	Score    map[string]int `json:"score"`  // Rounds won per team name
+	Winner   string         `json:"winner"` // Team with the most rounds; "" on a tie
+	Players  []PlayerLine   `json:"players"`
+
+	TickRate        float64 `json:"tick_rate,omitempty"`        // Server tick rate
+	DurationSeconds float64 `json:"duration_seconds,omitempty"` // In-game time covered by the demo
+	Source          string  `json:"source,omitempty"`           // Platform the demo was recorded on
+}
+
+// Teams returns the team names in the game, sorted.
+func (g *GameRecord) Teams() []string {
+	teams := make([]string, 0, len(g.Score))
+	for t := range g.Score {
+		teams = append(teams, t)
+	}
+	sort.Strings(teams)
+	return teams
+}
+
+// Archive is the persisted collection of game records.
+type Archive struct {
+	Games []GameRecord `json:"games"`
+	index map[string]int
+}
+
+// New creates an empty archive.
+func New() *Archive {
+	return &Archive{index: make(map[string]int)}
+}
+
+// Load reads an archive from path. A missing file returns an empty archive.
+func Load(filepath string) (*Archive, error) {
+	a := New()
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return a, nil
+		}
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("failed to parse archive: %w", err)
+	}
+	a.sortGames()
+	return a, nil
+}
+
+// Save writes the archive to path as JSON, with games ordered by play time.
+func (a *Archive) Save(filepath string) error {
+	a.sortGames()
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive: %w", err)
+	}
+	return os.WriteFile(filepath, data, 0644)
+}
+
+// Add stores a game, replacing any existing record with the same MatchID.
+// Returns true when the game was not previously archived.
+func (a *Archive) Add(g GameRecord) bool {
+	if idx, ok := a.index[g.MatchID]; ok {
+		a.Games[idx] = g
+		return false
+	}
+	a.index[g.MatchID] = len(a.Games)
+	a.Games = append(a.Games, g)
+	return true
+}
+
+// sortGames orders games by play time (then MatchID) and rebuilds the index.
+func (a *Archive) sortGames() {
+	sort.SliceStable(a.Games, func(i, j int) bool {
+		if !a.Games[i].PlayedAt.Equal(a.Games[j].PlayedAt) {
+			return a.Games[i].PlayedAt.Before(a.Games[j].PlayedAt)
+		}
+		return a.Games[i].MatchID < a.Games[j].MatchID
+	})
+	for i, g := range a.Games {
+		a.index[g.MatchID] = i
+	}
+}
+
+// PlayerGames returns every archived line for a player in play order, along
+// with the game each line came from.
+func (a *Archive) PlayerGames(steamID string) ([]PlayerLine, []*GameRecord) {
+	var lines []PlayerLine
+	var games []*GameRecord
+	for i := range a.Games {
+		for _, pl := range a.Games[i].Players {
+			if pl.SteamID == steamID {
+				lines = append(lines, pl)
+				games = append(games, &a.Games[i])
+				break
+			}
+		}
+	}
+	order := make([]int, len(games))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return games[order[i]].PlayedAt.Before(games[order[j]].PlayedAt)
+	})
+	sortedLines := make([]PlayerLine, len(lines))
+	sortedGames := make([]*GameRecord, len(games))
+	for i, idx := range order {
+		sortedLines[i] = lines[idx]
+		sortedGames[i] = games[idx]
+	}
+	return sortedLines, sortedGames
+}
+
+// Weeks returns every match week in the archive in ascending order.
+func (a *Archive) Weeks() []string {
+	seen := make(map[string]bool)
+	var weeks []string
+	for _, g := range a.Games {
+		if g.Week != "" && !seen[g.Week] {
+			seen[g.Week] = true
+			weeks = append(weeks, g.Week)
+		}
+	}
+	sort.Slice(weeks, func(i, j int) bool {
+		return weekNumber(weeks[i]) < weekNumber(weeks[j])
+	})
+	return weeks
+}
+
+// weekNumber returns the numeric part of a week identifier ("M03" -> 3).
+func weekNumber(week string) int {
+	n, _ := strconv.Atoi(strings.TrimLeft(week, "Mm"))
+	return n
+}
+
+// CompareWeeks orders two week identifiers numerically.
+func CompareWeeks(a, b string) int {
+	return weekNumber(a) - weekNumber(b)
+}
+
+// FirstSeasonPlayers returns the SteamIDs whose earliest archived game is in
+// season. Games without a season in their MatchID are ignored.
+func (a *Archive) FirstSeasonPlayers(season int) map[string]bool {
+	first := make(map[string]int)
+	for _, g := range a.Games {
+		s := ParseSeason(g.MatchID)
+		if s == 0 {
+			continue
+		}
+		for _, pl := range g.Players {
+			if prev, ok := first[pl.SteamID]; !ok || s < prev {
+				first[pl.SteamID] = s
+			}
+		}
+	}
+	players := make(map[string]bool)
+	for id, s := range first {
+		if s == season {
+			players[id] = true
+		}
+	}
+	return players
+}
+
+// NewGameRecord builds a game record from a game's match metadata and
+// parsed player stats.
+func NewGameRecord(match model.MatchInfo, tier string, players map[uint64]*model.PlayerStats) GameRecord {
+	g := GameRecord{
+		MatchID:  match.MatchID,
+		Map:      match.Map,
+		Tier:     tier,
+		Week:     ParseWeek(match.MatchID),
+		PlayedAt: match.StartTime,
+		Score:    make(map[string]int),
+
+		TickRate:        match.TickRate,
+		DurationSeconds: match.DurationSeconds,
+		Source:          match.Source,
+	}
+
+	for _, p := range players {
+		team := p.TeamName
+		if p.RoundsWon > g.Score[team] {
+			g.Score[team] = p.RoundsWon
+		}
+		g.Players = append(g.Players, PlayerLine{
+			SteamID:       p.SteamID,
+			Name:          p.Name,
+			Team:          team,
+			Rating:        p.FinalRating,
+			HLTVRating:    p.HLTVRating,
+			RoundsPlayed:  p.RoundsPlayed,
+			RoundsWon:     p.RoundsWon,
+			Kills:         p.Kills,
+			Deaths:        p.Deaths,
+			Assists:       p.Assists,
+			ADR:           p.ADR,
+			KAST:          p.KAST,
+			OpeningKills:  p.OpeningKills,
+			ClutchRounds:  p.ClutchRounds,
+			ClutchWins:    p.ClutchWins,
+			BigClutchWins: p.Clutch1v3Wins + p.Clutch1v4Wins + p.Clutch1v5Wins,
+			BiggestClutch: biggestClutch(p),
+			Aces:          p.MultiKillsRaw[5],
+			AWPKills:      p.AWPKills,
+			Won:           p.RoundsWon > p.RoundsLost,
+			SwingPerRound: p.ProbabilitySwingPerRound,
+		})
+	}
+	sort.Slice(g.Players, func(i, j int) bool {
+		return g.Players[i].SteamID < g.Players[j].SteamID
+	})
+
+	// Teams in name order, so the result doesn't depend on map order
+	best := -1
+	for _, team := range g.Teams() {
+		switch score := g.Score[team]; {
+		case score > best:
+			best, g.Winner = score, team
+		case score == best:
+			g.Winner = ""
+		}
+	}
+	return g
+}
+
+// biggestClutch returns the largest N of a 1vN clutch the player won.
+func biggestClutch(p *model.PlayerStats) int {
+	switch {
+	case p.Clutch1v5Wins > 0:
+		return 5
+	case p.Clutch1v4Wins > 0:
+		return 4
+	case p.Clutch1v3Wins > 0:
+		return 3
+	case p.Clutch1v2Wins > 0:
+		return 2
+	case p.Clutch1v1Wins > 0:
+		return 1
+	}
+	return 0
+}
+
+// ParseSeason extracts the season number from a demo key or bucket prefix
+// (e.g. 19 from "s19/M01/..."). Returns 0 when the key doesn't contain one.
+func ParseSeason(key string) int {
+	if m := seasonPattern.FindStringSubmatch(key); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	return 0
+}
+
+// ParseWeek extracts a match-week identifier (e.g. "M03") from a demo key.
+// Returns "" when the key doesn't contain one.
+func ParseWeek(key string) string {
+	if m := weekPattern.FindStringSubmatch(key); m != nil {
+		return strings.ToUpper(m[1])
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+package archive
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LoadExternalRatings reads per-player ratings from another rating source's
+// CSV export (e.g. Leetify). idColumn and ratingColumn name the header cells
+// holding the Steam ID64 and the rating; rows without a numeric rating are
+// skipped.
+func LoadExternalRatings(path, idColumn, ratingColumn string) (map[string]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open external ratings: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external ratings: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("external ratings file %s is empty", path)
+	}
+
+	idIdx, ratingIdx := -1, -1
+	for i, name := range records[0] {
+		switch strings.TrimSpace(name) {
+		case idColumn:
+			idIdx = i
+		case ratingColumn:
+			ratingIdx = i
+		}
+	}
+	if idIdx < 0 || ratingIdx < 0 {
+		return nil, fmt.Errorf("external ratings file %s needs columns %q and %q", path, idColumn, ratingColumn)
+	}
+
+	ratings := make(map[string]float64)
+	for _, row := range records[1:] {
+		if idIdx >= len(row) || ratingIdx >= len(row) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(row[ratingIdx]), 64)
+		if err != nil {
+			continue
+		}
+		ratings[strings.TrimSpace(row[idIdx])] = v
+	}
+	return ratings, nil
+}
+
+// ExternalComparison is one player's eco-rating next to an external rating.
+type ExternalComparison struct {
+	SteamID  string
+	Name     string
+	Games    int
+	Eco      float64 // Average archived eco-rating
+	External float64
+	Expected float64 // External rating predicted from Eco by the linear fit
+	Residual float64 // External - Expected
+	ZScore   float64 // Residual in standard deviations
+	Outlier  bool    // |ZScore| >= the outlier threshold
+}
+
+// CrossValidation compares eco-ratings with an external source over the
+// players both cover.
+type CrossValidation struct {
+	Players   []ExternalComparison // Largest |ZScore| first
+	Pearson   float64
+	Spearman  float64
+	Slope     float64 // External = Slope * Eco + Intercept
+	Intercept float64
+	Outliers  int
+}
+
+// CrossValidate compares each archived player's average eco-rating with the
+// external ratings. Players need at least minGames archived games. Players
+// whose external rating is outlierZ or more standard deviations from the
+// linear fit are flagged.
+func (a *Archive) CrossValidate(external map[string]float64, minGames int, outlierZ float64) CrossValidation {
+	var cv CrossValidation
+	for _, rp := range a.Recompute(nil) {
+		ext, ok := external[rp.SteamID]
+		if !ok || rp.Games < minGames {
+			continue
+		}
+		cv.Players = append(cv.Players, ExternalComparison{
+			SteamID:  rp.SteamID,
+			Name:     rp.Name,
+			Games:    rp.Games,
+			Eco:      rp.Archived,
+			External: ext,
+		})
+	}
+	n := len(cv.Players)
+	if n < 2 {
+		return cv
+	}
+
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, p := range cv.Players {
+		xs[i], ys[i] = p.Eco, p.External
+	}
+	cv.Pearson = pearson(xs, ys)
+	cv.Spearman = pearson(ranks(xs), ranks(ys))
+
+	var mx, my, sxx, sxy float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(n)
+	my /= float64(n)
+	for i := range xs {
+		sxx += (xs[i] - mx) * (xs[i] - mx)
+		sxy += (xs[i] - mx) * (ys[i] - my)
+	}
+	if sxx > 0 {
+		cv.Slope = sxy / sxx
+	}
+	cv.Intercept = my - cv.Slope*mx
+
+	var ssRes float64
+	for i := range cv.Players {
+		p := &cv.Players[i]
+		p.Expected = cv.Slope*p.Eco + cv.Intercept
+		p.Residual = p.External - p.Expected
+		ssRes += p.Residual * p.Residual
+	}
+	if sd := math.Sqrt(ssRes / float64(n)); sd > 0 {
+		for i := range cv.Players {
+			p := &cv.Players[i]
+			p.ZScore = p.Residual / sd
+			if math.Abs(p.ZScore) >= outlierZ {
+				p.Outlier = true
+				cv.Outliers++
+			}
+		}
+	}
+
+	sort.SliceStable(cv.Players, func(i, j int) bool {
+		return math.Abs(cv.Players[i].ZScore) > math.Abs(cv.Players[j].ZScore)
+	})
+	return cv
+}
+
+// ranks returns the 1-based rank of each value, averaging ties.
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+	r := make([]float64, len(values))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && values[order[j+1]] == values[order[i]] {
+			j++
+		}
+		avg := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			r[order[k]] = avg
+		}
+		i = j + 1
+	}
+	return r
+}
@@ -0,0 +1,186 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// DemoEntry describes a parsed demo and where to find it.
+type DemoEntry struct {
+	MatchID  string    `json:"match_id"` // Bucket key of the demo
+	Map      string    `json:"map"`
+	Tier     string    `json:"tier"`
+	Teams    []string  `json:"teams"`
+	PlayedAt time.Time `json:"played_at"`
+	ParsedAt time.Time `json:"parsed_at"`
+	Path     string    `json:"path,omitempty"` // Local path of the extracted .dem file
+	URL      string    `json:"url,omitempty"`  // Download URL of the original demo
+	SHA256   string    `json:"sha256,omitempty"`
+	Size     int64     `json:"size,omitempty"`
+}
+
+// DemoQuery filters demo index entries. Empty fields match everything; Text
+// matches case-insensitively against the match ID, map, and team names.
+type DemoQuery struct {
+	Text  string
+	Map   string
+	Team  string
+	Tier  string
+	Since time.Time
+	Until time.Time
+}
+
+// DemoIndex is the persisted index of every parsed demo.
+type DemoIndex struct {
+	Demos []DemoEntry `json:"demos"`
+	index map[string]int
+}
+
+// NewDemoIndex creates an empty demo index.
+func NewDemoIndex() *DemoIndex {
+	return &DemoIndex{index: make(map[string]int)}
+}
+
+// LoadDemoIndex reads a demo index from path. A missing file returns an empty index.
+func LoadDemoIndex(path string) (*DemoIndex, error) {
+	d := NewDemoIndex()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return d, nil
+		}
+		return nil, fmt.Errorf("failed to read demo index: %w", err)
+	}
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, fmt.Errorf("failed to parse demo index: %w", err)
+	}
+	d.sortDemos()
+	return d, nil
+}
+
+// Save writes the index to path as JSON, ordered by play time.
+func (d *DemoIndex) Save(path string) error {
+	d.sortDemos()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal demo index: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Add stores an entry, replacing any existing entry with the same MatchID.
+func (d *DemoIndex) Add(e DemoEntry) {
+	if idx, ok := d.index[e.MatchID]; ok {
+		d.Demos[idx] = e
+		return
+	}
+	d.index[e.MatchID] = len(d.Demos)
+	d.Demos = append(d.Demos, e)
+}
+
+// Get returns the entry for a match ID.
+func (d *DemoIndex) Get(matchID string) (DemoEntry, bool) {
+	idx, ok := d.index[matchID]
+	if !ok {
+		return DemoEntry{}, false
+	}
+	return d.Demos[idx], true
+}
+
+// Query returns the entries matching q in play order.
+func (d *DemoIndex) Query(q DemoQuery) []DemoEntry {
+	text := strings.ToLower(q.Text)
+	var matches []DemoEntry
+	for _, e := range d.Demos {
+		if q.Map != "" && !strings.EqualFold(e.Map, q.Map) {
+			continue
+		}
+		if q.Tier != "" && !strings.EqualFold(e.Tier, q.Tier) {
+			continue
+		}
+		if q.Team != "" && !containsFold(e.Teams, q.Team) {
+			continue
+		}
+		if !q.Since.IsZero() && e.PlayedAt.Before(q.Since) {
+			continue
+		}
+		if !q.Until.IsZero() && e.PlayedAt.After(q.Until) {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(e.MatchID+" "+e.Map+" "+strings.Join(e.Teams, " ")), text) {
+			continue
+		}
+		matches = append(matches, e)
+	}
+	return matches
+}
+
+// sortDemos orders entries by play time (then MatchID) and rebuilds the index.
+func (d *DemoIndex) sortDemos() {
+	sort.SliceStable(d.Demos, func(i, j int) bool {
+		if !d.Demos[i].PlayedAt.Equal(d.Demos[j].PlayedAt) {
+			return d.Demos[i].PlayedAt.Before(d.Demos[j].PlayedAt)
+		}
+		return d.Demos[i].MatchID < d.Demos[j].MatchID
+	})
+	d.index = make(map[string]int, len(d.Demos))
+	for i, e := range d.Demos {
+		d.index[e.MatchID] = i
+	}
+}
+
+// NewDemoEntry builds an index entry for a parsed demo. Teams are taken from
+// the players' team names.
+func NewDemoEntry(matchID, mapName, tier string, playedAt time.Time, players map[uint64]*model.PlayerStats) DemoEntry {
+	e := DemoEntry{
+		MatchID:  matchID,
+		Map:      mapName,
+		Tier:     tier,
+		PlayedAt: playedAt,
+		ParsedAt: time.Now().UTC(),
+	}
+	seen := make(map[string]bool)
+	for _, p := range players {
+		if p.TeamName != "" && !seen[p.TeamName] {
+			seen[p.TeamName] = true
+			e.Teams = append(e.Teams, p.TeamName)
+		}
+	}
+	sort.Strings(e.Teams)
+	return e
+}
+
+// HashFile returns the hex SHA-256 and size of the file at path.
+func HashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open file for hashing: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to hash file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package archive
+
+import "github.com/ethsmith/eco-rating/rating"
+
+// MapBaselines calibrates per-map rating baselines from the archive: the
+// league's KPR, DPR, ADR and KAST on each map, weighted by rounds. Maps with
+// fewer than minGames archived games are left out so they fall back to the
+// global baselines.
+func (a *Archive) MapBaselines(minGames int) map[string]rating.Baselines {
+	return a.baselinesBy(func(g GameRecord) string { return g.Map }, minGames)
+}
+
+// TierBaselines calibrates per-tier rating baselines from the archive the
+// same way, for the tier_baselines config.
+func (a *Archive) TierBaselines(minGames int) map[string]rating.Baselines {
+	return a.baselinesBy(func(g GameRecord) string { return g.Tier }, minGames)
+}
+
+// baselinesBy averages KPR, DPR, ADR and KAST over the games in each group.
+func (a *Archive) baselinesBy(group func(GameRecord) string, minGames int) map[string]rating.Baselines {
+	type totals struct {
+		games, rounds, kills, deaths int
+		damage, kast                 float64
+	}
+	byKey := make(map[string]*totals)
+	for _, g := range a.Games {
+		key := group(g)
+		t := byKey[key]
+		if t == nil {
+			t = &totals{}
+			byKey[key] = t
+		}
+		t.games++
+		for _, pl := range g.Players {
+			rounds := float64(pl.RoundsPlayed)
+			t.rounds += pl.RoundsPlayed
+			t.kills += pl.Kills
+			t.deaths += pl.Deaths
+			t.damage += pl.ADR * rounds
+			t.kast += pl.KAST * rounds
+		}
+	}
+
+	table := make(map[string]rating.Baselines)
+	for key, t := range byKey {
+		if key == "" || t.games < minGames || t.rounds == 0 {
+			continue
+		}
+		rounds := float64(t.rounds)
+		table[key] = rating.Baselines{
+			KPR:  float64(t.kills) / rounds,
+			DPR:  float64(t.deaths) / rounds,
+			ADR:  t.damage / rounds,
+			KAST: t.kast / rounds,
+		}
+	}
+	return table
+}
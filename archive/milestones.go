@@ -0,0 +1,175 @@
+package archive
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MilestoneRule defines a career stat and the totals worth announcing.
+type MilestoneRule struct {
+	Stat       string               // Identifier used in exports (e.g. "kills")
+	Label      string               // Human-readable noun (e.g. "league kill")
+	Thresholds []int                // Career totals that trigger a milestone, ascending
+	Value      func(PlayerLine) int // Amount a single game adds to the total
+}
+
+// DefaultMilestoneRules returns the standard league milestones: kill counts,
+// first ace, and clutch rounds played.
+func DefaultMilestoneRules() []MilestoneRule {
+	return []MilestoneRule{
+		{
+			Stat:       "kills",
+			Label:      "league kill",
+			Thresholds: []int{500, 1000, 2000, 3000, 5000},
+			Value:      func(pl PlayerLine) int { return pl.Kills },
+		},
+		{
+			Stat:       "aces",
+			Label:      "ace",
+			Thresholds: []int{1, 5, 10},
+			Value:      func(pl PlayerLine) int { return pl.Aces },
+		},
+		{
+			Stat:       "clutch_rounds",
+			Label:      "clutch round",
+			Thresholds: []int{50, 100, 250},
+			Value:      func(pl PlayerLine) int { return pl.ClutchRounds },
+		},
+	}
+}
+
+// Milestone is a career threshold a player crossed in a specific game.
+type Milestone struct {
+	SteamID   string    `json:"steam_id"`
+	Name      string    `json:"name"`
+	Team      string    `json:"team"`
+	Stat      string    `json:"stat"`
+	Threshold int       `json:"threshold"`
+	Total     int       `json:"total"`
+	MatchID   string    `json:"match_id"`
+	Map       string    `json:"map"`
+	PlayedAt  time.Time `json:"played_at"`
+	Message   string    `json:"message"`
+}
+
+// MilestoneTracker keeps career totals per player and reports thresholds as
+// new games are observed.
+type MilestoneTracker struct {
+	rules  []MilestoneRule
+	totals map[string]map[string]int // SteamID -> stat -> career total
+	seen   map[string]bool           // MatchIDs already counted
+	found  []Milestone
+}
+
+// NewMilestoneTracker creates a tracker with the given rules.
+func NewMilestoneTracker(rules []MilestoneRule) *MilestoneTracker {
+	return &MilestoneTracker{
+		rules:  rules,
+		totals: make(map[string]map[string]int),
+		seen:   make(map[string]bool),
+	}
+}
+
+// Seed adds every archived game to the career totals without reporting
+// milestones, so only thresholds crossed by new games are announced.
+func (t *MilestoneTracker) Seed(a *Archive) {
+	for _, g := range a.Games {
+		t.add(g)
+	}
+}
+
+// Observe adds a game to the career totals and returns the milestones it
+// produced. Games already counted (by MatchID) are ignored.
+func (t *MilestoneTracker) Observe(g GameRecord) []Milestone {
+	before := make(map[string]map[string]int, len(g.Players))
+	for _, pl := range g.Players {
+		before[pl.SteamID] = make(map[string]int, len(t.rules))
+		for _, r := range t.rules {
+			before[pl.SteamID][r.Stat] = t.totals[pl.SteamID][r.Stat]
+		}
+	}
+	if !t.add(g) {
+		return nil
+	}
+
+	var milestones []Milestone
+	for _, pl := range g.Players {
+		for _, r := range t.rules {
+			prev, total := before[pl.SteamID][r.Stat], t.totals[pl.SteamID][r.Stat]
+			for _, threshold := range r.Thresholds {
+				if prev >= threshold || total < threshold {
+					continue
+				}
+				milestones = append(milestones, Milestone{
+					SteamID:   pl.SteamID,
+					Name:      pl.Name,
+					Team:      pl.Team,
+					Stat:      r.Stat,
+					Threshold: threshold,
+					Total:     total,
+					MatchID:   g.MatchID,
+					Map:       g.Map,
+					PlayedAt:  g.PlayedAt,
+					Message:   milestoneMessage(pl.Name, r, threshold),
+				})
+			}
+		}
+	}
+	t.found = append(t.found, milestones...)
+	return milestones
+}
+
+// Milestones returns every milestone observed so far, ordered by play time.
+func (t *MilestoneTracker) Milestones() []Milestone {
+	out := make([]Milestone, len(t.found))
+	copy(out, t.found)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].PlayedAt.Before(out[j].PlayedAt)
+	})
+	return out
+}
+
+// add counts a game's stats once. Returns false if the game was already counted.
+func (t *MilestoneTracker) add(g GameRecord) bool {
+	if t.seen[g.MatchID] {
+		return false
+	}
+	t.seen[g.MatchID] = true
+	for _, pl := range g.Players {
+		if t.totals[pl.SteamID] == nil {
+			t.totals[pl.SteamID] = make(map[string]int, len(t.rules))
+		}
+		for _, r := range t.rules {
+			t.totals[pl.SteamID][r.Stat] += r.Value(pl)
+		}
+	}
+	return true
+}
+
+// milestoneMessage formats an announcement such as "Foo recorded their
+// 1000th league kill" or "Foo recorded their first ace".
+func milestoneMessage(name string, r MilestoneRule, threshold int) string {
+	if threshold == 1 {
+		return fmt.Sprintf("%s recorded their first %s", name, r.Label)
+	}
+	return fmt.Sprintf("%s recorded their %s %s", name, ordinal(threshold), r.Label)
+}
+
+// ordinal formats n with its English ordinal suffix (1st, 2nd, 100th).
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,205 @@
+package archive
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// Optimization targets built into the archive. Any other target is a path to
+// a per-player CSV (see LoadPlayerValues).
+const (
+	TargetWins = "wins" // Per-game result: 1 for a win, 0 for a loss
+	TargetHLTV = "hltv" // Per-game HLTV 2.0 rating stored with the box score
+)
+
+// OptimizeResult is a candidate set of weights and how well it fits the
+// target compared with the weights it started from.
+type OptimizeResult struct {
+	Target        string         `json:"target"`
+	BaselineScore float64        `json:"baseline_score"` // Pearson correlation with the starting weights
+	Score         float64        `json:"score"`          // Pearson correlation with the fitted weights
+	MaxChange     float64        `json:"max_change"`     // Each weight stayed within ±MaxChange of its start
+	Passes        int            `json:"passes"`
+	Samples       int            `json:"samples"`
+	Weights       rating.Weights `json:"weights"`
+}
+
+// LoadPlayerValues reads a per-player CSV whose first column is a Steam ID
+// and second a number (MVP votes, an external rating, ...). The header row
+// and rows with a non-numeric value are skipped.
+func LoadPlayerValues(path string) (map[string]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open player values: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read player values: %w", err)
+	}
+	values := make(map[string]float64)
+	for _, rec := range records {
+		if len(rec) < 2 {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(rec[1]), 64)
+		if err != nil {
+			continue
+		}
+		values[strings.TrimSpace(rec[0])] = v
+	}
+	return values, nil
+}
+
+// pearson returns the correlation of xs and ys, or 0 when either is constant.
+func pearson(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if n < 2 {
+		return 0
+	}
+	var sx, sy, sxx, syy, sxy float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxx += xs[i] * xs[i]
+		syy += ys[i] * ys[i]
+		sxy += xs[i] * ys[i]
+	}
+	cov := sxy - sx*sy/n
+	vx := sxx - sx*sx/n
+	vy := syy - sy*sy/n
+	if vx <= 0 || vy <= 0 {
+		return 0
+	}
+	return cov / math.Sqrt(vx*vy)
+}
+
+// objective returns a function scoring weights by their correlation with the
+// target, and the number of samples it uses. Per-game targets correlate each
+// box score's rating; per-player values correlate each player's average.
+// Box scores are laid out once as a rating.ComponentBatch, since the search
+// rates them hundreds of times.
+func (a *Archive) objective(target string, playerValues map[string]float64, kdprModifier bool) (func(rating.Weights) float64, int) {
+	if playerValues != nil {
+		var players [][]rating.Components
+		var ys []float64
+		index := make(map[string]int)
+		for _, g := range a.Games {
+			for _, pl := range g.Players {
+				value, ok := playerValues[pl.SteamID]
+				if !ok {
+					continue
+				}
+				i, seen := index[pl.SteamID]
+				if !seen {
+					i = len(players)
+					index[pl.SteamID] = i
+					players = append(players, nil)
+					ys = append(ys, value)
+				}
+				players[i] = append(players[i], pl.Components(g.Map, g.Tier))
+			}
+		}
+		// Each player's games are contiguous in the batch, ending at ends[i]
+		var components []rating.Components
+		ends := make([]int, len(players))
+		for i, games := range players {
+			components = append(components, games...)
+			ends[i] = len(components)
+		}
+		batch := rating.NewComponentBatch(components)
+		var ratings []float64
+		return func(w rating.Weights) float64 {
+			ratings = w.RateBatch(batch, kdprModifier, ratings)
+			xs := make([]float64, len(players))
+			start := 0
+			for i, end := range ends {
+				for _, r := range ratings[start:end] {
+					xs[i] += r
+				}
+				xs[i] /= float64(end - start)
+				start = end
+			}
+			return pearson(xs, ys)
+		}, len(players)
+	}
+
+	var components []rating.Components
+	var ys []float64
+	for _, g := range a.Games {
+		for _, pl := range g.Players {
+			components = append(components, pl.Components(g.Map, g.Tier))
+			switch target {
+			case TargetWins:
+				if pl.Won {
+					ys = append(ys, 1)
+				} else {
+					ys = append(ys, 0)
+				}
+			case TargetHLTV:
+				ys = append(ys, pl.HLTVRating)
+			}
+		}
+	}
+	batch := rating.NewComponentBatch(components)
+	var xs []float64
+	return func(w rating.Weights) float64 {
+		xs = w.RateBatch(batch, kdprModifier, xs)
+		return pearson(xs, ys)
+	}, len(components)
+}
+
+// Optimize fits the weights to the target by coordinate search: each weight
+// in turn is moved up or down by the current step and kept if the fit
+// improves; the step halves whenever a full pass finds nothing better.
+// Weights are constrained to ±maxChange of their starting value. target is
+// TargetWins, TargetHLTV, or a label for playerValues loaded from a file.
+func (a *Archive) Optimize(start rating.Weights, target string, playerValues map[string]float64, maxChange float64, kdprModifier bool) (OptimizeResult, error) {
+	if playerValues == nil && target != TargetWins && target != TargetHLTV {
+		return OptimizeResult{}, fmt.Errorf("unknown optimization target %q", target)
+	}
+	score, samples := a.objective(target, playerValues, kdprModifier)
+	if samples < 2 {
+		return OptimizeResult{}, fmt.Errorf("not enough archived samples for target %q", target)
+	}
+
+	result := OptimizeResult{Target: target, MaxChange: maxChange, Samples: samples}
+	result.BaselineScore = score(start)
+
+	best, bestScore := start, result.BaselineScore
+	origin := start.Fields()
+	const maxPasses = 200
+	for step := maxChange / 4; step > 0.001 && result.Passes < maxPasses; {
+		result.Passes++
+		improved := false
+		for i := range origin {
+			for _, dir := range []float64{1, -1} {
+				w := best
+				field := w.Fields()[i]
+				lo := *origin[i].Value * (1 - maxChange)
+				hi := *origin[i].Value * (1 + maxChange)
+				*field.Value = math.Max(lo, math.Min(hi, *field.Value*(1+dir*step)))
+				if s := score(w); s > bestScore {
+					best, bestScore = w, s
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+
+	result.Score = bestScore
+	result.Weights = best
+	return result, nil
+}
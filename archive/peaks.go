@@ -0,0 +1,160 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// PeakMinRounds is the rounds a player must play in a game for it to count
+// toward rating and ADR records, so abandoned or short demos don't set them.
+const PeakMinRounds = 16
+
+// PeakStat identifies a single-match record category.
+type PeakStat string
+
+// Single-match record categories.
+const (
+	PeakRating PeakStat = "rating"
+	PeakKills  PeakStat = "kills"
+	PeakADR    PeakStat = "adr"
+	PeakClutch PeakStat = "clutch"
+)
+
+// PeakStats lists the record categories in export order.
+var PeakStats = []PeakStat{PeakRating, PeakKills, PeakADR, PeakClutch}
+
+// RecordEntry is a single-match record value with the game it was set in.
+type RecordEntry struct {
+	Stat     PeakStat  `json:"stat"`
+	SteamID  string    `json:"steam_id"`
+	Name     string    `json:"name"`
+	Value    float64   `json:"value"`
+	MatchID  string    `json:"match_id"`
+	Map      string    `json:"map"`
+	Tier     string    `json:"tier"`
+	PlayedAt time.Time `json:"played_at"`
+}
+
+// PeakStore holds every player's single-match bests and the league-wide
+// bests. It's persisted between runs so records survive even when old demos
+// are no longer parsed.
+type PeakStore struct {
+	Players map[string]map[PeakStat]RecordEntry `json:"players"` // SteamID -> stat -> personal best
+	League  map[PeakStat]RecordEntry            `json:"league"`
+	Seen    map[string]bool                     `json:"seen"` // MatchIDs already counted
+}
+
+// NewPeakStore creates an empty store.
+func NewPeakStore() *PeakStore {
+	return &PeakStore{
+		Players: make(map[string]map[PeakStat]RecordEntry),
+		League:  make(map[PeakStat]RecordEntry),
+		Seen:    make(map[string]bool),
+	}
+}
+
+// LoadPeakStore reads a store from path. A missing file returns an empty store.
+func LoadPeakStore(path string) (*PeakStore, error) {
+	s := NewPeakStore()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("failed to read peak records: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse peak records: %w", err)
+	}
+	if s.Players == nil {
+		s.Players = make(map[string]map[PeakStat]RecordEntry)
+	}
+	if s.League == nil {
+		s.League = make(map[PeakStat]RecordEntry)
+	}
+	if s.Seen == nil {
+		s.Seen = make(map[string]bool)
+	}
+	return s, nil
+}
+
+// Save writes the store to path as JSON.
+func (s *PeakStore) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal peak records: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Observe updates personal and league records from a game and returns the
+// existing league records it broke. Games already counted (by MatchID) are
+// ignored.
+func (s *PeakStore) Observe(g GameRecord) []RecordEntry {
+	if s.Seen[g.MatchID] {
+		return nil
+	}
+	s.Seen[g.MatchID] = true
+
+	var broken []RecordEntry
+	for _, pl := range g.Players {
+		for _, stat := range PeakStats {
+			value, ok := peakValue(pl, stat)
+			if !ok {
+				continue
+			}
+			entry := RecordEntry{
+				Stat:     stat,
+				SteamID:  pl.SteamID,
+				Name:     pl.Name,
+				Value:    value,
+				MatchID:  g.MatchID,
+				Map:      g.Map,
+				Tier:     g.Tier,
+				PlayedAt: g.PlayedAt,
+			}
+			if s.Players[pl.SteamID] == nil {
+				s.Players[pl.SteamID] = make(map[PeakStat]RecordEntry)
+			}
+			if best, ok := s.Players[pl.SteamID][stat]; !ok || value > best.Value {
+				s.Players[pl.SteamID][stat] = entry
+			}
+			if best, ok := s.League[stat]; !ok || value > best.Value {
+				s.League[stat] = entry
+				if ok {
+					broken = append(broken, entry)
+				}
+			}
+		}
+	}
+	return broken
+}
+
+// PlayerIDs returns the SteamIDs with records, sorted.
+func (s *PeakStore) PlayerIDs() []string {
+	ids := make([]string, 0, len(s.Players))
+	for id := range s.Players {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// peakValue returns a player's value for a record category in one game.
+// Returns false when the line doesn't qualify for that category.
+func peakValue(pl PlayerLine, stat PeakStat) (float64, bool) {
+	switch stat {
+	case PeakRating:
+		return pl.Rating, pl.RoundsPlayed >= PeakMinRounds
+	case PeakKills:
+		return float64(pl.Kills), pl.Kills > 0
+	case PeakADR:
+		return pl.ADR, pl.RoundsPlayed >= PeakMinRounds
+	case PeakClutch:
+		return float64(pl.BiggestClutch), pl.BiggestClutch > 0
+	}
+	return 0, false
+}
@@ -0,0 +1,82 @@
+package archive
+
+import "sort"
+
+// PlayIn is an archived game between teams whose home tiers differ, such as a
+// play-in match. Index 0 and 1 are the game's teams in name order.
+type PlayIn struct {
+	MatchID     string
+	Teams       [2]string
+	Tiers       [2]string  // Each team's home tier
+	RoundsWon   [2]int     // Rounds each team won in the game
+	HomeRatings [2]float64 // Each team's average rating in its home tier games
+}
+
+// PlayIns returns the games between teams from different tiers. A team's home
+// tier is the tier it played the most archived games in (the first by name on
+// a tie); its home rating is the average of its players' ratings over those
+// games.
+func (a *Archive) PlayIns() []PlayIn {
+	tierGames := make(map[string]map[string]int)
+	for _, g := range a.Games {
+		for _, team := range g.Teams() {
+			if tierGames[team] == nil {
+				tierGames[team] = make(map[string]int)
+			}
+			tierGames[team][g.Tier]++
+		}
+	}
+	home := make(map[string]string, len(tierGames))
+	for team, counts := range tierGames {
+		best := ""
+		for tier, n := range counts {
+			if best == "" || n > counts[best] || (n == counts[best] && tier < best) {
+				best = tier
+			}
+		}
+		home[team] = best
+	}
+
+	ratingSum := make(map[string]float64)
+	ratingGames := make(map[string]int)
+	for _, g := range a.Games {
+		for _, team := range g.Teams() {
+			if g.Tier != home[team] {
+				continue
+			}
+			sum, n := 0.0, 0
+			for _, pl := range g.Players {
+				if pl.Team == team {
+					sum += pl.Rating
+					n++
+				}
+			}
+			if n > 0 {
+				ratingSum[team] += sum / float64(n)
+				ratingGames[team]++
+			}
+		}
+	}
+
+	var playIns []PlayIn
+	for _, g := range a.Games {
+		teams := g.Teams()
+		if len(teams) != 2 || home[teams[0]] == home[teams[1]] {
+			continue
+		}
+		p := PlayIn{MatchID: g.MatchID}
+		for i, team := range teams {
+			p.Teams[i] = team
+			p.Tiers[i] = home[team]
+			p.RoundsWon[i] = g.Score[team]
+			if ratingGames[team] > 0 {
+				p.HomeRatings[i] = ratingSum[team] / float64(ratingGames[team])
+			}
+		}
+		playIns = append(playIns, p)
+	}
+	sort.Slice(playIns, func(i, j int) bool {
+		return playIns[i].MatchID < playIns[j].MatchID
+	})
+	return playIns
+}
@@ -0,0 +1,128 @@
+package archive
+
+import (
+	"sort"
+)
+
+// ProgressionMinRounds is the rounds a player must have played by a week to
+// be ranked in that week's snapshot, so one hot game in week one doesn't top
+// the chart. A starting estimate, matching the digest's weekly minimum.
+const ProgressionMinRounds = 20
+
+// ProgressionEntry is one player's place in a tier's leaderboard as it stood
+// after a match week: the average of their per-game ratings from the start
+// of the season through that week, as in cumulative mode.
+type ProgressionEntry struct {
+	Week    string
+	Tier    string
+	SteamID string
+	Name    string
+	Games   int
+	Rounds  int
+	Rating  float64
+	Rank    int // 1 = highest rating in the tier that week
+}
+
+// progressionPlayer accumulates a player's season totals up to a week.
+type progressionPlayer struct {
+	name      string
+	games     int
+	rounds    int
+	ratingSum float64
+}
+
+// LatestSeason returns the highest season found in the archived MatchIDs, or
+// 0 when none has one.
+func (a *Archive) LatestSeason() int {
+	season := 0
+	for _, g := range a.Games {
+		season = max(season, ParseSeason(g.MatchID))
+	}
+	return season
+}
+
+// Progression returns the week-by-week leaderboard snapshots of a season,
+// ordered by week, tier and rank. A player keeps their place in the weeks
+// they don't play, with the rating they had. Season 0 takes every game;
+// games without a match week are left out.
+func (a *Archive) Progression(season int) []ProgressionEntry {
+	weeks := make(map[string][]*GameRecord)
+	for i := range a.Games {
+		g := &a.Games[i]
+		if g.Week == "" || (season != 0 && ParseSeason(g.MatchID) != season) {
+			continue
+		}
+		weeks[g.Week] = append(weeks[g.Week], g)
+	}
+	order := make([]string, 0, len(weeks))
+	for w := range weeks {
+		order = append(order, w)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return CompareWeeks(order[i], order[j]) < 0
+	})
+
+	tiers := make(map[string]map[string]*progressionPlayer)
+	var entries []ProgressionEntry
+	for _, week := range order {
+		for _, g := range weeks[week] {
+			players := tiers[g.Tier]
+			if players == nil {
+				players = make(map[string]*progressionPlayer)
+				tiers[g.Tier] = players
+			}
+			for _, pl := range g.Players {
+				pp := players[pl.SteamID]
+				if pp == nil {
+					pp = &progressionPlayer{}
+					players[pl.SteamID] = pp
+				}
+				pp.name = pl.Name
+				pp.games++
+				pp.rounds += pl.RoundsPlayed
+				pp.ratingSum += pl.Rating
+			}
+		}
+		entries = append(entries, weekSnapshot(week, tiers)...)
+	}
+	return entries
+}
+
+// weekSnapshot ranks each tier's players by their season rating so far.
+func weekSnapshot(week string, tiers map[string]map[string]*progressionPlayer) []ProgressionEntry {
+	names := make([]string, 0, len(tiers))
+	for t := range tiers {
+		names = append(names, t)
+	}
+	sort.Strings(names)
+
+	var entries []ProgressionEntry
+	for _, tier := range names {
+		var ranked []ProgressionEntry
+		for id, pp := range tiers[tier] {
+			if pp.rounds < ProgressionMinRounds {
+				continue
+			}
+			ranked = append(ranked, ProgressionEntry{
+				Week:    week,
+				Tier:    tier,
+				SteamID: id,
+				Name:    pp.name,
+				Games:   pp.games,
+				Rounds:  pp.rounds,
+				Rating:  pp.ratingSum / float64(pp.games),
+			})
+		}
+		sort.Slice(ranked, func(i, j int) bool {
+			if ranked[i].Rating != ranked[j].Rating {
+				return ranked[i].Rating > ranked[j].Rating
+			}
+			return ranked[i].SteamID < ranked[j].SteamID
+		})
+		for i := range ranked {
+			ranked[i].Rank = i + 1
+		}
+		entries = append(entries, ranked...)
+	}
+	return entries
+}
@@ -0,0 +1,85 @@
+package archive
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// RecomputedPlayer is one player's season re-rated under every version.
+// Ratings and Ranks line up with the versions passed to Recompute; a rating
+// is the average of the player's per-game ratings, as in cumulative mode.
+type RecomputedPlayer struct {
+	SteamID  string
+	Name     string
+	Games    int
+	Rounds   int
+	Archived float64 // Average of the ratings stored at parse time
+	Ratings  []float64
+	Ranks    []int // 1 = highest rating under that version
+}
+
+// Components returns the rating inputs stored in a box score for a game on
+// mapName in tier.
+func (pl PlayerLine) Components(mapName, tier string) rating.Components {
+	return rating.Components{
+		Rounds:        pl.RoundsPlayed,
+		Kills:         pl.Kills,
+		Deaths:        pl.Deaths,
+		ADR:           pl.ADR,
+		KAST:          pl.KAST,
+		SwingPerRound: pl.SwingPerRound,
+		Map:           mapName,
+		Tier:          tier,
+	}
+}
+
+// Recompute replays every archived game through each rating version and
+// returns the players sorted by their rating under the first version.
+func (a *Archive) Recompute(versions []rating.Version) []*RecomputedPlayer {
+	byID := make(map[string]*RecomputedPlayer)
+	var players []*RecomputedPlayer
+	for _, g := range a.Games {
+		for _, pl := range g.Players {
+			rp := byID[pl.SteamID]
+			if rp == nil {
+				rp = &RecomputedPlayer{SteamID: pl.SteamID, Ratings: make([]float64, len(versions))}
+				byID[pl.SteamID] = rp
+				players = append(players, rp)
+			}
+			rp.Name = pl.Name
+			rp.Games++
+			rp.Rounds += pl.RoundsPlayed
+			rp.Archived += pl.Rating
+			components := pl.Components(g.Map, g.Tier)
+			for i, v := range versions {
+				rp.Ratings[i] += v.Compute(components)
+			}
+		}
+	}
+
+	for _, rp := range players {
+		games := float64(rp.Games)
+		rp.Archived /= games
+		for i := range rp.Ratings {
+			rp.Ratings[i] /= games
+		}
+		rp.Ranks = make([]int, len(versions))
+	}
+
+	for i := range versions {
+		sort.SliceStable(players, func(x, y int) bool {
+			return players[x].Ratings[i] > players[y].Ratings[i]
+		})
+		for rank, rp := range players {
+			rp.Ranks[i] = rank + 1
+		}
+	}
+
+	if len(versions) > 0 {
+		sort.SliceStable(players, func(x, y int) bool {
+			return players[x].Ratings[0] > players[y].Ratings[0]
+		})
+	}
+	return players
+}
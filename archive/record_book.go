@@ -0,0 +1,171 @@
+package archive
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Record book categories.
+const (
+	RecordHighestMatchRating  = "highest_match_rating"
+	RecordMostAcesInSeason    = "most_aces_in_season"
+	RecordLongestClutchStreak = "longest_clutch_streak"
+)
+
+// BookRecord is one entry in the league record book. Matches lists every
+// game behind the record (one for single-match records), each with a link
+// to its demo when a base URL is known.
+type BookRecord struct {
+	Category string        `json:"category"`
+	Tier     string        `json:"tier,omitempty"`
+	Season   int           `json:"season,omitempty"`
+	SteamID  string        `json:"steam_id"`
+	Name     string        `json:"name"`
+	Value    float64       `json:"value"`
+	Matches  []MatchSource `json:"matches"`
+}
+
+// MatchSource is provenance for a record: the game and where to find its demo.
+type MatchSource struct {
+	MatchID  string    `json:"match_id"`
+	Map      string    `json:"map"`
+	PlayedAt time.Time `json:"played_at"`
+	URL      string    `json:"url,omitempty"`
+}
+
+// RecordBook is the league-wide record book built from the archive.
+type RecordBook struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Games       int          `json:"games"`
+	Records     []BookRecord `json:"records"`
+}
+
+// BuildRecordBook computes the league record book from every archived game:
+// the highest single-match rating per tier, the most aces in a season, and
+// the longest clutch streak. Demo links are baseURL joined with the MatchID
+// (the bucket key); pass "" to omit them.
+//
+// Clutch streaks are tracked per game because rounds aren't archived: a game
+// where every clutch was won extends the streak, any lost clutch ends it.
+func BuildRecordBook(a *Archive, baseURL string) RecordBook {
+	book := RecordBook{GeneratedAt: time.Now().UTC(), Games: len(a.Games)}
+	source := func(g *GameRecord) MatchSource {
+		s := MatchSource{MatchID: g.MatchID, Map: g.Map, PlayedAt: g.PlayedAt}
+		if baseURL != "" {
+			s.URL = strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(g.MatchID, "/")
+		}
+		return s
+	}
+
+	// Highest single-match rating per tier
+	bestRating := make(map[string]BookRecord)
+	for i := range a.Games {
+		g := &a.Games[i]
+		for _, pl := range g.Players {
+			if pl.RoundsPlayed < PeakMinRounds {
+				continue
+			}
+			if best, ok := bestRating[g.Tier]; ok && pl.Rating <= best.Value {
+				continue
+			}
+			bestRating[g.Tier] = BookRecord{
+				Category: RecordHighestMatchRating,
+				Tier:     g.Tier,
+				Season:   ParseSeason(g.MatchID),
+				SteamID:  pl.SteamID,
+				Name:     pl.Name,
+				Value:    pl.Rating,
+				Matches:  []MatchSource{source(g)},
+			}
+		}
+	}
+	for _, r := range bestRating {
+		book.Records = append(book.Records, r)
+	}
+
+	// Most aces in a season
+	type seasonKey struct {
+		season  int
+		steamID string
+	}
+	aces := make(map[seasonKey]*BookRecord)
+	for i := range a.Games {
+		g := &a.Games[i]
+		season := ParseSeason(g.MatchID)
+		for _, pl := range g.Players {
+			if pl.Aces == 0 {
+				continue
+			}
+			k := seasonKey{season, pl.SteamID}
+			if aces[k] == nil {
+				aces[k] = &BookRecord{Category: RecordMostAcesInSeason, Season: season, SteamID: pl.SteamID}
+			}
+			aces[k].Name = pl.Name
+			aces[k].Value += float64(pl.Aces)
+			aces[k].Matches = append(aces[k].Matches, source(g))
+		}
+	}
+	bestAces := make(map[int]*BookRecord)
+	for _, r := range aces {
+		if best := bestAces[r.Season]; best == nil || r.Value > best.Value ||
+			(r.Value == best.Value && r.SteamID < best.SteamID) {
+			bestAces[r.Season] = r
+		}
+	}
+	for _, r := range bestAces {
+		book.Records = append(book.Records, *r)
+	}
+
+	// Longest clutch streak (archive games are already in play order)
+	type streak struct {
+		wins    int
+		name    string
+		matches []MatchSource
+	}
+	current := make(map[string]*streak)
+	var longest *BookRecord
+	for i := range a.Games {
+		g := &a.Games[i]
+		for _, pl := range g.Players {
+			if pl.ClutchRounds == 0 {
+				continue
+			}
+			s := current[pl.SteamID]
+			if s == nil || pl.ClutchWins < pl.ClutchRounds {
+				s = &streak{}
+				current[pl.SteamID] = s
+			}
+			if pl.ClutchWins < pl.ClutchRounds {
+				continue
+			}
+			s.wins += pl.ClutchWins
+			s.name = pl.Name
+			s.matches = append(s.matches, source(g))
+			if longest == nil || float64(s.wins) > longest.Value {
+				longest = &BookRecord{
+					Category: RecordLongestClutchStreak,
+					SteamID:  pl.SteamID,
+					Name:     s.name,
+					Value:    float64(s.wins),
+					Matches:  append([]MatchSource(nil), s.matches...),
+				}
+			}
+		}
+	}
+	if longest != nil {
+		book.Records = append(book.Records, *longest)
+	}
+
+	sort.Slice(book.Records, func(i, j int) bool {
+		ri, rj := book.Records[i], book.Records[j]
+		if ri.Category != rj.Category {
+			return ri.Category < rj.Category
+		}
+		if ri.Tier != rj.Tier {
+			return ri.Tier < rj.Tier
+		}
+		return ri.Season < rj.Season
+	})
+	return book
+}
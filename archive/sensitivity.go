@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// RankMove is a player's leaderboard position before and after a weight
+// change.
+type RankMove struct {
+	SteamID string
+	Name    string
+	From    int
+	To      int
+}
+
+// SensitivityResult is the leaderboard effect of changing one weight.
+type SensitivityResult struct {
+	Weight         string
+	Change         float64 // Relative change applied, e.g. 0.1 for +10%
+	Value          float64 // Perturbed value of the weight
+	Spearman       float64 // Rank correlation with the unperturbed leaderboard
+	MeanRankChange float64
+	Movers         []RankMove // Largest rank changes, biggest first
+}
+
+// Sensitivity re-rates the archive with each weight moved up and down by
+// step (a fraction, e.g. 0.1) and reports how much the leaderboard ordering
+// changes. Players with fewer than minGames archived games are left out.
+// Results are sorted with the most disruptive changes first.
+func (a *Archive) Sensitivity(base rating.Weights, step float64, minGames int, kdprModifier bool, movers int) []SensitivityResult {
+	versions := []rating.Version{{
+		Name:    "baseline",
+		Compute: func(c rating.Components) float64 { return base.Rate(c, kdprModifier) },
+	}}
+	var results []SensitivityResult
+
+	for i := range base.Fields() {
+		for _, change := range []float64{step, -step} {
+			w := base
+			field := w.Fields()[i]
+			*field.Value *= 1 + change
+			results = append(results, SensitivityResult{Weight: field.Name, Change: change, Value: *field.Value})
+			versions = append(versions, rating.Version{
+				Name:    fmt.Sprintf("%s %+.0f%%", field.Name, change*100),
+				Compute: func(c rating.Components) float64 { return w.Rate(c, kdprModifier) },
+			})
+		}
+	}
+
+	var players []*RecomputedPlayer
+	for _, rp := range a.Recompute(versions) {
+		if rp.Games >= minGames {
+			players = append(players, rp)
+		}
+	}
+	rerank(players, len(versions))
+
+	for i := range results {
+		r := &results[i]
+		r.Spearman, r.MeanRankChange, r.Movers = compareRanks(players, i+1, movers)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Spearman < results[j].Spearman
+	})
+	return results
+}
+
+// rerank recomputes each version's ranks among the given players only.
+func rerank(players []*RecomputedPlayer, versions int) {
+	order := append([]*RecomputedPlayer(nil), players...)
+	for v := 0; v < versions; v++ {
+		sort.SliceStable(order, func(x, y int) bool {
+			return order[x].Ratings[v] > order[y].Ratings[v]
+		})
+		for rank, rp := range order {
+			rp.Ranks[v] = rank + 1
+		}
+	}
+}
+
+// compareRanks compares version v's ranks with the baseline (version 0).
+func compareRanks(players []*RecomputedPlayer, v, movers int) (float64, float64, []RankMove) {
+	n := float64(len(players))
+	if n < 2 {
+		return 1, 0, nil
+	}
+	var sumSq, sumAbs float64
+	moves := make([]RankMove, 0, len(players))
+	for _, rp := range players {
+		d := float64(rp.Ranks[v] - rp.Ranks[0])
+		sumSq += d * d
+		sumAbs += math.Abs(d)
+		if d != 0 {
+			moves = append(moves, RankMove{SteamID: rp.SteamID, Name: rp.Name, From: rp.Ranks[0], To: rp.Ranks[v]})
+		}
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		di := moves[i].To - moves[i].From
+		dj := moves[j].To - moves[j].From
+		return di*di > dj*dj
+	})
+	spearman := 1 - 6*sumSq/(n*(n*n-1))
+	return spearman, sumAbs / n, moves[:min(movers, len(moves))]
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/config"
+	"github.com/ethsmith/eco-rating/export"
+)
+
+// commandRun runs a command once the config is loaded with the flags applied.
+type commandRun func(cfg *config.Config, exporter export.ExportOption)
+
+// command is a subcommand of the CLI. Each command parses its own flag set:
+// its own flags plus every shared flag, but none of the flags that choose
+// another mode, e.g. "eco-rating parse demo.dem -output=game.csv". Running
+// without a command keeps the flags-only form.
+type command struct {
+	name    string
+	args    string // Arguments shown in usage
+	summary string
+	// setup registers the command's own flags and returns the function that
+	// checks its positional arguments and returns what runs it.
+	setup func(fs *flag.FlagSet) func(args []string) (commandRun, error)
+}
+
+// commands are the subcommands, in usage order.
+var commands = []command{
+	{
+		name: "parse", args: "<demo>",
+		summary: "Parse one demo and export its stats: a .dem or .zip file, an http(s) URL, an s3:// path, or - for stdin",
+		setup:   setupParse,
+	},
+	{
+		name: "aggregate", args: "<dir>",
+		summary: "Aggregate every .dem file under a directory like cumulative mode (-tier names the tier, default all)",
+		setup:   setupAggregate,
+	},
+	{
+		name: "upload", args: "[stats.csv] --sheet=<spreadsheet id>",
+		summary: "Upload a stats CSV written by an earlier run (default: -output) to a spreadsheet",
+		setup:   setupUpload,
+	},
+	{
+		name: "explain", args: "<demo> --player=<name or Steam ID>",
+		summary: "Print what drove a player's rating in a demo, component by component",
+		setup:   setupExplain,
+	},
+	{
+		name: "serve", args: "[addr]",
+		summary: "Run the REST API (default :8080) on the ratings in -ratings",
+		setup:   setupServe,
+	},
+}
+
+// modeFlags are the flags that choose what a flags-only run does. Commands
+// don't accept them, since each command is a mode of its own.
+var modeFlags = map[string]bool{
+	"cumulative": true, "demo": true, "url": true, "stream": true, "stdin": true,
+	"aggregate": true, "upload": true, "explain": true, "player": true, "serve": true,
+	"predict": true, "caster-notes": true, "digest": true, "season-progression": true,
+	"recompute": true, "sensitivity": true, "optimize": true, "cross-validate": true,
+	"calibrate-maps": true, "calibrate-tiers": true, "quick-scan": true, "find-demos": true,
+}
+
+// parseCommandLine parses the command line. With a leading subcommand, the
+// rest is parsed with the command's flag set, flags and positional arguments
+// mixed, and the command's run is returned; it is nil for the flags-only form.
+func parseCommandLine() commandRun {
+	flag.Usage = printUsage
+	args := os.Args[1:]
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		flag.Parse()
+		return nil
+	}
+	if args[0] == "help" {
+		flag.CommandLine.SetOutput(os.Stdout)
+		printUsage()
+		os.Exit(0)
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("eco-rating "+cmd.name, flag.ExitOnError)
+	apply := cmd.setup(fs)
+	// Shared flags set the same variables as on the flags-only command line
+	flag.VisitAll(func(f *flag.Flag) {
+		if !modeFlags[f.Name] && fs.Lookup(f.Name) == nil {
+			fs.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: eco-rating %s %s [flags]\n\n%s\n\nFlags:\n", cmd.name, cmd.args, cmd.summary)
+		fs.PrintDefaults()
+	}
+
+	positional := parseInterleaved(fs, args[1:])
+	run, err := apply(positional)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\nUsage: eco-rating %s %s [flags]\n", cmd.name, err, cmd.name, cmd.args)
+		os.Exit(2)
+	}
+	return run
+}
+
+// parseInterleaved parses args with fs, which stops at the first positional
+// argument, resuming after each one, and returns the positional arguments.
+func parseInterleaved(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		// The flag set exits on errors
+		_ = fs.Parse(args)
+		if fs.NArg() == 0 {
+			return positional
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// printUsage lists the commands, then every flag.
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "Usage: eco-rating [command] [arguments] [flags]")
+	fmt.Fprintln(out)
+	printCommands(out)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands take every flag below except the ones that choose a mode (e.g. -demo, -cumulative).")
+	fmt.Fprintln(out, "Without a command, the mode is chosen by flags. Flags:")
+	flag.PrintDefaults()
+}
+
+// printCommands lists the commands with their arguments and own flags.
+func printCommands(out io.Writer) {
+	fmt.Fprintln(out, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", c.name, c.args)
+		fmt.Fprintf(out, "             %s\n", c.summary)
+		own := flag.NewFlagSet(c.name, flag.ContinueOnError)
+		c.setup(own)
+		own.VisitAll(func(f *flag.Flag) {
+			fmt.Fprintf(out, "             --%s: %s\n", f.Name, f.Usage)
+		})
+	}
+}
+
+// setupParse parses the demo argument: - reads stdin, s3:// paths and
+// compressed URLs are streamed, other URLs downloaded.
+func setupParse(fs *flag.FlagSet) func(args []string) (commandRun, error) {
+	return func(args []string) (commandRun, error) {
+		if len(args) != 1 {
+			return nil, errors.New("expected one demo")
+		}
+		demo := args[0]
+		remote := strings.HasPrefix(demo, "http://") || strings.HasPrefix(demo, "https://")
+		file := strings.SplitN(demo, "?", 2)[0]
+		switch {
+		case demo == "-":
+			return func(cfg *config.Config, _ export.ExportOption) {
+				parseDemoFromStdin(cfg)
+			}, nil
+		case strings.HasPrefix(demo, "s3://"),
+			remote && (strings.HasSuffix(file, ".gz") || strings.HasSuffix(file, ".bz2")):
+			return func(cfg *config.Config, exporter export.ExportOption) {
+				parseSingleDemoFromStream(demo, cfg, exporter)
+			}, nil
+		case remote:
+			return func(cfg *config.Config, exporter export.ExportOption) {
+				parseSingleDemoFromURL(demo, cfg, exporter)
+			}, nil
+		}
+		return func(cfg *config.Config, exporter export.ExportOption) {
+			parseLocalDemo(demo, cfg, exporter)
+		}, nil
+	}
+}
+
+// setupAggregate aggregates the directory argument.
+func setupAggregate(fs *flag.FlagSet) func(args []string) (commandRun, error) {
+	return func(args []string) (commandRun, error) {
+		if len(args) != 1 {
+			return nil, errors.New("expected one demo directory")
+		}
+		return func(cfg *config.Config, exporter export.ExportOption) {
+			runAggregate(cfg, args[0], exporter)
+		}, nil
+	}
+}
+
+// setupUpload uploads the stats CSV argument, defaulting to -output, with
+// --sheet standing for -sheet-id.
+func setupUpload(fs *flag.FlagSet) func(args []string) (commandRun, error) {
+	sheet := fs.String("sheet", "", "Spreadsheet ID to upload to (same as -sheet-id)")
+	return func(args []string) (commandRun, error) {
+		if len(args) > 1 {
+			return nil, errors.New("expected at most one stats CSV")
+		}
+		statsPath := fs.Lookup("output").Value.String()
+		if len(args) == 1 {
+			statsPath = args[0]
+		}
+		if *sheet != "" {
+			if err := fs.Set("sheet-id", *sheet); err != nil {
+				return nil, err
+			}
+		}
+		return func(cfg *config.Config, _ export.ExportOption) {
+			runUpload(cfg, statsPath)
+		}, nil
+	}
+}
+
+// setupExplain explains --player's rating in the demo argument.
+func setupExplain(fs *flag.FlagSet) func(args []string) (commandRun, error) {
+	player := fs.String("player", "", "Player whose rating to explain, by Steam ID or name")
+	return func(args []string) (commandRun, error) {
+		if len(args) != 1 {
+			return nil, errors.New("expected one demo")
+		}
+		if *player == "" {
+			return nil, errors.New("missing --player")
+		}
+		return func(cfg *config.Config, _ export.ExportOption) {
+			runExplain(cfg, args[0], *player)
+		}, nil
+	}
+}
+
+// setupServe serves the REST API on the address argument or --addr.
+func setupServe(fs *flag.FlagSet) func(args []string) (commandRun, error) {
+	addr := fs.String("addr", ":8080", "Address to serve the REST API on")
+	return func(args []string) (commandRun, error) {
+		if len(args) > 1 {
+			return nil, errors.New("expected at most one address")
+		}
+		if len(args) == 1 {
+			*addr = args[0]
+		}
+		ratingsPath := fs.Lookup("ratings").Value.String()
+		return func(cfg *config.Config, _ export.ExportOption) {
+			runServer(*addr, ratingsPath, cfg.DemoIndex)
+		}, nil
+	}
+}
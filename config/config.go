@@ -16,36 +16,478 @@ import (
 // Config holds all application configuration settings.
 // These can be set via JSON config file or command-line flags.
 type Config struct {
-	Cumulative       bool     `json:"cumulative"`     // Enable batch processing mode
-	Tier             string   `json:"tier"`           // Competitive tier filter (comma-separated for multiple)
-	BaseURL          string   `json:"base_url"`       // Cloud bucket base URL
-	Prefixes         []string `json:"prefixes"`       // Bucket prefixes for demo files (multiple paths)
-	DemoPath         string   `json:"demo_path"`      // Path to single demo file (single mode)
-	DemoDir          string   `json:"demo_dir"`       // Local directory for downloaded demos
-	EnableLogging    bool     `json:"enable_logging"` // Enable detailed parsing logs
-	IgnoreScrims     bool     `json:"ignore_scrims"`
-	KDPRModifier     bool     `json:"kdpr_modifier"`     // Enable KPR/DPR rating adjustment
-	Workers          int      `json:"workers"`           // Number of parallel parsing workers (0 = auto)
-	GenerateFiles    bool     `json:"generate_files"`    // Generate stats.csv and probability_data.json files
-	CSCCompatibility bool     `json:"csc_compatibility"` // Output demoScrape2-compatible JSON (mutually exclusive with cumulative)
+	Cumulative        bool     `json:"cumulative"`     // Enable batch processing mode
+	Tier              string   `json:"tier"`           // Competitive tier filter (comma-separated for multiple)
+	BaseURL           string   `json:"base_url"`       // Cloud bucket base URL
+	Prefixes          []string `json:"prefixes"`       // Bucket prefixes for demo files (multiple paths)
+	DemoPath          string   `json:"demo_path"`      // Path to single demo file (single mode)
+	DemoDir           string   `json:"demo_dir"`       // Local directory for downloaded demos
+	EnableLogging     bool     `json:"enable_logging"` // Enable detailed parsing logs
+	IgnoreScrims      bool     `json:"ignore_scrims"`
+	KDPRModifier      bool     `json:"kdpr_modifier"`       // Enable KPR/DPR rating adjustment
+	Workers           int      `json:"workers"`             // Number of parallel parsing workers (0 = auto)
+	GenerateFiles     bool     `json:"generate_files"`      // Generate stats.csv and probability_data.json files
+	CSCCompatibility  bool     `json:"csc_compatibility"`   // Output demoScrape2-compatible JSON (mutually exclusive with cumulative)
+	Columns           string   `json:"columns"`             // Stats CSV column preset: core, overview, utility, awp, maps, sides, overtime, or full
+	CloseMatchWeight  float64  `json:"close_match_weight"`  // Weight of close games (decided by 3 or fewer rounds, or OT) in aggregated ratings (1 = unweighted)
+	ExitFragPenalty   float64  `json:"exit_frag_penalty"`   // Probability swing taken from the killer per exit frag (0 = no penalty)
+	KillQualityWeight float64  `json:"kill_quality_weight"` // How far the opponent-adjusted rating follows kill quality (0 = equal to final rating, 1 = fully)
+	MapBaselines      string   `json:"map_baselines"`       // Per-map rating baselines JSON ("" = global baselines only)
+	Identities        string   `json:"identities"`          // Player identity mapping JSON merging alternate accounts and names ("" = none)
+
+	DemoSource string `json:"demo_source"` // Platform the demos were recorded on: faceit, esea, valve, ebot or unknown ("" = detect per demo)
+
+	Qualification   QualificationConfig   `json:"qualification"`    // Games and rounds needed to be ranked in aggregated leaderboards
+	RoundImportance RoundImportanceConfig `json:"round_importance"` // How each round's swing and clutch credit is weighed by the score
+	Convergence     ConvergenceConfig     `json:"convergence"`      // Season-wide iterative rating solves and their convergence report
+	WinProbability  WinProbabilityConfig  `json:"win_probability"`  // Empirical round win probability table used for swing
+	Trades          TradeConfig           `json:"trades"`           // What counts as a trade: time window and teammate proximity
+
+	TierBaselines map[string]Baselines `json:"tier_baselines"` // Rating baselines per tier, so each tier centers around 1.00 (tiers not listed use the global baselines)
+
+	DraftValue DraftValueConfig `json:"draft_value"` // Preseason auction draft value export
+	CrossTier  CrossTierConfig  `json:"cross_tier"`  // Tier-normalized ratings for multi-tier players
+	Awards     AwardsConfig     `json:"awards"`      // End-of-season award race standings
+	IGL        IGLConfig        `json:"igl"`         // In-game leader designation and IGL-adjusted ratings
+	Support    SupportConfig    `json:"support"`     // Players rated on the support profile
+	Entry      EntryConfig      `json:"entry"`       // Entry fragger leaderboard
+	Pistol     PistolConfig     `json:"pistol"`      // Pistol round leaderboard
+	Peaks      PeaksConfig      `json:"peaks"`       // Per-player and league single-match records
+	RecordBook RecordBookConfig `json:"record_book"` // League record book built from the archive
+	Teams      TeamStatsConfig  `json:"teams"`       // Team-level ratings
+	Links      LinksConfig      `json:"links"`       // Player and match hyperlinks in sheet exports
+	Discord    DiscordConfig    `json:"discord"`     // Match summary posts to a Discord webhook
+	Dataset    DatasetConfig    `json:"dataset"`     // Anonymized public dataset export
+	Sheets     SheetsConfig     `json:"sheets"`      // Google Sheets upload of the stats exports
+	Metrics    MetricsConfig    `json:"metrics"`     // Prometheus metrics for the parsing pipeline
+	Logging    LoggingConfig    `json:"logging"`     // Structured log level and format
+
+	PickemHistory  string `json:"pickem_history"`  // Prediction history file for pick'em accuracy tracking ("" = disabled)
+	ImpactFeed     string `json:"impact_feed"`     // Round-by-round impact points CSV for single demos ("" = disabled)
+	RatingTimeline string `json:"rating_timeline"` // Per-round and running rating CSV for single demos ("" = disabled)
+	SwingAudit     string `json:"swing_audit"`     // Per-event win probability and swing credit JSON for single demos ("" = disabled)
+	ArchivePath    string `json:"archive_path"`    // Per-game archive updated in cumulative mode ("" = disabled)
+	DemoIndex      string `json:"demo_index"`      // Index of every parsed demo (match, teams, file, hash) ("" = disabled)
+	JSONOutput     string `json:"json_output"`     // Nested JSON export of aggregated stats ("" = disabled)
+	Milestones     string `json:"milestones"`      // Career milestones CSV, requires archive_path ("" = disabled)
+	Clutches       string `json:"clutches"`        // Clutch situation descriptors JSON ("" = disabled)
+	Grenades       string `json:"grenades"`        // Every grenade throw (origin, trajectory, detonation, players hit) keyed by map ("" = disabled)
+	Throws         string `json:"throws"`          // Thrown-round descriptors JSON (lost after passing 90% win probability) ("" = disabled)
+	DemoErrors     string `json:"demo_errors"`     // Cumulative-mode report of demos that failed or were only partly parsed ("" = disabled)
+	Roles          string `json:"roles"`           // Detected role per player and map, with role-relative percentiles ("" = disabled)
+	Anchoring      string `json:"anchoring"`       // CT anchoring leaderboard per map (site holds survived, multi-kills, retake delay) CSV ("" = disabled)
+	ZoneTendencies string `json:"zone_tendencies"` // Share of round time per named map zone, by player, map and side ("" = disabled)
+	Duels          string `json:"duels"`           // Head-to-head kill records per match and across the season, JSON ("" = disabled)
+	BuyTendencies  string `json:"buy_tendencies"`  // Buy tendency profiles (AWP by money, armor skips, force-buy guns) CSV ("" = disabled)
+	EntryDuos      string `json:"entry_duos"`      // Pair-level entry synergy (opening-death trades, duo entry win rate) CSV ("" = disabled)
+	OpeningWeapons string `json:"opening_weapons"` // Opening duels per player by weapon matchup (AWP vs rifle, pistol involved...) CSV ("" = disabled)
+
+	Rookies      []string     `json:"rookies"`       // Steam IDs of first-season players
+	RookieReport RookieConfig `json:"rookie_report"` // Rookie leaderboard and rookie-vs-veteran baselines
+
+	Goals GoalsConfig `json:"goals"` // Per-player stat goals set by captains
+}
+
+// QualificationConfig sets the minimum games and rounds a player needs in a
+// tier to be ranked. Players below either are kept in the aggregated export
+// with Qualified false, listed after the tier's ranked players.
+type QualificationConfig struct {
+	MinGames  int `json:"min_games"`  // Games in a tier needed to qualify
+	MinRounds int `json:"min_rounds"` // Rounds in a tier needed to qualify
+}
+
+// RoundImportanceConfig selects the model that weighs each round's swing and
+// clutch credit by the score going into it. The leverage model counts a
+// round by how much it can swing the match, so 11-11 matters more than 11-3.
+type RoundImportanceConfig struct {
+	Model    string  `json:"model"`    // flat (every round 1.0) or leverage
+	Strength float64 `json:"strength"` // How far leverage weights follow the score, 0-1 (leverage only)
+}
+
+// ConvergenceConfig bounds the season-wide fixed-point solves, such as
+// opponent-adjusted ratings and the tier strength estimate, whose values
+// depend on each other. Each solve
+// starts from the final ratings and iterates until no value moves by more
+// than Tolerance.
+type ConvergenceConfig struct {
+	MaxIterations int     `json:"max_iterations"` // Iterations before a solve gives up
+	Tolerance     float64 `json:"tolerance"`      // Largest change at which a solve has converged
+	OutputPath    string  `json:"output_path"`    // CSV report of each solve's iterations and final change ("" = disabled)
+}
+
+// WinProbabilityConfig controls the empirical round win probability table,
+// keyed by players alive on each side, bomb state and time remaining. A
+// cumulative run trains one from its demos when TrainOutput is set; a run
+// given Table computes swing from it, using the heuristic model for states
+// it has fewer than MinSamples rounds of.
+type WinProbabilityConfig struct {
+	Table       string `json:"table"`        // Table JSON to compute swing from ("" = heuristic model only)
+	TrainOutput string `json:"train_output"` // Write a table trained from this run's demos to this path (cumulative mode, "" = disabled)
+	MinSamples  int    `json:"min_samples"`  // Rounds a state must be seen in for a trained table to use it
+}
+
+// TradeConfig defines a trade. A kill on the killer within WindowSeconds of
+// a death trades it; a teammate within ProximityUnits of the death when it
+// happened had a chance to. The window is converted to ticks at each demo's
+// tick rate, so it means the same on 64 and 128 tick servers.
+type TradeConfig struct {
+	WindowSeconds  float64 `json:"window_seconds"`  // Seconds after a death a kill on the killer still trades it
+	ProximityUnits float64 `json:"proximity_units"` // Maximum teammate distance from a death for a trade opportunity (units)
+}
+
+// GoalsConfig holds per-player stat goals, keyed by Steam ID, written as
+// "<stat> <op> <target>" with an aggregated stat's JSON name, e.g.
+// "kast >= 0.72" or "awp_deaths_no_kill_per_round < 0.2". Cumulative runs
+// export each goal's attainment in every tier the player played.
+type GoalsConfig struct {
+	OutputPath string              `json:"output_path"` // CSV path for the weekly goal attainment history
+	Players    map[string][]string `json:"players"`     // Goals per Steam ID (empty = disabled)
+}
+
+// RookieConfig controls first-season player detection and the rookie exports.
+// Rookies are the configured Steam IDs plus, when DetectFromArchive is set,
+// players whose earliest archived game is in the season being parsed. The
+// tool has no CSC API client, so the league's first-season list goes in the
+// rookies setting and the archive stands in for it where that list is empty.
+type RookieConfig struct {
+	Enabled           bool   `json:"enabled"`             // Write rookie exports in cumulative mode
+	LeaderboardPath   string `json:"leaderboard_path"`    // CSV output path for the rookie leaderboard
+	BaselinesPath     string `json:"baselines_path"`      // CSV output path for rookie-vs-veteran baselines
+	MinRounds         int    `json:"min_rounds"`          // Rounds in a tier needed to be ranked
+	DetectFromArchive bool   `json:"detect_from_archive"` // Treat players first seen this season in the archive as rookies
+}
+
+// SupportConfig picks the players rated on the support profile, which
+// re-weights utility, flash assists and trades up and kills down. Every
+// player gets a support rating; the profile marks whose it applies to.
+type SupportConfig struct {
+	Players    []string `json:"players"`     // Steam IDs always on the support profile
+	AutoDetect bool     `json:"auto_detect"` // Also put players whose utility and trade numbers fit it on the profile
+
+	LeaderboardPath string `json:"leaderboard_path"` // Support leaderboard CSV, ranking qualified players by support score (cumulative mode, "" = disabled)
+}
+
+// EntryConfig controls the entry leaderboard, which ranks entry fraggers by
+// their opening duels rather than final rating.
+type EntryConfig struct {
+	LeaderboardPath string `json:"leaderboard_path"` // Entry leaderboard CSV, ranking qualified players by entry score (cumulative mode, "" = disabled)
+}
+
+// PistolConfig controls the pistol leaderboard, which ranks players by
+// pistol round rating. It has its own thresholds since a season gives each
+// player only two pistol rounds per map.
+type PistolConfig struct {
+	LeaderboardPath string `json:"leaderboard_path"` // Pistol leaderboard CSV (cumulative mode, "" = disabled)
+	MinRounds       int    `json:"min_rounds"`       // Pistol rounds in a tier needed to be ranked
+	MinSideRounds   int    `json:"min_side_rounds"`  // Pistol rounds on a side needed for that side's rating
+}
+
+// IGLConfig designates in-game leaders per roster and controls the
+// IGL-adjusted rating export. Rosters maps a roster name to its IGL's Steam ID;
+// Players lists IGLs without a roster name. Calling costs individual output:
+// IGLs take fewer opening duels, hold information positions, and spend utility
+// for the team. The default Adjustment is a deliberately small compensation so
+// IGLs are compared more fairly without overtaking equal fraggers.
+type IGLConfig struct {
+	Enabled    bool              `json:"enabled"`     // Write IGL-adjusted ratings in cumulative mode
+	OutputPath string            `json:"output_path"` // CSV output path
+	Rosters    map[string]string `json:"rosters"`     // Roster name -> IGL Steam ID
+	Players    []string          `json:"players"`     // Steam IDs of further IGLs
+	Adjustment float64           `json:"adjustment"`  // Rating added to an IGL's expectation
+}
+
+// PeaksConfig controls single-match record tracking. Records are kept in
+// StorePath between runs and exported as CSV after each cumulative run.
+type PeaksConfig struct {
+	Enabled          bool   `json:"enabled"`            // Track records in cumulative mode
+	StorePath        string `json:"store_path"`         // JSON file holding records across runs
+	OutputPath       string `json:"output_path"`        // CSV output path for per-player records
+	LeagueOutputPath string `json:"league_output_path"` // CSV output path for league-wide records
+}
+
+// RecordBookConfig controls the league record book export. The book is
+// rebuilt from the archive after each cumulative run, so archive_path is required.
+type RecordBookConfig struct {
+	Enabled    bool   `json:"enabled"`     // Write the record book in cumulative mode
+	OutputPath string `json:"output_path"` // JSON output path
+	CSVPath    string `json:"csv_path"`    // CSV output path ("" = JSON only)
+}
+
+// DiscordConfig controls the match summary posted to Discord after each demo
+// is parsed: scoreline, top three ratings, MVP and notable clutches.
+type DiscordConfig struct {
+	WebhookURL string `json:"webhook_url"` // Discord webhook URL ("" = disabled)
+	PostedPath string `json:"posted_path"` // JSON list of match IDs already posted, so re-runs skip them
+}
+
+// MetricsConfig controls the Prometheus metrics of a cumulative run: demos
+// parsed, parse failures and durations, rounds processed and upload
+// latency. The REST API always serves them at GET /metrics.
+type MetricsConfig struct {
+	Addr    string `json:"addr"`     // Serve /metrics on this address while the run lasts ("" = disabled)
+	PushURL string `json:"push_url"` // Pushgateway to push the metrics to when the run ends ("" = disabled)
+	Job     string `json:"job"`      // Pushgateway job name
+}
+
+// LoggingConfig controls the structured log written to stderr. Parser
+// records are tagged with the demo file, map and round; at debug level every
+// kill's value, each round's swing and each player's rating components are
+// logged too.
+type LoggingConfig struct {
+	Level  string `json:"level"`  // debug, info, warn or error
+	Format string `json:"format"` // text or json
+}
+
+// DatasetConfig controls the anonymized public dataset: per-round and
+// per-player CSVs plus a data dictionary, with Steam IDs and match IDs
+// replaced by salted hashes.
+type DatasetConfig struct {
+	Dir  string `json:"dir"`  // Output directory ("" = disabled)
+	Salt string `json:"salt"` // Secret salt for anonymized IDs (required); keep it stable so releases can be joined
+}
+
+// SheetsConfig controls uploading stats to a Google spreadsheet alongside
+// the CSV export. Mode "replace" clears and rewrites the tab; "upsert"
+// matches rows by Steam ID (and tier), rewrites only changed cells, appends
+// new players and leaves manual columns right of the export untouched.
+type SheetsConfig struct {
+	SpreadsheetID string `json:"spreadsheet_id"` // Spreadsheet to upload to ("" = disabled)
+	Credentials   string `json:"credentials"`    // Service account key file with edit access to the spreadsheet
+	Sheet         string `json:"sheet"`          // Raw tab, with the same columns as the stats CSV
+	Mode          string `json:"mode"`           // "replace" or "upsert"
+	LocalDir      string `json:"local_dir"`      // Write tabs as CSV files here instead of to Google ("" = use the spreadsheet)
+	DuelsTab      string `json:"duels_tab"`      // Tab for the head-to-head duel matrix ("" = not uploaded)
+	SupportTab    string `json:"support_tab"`    // Tab for the support leaderboard ("" = not uploaded)
+	EntryTab      string `json:"entry_tab"`      // Tab for the entry leaderboard ("" = not uploaded)
+	PistolTab     string `json:"pistol_tab"`     // Tab for the pistol leaderboard ("" = not uploaded)
+
+	CredentialsPool   []string `json:"credentials_pool"`    // Extra service account keys, ideally from other Google projects; requests rotate across all keys
+	RequestsPerMinute int      `json:"requests_per_minute"` // Per-key pacing to stay under the write quota (0 = no pacing)
+	Format            bool     `json:"format"`              // Freeze the header, show ratings to 2 decimals and color-scale Final Rating
+
+	ChangeAlerts  bool   `json:"change_alerts"`   // Flag improbable jumps in rate columns since the previous upload
+	AlertsPath    string `json:"alerts_path"`     // CSV of the flagged jumps ("" = log only)
+	BlockOnAlerts bool   `json:"block_on_alerts"` // Upload no tab when any tab has flagged jumps
+
+	Tabs []SheetTabConfig `json:"tabs"` // Compact views written before the raw tab
+}
+
+// SheetTabConfig is one extra spreadsheet tab showing a column preset.
+type SheetTabConfig struct {
+	Name    string `json:"name"`
+	Columns string `json:"columns"` // Column preset, as for the stats CSV
+}
+
+// LinksConfig holds the URL templates used to hyperlink player names and
+// match IDs in CSV exports. "{steam_id}" and "{match_id}" are replaced per row.
+// When MatchURL is empty and a demo index is configured, matches link to
+// their demo download instead.
+type LinksConfig struct {
+	PlayerURL string `json:"player_url"` // Player page template ("" = no player links)
+	MatchURL  string `json:"match_url"`  // Match page template ("" = demo link or none)
+}
+
+// TeamStatsConfig controls the team-level rating export. Players are grouped
+// into teams by their team name in each demo.
+type TeamStatsConfig struct {
+	Enabled    bool   `json:"enabled"`     // Write team ratings in cumulative mode
+	OutputPath string `json:"output_path"` // CSV output path
+	JSONPath   string `json:"json_path"`   // JSON output path ("" = CSV only)
+}
+
+// AwardsConfig controls the award race standings export. Each award is written
+// to its own CSV in OutputDir.
+type AwardsConfig struct {
+	Enabled        bool     `json:"enabled"`           // Write award standings in cumulative mode
+	OutputDir      string   `json:"output_dir"`        // Directory for the per-award standings files
+	MinRounds      int      `json:"min_rounds"`        // Rounds in a tier needed to qualify
+	MinAWPKillsPct float64  `json:"min_awp_kills_pct"` // AWP share of kills needed for Best AWPer (0-1)
+	Rostered       []string `json:"rostered"`          // Steam IDs on active rosters (empty = everyone qualifies)
+}
+
+// DraftValueConfig controls the draft value export used for the preseason auction.
+// Weights combine rating, consistency, and map pool breadth; the weighted sum is
+// multiplied by the player's tier multiplier.
+type DraftValueConfig struct {
+	Enabled           bool               `json:"enabled"`             // Write draft values in cumulative mode
+	OutputPath        string             `json:"output_path"`         // CSV output path
+	RatingWeight      float64            `json:"rating_weight"`       // Weight for aggregated final rating
+	ConsistencyWeight float64            `json:"consistency_weight"`  // Weight for game-to-game consistency
+	MapPoolWeight     float64            `json:"map_pool_weight"`     // Weight for map pool breadth
+	TierMultipliers   map[string]float64 `json:"tier_multipliers"`    // Per-tier multiplier
+	MinMapGames       int                `json:"min_map_games"`       // Games needed on a map to count it
+	MapPool           []string           `json:"map_pool"`            // Maps counted toward map pool breadth
+	ConsistencyStdDev float64            `json:"consistency_std_dev"` // Rating standard deviation at which consistency reaches 0
+	Scale             float64            `json:"scale"`               // Scale applied to the final value
+}
+
+// CrossTierConfig controls the tier-normalized combined rating export.
+// TierStrength maps each tier to a coefficient relative to premier (1.0).
+type CrossTierConfig struct {
+	Enabled      bool               `json:"enabled"`       // Write cross-tier ratings in cumulative mode
+	OutputPath   string             `json:"output_path"`   // CSV output path
+	TierStrength map[string]float64 `json:"tier_strength"` // Strength coefficient per tier
+
+	EstimateStrength   bool    `json:"estimate_strength"`    // Derive coefficients from multi-tier players and archived play-ins
+	StrengthOutputPath string  `json:"strength_output_path"` // CSV output path for estimated coefficients
+	MinRoundsPerTier   int     `json:"min_rounds_per_tier"`  // Rounds needed in each tier to count a player
+	PriorWeight        float64 `json:"prior_weight"`         // Pseudo-rounds pulling estimates toward tier_strength
+}
+
+// Baselines are the league averages a tier's ratings are measured against.
+type Baselines struct {
+	KPR  float64 `json:"kpr"`  // Kills per round
+	DPR  float64 `json:"dpr"`  // Deaths per round
+	ADR  float64 `json:"adr"`  // Damage per round
+	KAST float64 `json:"kast"` // KAST as a fraction of rounds, 0-1
 }
 
 // DefaultConfig returns a Config with sensible default values.
 // The defaults point to the CSC demo bucket for season 19 combines.
 func DefaultConfig() *Config {
 	return &Config{
-		Cumulative:       false,
-		Tier:             "",
-		BaseURL:          "https://cscdemos.nyc3.digitaloceanspaces.com/",
-		Prefixes:         []string{"s19/Combines/"},
-		DemoPath:         "",
-		DemoDir:          "./demos",
-		EnableLogging:    true,
-		IgnoreScrims:     false,
-		KDPRModifier:     false,
-		Workers:          8,     // Number of parallel workers (0 = use CPU count)
-		GenerateFiles:    true,  // Generate output files by default
-		CSCCompatibility: false, // Disabled by default
+		Cumulative:        false,
+		Tier:              "",
+		BaseURL:           "https://cscdemos.nyc3.digitaloceanspaces.com/",
+		Prefixes:          []string{"s19/Combines/"},
+		DemoPath:          "",
+		DemoDir:           "./demos",
+		EnableLogging:     true,
+		IgnoreScrims:      false,
+		KDPRModifier:      false,
+		CloseMatchWeight:  1,
+		ExitFragPenalty:   0.02,
+		KillQualityWeight: 0.5,
+		Workers:           8,     // Number of parallel workers (0 = use CPU count)
+		GenerateFiles:     true,  // Generate output files by default
+		CSCCompatibility:  false, // Disabled by default
+		Columns:           "full",
+		DemoErrors:        "demo_errors.csv",
+		DraftValue: DraftValueConfig{
+			Enabled:           false,
+			OutputPath:        "draft_values.csv",
+			RatingWeight:      0.70,
+			ConsistencyWeight: 0.20,
+			MapPoolWeight:     0.10,
+			TierMultipliers: map[string]float64{
+				"premier":    1.00,
+				"elite":      0.85,
+				"challenger": 0.70,
+				"contender":  0.55,
+				"prospect":   0.40,
+				"recruit":    0.25,
+			},
+			MinMapGames: 2,
+			MapPool: []string{
+				"de_ancient", "de_anubis", "de_dust2", "de_inferno", "de_mirage", "de_nuke", "de_overpass",
+			},
+			ConsistencyStdDev: 0.5,
+			Scale:             100,
+		},
+		CrossTier: CrossTierConfig{
+			Enabled:    false,
+			OutputPath: "cross_tier_ratings.csv",
+			TierStrength: map[string]float64{
+				"premier":    1.00,
+				"elite":      0.92,
+				"challenger": 0.85,
+				"contender":  0.78,
+				"prospect":   0.70,
+				"recruit":    0.62,
+			},
+			EstimateStrength:   false,
+			StrengthOutputPath: "tier_strength.csv",
+			MinRoundsPerTier:   48,
+			PriorWeight:        100,
+		},
+		RookieReport: RookieConfig{
+			Enabled:           false,
+			LeaderboardPath:   "rookie_leaderboard.csv",
+			BaselinesPath:     "rookie_baselines.csv",
+			MinRounds:         48,
+			DetectFromArchive: false,
+		},
+		Goals: GoalsConfig{
+			OutputPath: "goals.csv",
+		},
+		Discord: DiscordConfig{
+			PostedPath: "discord_posted.json",
+		},
+		Support: SupportConfig{
+			AutoDetect: true,
+		},
+		Pistol: PistolConfig{
+			MinRounds:     8, // Four maps
+			MinSideRounds: 4,
+		},
+		IGL: IGLConfig{
+			Enabled:    false,
+			OutputPath: "igl_ratings.csv",
+			Rosters:    map[string]string{},
+			Adjustment: 0.05,
+		},
+		Peaks: PeaksConfig{
+			Enabled:          false,
+			StorePath:        "peak_records.json",
+			OutputPath:       "peak_records.csv",
+			LeagueOutputPath: "league_records.csv",
+		},
+		RecordBook: RecordBookConfig{
+			Enabled:    false,
+			OutputPath: "record_book.json",
+			CSVPath:    "record_book.csv",
+		},
+		Teams: TeamStatsConfig{
+			Enabled:    false,
+			OutputPath: "team_stats.csv",
+			JSONPath:   "team_stats.json",
+		},
+		Qualification: QualificationConfig{
+			MinGames:  0,
+			MinRounds: 48,
+		},
+		Convergence: ConvergenceConfig{
+			MaxIterations: 100,
+			Tolerance:     1e-6,
+		},
+		WinProbability: WinProbabilityConfig{
+			MinSamples: 30, // probability.DefaultMinTableSamples
+		},
+		Trades: TradeConfig{
+			WindowSeconds:  5.0,    // rating.TradeWindowSeconds
+			ProximityUnits: 1200.0, // rating.TradeProximityUnits
+		},
+		RoundImportance: RoundImportanceConfig{
+			Model:    "flat",
+			Strength: 0.25,
+		},
+		Metrics: MetricsConfig{
+			Job: "eco-rating",
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "text",
+		},
+		Sheets: SheetsConfig{
+			Credentials:       "credentials.json",
+			Sheet:             "Stats",
+			Mode:              "replace",
+			RequestsPerMinute: 60,
+			Format:            true,
+			ChangeAlerts:      true,
+			AlertsPath:        "sheet_alerts.csv",
+			Tabs: []SheetTabConfig{
+				{Name: "Overview", Columns: "overview"},
+				{Name: "Maps", Columns: "maps"},
+				{Name: "Sides", Columns: "sides"},
+			},
+		},
+		Awards: AwardsConfig{
+			Enabled:        false,
+			OutputDir:      "awards",
+			MinRounds:      150,
+			MinAWPKillsPct: 0.30,
+		},
 	}
 }
 
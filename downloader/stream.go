@@ -0,0 +1,88 @@
+package downloader
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// S3URL converts an s3://bucket/key path into the bucket's virtual-hosted
+// HTTPS URL. AWS_REGION, when set, selects the regional endpoint. Objects
+// are fetched anonymously, so the object must be publicly readable; use a
+// presigned HTTPS URL for private buckets.
+func S3URL(s3Path string) (string, error) {
+	rest := strings.TrimPrefix(s3Path, "s3://")
+	bucket, key, ok := strings.Cut(rest, "/")
+	if !ok || bucket == "" || key == "" {
+		return "", fmt.Errorf("invalid S3 path %q (expected s3://bucket/key)", s3Path)
+	}
+	host := "s3.amazonaws.com"
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		host = "s3." + region + ".amazonaws.com"
+	}
+	return "https://" + bucket + "." + host + "/" + key, nil
+}
+
+// OpenStream opens a demo at an HTTP(S) URL or s3:// path for reading as it
+// downloads, without saving it to disk. A .gz or .bz2 suffix (e.g.
+// match.dem.gz) is decompressed on the fly. Zip archives need random access
+// and can't be streamed; download those with DownloadAndExtract instead.
+// The caller must close the returned reader.
+func OpenStream(source string) (io.ReadCloser, error) {
+	url := source
+	if strings.HasPrefix(source, "s3://") {
+		var err error
+		if url, err = S3URL(source); err != nil {
+			return nil, err
+		}
+	}
+	name := strings.ToLower(path.Base(strings.SplitN(url, "?", 2)[0]))
+	if strings.HasSuffix(name, ".zip") {
+		return nil, fmt.Errorf("cannot stream zip archive %s; download it instead", source)
+	}
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", source, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, source)
+	}
+
+	switch {
+	case strings.HasSuffix(name, ".gz"):
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to decompress %s: %w", source, err)
+		}
+		return &streamReader{Reader: gz, closers: []io.Closer{gz, resp.Body}}, nil
+	case strings.HasSuffix(name, ".bz2"):
+		return &streamReader{Reader: bzip2.NewReader(resp.Body), closers: []io.Closer{resp.Body}}, nil
+	}
+	return resp.Body, nil
+}
+
+// streamReader reads decompressed demo data and closes the decompressor and
+// the underlying response body together.
+type streamReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes every underlying reader, returning the first error.
+func (s *streamReader) Close() error {
+	var first error
+	for _, c := range s.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
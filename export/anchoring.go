@@ -0,0 +1,42 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteAnchorLeaderboard writes each map's CT anchoring leaderboard to a
+// CSV file.
+func WriteAnchorLeaderboard(path string, entries []analysis.AnchorEntry) error {
+	header := []string{
+		"Map", "Rank", "Steam ID", "Name", "Games", "Rounds", "Anchor Score",
+		"Anchor Rounds", "Holds Survived", "Hold Survival Pct",
+		"Anchor Multi Kills", "Anchor Multi Kill Pct",
+		"Site Contacts", "Avg Retake Delay", "CT Bomb Zone Pct", "Rating",
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Map,
+			strconv.Itoa(e.Rank),
+			e.SteamID,
+			e.Name,
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.Rounds),
+			formatFloat(e.AnchorScore),
+			strconv.Itoa(e.AnchorRounds),
+			strconv.Itoa(e.HoldsSurvived),
+			formatFloat(e.HoldSurvivalPct),
+			strconv.Itoa(e.AnchorMultiKills),
+			formatFloat(e.AnchorMultiKillPct),
+			strconv.Itoa(e.SiteContacts),
+			formatFloat(e.AvgRetakeDelay),
+			formatFloat(e.CTBombZonePct),
+			formatFloat(e.Rating),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,35 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteAwardStandings writes one award race's standings to a CSV file.
+func WriteAwardStandings(path, scoreLabel string, standings []output.AwardStanding) error {
+	header := []string{
+		"Tier", "Rank", "Steam ID", "Name", "Games", "Rounds", scoreLabel, "Qualified", "Reason",
+	}
+
+	rows := make([][]string, 0, len(standings))
+	for _, s := range standings {
+		rank := "-"
+		if s.Rank > 0 {
+			rank = strconv.Itoa(s.Rank)
+		}
+		rows = append(rows, []string{
+			s.Tier,
+			rank,
+			s.SteamID,
+			s.Name,
+			strconv.Itoa(s.Games),
+			strconv.Itoa(s.Rounds),
+			formatFloat(s.Score),
+			strconv.FormatBool(s.Qualified),
+			s.Reason,
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
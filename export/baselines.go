@@ -0,0 +1,25 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// WriteBaselines writes a per-map or per-tier baseline table as JSON, in the
+// format rating.LoadMapBaselines and the tier_baselines config read.
+func WriteBaselines(path string, table map[string]rating.Baselines) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baselines: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baselines: %w", err)
+	}
+	return nil
+}
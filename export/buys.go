@@ -0,0 +1,41 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteBuyTendencies writes each player's buy tendency profile to a CSV
+// file: AWP frequency per start-of-round money band, armor-skip rate and the
+// guns held on force buys.
+func WriteBuyTendencies(path string, profiles []analysis.BuyProfile) error {
+	header := []string{"Steam ID", "Name", "Rounds"}
+	for i := range analysis.MoneyBands {
+		band := analysis.MoneyBandLabel(i)
+		header = append(header, "Rounds "+band, "AWP Pct "+band)
+	}
+	header = append(header, "Armor Skip Pct", "Force Buy Rounds", "Force Buy Weapons")
+
+	rows := make([][]string, 0, len(profiles))
+	for _, p := range profiles {
+		row := []string{p.SteamID, p.Name, strconv.Itoa(p.Rounds)}
+		for i := range analysis.MoneyBands {
+			row = append(row, strconv.Itoa(p.BandRounds[i]), formatFloat(p.BandAWPPct[i]))
+		}
+		weapons := make([]string, 0, len(p.ForceWeapons))
+		for _, w := range p.ForceWeapons {
+			weapons = append(weapons, fmt.Sprintf("%s %.0f%%", w.Weapon, 100*w.Pct))
+		}
+		row = append(row,
+			formatFloat(p.ArmorSkipPct),
+			strconv.Itoa(p.ForceRounds),
+			strings.Join(weapons, "; "),
+		)
+		rows = append(rows, row)
+	}
+
+	return writeCSV(path, header, rows)
+}
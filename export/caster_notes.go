@@ -0,0 +1,297 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/archive"
+	"github.com/ethsmith/eco-rating/predict"
+)
+
+// Caster note thresholds.
+const (
+	FormGames         = 3    // Recent games used to measure form
+	HotStreakDelta    = 0.15 // Recent rating above season rating to call a hot streak
+	ColdStreakDelta   = 0.15 // Recent rating below season rating to call a slump
+	MinRosterOverlap  = 3    // Roster players needed to identify a team in an archived game
+	MaxHeadToHeadRows = 10   // Most recent head-to-head games listed
+)
+
+// WriteCasterNotes writes Markdown pre-game notes for a fixture to path.
+func WriteCasterNotes(path string, a *archive.Archive, f predict.Fixture) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(BuildCasterNotes(a, f)), 0644); err != nil {
+		return fmt.Errorf("failed to write caster notes: %w", err)
+	}
+	return nil
+}
+
+// BuildCasterNotes generates Markdown storylines for a fixture from archived
+// games: player form streaks, head-to-head history, and map records.
+func BuildCasterNotes(a *archive.Archive, f predict.Fixture) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s vs %s — Caster Notes\n\n", teamLabel(f.TeamA), teamLabel(f.TeamB))
+	if len(f.Maps) > 0 {
+		fmt.Fprintf(&b, "Maps: %s\n\n", strings.Join(f.Maps, ", "))
+	}
+
+	b.WriteString("## Storylines\n\n")
+	storylines := append(formStorylines(a, f.TeamA), formStorylines(a, f.TeamB)...)
+	if len(storylines) == 0 {
+		b.WriteString("- No notable streaks in the archived games.\n")
+	}
+	for _, s := range storylines {
+		fmt.Fprintf(&b, "- %s\n", s)
+	}
+	b.WriteString("\n")
+
+	writeHeadToHead(&b, a, f)
+	writeMapRecords(&b, a, f)
+	writePlayerForm(&b, a, f.TeamA)
+	writePlayerForm(&b, a, f.TeamB)
+	return b.String()
+}
+
+// teamLabel returns the roster name or a placeholder when it's unnamed.
+func teamLabel(r predict.Roster) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return "Unnamed team"
+}
+
+// rosterTeam returns the team name a roster played under in an archived game,
+// matched by roster overlap or team name, or "" if the roster didn't play.
+func rosterTeam(g *archive.GameRecord, r predict.Roster) string {
+	counts := make(map[string]int)
+	members := make(map[string]bool, len(r.Players))
+	for _, id := range r.Players {
+		members[id] = true
+	}
+	for _, pl := range g.Players {
+		if members[pl.SteamID] {
+			counts[pl.Team]++
+		}
+	}
+	for team, n := range counts {
+		if n >= MinRosterOverlap {
+			return team
+		}
+	}
+	for _, team := range g.Teams() {
+		if r.Name != "" && strings.EqualFold(team, r.Name) {
+			return team
+		}
+	}
+	return ""
+}
+
+// seasonGames returns a player's lines from the latest season in the archive
+// and their games, in play order. An archive without seasons in its MatchIDs
+// counts as one season.
+func seasonGames(a *archive.Archive, steamID string) ([]archive.PlayerLine, []*archive.GameRecord) {
+	lines, games := a.PlayerGames(steamID)
+	season := a.LatestSeason()
+	if season == 0 {
+		return lines, games
+	}
+	var seasonLines []archive.PlayerLine
+	var seasonRecords []*archive.GameRecord
+	for i, g := range games {
+		if archive.ParseSeason(g.MatchID) == season {
+			seasonLines = append(seasonLines, lines[i])
+			seasonRecords = append(seasonRecords, g)
+		}
+	}
+	return seasonLines, seasonRecords
+}
+
+// formStorylines flags roster players whose recent games this season are well
+// above or below their season average.
+func formStorylines(a *archive.Archive, r predict.Roster) []string {
+	var lines []string
+	for _, id := range r.Players {
+		games, _ := seasonGames(a, id)
+		if len(games) == 0 {
+			continue
+		}
+		name := games[len(games)-1].Name
+		aces := 0
+		for _, g := range games {
+			aces += g.Aces
+		}
+		if aces > 0 {
+			lines = append(lines, fmt.Sprintf("**%s** has %d ace(s) on record this season.", name, aces))
+		}
+		if len(games) <= FormGames {
+			continue
+		}
+		season, recent := 0.0, 0.0
+		for i, g := range games {
+			season += g.Rating
+			if i >= len(games)-FormGames {
+				recent += g.Rating
+			}
+		}
+		season /= float64(len(games))
+		recent /= FormGames
+
+		switch {
+		case recent-season >= HotStreakDelta:
+			lines = append(lines, fmt.Sprintf("**%s** (%s) is on a hot streak: %.2f over the last %d games vs %.2f on the season.",
+				name, teamLabel(r), recent, FormGames, season))
+		case season-recent >= ColdStreakDelta:
+			lines = append(lines, fmt.Sprintf("**%s** (%s) is in a slump: %.2f over the last %d games vs %.2f on the season.",
+				name, teamLabel(r), recent, FormGames, season))
+		}
+	}
+	return lines
+}
+
+// writeHeadToHead lists previous games between the two rosters.
+func writeHeadToHead(b *strings.Builder, a *archive.Archive, f predict.Fixture) {
+	b.WriteString("## Head-to-Head\n\n")
+
+	type meeting struct {
+		game         *archive.GameRecord
+		teamA, teamB string
+	}
+	var meetings []meeting
+	for i := range a.Games {
+		g := &a.Games[i]
+		ta, tb := rosterTeam(g, f.TeamA), rosterTeam(g, f.TeamB)
+		if ta != "" && tb != "" && ta != tb {
+			meetings = append(meetings, meeting{game: g, teamA: ta, teamB: tb})
+		}
+	}
+	if len(meetings) == 0 {
+		b.WriteString("No previous meetings in the archive.\n\n")
+		return
+	}
+
+	sort.Slice(meetings, func(i, j int) bool {
+		return meetings[i].game.PlayedAt.After(meetings[j].game.PlayedAt)
+	})
+
+	winsA, winsB := 0, 0
+	for _, m := range meetings {
+		if m.game.Winner == m.teamA {
+			winsA++
+		} else if m.game.Winner == m.teamB {
+			winsB++
+		}
+	}
+	fmt.Fprintf(b, "Series record: %s %d – %d %s\n\n", teamLabel(f.TeamA), winsA, winsB, teamLabel(f.TeamB))
+	b.WriteString("| Date | Map | Score | Top Performer |\n|---|---|---|---|\n")
+	for i, m := range meetings {
+		if i >= MaxHeadToHeadRows {
+			break
+		}
+		top := topPerformer(m.game)
+		fmt.Fprintf(b, "| %s | %s | %d–%d | %s (%.2f) |\n",
+			m.game.PlayedAt.Format("2006-01-02"), m.game.Map,
+			m.game.Score[m.teamA], m.game.Score[m.teamB], top.Name, top.Rating)
+	}
+	b.WriteString("\n")
+}
+
+// writeMapRecords shows each roster's win-loss record on the fixture maps
+// (or every archived map when the fixture lists none).
+func writeMapRecords(b *strings.Builder, a *archive.Archive, f predict.Fixture) {
+	b.WriteString("## Map Records\n\n")
+
+	type record struct{ wins, losses int }
+	recA := make(map[string]*record)
+	recB := make(map[string]*record)
+	mapSet := make(map[string]bool)
+	for _, m := range f.Maps {
+		mapSet[m] = true
+	}
+
+	for i := range a.Games {
+		g := &a.Games[i]
+		if len(f.Maps) == 0 {
+			mapSet[g.Map] = true
+		}
+		for _, side := range []struct {
+			roster predict.Roster
+			rec    map[string]*record
+		}{{f.TeamA, recA}, {f.TeamB, recB}} {
+			team := rosterTeam(g, side.roster)
+			if team == "" {
+				continue
+			}
+			if side.rec[g.Map] == nil {
+				side.rec[g.Map] = &record{}
+			}
+			if g.Winner == team {
+				side.rec[g.Map].wins++
+			} else {
+				side.rec[g.Map].losses++
+			}
+		}
+	}
+
+	maps := make([]string, 0, len(mapSet))
+	for m := range mapSet {
+		maps = append(maps, m)
+	}
+	sort.Strings(maps)
+
+	fmt.Fprintf(b, "| Map | %s | %s |\n|---|---|---|\n", teamLabel(f.TeamA), teamLabel(f.TeamB))
+	format := func(r *record) string {
+		if r == nil {
+			return "–"
+		}
+		return fmt.Sprintf("%d-%d", r.wins, r.losses)
+	}
+	for _, m := range maps {
+		fmt.Fprintf(b, "| %s | %s | %s |\n", m, format(recA[m]), format(recB[m]))
+	}
+	b.WriteString("\n")
+}
+
+// writePlayerForm writes a per-player form table for a roster from the
+// season's games.
+func writePlayerForm(b *strings.Builder, a *archive.Archive, r predict.Roster) {
+	fmt.Fprintf(b, "## %s Player Form\n\n", teamLabel(r))
+	b.WriteString("| Player | Games | Season Rating | Last 3 | Best Game |\n|---|---|---|---|---|\n")
+	for _, id := range r.Players {
+		games, records := seasonGames(a, id)
+		if len(games) == 0 {
+			fmt.Fprintf(b, "| %s | 0 | – | – | – |\n", id)
+			continue
+		}
+		season, recent, recentN := 0.0, 0.0, 0
+		bestIdx := 0
+		for i, g := range games {
+			season += g.Rating
+			if i >= len(games)-FormGames {
+				recent += g.Rating
+				recentN++
+			}
+			if g.Rating > games[bestIdx].Rating {
+				bestIdx = i
+			}
+		}
+		fmt.Fprintf(b, "| %s | %d | %.2f | %.2f | %.2f on %s |\n",
+			games[len(games)-1].Name, len(games), season/float64(len(games)),
+			recent/float64(recentN), games[bestIdx].Rating, records[bestIdx].Map)
+	}
+	b.WriteString("\n")
+}
+
+// topPerformer returns the highest-rated player line in a game.
+func topPerformer(g *archive.GameRecord) archive.PlayerLine {
+	var top archive.PlayerLine
+	for i, pl := range g.Players {
+		if i == 0 || pl.Rating > top.Rating {
+			top = pl
+		}
+	}
+	return top
+}
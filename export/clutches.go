@@ -0,0 +1,30 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteClutches writes clutch descriptors as a JSON document with a
+// top-level "clutches" list.
+func WriteClutches(path string, clutches []model.ClutchDescriptor) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if clutches == nil {
+		clutches = []model.ClutchDescriptor{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Clutches []model.ClutchDescriptor `json:"clutches"`
+	}{clutches}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal clutches: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write clutches: %w", err)
+	}
+	return nil
+}
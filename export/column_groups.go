@@ -0,0 +1,92 @@
+package export
+
+import "strings"
+
+// Column group names, in the order they first appear in the aggregated export.
+const (
+	GroupCore       = "Core"
+	GroupOpening    = "Opening"
+	GroupTrades     = "Trades"
+	GroupClutches   = "Clutches"
+	GroupAWP        = "AWP"
+	GroupRange      = "Range"
+	GroupMultiKills = "Multi Kills"
+	GroupUtility    = "Utility"
+	GroupEconomy    = "Economy"
+	GroupPistols    = "Pistols"
+	GroupTSide      = "T Side"
+	GroupCTSide     = "CT Side"
+	GroupOvertime   = "Overtime"
+	GroupMaps       = "Maps"
+)
+
+// ColumnGroup is a contiguous span of CSV columns in one group. Start and
+// End are 0-based and End is exclusive, matching a sheet's dimension ranges.
+// A group whose columns are not all adjacent gets one span per run.
+type ColumnGroup struct {
+	Name  string
+	Start int
+	End   int
+}
+
+// columnGroup returns the group a CSV header belongs to. Checks run from the
+// most specific to the least, so "T Opening Kills" lands in T Side,
+// "Opening Deaths Traded" in Trades and "Utility Damage Per Round Win" with
+// the other round win splits in Core.
+func columnGroup(header string) string {
+	fields := strings.Fields(header)
+	switch {
+	case len(fields) == 2 && mapColumnNames[fields[0]]:
+		return GroupMaps
+	case strings.HasPrefix(header, "T "):
+		return GroupTSide
+	case strings.HasPrefix(header, "CT "):
+		return GroupCTSide
+	case strings.HasPrefix(header, "OT "):
+		return GroupOvertime
+	case strings.HasPrefix(header, "Pistol Round"):
+		return GroupPistols
+	case strings.Contains(header, " Range "), strings.HasSuffix(header, " Distance"):
+		return GroupRange
+	case strings.Contains(header, "AWP"):
+		return GroupAWP
+	case strings.Contains(header, "Trade"), strings.HasPrefix(header, "Saved "):
+		return GroupTrades
+	case strings.Contains(header, "Opening"):
+		return GroupOpening
+	case strings.Contains(header, "Clutch"):
+		return GroupClutches
+	case strings.Contains(header, "Multi Kill"), strings.HasPrefix(header, "Rounds With Kill"),
+		len(header) == 2 && header[1] == 'K':
+		return GroupMultiKills
+	case strings.HasSuffix(header, "Per Round Win"), strings.HasSuffix(header, "Per Round Loss"):
+		return GroupCore
+	case strings.Contains(header, "Utility"), strings.Contains(header, "Flash"),
+		strings.Contains(header, "Nades"), strings.HasPrefix(header, "Smokes"),
+		strings.HasPrefix(header, "HEs"), strings.HasPrefix(header, "Molotov"),
+		strings.Contains(header, "Smoke"),
+		header == "HE Damage", header == "Fire Damage":
+		return GroupUtility
+	case strings.Contains(header, "Buy"), strings.HasPrefix(header, "Eco Round"), header == "Money Saved",
+		strings.HasPrefix(header, "Kills Vs Eco"), strings.HasPrefix(header, "Decided Round"),
+		strings.Contains(header, "Padding"):
+		return GroupEconomy
+	}
+	return GroupCore
+}
+
+// ColumnGroups splits a CSV header into grouped spans for collapsible
+// sections. A sheet upload keeps each span's first column in view, notes the
+// span's name on its header, and groups the rest of its columns under it.
+func ColumnGroups(header []string) []ColumnGroup {
+	var groups []ColumnGroup
+	for i, h := range header {
+		name := columnGroup(h)
+		if n := len(groups); n > 0 && groups[n-1].Name == name {
+			groups[n-1].End = i + 1
+			continue
+		}
+		groups = append(groups, ColumnGroup{Name: name, Start: i, End: i + 1})
+	}
+	return groups
+}
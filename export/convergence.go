@@ -0,0 +1,29 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteConvergenceReport writes one row per fixed-point solve: how many
+// iterations it took, whether it converged, and the largest value change
+// of its last iteration.
+func WriteConvergenceReport(path string, reports []output.ConvergenceReport) error {
+	header := []string{"Solve", "Values", "Iterations", "Max Iterations", "Converged", "Final Change", "Tolerance"}
+
+	rows := make([][]string, 0, len(reports))
+	for _, r := range reports {
+		rows = append(rows, []string{
+			r.Solve,
+			strconv.Itoa(r.Values),
+			strconv.Itoa(r.Iterations),
+			strconv.Itoa(r.MaxIterations),
+			strconv.FormatBool(r.Converged),
+			strconv.FormatFloat(r.FinalChange, 'g', 3, 64),
+			strconv.FormatFloat(r.Tolerance, 'g', -1, 64),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,56 @@
+package export
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteCrossTierRatings writes tier-normalized combined ratings to a CSV file.
+// The per-tier ratings are included so the normalization can be checked by hand.
+func WriteCrossTierRatings(path string, ratings []output.CrossTierRating) error {
+	header := []string{
+		"Steam ID", "Name", "Tiers", "Games", "Rounds Played",
+		"Tier Ratings", "Blended Rating", "Normalized Rating",
+	}
+
+	rows := make([][]string, 0, len(ratings))
+	for _, r := range ratings {
+		tierRatings := make([]string, 0, len(r.Tiers))
+		for _, t := range r.Tiers {
+			tierRatings = append(tierRatings, t+"="+formatFloat(r.TierRatings[t])+" ("+strconv.Itoa(r.TierRounds[t])+"r)")
+		}
+		rows = append(rows, []string{
+			r.SteamID,
+			r.Name,
+			strings.Join(r.Tiers, ";"),
+			strconv.Itoa(r.Games),
+			strconv.Itoa(r.RoundsPlayed),
+			strings.Join(tierRatings, ";"),
+			formatFloat(r.BlendedRating),
+			formatFloat(r.NormalizedRating),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
+
+// WriteTierStrength writes estimated tier conversion factors to a CSV file.
+func WriteTierStrength(path string, estimates []output.TierStrengthEstimate) error {
+	header := []string{"Tier", "Coefficient", "Prior", "Sample Players", "Sample Rounds", "Play-ins"}
+
+	rows := make([][]string, 0, len(estimates))
+	for _, e := range estimates {
+		rows = append(rows, []string{
+			e.Tier,
+			formatFloat(e.Coefficient),
+			formatFloat(e.Prior),
+			strconv.Itoa(e.SamplePlayers),
+			strconv.Itoa(e.SampleRounds),
+			strconv.Itoa(e.PlayIns),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteCrossValidation writes the eco-rating vs external rating report: a
+// summary row with the correlations and linear fit, then one row per player,
+// largest outliers first.
+func WriteCrossValidation(path string, cv archive.CrossValidation) error {
+	header := []string{"Steam ID", "Name", "Games", "Eco Rating", "External Rating", "Expected External", "Residual", "Z Score", "Outlier"}
+
+	rows := make([][]string, 0, len(cv.Players)+1)
+	rows = append(rows, []string{
+		"", "Summary", strconv.Itoa(len(cv.Players)),
+		"pearson=" + formatFloat(cv.Pearson),
+		"spearman=" + formatFloat(cv.Spearman),
+		"slope=" + formatFloat(cv.Slope),
+		"intercept=" + formatFloat(cv.Intercept),
+		"",
+		strconv.Itoa(cv.Outliers),
+	})
+	for _, p := range cv.Players {
+		rows = append(rows, []string{
+			p.SteamID,
+			p.Name,
+			strconv.Itoa(p.Games),
+			formatFloat(p.Eco),
+			formatFloat(p.External),
+			formatFloat(p.Expected),
+			formatFloat(p.Residual),
+			formatFloat(p.ZScore),
+			strconv.FormatBool(p.Outlier),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -3,6 +3,7 @@
 package export
 
 import (
+	"math"
 	"strconv"
 
 	"github.com/ethsmith/eco-rating/model"
@@ -10,18 +11,23 @@ import (
 
 // ConvertToCSCGame converts ecorating's parsed data to a demoScrape2-compatible Game struct.
 // This allows ecorating to be a drop-in replacement for csgo-demo-worker.
+// The map and tick rate come from match; the tick rate falls back to 64 when
+// the demo didn't report one.
 func ConvertToCSCGame(
 	players map[uint64]*model.PlayerStats,
-	mapName string,
+	match model.MatchInfo,
 	totalRounds int,
-	tickRate int,
 ) *CSCGame {
+	tickRate := int(math.Round(match.TickRate))
+	if tickRate <= 0 {
+		tickRate = 64
+	}
 	game := &CSCGame{
 		CoreID:           "",
 		MapNum:           1,
 		WinnerClanName:   determineWinner(players),
 		Result:           "Ended",
-		MapName:          mapName,
+		MapName:          match.Map,
 		TickRate:         tickRate,
 		TotalRounds:      totalRounds,
 		TotalPlayerStats: make(map[uint64]*CSCPlayerStats),
@@ -0,0 +1,39 @@
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// Files written by WriteDataset.
+const (
+	DatasetRoundsFile     = "rounds.csv"
+	DatasetPlayersFile    = "players.csv"
+	DatasetDictionaryFile = "data_dictionary.csv"
+)
+
+// WriteDataset writes the anonymized public dataset to dir: per-round rows,
+// per-player aggregates, and a data dictionary describing every column.
+// rounds is a table of output.DatasetRound.
+func WriteDataset(dir string, rounds *output.Table, players []output.DatasetPlayer) error {
+	if err := writeCSV(filepath.Join(dir, DatasetRoundsFile), rounds.ColumnNames(), tableValues(rounds)); err != nil {
+		return fmt.Errorf("failed to write rounds: %w", err)
+	}
+
+	playerRows := make([][]string, 0, len(players))
+	for _, p := range players {
+		playerRows = append(playerRows, structValues(p))
+	}
+	if err := writeCSV(filepath.Join(dir, DatasetPlayersFile), structColumns(output.DatasetPlayer{}), playerRows); err != nil {
+		return fmt.Errorf("failed to write players: %w", err)
+	}
+
+	entries := append(dictionaryEntries(DatasetRoundsFile, output.DatasetRound{}),
+		dictionaryEntries(DatasetPlayersFile, output.DatasetPlayer{})...)
+	if err := writeDictionary(filepath.Join(dir, DatasetDictionaryFile), entries); err != nil {
+		return fmt.Errorf("failed to write data dictionary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteDemoCatalog writes quick-scan match summaries to a CSV file, one row
+// per demo with its players listed as "Team: name, name; Team: ...".
+func WriteDemoCatalog(path string, summaries []model.DemoSummary) error {
+	header := []string{"File", "Map", "Team 1", "Team 2", "Score", "Rounds", "Tick Rate", "Duration (min)", "Player Count", "Players", "Error"}
+
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		m := s.Match
+		rows = append(rows, []string{
+			s.File,
+			m.Map,
+			m.Team1,
+			m.Team2,
+			fmt.Sprintf("%d-%d", m.Team1Score, m.Team2Score),
+			strconv.Itoa(m.Team1Score + m.Team2Score),
+			strconv.FormatFloat(m.TickRate, 'f', -1, 64),
+			strconv.FormatFloat(m.DurationSeconds/60, 'f', 1, 64),
+			strconv.Itoa(len(s.Players)),
+			catalogPlayers(s.Players),
+			s.Error,
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
+
+// catalogPlayers lists players grouped by team; they are sorted by team.
+func catalogPlayers(players []model.SummaryPlayer) string {
+	var groups []string
+	for i := 0; i < len(players); {
+		team := players[i].Team
+		var names []string
+		for ; i < len(players) && players[i].Team == team; i++ {
+			names = append(names, players[i].Name)
+		}
+		if team == "" {
+			team = "?"
+		}
+		groups = append(groups, team+": "+strings.Join(names, ", "))
+	}
+	return strings.Join(groups, "; ")
+}
@@ -0,0 +1,27 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteDemoIssues writes the per-file error report of a batch run to a CSV
+// file, one row per demo that couldn't be parsed in full.
+func WriteDemoIssues(path string, issues []model.DemoIssue) error {
+	header := []string{"Demo", "Tier", "Kind", "Partial", "Rounds Kept", "Error"}
+
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			issue.Demo,
+			issue.Tier,
+			issue.Kind,
+			strconv.FormatBool(issue.Partial),
+			strconv.Itoa(issue.Rounds),
+			issue.Error,
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
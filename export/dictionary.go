@@ -0,0 +1,179 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// DataDictionaryFile is the data dictionary written next to every stats
+// export.
+const DataDictionaryFile = "data_dictionary.json"
+
+// DictionaryEntry documents one column of an exported file. Definitions and
+// formula references come from the desc and formula struct tags.
+type DictionaryEntry struct {
+	File       string `json:"file,omitempty"`
+	Column     string `json:"column"`
+	Field      string `json:"field"`
+	Type       string `json:"type"`
+	Definition string `json:"definition"`
+	Formula    string `json:"formula,omitempty"`
+}
+
+// columnName returns the exported name of a struct field: its csv tag, else
+// its json tag, else "" for fields that aren't exported.
+func columnName(f reflect.StructField) string {
+	if name := f.Tag.Get("csv"); name != "" {
+		return name
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// structColumns returns the csv tag of each field of a row struct, in order.
+func structColumns(row any) []string {
+	t := reflect.TypeOf(row)
+	columns := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		columns = append(columns, t.Field(i).Tag.Get("csv"))
+	}
+	return columns
+}
+
+// structValues formats each field of a row struct for CSV: floats to three
+// decimals, everything else in its default form.
+func structValues(row any) []string {
+	v := reflect.ValueOf(row)
+	values := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Float32, reflect.Float64:
+			values = append(values, formatFloat(f.Float()))
+		default:
+			values = append(values, fmt.Sprint(f.Interface()))
+		}
+	}
+	return values
+}
+
+// tableValues formats a table's rows for CSV the way structValues formats
+// row structs.
+func tableValues(t *output.Table) [][]string {
+	columns := t.Columns()
+	rows := make([][]string, t.Len())
+	for i := range rows {
+		row := make([]string, len(columns))
+		for j, c := range columns {
+			switch c.Kind {
+			case reflect.Bool:
+				row[j] = fmt.Sprint(c.Bools[i])
+			case reflect.Int:
+				row[j] = fmt.Sprint(c.Ints[i])
+			case reflect.Float64:
+				row[j] = formatFloat(c.Floats[i])
+			case reflect.String:
+				row[j] = c.Strings[i]
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// dictionaryEntries documents each exported field of a struct from its tags.
+// Fields without a column name are skipped.
+func dictionaryEntries(file string, row any) []DictionaryEntry {
+	t := reflect.TypeOf(row)
+	entries := make([]DictionaryEntry, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		column := columnName(f)
+		if column == "" {
+			continue
+		}
+		entries = append(entries, DictionaryEntry{
+			File:       file,
+			Column:     column,
+			Field:      f.Name,
+			Type:       columnType(f.Type),
+			Definition: f.Tag.Get("desc"),
+			Formula:    f.Tag.Get("formula"),
+		})
+	}
+	return entries
+}
+
+// columnType names a field's type the way a dataset user would read it.
+func columnType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// PlayerStatsDictionary documents every exported PlayerStats field.
+func PlayerStatsDictionary() []DictionaryEntry {
+	return dictionaryEntries("", model.PlayerStats{})
+}
+
+// AggregatedStatsDictionary documents every exported AggregatedStats field.
+func AggregatedStatsDictionary() []DictionaryEntry {
+	return dictionaryEntries("", output.AggregatedStats{})
+}
+
+// WriteDataDictionary writes the PlayerStats and AggregatedStats data
+// dictionary as JSON.
+func WriteDataDictionary(path string) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		PlayerStats     []DictionaryEntry `json:"player_stats"`
+		AggregatedStats []DictionaryEntry `json:"aggregated_stats"`
+	}{PlayerStatsDictionary(), AggregatedStatsDictionary()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal data dictionary: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write data dictionary: %w", err)
+	}
+	return nil
+}
+
+// dataDictionaryPath returns where the data dictionary goes for an export
+// written to exportPath.
+func dataDictionaryPath(exportPath string) string {
+	return filepath.Join(filepath.Dir(exportPath), DataDictionaryFile)
+}
+
+// writeDictionary writes data dictionary entries as CSV.
+func writeDictionary(path string, entries []DictionaryEntry) error {
+	header := []string{"File", "Column", "Type", "Definition", "Formula"}
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.File, e.Column, e.Type, e.Definition, e.Formula})
+	}
+	return writeCSV(path, header, rows)
+}
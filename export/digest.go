@@ -0,0 +1,306 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// Digest settings.
+const (
+	DigestTopPlayers    = 5  // Players listed in the top ratings table
+	DigestMinRounds     = 20 // Rounds in the week needed to appear in rating/ADR tables
+	DigestMaxClutchRows = 5  // Notable clutch entries listed per tier
+)
+
+// WriteDigest writes the weekly Markdown digest for week to path.
+func WriteDigest(path string, a *archive.Archive, week string) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(BuildDigest(a, week)), 0644); err != nil {
+		return fmt.Errorf("failed to write digest: %w", err)
+	}
+	return nil
+}
+
+// weekPlayer accumulates one player's totals for the digest week.
+type weekPlayer struct {
+	steamID       string
+	name          string
+	team          string
+	games         int
+	rounds        int
+	ratingSum     float64
+	kills         int
+	damage        float64
+	openingKills  int
+	awpKills      int
+	clutchWins    int
+	bigClutchWins int
+	aces          int
+}
+
+// BuildDigest generates a Markdown summary of one match week, split by tier:
+// standings movement, top ratings, notable clutches, and stat leaders.
+func BuildDigest(a *archive.Archive, week string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Weekly Digest — %s\n\n", week)
+
+	tiers := make(map[string]bool)
+	for _, g := range a.Games {
+		if g.Week == week {
+			tiers[g.Tier] = true
+		}
+	}
+	if len(tiers) == 0 {
+		b.WriteString("No games were archived for this week.\n")
+		return b.String()
+	}
+	tierNames := make([]string, 0, len(tiers))
+	for t := range tiers {
+		tierNames = append(tierNames, t)
+	}
+	sort.Strings(tierNames)
+
+	for _, tier := range tierNames {
+		fmt.Fprintf(&b, "## %s\n\n", tierHeading(tier))
+		writeStandingsMovement(&b, a, tier, week)
+
+		players := make(map[string]*weekPlayer)
+		for _, g := range a.Games {
+			if g.Week != week || g.Tier != tier {
+				continue
+			}
+			for _, pl := range g.Players {
+				wp := players[pl.SteamID]
+				if wp == nil {
+					wp = &weekPlayer{steamID: pl.SteamID}
+					players[pl.SteamID] = wp
+				}
+				wp.name, wp.team = pl.Name, pl.Team
+				wp.games++
+				wp.rounds += pl.RoundsPlayed
+				wp.ratingSum += pl.Rating
+				wp.kills += pl.Kills
+				wp.damage += pl.ADR * float64(pl.RoundsPlayed)
+				wp.openingKills += pl.OpeningKills
+				wp.awpKills += pl.AWPKills
+				wp.clutchWins += pl.ClutchWins
+				wp.bigClutchWins += pl.BigClutchWins
+				wp.aces += pl.Aces
+			}
+		}
+
+		writeTopRatings(&b, players)
+		writeNotableClutches(&b, players)
+		writeStatLeaders(&b, players)
+	}
+	return b.String()
+}
+
+// tierHeading capitalizes a tier name for a section heading.
+func tierHeading(tier string) string {
+	if tier == "" {
+		return "No Tier"
+	}
+	return strings.ToUpper(tier[:1]) + tier[1:]
+}
+
+// teamRecord is a team's win-loss record.
+type teamRecord struct {
+	team         string
+	wins, losses int
+}
+
+// standings returns team records for a tier up to and including week.
+func standings(a *archive.Archive, tier, week string) []teamRecord {
+	records := make(map[string]*teamRecord)
+	for _, g := range a.Games {
+		if g.Tier != tier || g.Week == "" || archive.CompareWeeks(g.Week, week) > 0 {
+			continue
+		}
+		for _, team := range g.Teams() {
+			if team == "" {
+				continue
+			}
+			if records[team] == nil {
+				records[team] = &teamRecord{team: team}
+			}
+			if g.Winner == team {
+				records[team].wins++
+			} else {
+				records[team].losses++
+			}
+		}
+	}
+	list := make([]teamRecord, 0, len(records))
+	for _, r := range records {
+		list = append(list, *r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].wins != list[j].wins {
+			return list[i].wins > list[j].wins
+		}
+		if list[i].losses != list[j].losses {
+			return list[i].losses < list[j].losses
+		}
+		return list[i].team < list[j].team
+	})
+	return list
+}
+
+// writeStandingsMovement writes the standings after this week with the change
+// in position since the previous week.
+func writeStandingsMovement(b *strings.Builder, a *archive.Archive, tier, week string) {
+	current := standings(a, tier, week)
+	if len(current) == 0 {
+		return
+	}
+
+	previousWeek := ""
+	for _, w := range a.Weeks() {
+		if archive.CompareWeeks(w, week) < 0 {
+			previousWeek = w
+		}
+	}
+	previousRank := make(map[string]int)
+	if previousWeek != "" {
+		for i, r := range standings(a, tier, previousWeek) {
+			previousRank[r.team] = i + 1
+		}
+	}
+
+	b.WriteString("### Standings\n\n| # | Team | W-L | Move |\n|---|---|---|---|\n")
+	for i, r := range current {
+		move := "new"
+		if prev, ok := previousRank[r.team]; ok {
+			switch delta := prev - (i + 1); {
+			case delta > 0:
+				move = fmt.Sprintf("▲%d", delta)
+			case delta < 0:
+				move = fmt.Sprintf("▼%d", -delta)
+			default:
+				move = "–"
+			}
+		}
+		fmt.Fprintf(b, "| %d | %s | %d-%d | %s |\n", i+1, r.team, r.wins, r.losses, move)
+	}
+	b.WriteString("\n")
+}
+
+// writeTopRatings lists the highest average ratings of the week.
+func writeTopRatings(b *strings.Builder, players map[string]*weekPlayer) {
+	var eligible []*weekPlayer
+	for _, p := range players {
+		if p.rounds >= DigestMinRounds {
+			eligible = append(eligible, p)
+		}
+	}
+	sort.Slice(eligible, func(i, j int) bool {
+		ri := eligible[i].ratingSum / float64(eligible[i].games)
+		rj := eligible[j].ratingSum / float64(eligible[j].games)
+		if ri != rj {
+			return ri > rj
+		}
+		return eligible[i].steamID < eligible[j].steamID
+	})
+
+	b.WriteString("### Top Ratings\n\n| # | Player | Team | Games | Rating |\n|---|---|---|---|---|\n")
+	for i, p := range eligible {
+		if i >= DigestTopPlayers {
+			break
+		}
+		fmt.Fprintf(b, "| %d | %s | %s | %d | %.2f |\n", i+1, p.name, p.team, p.games, p.ratingSum/float64(p.games))
+	}
+	b.WriteString("\n")
+}
+
+// writeNotableClutches lists big clutches and aces from the week.
+func writeNotableClutches(b *strings.Builder, players map[string]*weekPlayer) {
+	var notable []*weekPlayer
+	for _, p := range players {
+		if p.bigClutchWins > 0 || p.aces > 0 || p.clutchWins >= 2 {
+			notable = append(notable, p)
+		}
+	}
+	sort.Slice(notable, func(i, j int) bool {
+		if notable[i].bigClutchWins != notable[j].bigClutchWins {
+			return notable[i].bigClutchWins > notable[j].bigClutchWins
+		}
+		if notable[i].aces != notable[j].aces {
+			return notable[i].aces > notable[j].aces
+		}
+		if notable[i].clutchWins != notable[j].clutchWins {
+			return notable[i].clutchWins > notable[j].clutchWins
+		}
+		return notable[i].steamID < notable[j].steamID
+	})
+
+	b.WriteString("### Notable Clutches\n\n")
+	if len(notable) == 0 {
+		b.WriteString("- None this week.\n\n")
+		return
+	}
+	for i, p := range notable {
+		if i >= DigestMaxClutchRows {
+			break
+		}
+		var parts []string
+		if p.bigClutchWins > 0 {
+			parts = append(parts, fmt.Sprintf("%d 1v3+ clutch(es)", p.bigClutchWins))
+		}
+		if p.aces > 0 {
+			parts = append(parts, fmt.Sprintf("%d ace(s)", p.aces))
+		}
+		parts = append(parts, fmt.Sprintf("%d clutch win(s) total", p.clutchWins))
+		fmt.Fprintf(b, "- **%s** (%s): %s\n", p.name, p.team, strings.Join(parts, ", "))
+	}
+	b.WriteString("\n")
+}
+
+// writeStatLeaders lists the leader for several headline stats.
+func writeStatLeaders(b *strings.Builder, players map[string]*weekPlayer) {
+	type leader struct {
+		label string
+		value func(p *weekPlayer) float64
+		min   int
+		fmt   string
+	}
+	leaders := []leader{
+		{"Kills", func(p *weekPlayer) float64 { return float64(p.kills) }, 0, "%.0f"},
+		{"ADR", func(p *weekPlayer) float64 { return p.damage / float64(p.rounds) }, DigestMinRounds, "%.1f"},
+		{"Opening Kills", func(p *weekPlayer) float64 { return float64(p.openingKills) }, 0, "%.0f"},
+		{"AWP Kills", func(p *weekPlayer) float64 { return float64(p.awpKills) }, 0, "%.0f"},
+		{"Clutch Wins", func(p *weekPlayer) float64 { return float64(p.clutchWins) }, 0, "%.0f"},
+	}
+
+	ids := make([]string, 0, len(players))
+	for id := range players {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	b.WriteString("### Stat Leaders\n\n| Stat | Player | Value |\n|---|---|---|\n")
+	for _, l := range leaders {
+		var best *weekPlayer
+		bestValue := 0.0
+		for _, id := range ids {
+			p := players[id]
+			if p.rounds == 0 || p.rounds < l.min {
+				continue
+			}
+			if v := l.value(p); best == nil || v > bestValue {
+				best, bestValue = p, v
+			}
+		}
+		if best == nil {
+			continue
+		}
+		fmt.Fprintf(b, "| %s | %s | "+l.fmt+" |\n", l.label, best.name, bestValue)
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteDraftValues writes draft value scores to a CSV file, including every
+// input to the formula so the auction committee can audit each price.
+func WriteDraftValues(path string, values []output.DraftValue) error {
+	header := []string{
+		"Steam ID", "Name", "Tier", "Games",
+		"Rating", "Rating Std Dev", "Consistency",
+		"Maps Played", "Map Pool Breadth", "Tier Multiplier",
+		"Draft Value",
+	}
+
+	rows := make([][]string, 0, len(values))
+	for _, v := range values {
+		rows = append(rows, []string{
+			v.SteamID,
+			v.Name,
+			v.Tier,
+			strconv.Itoa(v.Games),
+			formatFloat(v.Rating),
+			formatFloat(v.RatingStdDev),
+			formatFloat(v.Consistency),
+			strconv.Itoa(v.MapsPlayed),
+			formatFloat(v.MapPoolBreadth),
+			formatFloat(v.TierMultiplier),
+			formatFloat(v.Value),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
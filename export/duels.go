@@ -0,0 +1,57 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// DuelsTabKeys are the columns identifying a row of the duel matrix tab.
+var DuelsTabKeys = []string{"Steam ID", "Opponent Steam ID"}
+
+// WriteDuels writes the duel matrix as a JSON document with the season's
+// head-to-head records under "season" and each match's under "matches".
+func WriteDuels(path string, season []analysis.Duel, matches []analysis.MatchDuels) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if season == nil {
+		season = []analysis.Duel{}
+	}
+	if matches == nil {
+		matches = []analysis.MatchDuels{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Season  []analysis.Duel       `json:"season"`
+		Matches []analysis.MatchDuels `json:"matches"`
+	}{season, matches}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal duels: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write duels: %w", err)
+	}
+	return nil
+}
+
+// DuelsTable lays head-to-head records out as a spreadsheet tab, one row per
+// player and opponent, keyed by DuelsTabKeys.
+func DuelsTable(duels []analysis.Duel) (header []string, rows [][]string) {
+	header = []string{"Steam ID", "Name", "Opponent Steam ID", "Opponent", "Kills", "Deaths", "Kill Diff"}
+	rows = make([][]string, 0, len(duels))
+	for _, d := range duels {
+		rows = append(rows, []string{
+			d.SteamID,
+			d.Name,
+			d.OpponentID,
+			d.OpponentName,
+			strconv.Itoa(d.Kills),
+			strconv.Itoa(d.Deaths),
+			strconv.Itoa(d.Kills - d.Deaths),
+		})
+	}
+	return header, rows
+}
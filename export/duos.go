@@ -0,0 +1,35 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteEntryDuos writes each player's entry synergy with every teammate to
+// a CSV file.
+func WriteEntryDuos(path string, duos []analysis.EntryDuo) error {
+	header := []string{
+		"Steam ID", "Name", "Partner Steam ID", "Partner", "Rounds Together",
+		"Opening Deaths", "Traded By Partner", "Duo Trade Rate",
+		"Duo Entry Rounds", "Duo Entry Win Rate",
+	}
+
+	rows := make([][]string, 0, len(duos))
+	for _, d := range duos {
+		rows = append(rows, []string{
+			d.SteamID,
+			d.Name,
+			d.PartnerID,
+			d.PartnerName,
+			strconv.Itoa(d.Rounds),
+			strconv.Itoa(d.OpeningDeaths),
+			strconv.Itoa(d.TradedByPartner),
+			formatFloat(d.DuoTradeRate),
+			strconv.Itoa(d.DuoEntryRounds),
+			formatFloat(d.DuoEntryWinRate),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
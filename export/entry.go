@@ -0,0 +1,40 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// EntryTabKeys are the columns identifying a row of the entry leaderboard
+// tab.
+var EntryTabKeys = []string{"Tier", "Steam ID"}
+
+// EntryLeaderboardTable lays the entry leaderboard out as rows, for both
+// the CSV and the spreadsheet tab (see WriteTable).
+func EntryLeaderboardTable(entries []output.EntryFraggerEntry) (header []string, rows [][]string) {
+	header = []string{
+		"Tier", "Rank", "Steam ID", "Name", "Games", "Rounds", "Entry Score",
+		"Opening Attempts", "Opening Attempts Pct", "Opening Success Pct",
+		"Opening Deaths Traded Pct", "Win Pct After Opening Kill", "Final Rating",
+	}
+	rows = make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Tier,
+			strconv.Itoa(e.Rank),
+			e.SteamID,
+			e.Name,
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.Rounds),
+			formatFloat(e.EntryScore),
+			strconv.Itoa(e.OpeningAttempts),
+			formatFloat(e.OpeningAttemptsPct),
+			formatFloat(e.OpeningSuccessPct),
+			formatFloat(e.OpeningDeathsTradedPct),
+			formatFloat(e.WinPctAfterOpeningKill),
+			formatFloat(e.FinalRating),
+		})
+	}
+	return header, rows
+}
@@ -9,6 +9,8 @@
 package export
 
 import (
+	"errors"
+
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/output"
 )
@@ -16,9 +18,74 @@ import (
 // ExportOption defines the interface for exporting player statistics.
 // Implementations can export to different formats (CSV, JSON, database, etc.).
 type ExportOption interface {
-	// Export writes single-game player statistics to the output destination.
-	Export(players map[uint64]*model.PlayerStats) error
+	// Export writes single-game player statistics and the game's match
+	// metadata to the output destination.
+	Export(match model.MatchInfo, players map[uint64]*model.PlayerStats) error
 
 	// ExportAggregated writes aggregated multi-game statistics to the output destination.
 	ExportAggregated(players map[string]*output.AggregatedStats) error
 }
+
+// TableExporter is implemented by export options that can also publish a
+// standalone table, such as a spreadsheet tab beside the stats tabs.
+type TableExporter interface {
+	// ExportTable writes header and rows as the table called name, keyed by
+	// the keys columns.
+	ExportTable(name string, header []string, rows [][]string, keys []string) error
+}
+
+// ExportsTables reports whether o can publish standalone tables: it is a
+// TableExporter, or a MultiExportOption with at least one option that can.
+// A MultiExportOption is always a TableExporter itself, so asserting the
+// interface on it isn't enough.
+func ExportsTables(o ExportOption) bool {
+	if m, ok := o.(MultiExportOption); ok {
+		for _, option := range m {
+			if ExportsTables(option) {
+				return true
+			}
+		}
+		return false
+	}
+	_, ok := o.(TableExporter)
+	return ok
+}
+
+// MultiExportOption sends every export to each of its options in turn.
+type MultiExportOption []ExportOption
+
+// NewMultiExportOption combines options into one ExportOption.
+func NewMultiExportOption(options ...ExportOption) MultiExportOption {
+	return MultiExportOption(options)
+}
+
+// Export runs every option's Export, returning their errors joined.
+func (m MultiExportOption) Export(match model.MatchInfo, players map[uint64]*model.PlayerStats) error {
+	var errs []error
+	for _, o := range m {
+		errs = append(errs, o.Export(match, players))
+	}
+	return errors.Join(errs...)
+}
+
+// ExportAggregated runs every option's ExportAggregated, returning their
+// errors joined.
+func (m MultiExportOption) ExportAggregated(players map[string]*output.AggregatedStats) error {
+	var errs []error
+	for _, o := range m {
+		errs = append(errs, o.ExportAggregated(players))
+	}
+	return errors.Join(errs...)
+}
+
+// ExportTable runs ExportTable on every option that is a TableExporter,
+// returning their errors joined.
+func (m MultiExportOption) ExportTable(name string, header []string, rows [][]string, keys []string) error {
+	var errs []error
+	for _, o := range m {
+		if t, ok := o.(TableExporter); ok {
+			errs = append(errs, t.ExportTable(name, header, rows, keys))
+		}
+	}
+	return errors.Join(errs...)
+}
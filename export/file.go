@@ -25,6 +25,7 @@ import (
 // FileExportOption implements ExportOption for CSV file output.
 type FileExportOption struct {
 	OutputPath string // Path where the CSV file will be written
+	Columns    string // Column preset (see output.ColumnPresets); empty means full
 }
 
 // NewFileExportOption creates a new FileExportOption with the specified output path.
@@ -32,27 +33,46 @@ func NewFileExportOption(outputPath string) *FileExportOption {
 	return &FileExportOption{OutputPath: outputPath}
 }
 
-// Export writes single-game player statistics to a CSV file.
-// Players are sorted by FinalRating in descending order.
-func (f *FileExportOption) Export(players map[uint64]*model.PlayerStats) error {
-	if err := ensureDir(f.OutputPath); err != nil {
+// NewFileExportOptionWithColumns creates a FileExportOption that writes only
+// the columns of the named preset.
+func NewFileExportOptionWithColumns(outputPath, columns string) *FileExportOption {
+	return &FileExportOption{OutputPath: outputPath, Columns: columns}
+}
+
+// Export writes single-game player statistics to a CSV file, with the match
+// metadata next to it. Players are sorted by FinalRating in descending order.
+func (f *FileExportOption) Export(match model.MatchInfo, players map[uint64]*model.PlayerStats) error {
+	playerList := sortedPlayers(players)
+	if err := f.writeStatsCSV(getSingleGameHeader(), singleGameRows(playerList)); err != nil {
 		return err
 	}
 
-	file, err := os.Create(f.OutputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+	if err := f.writePlayerDetailsJSON(playerList); err != nil {
+		return err
+	}
+	if err := WriteMatchInfo(matchInfoPath(f.OutputPath), match); err != nil {
+		return err
 	}
-	defer file.Close()
 
-	w := csv.NewWriter(file)
-	defer w.Flush()
+	return WriteDataDictionary(dataDictionaryPath(f.OutputPath))
+}
 
-	header := getSingleGameHeader()
-	if err := w.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
+// ExportAggregated writes aggregated multi-game statistics to a CSV file,
+// with a player card for each row next to it. Players are sorted first by
+// tier (highest to lowest), then by FinalRating.
+func (f *FileExportOption) ExportAggregated(players map[string]*output.AggregatedStats) error {
+	playerList := sortedAggregated(players)
+	if err := f.writeStatsCSV(getAggregatedHeader(), aggregatedRows(playerList)); err != nil {
+		return err
+	}
+	if err := f.writeAggregatedCardsJSON(players, playerList); err != nil {
+		return err
 	}
+	return WriteDataDictionary(dataDictionaryPath(f.OutputPath))
+}
 
+// sortedPlayers returns single-game players by FinalRating, highest first.
+func sortedPlayers(players map[uint64]*model.PlayerStats) []*model.PlayerStats {
 	playerList := make([]*model.PlayerStats, 0, len(players))
 	for _, p := range players {
 		playerList = append(playerList, p)
@@ -60,57 +80,45 @@ func (f *FileExportOption) Export(players map[uint64]*model.PlayerStats) error {
 	sort.Slice(playerList, func(i, j int) bool {
 		return playerList[i].FinalRating > playerList[j].FinalRating
 	})
+	return playerList
+}
 
+// singleGameRows converts players to CSV rows.
+func singleGameRows(playerList []*model.PlayerStats) [][]string {
+	rows := make([][]string, 0, len(playerList))
 	for _, p := range playerList {
-		row := getSingleGameRow(p)
-		if err := w.Write(row); err != nil {
-			return fmt.Errorf("failed to write row: %w", err)
-		}
+		rows = append(rows, getSingleGameRow(p))
 	}
-
-	if err := f.writePlayerDetailsJSON(playerList); err != nil {
-		return err
-	}
-
-	return nil
+	return rows
 }
 
-// ExportAggregated writes aggregated multi-game statistics to a CSV file.
-// Players are sorted first by tier (highest to lowest), then by FinalRating.
-func (f *FileExportOption) ExportAggregated(players map[string]*output.AggregatedStats) error {
-	if err := ensureDir(f.OutputPath); err != nil {
-		return err
-	}
-
-	file, err := os.Create(f.OutputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	w := csv.NewWriter(file)
-	defer w.Flush()
-
-	header := getAggregatedHeader()
-	if err := w.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
-	}
-
-	tierOrder := map[string]int{
-		"premier":    0,
-		"elite":      1,
-		"challenger": 2,
-		"contender":  3,
-		"prospect":   4,
-		"recruit":    5,
+// sortedAggregated returns aggregated players sorted first by league tier
+// (highest to lowest), then by tier (highest to lowest) or team, qualified
+// players before the rest, then by FinalRating.
+func sortedAggregated(players map[string]*output.AggregatedStats) []*output.AggregatedStats {
+	tierOrder := make(map[string]int, len(tierRanking))
+	for i, tier := range tierRanking {
+		tierOrder[tier] = i
 	}
 	unknownTierBase := len(tierOrder)
+	leagueRank := func(p *output.AggregatedStats) int {
+		if rank, ok := tierOrder[p.LeagueTier]; ok {
+			return rank
+		}
+		return unknownTierBase
+	}
 
 	playerList := make([]*output.AggregatedStats, 0, len(players))
 	for _, p := range players {
 		playerList = append(playerList, p)
 	}
 	sort.Slice(playerList, func(i, j int) bool {
+		if rankI, rankJ := leagueRank(playerList[i]), leagueRank(playerList[j]); rankI != rankJ {
+			return rankI < rankJ
+		}
+		if playerList[i].LeagueTier != playerList[j].LeagueTier {
+			return playerList[i].LeagueTier < playerList[j].LeagueTier
+		}
 		tierI, knownI := tierOrder[playerList[i].Tier]
 		tierJ, knownJ := tierOrder[playerList[j].Tier]
 		if !knownI {
@@ -126,17 +134,31 @@ func (f *FileExportOption) ExportAggregated(players map[string]*output.Aggregate
 		if !knownI && !knownJ && playerList[i].Tier != playerList[j].Tier {
 			return playerList[i].Tier < playerList[j].Tier
 		}
+		// Non-qualified players follow the tier's ranked players
+		if playerList[i].Qualified != playerList[j].Qualified {
+			return playerList[i].Qualified
+		}
 		return playerList[i].FinalRating > playerList[j].FinalRating
 	})
+	return playerList
+}
 
+// aggregatedRows converts players to CSV rows.
+func aggregatedRows(playerList []*output.AggregatedStats) [][]string {
+	rows := make([][]string, 0, len(playerList))
 	for _, p := range playerList {
-		row := getAggregatedRow(p)
-		if err := w.Write(row); err != nil {
-			return fmt.Errorf("failed to write row: %w", err)
-		}
+		rows = append(rows, getAggregatedRow(p))
 	}
+	return rows
+}
 
-	return nil
+// writeStatsCSV writes the columns of the export's preset to OutputPath.
+func (f *FileExportOption) writeStatsCSV(header []string, rows [][]string) error {
+	header, rows, err := output.SelectColumns(header, rows, f.Columns)
+	if err != nil {
+		return err
+	}
+	return writeCSV(f.OutputPath, header, rows)
 }
 
 // ensureDir creates the parent directory for the given path if it doesn't exist.
@@ -148,6 +170,37 @@ func ensureDir(path string) error {
 	return nil
 }
 
+// WriteTable writes a table laid out for both a CSV and a spreadsheet tab,
+// such as a leaderboard, to a CSV file at path.
+func WriteTable(path string, header []string, rows [][]string) error {
+	return writeCSV(path, header, rows)
+}
+
+// writeCSV writes a header and rows to a new CSV file at path.
+func writeCSV(path string, header []string, rows [][]string) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
 type swingSummary struct {
 	Total            float64 `json:"total"`
 	PerRound         float64 `json:"per_round"`
@@ -160,6 +213,7 @@ type playerDetail struct {
 	Name             string                      `json:"name"`
 	FinalRating      float64                     `json:"final_rating"`
 	RoundsPlayed     int                         `json:"rounds_played"`
+	Explanation      string                      `json:"explanation"`
 	RatingBreakdown  model.RatingBreakdown       `json:"rating_breakdown"`
 	ProbabilitySwing swingSummary                `json:"probability_swing"`
 	RoundBreakdowns  []model.RoundSwingBreakdown `json:"round_breakdowns"`
@@ -171,6 +225,7 @@ func newPlayerDetail(p *model.PlayerStats) playerDetail {
 		Name:            p.Name,
 		FinalRating:     p.FinalRating,
 		RoundsPlayed:    p.RoundsPlayed,
+		Explanation:     output.ExplainRating(p),
 		RatingBreakdown: p.RatingBreakdown,
 		ProbabilitySwing: swingSummary{
 			Total:            p.ProbabilitySwing,
@@ -209,6 +264,63 @@ func (f *FileExportOption) writePlayerDetailsJSON(players []*model.PlayerStats)
 	return nil
 }
 
+// aggregatedCard is a player's card for one tier of the aggregated export.
+type aggregatedCard struct {
+	SteamID       string                   `json:"steam_id"`
+	Name          string                   `json:"name"`
+	LeagueTier    string                   `json:"league_tier"`
+	Team          string                   `json:"team,omitempty"`
+	GamesCount    int                      `json:"games_count"`
+	RoundsPlayed  int                      `json:"rounds_played"`
+	FinalRating   float64                  `json:"final_rating"`
+	Qualified     bool                     `json:"qualified"`
+	TrainingFocus []output.FocusSuggestion `json:"training_focus"`
+}
+
+// writeAggregatedCardsJSON writes a card per aggregated row, in playerList
+// order, with the player's training focus within their tier.
+func (f *FileExportOption) writeAggregatedCardsJSON(players map[string]*output.AggregatedStats, playerList []*output.AggregatedStats) error {
+	focus := output.ComputeTrainingFocus(players)
+	focusByRow := make(map[*output.AggregatedStats][]output.FocusSuggestion, len(focus))
+	for key, suggestions := range focus {
+		focusByRow[players[key]] = suggestions
+	}
+
+	cards := make([]aggregatedCard, 0, len(playerList))
+	for _, p := range playerList {
+		card := aggregatedCard{
+			SteamID:       p.SteamID,
+			Name:          p.Name,
+			LeagueTier:    p.LeagueTier,
+			GamesCount:    p.GamesCount,
+			RoundsPlayed:  p.RoundsPlayed,
+			FinalRating:   p.FinalRating,
+			Qualified:     p.Qualified,
+			TrainingFocus: focusByRow[p],
+		}
+		if p.Tier != p.LeagueTier {
+			card.Team = p.Tier
+		}
+		if card.TrainingFocus == nil {
+			card.TrainingFocus = []output.FocusSuggestion{}
+		}
+		cards = append(cards, card)
+	}
+
+	data, err := json.MarshalIndent(cards, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode player cards: %w", err)
+	}
+	outputPath := f.jsonOutputPath()
+	if err := ensureDir(outputPath); err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+	return nil
+}
+
 func (f *FileExportOption) jsonOutputPath() string {
 	base := f.OutputPath
 	ext := filepath.Ext(base)
@@ -227,29 +339,41 @@ func getSingleGameHeader() []string {
 		"Kills", "Assists", "Deaths", "Damage",
 		"ADR", "KPR", "DPR", "KAST", "Survival",
 		"Headshots", "Headshot Pct", "Avg Time To Kill",
+		"Avg Sight To Kill", "Duels", "First Damage Duels", "First Damage Pct",
 		"Opening Kills", "Opening Deaths", "Opening Attempts", "Opening Successes",
 		"Opening Kills Per Round", "Opening Deaths Per Round", "Opening Attempts Pct", "Opening Success Pct",
 		"Rounds Won After Opening", "Win Pct After Opening Kill",
 		"Eco Kill Value", "Eco Death Value", "Duel Swing", "Duel Swing Per Round",
 		"Econ Impact", "Round Impact",
 		"Probability Swing", "Probability Swing Per Round",
-		"Clutch Rounds", "Clutch Wins", "Clutch Points Per Round",
+		"Economy Swing", "Economy Swing Per Round",
+		"Rounds Thrown", "Throw Deaths", "Throw Probability Lost",
+		"Garbage Time Rounds", "Garbage Time Kills", "Garbage Time Damage", "Rating Excl Garbage Time",
+		"Clutch Rounds", "Clutch Wins", "Weighted Clutch Wins", "Leverage Clutch Wins", "Clutch Points Per Round",
 		"Clutch 1v1 Attempts", "Clutch 1v1 Wins", "Clutch 1v1 Win Pct",
 		"Trade Kills", "Trade Kills Per Round", "Trade Kills Pct", "Fast Trades",
 		"Traded Deaths", "Traded Deaths Per Round", "Traded Deaths Pct",
 		"Trade Denials", "Saved By Teammate", "Saved By Teammate Per Round",
 		"Saved Teammate", "Saved Teammate Per Round",
-		"Opening Deaths Traded", "Opening Deaths Traded Pct",
+		"Opening Deaths Traded", "Opening Deaths Traded Pct", "Entry Trades",
 		"AWP Kills", "AWP Kills Per Round", "AWP Kills Pct",
 		"Rounds With AWP Kill", "Rounds With AWP Kill Pct",
 		"AWP Multi Kill Rounds", "AWP Multi Kill Rounds Per Round",
 		"AWP Opening Kills", "AWP Opening Kills Per Round",
 		"AWP Deaths", "AWP Deaths No Kill",
+		"Avg Kill Distance", "Avg Death Distance",
+		"Close Range Kills", "Mid Range Kills", "Long Range Kills",
+		"Close Range Deaths", "Mid Range Deaths", "Long Range Deaths",
+		"Long Range AWP Kills", "Close Range Rifle Deaths",
 		"1K", "2K", "3K", "4K", "5K",
 		"Rounds With Kill", "Rounds With Kill Pct",
 		"Rounds With Multi Kill", "Rounds With Multi Kill Pct",
+		"Multi Kill Points", "Weighted Multi Kills", "Leverage Multi Kills", "Multi Kill Context Weight", "Multi Kill Swing",
 		"Kills In Won Rounds", "Kills Per Round Win",
 		"Damage In Won Rounds", "Damage Per Round Win",
+		"Deaths Per Round Win", "Utility Damage Per Round Win",
+		"Kills Per Round Loss", "Deaths Per Round Loss",
+		"Damage Per Round Loss", "Utility Damage Per Round Loss",
 		"Perfect Kills", "Damage Per Kill", "Knife Kills", "Pistol Vs Rifle Kills",
 		"Support Rounds", "Support Rounds Pct",
 		"Assisted Kills", "Assisted Kills Pct", "Assists Per Round",
@@ -265,8 +389,12 @@ func getSingleGameHeader() []string {
 		"Exit Frags", "Early Deaths",
 		"Man Advantage Kills", "Man Advantage Kills Pct",
 		"Man Disadvantage Deaths", "Man Disadvantage Deaths Pct",
+		"Eco Rounds", "Eco Round Kills", "Eco Round Win Pct",
+		"Force Buy Rounds", "Force Buy Win Pct", "Full Buy Rounds", "Full Buy Win Pct", "Money Saved",
 		"Low Buy Kills", "Low Buy Kills Pct",
 		"Disadvantaged Buy Kills", "Disadvantaged Buy Kills Pct",
+		"Kills Vs Eco", "Kills Vs Eco Pct", "Decided Round Kills", "Decided Round Kills Pct",
+		"Padding Kills", "Stat Padding Index",
 		"Pistol Rounds Played", "Pistol Round Kills", "Pistol Round Deaths",
 		"Pistol Round Damage", "Pistol Rounds Won", "Pistol Round Survivals",
 		"Pistol Round Multi Kills", "Pistol Round Rating",
@@ -282,6 +410,10 @@ func getSingleGameHeader() []string {
 		"CT Man Advantage Kills", "CT Man Advantage Kills Pct",
 		"CT Man Disadvantage Deaths", "CT Man Disadvantage Deaths Pct",
 		"CT Rating", "CT Eco Rating",
+		"OT Rounds Played", "OT Kills", "OT Deaths", "OT Damage", "OT Survivals",
+		"OT Rounds With Multi Kill", "OT Eco Kill Value", "OT KAST",
+		"OT Clutch Rounds", "OT Clutch Wins",
+		"OT Rating", "OT Eco Rating",
 		// demoScrape2 compatibility stats
 		"Clutch 1v2 Attempts", "Clutch 1v2 Wins",
 		"Clutch 1v3 Attempts", "Clutch 1v3 Wins",
@@ -289,6 +421,8 @@ func getSingleGameHeader() []string {
 		"Clutch 1v5 Attempts", "Clutch 1v5 Wins",
 		"Smokes Thrown", "HEs Thrown", "Molotovs Thrown", "Total Nades Thrown",
 		"HE Damage", "Fire Damage",
+		"Molotov Zone Damage", "Molotov Zone Kills", "Molotov Denial Time",
+		"Execute Smokes", "Smoke Sightlines Blocked",
 		"Damage Taken", "Avg Time To Death",
 		"T Opening Kills", "T Opening Deaths",
 		"CT Opening Kills", "CT Opening Deaths",
@@ -318,6 +452,10 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.Headshots),
 		formatFloat(p.HeadshotPct),
 		formatFloat(p.AvgTimeToKill),
+		formatFloat(p.AvgSightToKill),
+		strconv.Itoa(p.Duels),
+		strconv.Itoa(p.FirstDamageDuels),
+		formatFloat(p.FirstDamagePct),
 		strconv.Itoa(p.OpeningKills),
 		strconv.Itoa(p.OpeningDeaths),
 		strconv.Itoa(p.OpeningAttempts),
@@ -336,8 +474,19 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.RoundImpact),
 		formatFloat(p.ProbabilitySwing),
 		formatFloat(p.ProbabilitySwingPerRound),
+		formatFloat(p.EconomySwing),
+		formatFloat(p.EconomySwingPerRound),
+		strconv.Itoa(p.RoundsThrown),
+		strconv.Itoa(p.ThrowDeaths),
+		formatFloat(p.ThrowProbabilityLost),
+		strconv.Itoa(p.GarbageTimeRounds),
+		strconv.Itoa(p.GarbageTimeKills),
+		strconv.Itoa(p.GarbageTimeDamage),
+		formatFloat(p.RatingExclGarbageTime),
 		strconv.Itoa(p.ClutchRounds),
 		strconv.Itoa(p.ClutchWins),
+		formatFloat(p.WeightedClutchWins),
+		formatFloat(p.LeverageClutchWins),
 		formatFloat(p.ClutchPointsPerRound),
 		strconv.Itoa(p.Clutch1v1Attempts),
 		strconv.Itoa(p.Clutch1v1Wins),
@@ -356,6 +505,7 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.SavedTeammatePerRound),
 		strconv.Itoa(p.OpeningDeathsTraded),
 		formatFloat(p.OpeningDeathsTradedPct),
+		strconv.Itoa(p.EntryTrades),
 		strconv.Itoa(p.AWPKills),
 		formatFloat(p.AWPKillsPerRound),
 		formatFloat(p.AWPKillsPct),
@@ -367,6 +517,16 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.AWPOpeningKillsPerRound),
 		strconv.Itoa(p.AWPDeaths),
 		strconv.Itoa(p.AWPDeathsNoKill),
+		formatFloat(p.AvgKillDistance),
+		formatFloat(p.AvgDeathDistance),
+		strconv.Itoa(p.CloseRangeKills),
+		strconv.Itoa(p.MidRangeKills),
+		strconv.Itoa(p.LongRangeKills),
+		strconv.Itoa(p.CloseRangeDeaths),
+		strconv.Itoa(p.MidRangeDeaths),
+		strconv.Itoa(p.LongRangeDeaths),
+		strconv.Itoa(p.LongRangeAWPKills),
+		strconv.Itoa(p.CloseRangeRifleDeaths),
 		strconv.Itoa(p.MultiKills.OneK),
 		strconv.Itoa(p.MultiKills.TwoK),
 		strconv.Itoa(p.MultiKills.ThreeK),
@@ -376,10 +536,21 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.RoundsWithKillPct),
 		strconv.Itoa(p.RoundsWithMultiKill),
 		formatFloat(p.RoundsWithMultiKillPct),
+		strconv.Itoa(p.MultiKillPoints),
+		formatFloat(p.WeightedMultiKills),
+		formatFloat(p.LeverageMultiKills),
+		formatFloat(p.MultiKillContextWeight),
+		formatFloat(p.MultiKillSwing),
 		strconv.Itoa(p.KillsInWonRounds),
 		formatFloat(p.KillsPerRoundWin),
 		strconv.Itoa(p.DamageInWonRounds),
 		formatFloat(p.DamagePerRoundWin),
+		formatFloat(p.DeathsPerRoundWin),
+		formatFloat(p.UtilDamagePerRoundWin),
+		formatFloat(p.KillsPerRoundLoss),
+		formatFloat(p.DeathsPerRoundLoss),
+		formatFloat(p.DamagePerRoundLoss),
+		formatFloat(p.UtilDamagePerRoundLoss),
 		strconv.Itoa(p.PerfectKills),
 		formatFloat(p.DamagePerKill),
 		strconv.Itoa(p.KnifeKills),
@@ -413,10 +584,24 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.ManAdvantageKillsPct),
 		strconv.Itoa(p.ManDisadvantageDeaths),
 		formatFloat(p.ManDisadvantageDeathsPct),
+		strconv.Itoa(p.EcoRounds),
+		strconv.Itoa(p.EcoRoundKills),
+		formatFloat(p.EcoRoundWinPct),
+		strconv.Itoa(p.ForceBuyRounds),
+		formatFloat(p.ForceBuyWinPct),
+		strconv.Itoa(p.FullBuyRounds),
+		formatFloat(p.FullBuyWinPct),
+		strconv.Itoa(p.MoneySaved),
 		strconv.Itoa(p.LowBuyKills),
 		formatFloat(p.LowBuyKillsPct),
 		strconv.Itoa(p.DisadvantagedBuyKills),
 		formatFloat(p.DisadvantagedBuyKillsPct),
+		strconv.Itoa(p.KillsVsEco),
+		formatFloat(p.KillsVsEcoPct),
+		strconv.Itoa(p.DecidedRoundKills),
+		formatFloat(p.DecidedRoundKillsPct),
+		strconv.Itoa(p.PaddingKills),
+		formatFloat(p.StatPaddingIndex),
 		strconv.Itoa(p.PistolRoundsPlayed),
 		strconv.Itoa(p.PistolRoundKills),
 		strconv.Itoa(p.PistolRoundDeaths),
@@ -457,6 +642,18 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		formatFloat(p.CTManDisadvantageDeathsPct),
 		formatFloat(p.CTRating),
 		formatFloat(p.CTEcoRating),
+		strconv.Itoa(p.OTRoundsPlayed),
+		strconv.Itoa(p.OTKills),
+		strconv.Itoa(p.OTDeaths),
+		strconv.Itoa(p.OTDamage),
+		strconv.Itoa(p.OTSurvivals),
+		strconv.Itoa(p.OTRoundsWithMultiKill),
+		formatFloat(p.OTEcoKillValue),
+		formatFloat(p.OTKAST),
+		strconv.Itoa(p.OTClutchRounds),
+		strconv.Itoa(p.OTClutchWins),
+		formatFloat(p.OTRating),
+		formatFloat(p.OTEcoRating),
 		// demoScrape2 compatibility stats
 		strconv.Itoa(p.Clutch1v2Attempts),
 		strconv.Itoa(p.Clutch1v2Wins),
@@ -472,6 +669,11 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 		strconv.Itoa(p.TotalNadesThrown),
 		strconv.Itoa(p.HEDamage),
 		strconv.Itoa(p.FireDamage),
+		strconv.Itoa(p.MolotovZoneDamage),
+		strconv.Itoa(p.MolotovZoneKills),
+		formatFloat(p.MolotovDenialTime),
+		strconv.Itoa(p.ExecuteSmokes),
+		strconv.Itoa(p.SmokeSightlinesBlocked),
 		strconv.Itoa(p.DamageTaken),
 		formatFloat(p.AvgTimeToDeath),
 		strconv.Itoa(p.TOpeningKills),
@@ -486,34 +688,47 @@ func getSingleGameRow(p *model.PlayerStats) []string {
 // Includes additional columns for games count, tier, and per-map statistics.
 func getAggregatedHeader() []string {
 	return []string{
-		"Steam ID", "Name", "Tier", "Games", "Final Rating", "HLTV Rating",
+		"Steam ID", "Name", "Tier", "Qualified", "Games", "Final Rating", "HLTV Rating",
 		"Rounds Played", "Rounds Won", "Rounds Lost",
 		"Kills", "Assists", "Deaths", "Damage",
 		"ADR", "KPR", "DPR", "KAST", "Survival",
 		"Headshots", "Headshot Pct", "Avg Time To Kill",
+		"Avg Sight To Kill", "Duels", "First Damage Duels", "First Damage Pct",
+		"Kill Quality", "Opponent Adjusted Rating", "Support Profile", "Support Rating",
 		"Opening Kills", "Opening Deaths", "Opening Attempts", "Opening Successes",
 		"Opening Kills Per Round", "Opening Deaths Per Round", "Opening Attempts Pct", "Opening Success Pct",
 		"Rounds Won After Opening", "Win Pct After Opening Kill",
 		"Eco Kill Value", "Eco Death Value", "Duel Swing", "Duel Swing Per Round",
 		"Econ Impact", "Round Impact",
 		"Probability Swing", "Probability Swing Per Round",
-		"Clutch Rounds", "Clutch Wins", "Clutch Points Per Round",
+		"Economy Swing", "Economy Swing Per Round",
+		"Rounds Thrown", "Throw Deaths", "Throw Probability Lost",
+		"Garbage Time Rounds", "Garbage Time Kills", "Garbage Time Damage", "Rating Excl Garbage Time",
+		"Clutch Rounds", "Clutch Wins", "Weighted Clutch Wins", "Leverage Clutch Wins", "Clutch Points Per Round",
 		"Clutch 1v1 Attempts", "Clutch 1v1 Wins", "Clutch 1v1 Win Pct",
 		"Trade Kills", "Trade Kills Per Round", "Trade Kills Pct", "Fast Trades",
 		"Traded Deaths", "Traded Deaths Per Round", "Traded Deaths Pct",
 		"Trade Denials", "Saved By Teammate", "Saved By Teammate Per Round",
 		"Saved Teammate", "Saved Teammate Per Round",
-		"Opening Deaths Traded", "Opening Deaths Traded Pct",
+		"Opening Deaths Traded", "Opening Deaths Traded Pct", "Entry Trades",
 		"AWP Kills", "AWP Kills Per Round", "AWP Kills Pct",
 		"Rounds With AWP Kill", "Rounds With AWP Kill Pct",
 		"AWP Multi Kill Rounds", "AWP Multi Kill Rounds Per Round",
 		"AWP Opening Kills", "AWP Opening Kills Per Round",
 		"AWP Deaths", "AWP Deaths No Kill",
+		"Avg Kill Distance", "Avg Death Distance",
+		"Close Range Kills", "Mid Range Kills", "Long Range Kills",
+		"Close Range Deaths", "Mid Range Deaths", "Long Range Deaths",
+		"Long Range AWP Kills", "Close Range Rifle Deaths",
 		"1K", "2K", "3K", "4K", "5K",
 		"Rounds With Kill", "Rounds With Kill Pct",
 		"Rounds With Multi Kill", "Rounds With Multi Kill Pct",
+		"Multi Kill Points", "Weighted Multi Kills", "Leverage Multi Kills", "Multi Kill Context Weight", "Multi Kill Swing",
 		"Kills In Won Rounds", "Kills Per Round Win",
 		"Damage In Won Rounds", "Damage Per Round Win",
+		"Deaths Per Round Win", "Utility Damage Per Round Win",
+		"Kills Per Round Loss", "Deaths Per Round Loss",
+		"Damage Per Round Loss", "Utility Damage Per Round Loss",
 		"Perfect Kills", "Damage Per Kill", "Knife Kills", "Pistol Vs Rifle Kills",
 		"Support Rounds", "Support Rounds Pct",
 		"Assisted Kills", "Assisted Kills Pct", "Assists Per Round",
@@ -529,8 +744,12 @@ func getAggregatedHeader() []string {
 		"Exit Frags", "Early Deaths",
 		"Man Advantage Kills", "Man Advantage Kills Pct",
 		"Man Disadvantage Deaths", "Man Disadvantage Deaths Pct",
+		"Eco Rounds", "Eco Round Kills", "Eco Round Win Pct",
+		"Force Buy Rounds", "Force Buy Win Pct", "Full Buy Rounds", "Full Buy Win Pct", "Money Saved",
 		"Low Buy Kills", "Low Buy Kills Pct",
 		"Disadvantaged Buy Kills", "Disadvantaged Buy Kills Pct",
+		"Kills Vs Eco", "Kills Vs Eco Pct", "Decided Round Kills", "Decided Round Kills Pct",
+		"Padding Kills", "Stat Padding Index",
 		"Pistol Rounds Played", "Pistol Round Kills", "Pistol Round Deaths",
 		"Pistol Round Damage", "Pistol Rounds Won", "Pistol Round Survivals",
 		"Pistol Round Multi Kills", "Pistol Round Rating",
@@ -546,6 +765,10 @@ func getAggregatedHeader() []string {
 		"CT Man Advantage Kills", "CT Man Advantage Kills Pct",
 		"CT Man Disadvantage Deaths", "CT Man Disadvantage Deaths Pct",
 		"CT Rating", "CT Eco Rating",
+		"OT Rounds Played", "OT Kills", "OT Deaths", "OT Damage", "OT Survivals",
+		"OT Rounds With Multi Kill", "OT Eco Kill Value", "OT KAST",
+		"OT Clutch Rounds", "OT Clutch Wins",
+		"OT Rating", "OT Eco Rating",
 		// demoScrape2 compatibility stats
 		"Clutch 1v2 Attempts", "Clutch 1v2 Wins",
 		"Clutch 1v3 Attempts", "Clutch 1v3 Wins",
@@ -553,6 +776,8 @@ func getAggregatedHeader() []string {
 		"Clutch 1v5 Attempts", "Clutch 1v5 Wins",
 		"Smokes Thrown", "HEs Thrown", "Molotovs Thrown", "Total Nades Thrown",
 		"HE Damage", "Fire Damage",
+		"Molotov Zone Damage", "Molotov Zone Kills", "Molotov Denial Time",
+		"Execute Smokes", "Smoke Sightlines Blocked",
 		"Damage Taken", "Avg Time To Death",
 		"T Opening Kills", "T Opening Deaths",
 		"CT Opening Kills", "CT Opening Deaths",
@@ -564,6 +789,7 @@ func getAggregatedHeader() []string {
 		"Mirage Rating", "Mirage Games",
 		"Nuke Rating", "Nuke Games",
 		"Overpass Rating", "Overpass Games",
+		"Close Games", "League Tier",
 	}
 }
 
@@ -573,6 +799,7 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		p.SteamID,
 		p.Name,
 		p.Tier,
+		strconv.FormatBool(p.Qualified),
 		strconv.Itoa(p.GamesCount),
 		formatFloat(p.FinalRating),
 		formatFloat(p.HLTVRating),
@@ -591,6 +818,14 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.Headshots),
 		formatFloat(p.HeadshotPct),
 		formatFloat(p.AvgTimeToKill),
+		formatFloat(p.AvgSightToKill),
+		strconv.Itoa(p.Duels),
+		strconv.Itoa(p.FirstDamageDuels),
+		formatFloat(p.FirstDamagePct),
+		formatFloat(p.KillQuality),
+		formatFloat(p.OpponentAdjustedRating),
+		strconv.FormatBool(p.SupportProfile),
+		formatFloat(p.SupportRating),
 		strconv.Itoa(p.OpeningKills),
 		strconv.Itoa(p.OpeningDeaths),
 		strconv.Itoa(p.OpeningAttempts),
@@ -609,8 +844,19 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.RoundImpact),
 		formatFloat(p.ProbabilitySwing),
 		formatFloat(p.ProbabilitySwingPerRound),
+		formatFloat(p.EconomySwing),
+		formatFloat(p.EconomySwingPerRound),
+		strconv.Itoa(p.RoundsThrown),
+		strconv.Itoa(p.ThrowDeaths),
+		formatFloat(p.ThrowProbabilityLost),
+		strconv.Itoa(p.GarbageTimeRounds),
+		strconv.Itoa(p.GarbageTimeKills),
+		strconv.Itoa(p.GarbageTimeDamage),
+		formatFloat(p.RatingExclGarbageTime),
 		strconv.Itoa(p.ClutchRounds),
 		strconv.Itoa(p.ClutchWins),
+		formatFloat(p.WeightedClutchWins),
+		formatFloat(p.LeverageClutchWins),
 		formatFloat(p.ClutchPointsPerRound),
 		strconv.Itoa(p.Clutch1v1Attempts),
 		strconv.Itoa(p.Clutch1v1Wins),
@@ -629,6 +875,7 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.SavedTeammatePerRound),
 		strconv.Itoa(p.OpeningDeathsTraded),
 		formatFloat(p.OpeningDeathsTradedPct),
+		strconv.Itoa(p.EntryTrades),
 		strconv.Itoa(p.AWPKills),
 		formatFloat(p.AWPKillsPerRound),
 		formatFloat(p.AWPKillsPct),
@@ -640,6 +887,16 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.AWPOpeningKillsPerRound),
 		strconv.Itoa(p.AWPDeaths),
 		strconv.Itoa(p.AWPDeathsNoKill),
+		formatFloat(p.AvgKillDistance),
+		formatFloat(p.AvgDeathDistance),
+		strconv.Itoa(p.CloseRangeKills),
+		strconv.Itoa(p.MidRangeKills),
+		strconv.Itoa(p.LongRangeKills),
+		strconv.Itoa(p.CloseRangeDeaths),
+		strconv.Itoa(p.MidRangeDeaths),
+		strconv.Itoa(p.LongRangeDeaths),
+		strconv.Itoa(p.LongRangeAWPKills),
+		strconv.Itoa(p.CloseRangeRifleDeaths),
 		strconv.Itoa(p.MultiKills.OneK),
 		strconv.Itoa(p.MultiKills.TwoK),
 		strconv.Itoa(p.MultiKills.ThreeK),
@@ -649,10 +906,21 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.RoundsWithKillPct),
 		strconv.Itoa(p.RoundsWithMultiKill),
 		formatFloat(p.RoundsWithMultiKillPct),
+		strconv.Itoa(p.MultiKillPoints),
+		formatFloat(p.WeightedMultiKills),
+		formatFloat(p.LeverageMultiKills),
+		formatFloat(p.MultiKillContextWeight),
+		formatFloat(p.MultiKillSwing),
 		strconv.Itoa(p.KillsInWonRounds),
 		formatFloat(p.KillsPerRoundWin),
 		strconv.Itoa(p.DamageInWonRounds),
 		formatFloat(p.DamagePerRoundWin),
+		formatFloat(p.DeathsPerRoundWin),
+		formatFloat(p.UtilDamagePerRoundWin),
+		formatFloat(p.KillsPerRoundLoss),
+		formatFloat(p.DeathsPerRoundLoss),
+		formatFloat(p.DamagePerRoundLoss),
+		formatFloat(p.UtilDamagePerRoundLoss),
 		strconv.Itoa(p.PerfectKills),
 		formatFloat(p.DamagePerKill),
 		strconv.Itoa(p.KnifeKills),
@@ -686,10 +954,24 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.ManAdvantageKillsPct),
 		strconv.Itoa(p.ManDisadvantageDeaths),
 		formatFloat(p.ManDisadvantageDeathsPct),
+		strconv.Itoa(p.EcoRounds),
+		strconv.Itoa(p.EcoRoundKills),
+		formatFloat(p.EcoRoundWinPct),
+		strconv.Itoa(p.ForceBuyRounds),
+		formatFloat(p.ForceBuyWinPct),
+		strconv.Itoa(p.FullBuyRounds),
+		formatFloat(p.FullBuyWinPct),
+		strconv.Itoa(p.MoneySaved),
 		strconv.Itoa(p.LowBuyKills),
 		formatFloat(p.LowBuyKillsPct),
 		strconv.Itoa(p.DisadvantagedBuyKills),
 		formatFloat(p.DisadvantagedBuyKillsPct),
+		strconv.Itoa(p.KillsVsEco),
+		formatFloat(p.KillsVsEcoPct),
+		strconv.Itoa(p.DecidedRoundKills),
+		formatFloat(p.DecidedRoundKillsPct),
+		strconv.Itoa(p.PaddingKills),
+		formatFloat(p.StatPaddingIndex),
 		strconv.Itoa(p.PistolRoundsPlayed),
 		strconv.Itoa(p.PistolRoundKills),
 		strconv.Itoa(p.PistolRoundDeaths),
@@ -730,6 +1012,18 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		formatFloat(p.CTManDisadvantageDeathsPct),
 		formatFloat(p.CTRating),
 		formatFloat(p.CTEcoRating),
+		strconv.Itoa(p.OTRoundsPlayed),
+		strconv.Itoa(p.OTKills),
+		strconv.Itoa(p.OTDeaths),
+		strconv.Itoa(p.OTDamage),
+		strconv.Itoa(p.OTSurvivals),
+		strconv.Itoa(p.OTRoundsWithMultiKill),
+		formatFloat(p.OTEcoKillValue),
+		formatFloat(p.OTKAST),
+		strconv.Itoa(p.OTClutchRounds),
+		strconv.Itoa(p.OTClutchWins),
+		formatFloat(p.OTRating),
+		formatFloat(p.OTEcoRating),
 		// demoScrape2 compatibility stats
 		strconv.Itoa(p.Clutch1v2Attempts),
 		strconv.Itoa(p.Clutch1v2Wins),
@@ -745,6 +1039,11 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		strconv.Itoa(p.TotalNadesThrown),
 		strconv.Itoa(p.HEDamage),
 		strconv.Itoa(p.FireDamage),
+		strconv.Itoa(p.MolotovZoneDamage),
+		strconv.Itoa(p.MolotovZoneKills),
+		formatFloat(p.MolotovDenialTime),
+		strconv.Itoa(p.ExecuteSmokes),
+		strconv.Itoa(p.SmokeSightlinesBlocked),
 		strconv.Itoa(p.DamageTaken),
 		formatFloat(p.AvgTimeToDeath),
 		strconv.Itoa(p.TOpeningKills),
@@ -766,6 +1065,8 @@ func getAggregatedRow(p *output.AggregatedStats) []string {
 		getMapGames(p, "de_nuke"),
 		getMapRating(p, "de_overpass"),
 		getMapGames(p, "de_overpass"),
+		strconv.Itoa(p.CloseGames),
+		p.LeagueTier,
 	}
 }
 
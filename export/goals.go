@@ -0,0 +1,64 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// goalsHeader is the header of the goals CSV.
+var goalsHeader = []string{"Week", "Steam ID", "Name", "Tier", "Goal", "Actual", "Met", "Margin"}
+
+// WriteGoals adds each player's goal attainment for week to the goals CSV at
+// path, keeping the rows of earlier weeks so the file is a weekly history.
+// Rows already written for week are replaced, so re-running a week doesn't
+// duplicate it. A file without the Week column is started over.
+func WriteGoals(path, week string, results []output.GoalResult) error {
+	rows, err := readGoalsHistory(path)
+	if err != nil {
+		return err
+	}
+	rows = slices.DeleteFunc(rows, func(row []string) bool { return row[0] == week })
+
+	for _, r := range results {
+		rows = append(rows, []string{
+			week,
+			r.SteamID,
+			r.Name,
+			r.Tier,
+			r.Goal.String(),
+			formatFloat(r.Actual),
+			strconv.FormatBool(r.Met),
+			formatFloat(r.Margin),
+		})
+	}
+
+	return writeCSV(path, goalsHeader, rows)
+}
+
+// readGoalsHistory returns the rows of an existing goals CSV, or none when
+// the file doesn't exist or predates the Week column.
+func readGoalsHistory(path string) ([][]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open goals history: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read goals history: %w", err)
+	}
+	if len(records) == 0 || !slices.Equal(records[0], goalsHeader) {
+		return nil, nil
+	}
+	return records[1:], nil
+}
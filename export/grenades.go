@@ -0,0 +1,27 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteGrenades writes grenade throws as a JSON document with a top-level
+// "maps" object keyed by map name.
+func WriteGrenades(path string, byMap map[string][]model.GrenadeThrow) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		Maps map[string][]model.GrenadeThrow `json:"maps"`
+	}{byMap}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal grenades: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write grenades: %w", err)
+	}
+	return nil
+}
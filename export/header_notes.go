@@ -0,0 +1,60 @@
+package export
+
+import "strings"
+
+// headerAliases maps CSV headers whose names don't normalize to a field's
+// json name onto that field.
+var headerAliases = map[string]string{
+	"Games": "games_count",
+	"1K":    "multi_kills",
+	"2K":    "multi_kills",
+	"3K":    "multi_kills",
+	"4K":    "multi_kills",
+	"5K":    "multi_kills",
+}
+
+// headerColumn returns the data dictionary column a CSV header describes:
+// "Rounds With Multi Kill" becomes "rounds_with_multi_kill". Per-map columns
+// such as "Nuke Rating" map onto map_ratings and map_games_played.
+func headerColumn(header string) string {
+	if column, ok := headerAliases[header]; ok {
+		return column
+	}
+	if fields := strings.Fields(header); len(fields) == 2 && mapColumnNames[fields[0]] {
+		switch fields[1] {
+		case "Rating":
+			return "map_ratings"
+		case "Games":
+			return "map_games_played"
+		}
+	}
+	return strings.ToLower(strings.ReplaceAll(header, " ", "_"))
+}
+
+// mapColumnNames are the map names used in per-map CSV columns.
+var mapColumnNames = map[string]bool{
+	"Ancient": true, "Anubis": true, "Dust2": true, "Inferno": true,
+	"Mirage": true, "Nuke": true, "Overpass": true,
+}
+
+// HeaderNotes returns a note for each CSV header, built from the column's
+// definition and formula reference in dict. Headers with no dictionary entry
+// get an empty note. Sheet uploads attach these as header cell notes.
+func HeaderNotes(header []string, dict []DictionaryEntry) []string {
+	byColumn := make(map[string]DictionaryEntry, len(dict))
+	for _, e := range dict {
+		byColumn[e.Column] = e
+	}
+	notes := make([]string, len(header))
+	for i, h := range header {
+		e, ok := byColumn[headerColumn(h)]
+		if !ok || e.Definition == "" {
+			continue
+		}
+		notes[i] = e.Definition
+		if e.Formula != "" {
+			notes[i] += "\nFormula: " + e.Formula
+		}
+	}
+	return notes
+}
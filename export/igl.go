@@ -0,0 +1,36 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteIGLRatings writes the IGL-adjusted rating view to a CSV file.
+func WriteIGLRatings(path string, ratings []output.IGLRating) error {
+	header := []string{
+		"Tier", "Steam ID", "Name", "Roster", "IGL", "Games",
+		"Rating", "IGL Adjustment", "Adjusted Rating", "Rank", "Adjusted Rank",
+		"Team Swing While Alive Per Round",
+	}
+
+	rows := make([][]string, 0, len(ratings))
+	for _, r := range ratings {
+		rows = append(rows, []string{
+			r.Tier,
+			r.SteamID,
+			r.Name,
+			r.Roster,
+			strconv.FormatBool(r.IGL),
+			strconv.Itoa(r.Games),
+			formatFloat(r.Rating),
+			formatFloat(r.Adjustment),
+			formatFloat(r.AdjustedRating),
+			strconv.Itoa(r.Rank),
+			strconv.Itoa(r.AdjustedRank),
+			formatFloat(r.TeamSwingPerRound),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
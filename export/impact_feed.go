@@ -0,0 +1,40 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteImpactFeed writes the round-by-round impact points feed to a CSV file.
+func WriteImpactFeed(path string, feed []output.ImpactPoint) error {
+	header := []string{
+		"Round", "Half", "Steam ID", "Name", "Team", "Side",
+		"Kills", "Assists", "Damage", "Bomb Planted", "Bomb Defused",
+		"Impact Points", "Match Points", "Half Points", "Half Rank", "Top Factor",
+	}
+
+	rows := make([][]string, 0, len(feed))
+	for _, f := range feed {
+		rows = append(rows, []string{
+			strconv.Itoa(f.RoundNumber),
+			strconv.Itoa(f.Half),
+			f.SteamID,
+			f.Name,
+			f.Team,
+			f.Side,
+			strconv.Itoa(f.Kills),
+			strconv.Itoa(f.Assists),
+			strconv.Itoa(f.Damage),
+			strconv.FormatBool(f.BombPlanted),
+			strconv.FormatBool(f.BombDefused),
+			formatFloat(f.Points),
+			formatFloat(f.MatchPoints),
+			formatFloat(f.HalfPoints),
+			strconv.Itoa(f.HalfRank),
+			f.ImpactFactor,
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
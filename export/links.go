@@ -0,0 +1,39 @@
+package export
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Links holds the URL templates used to turn player names and match IDs in
+// sheet exports into HYPERLINK formulas. "{steam_id}" and "{match_id}" are
+// replaced with the row's values, path-escaped since match IDs are bucket
+// keys with slashes and spaces; an empty template leaves the cell as plain
+// text.
+type Links struct {
+	PlayerURL string // e.g. https://stats.example.com/players/{steam_id}
+	MatchURL  string // e.g. https://stats.example.com/matches/{match_id}
+}
+
+// Player returns a cell linking name to the player's page.
+func (l Links) Player(steamID, name string) string {
+	if l.PlayerURL == "" || steamID == "" {
+		return name
+	}
+	return Hyperlink(strings.ReplaceAll(l.PlayerURL, "{steam_id}", url.PathEscape(steamID)), name)
+}
+
+// Match returns a cell linking label to the match's page.
+func (l Links) Match(matchID, label string) string {
+	if l.MatchURL == "" || matchID == "" {
+		return label
+	}
+	return Hyperlink(strings.ReplaceAll(l.MatchURL, "{match_id}", url.PathEscape(matchID)), label)
+}
+
+// Hyperlink returns a Sheets HYPERLINK formula showing label and linking to
+// link. Double quotes are escaped so names can't break out of the formula.
+func Hyperlink(link, label string) string {
+	escape := func(s string) string { return strings.ReplaceAll(s, `"`, `""`) }
+	return `=HYPERLINK("` + escape(link) + `","` + escape(label) + `")`
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteMatchInfo writes a game's match metadata as JSON.
+func WriteMatchInfo(path string, match model.MatchInfo) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(match, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal match info: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write match info: %w", err)
+	}
+	return nil
+}
+
+// matchInfoPath returns where the match metadata goes for a single-game CSV
+// export written to exportPath: stats.csv gets stats_match.json.
+func matchInfoPath(exportPath string) string {
+	ext := filepath.Ext(exportPath)
+	return strings.TrimSuffix(exportPath, ext) + "_match.json"
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteMilestones writes detected career milestones to a CSV file.
+func WriteMilestones(path string, milestones []archive.Milestone) error {
+	header := []string{
+		"Played At", "Match", "Map", "Steam ID", "Name", "Team",
+		"Stat", "Threshold", "Career Total", "Message",
+	}
+
+	rows := make([][]string, 0, len(milestones))
+	for _, m := range milestones {
+		rows = append(rows, []string{
+			m.PlayedAt.Format("2006-01-02"),
+			m.MatchID,
+			m.Map,
+			m.SteamID,
+			m.Name,
+			m.Team,
+			m.Stat,
+			strconv.Itoa(m.Threshold),
+			strconv.Itoa(m.Total),
+			m.Message,
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
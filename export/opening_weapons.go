@@ -0,0 +1,29 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteOpeningMatchups writes each player's opening duels by weapon matchup
+// to a CSV file.
+func WriteOpeningMatchups(path string, matchups []analysis.OpeningMatchup) error {
+	header := []string{"Steam ID", "Name", "Matchup", "Opening Duels", "Wins", "Losses", "Win Pct", "Pistol Involved"}
+
+	rows := make([][]string, 0, len(matchups))
+	for _, m := range matchups {
+		rows = append(rows, []string{
+			m.SteamID,
+			m.Name,
+			m.Matchup,
+			strconv.Itoa(m.Wins + m.Losses),
+			strconv.Itoa(m.Wins),
+			strconv.Itoa(m.Losses),
+			formatFloat(m.WinPct),
+			strconv.FormatBool(m.PistolInvolved),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,25 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteCandidateWeights writes an optimizer result as JSON: the target, the
+// fit before and after, and the candidate weights.
+func WriteCandidateWeights(path string, result archive.OptimizeResult) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal candidate weights: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write candidate weights: %w", err)
+	}
+	return nil
+}
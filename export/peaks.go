@@ -0,0 +1,49 @@
+package export
+
+import (
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// peakHeader is shared by the player and league record exports.
+var peakHeader = []string{
+	"Record", "Steam ID", "Name", "Value", "Match", "Map", "Tier", "Played At",
+}
+
+// peakRow formats one record entry as a CSV row, linking the player and match
+// when link templates are set.
+func peakRow(stat archive.PeakStat, e archive.RecordEntry, links Links) []string {
+	return []string{
+		string(stat),
+		e.SteamID,
+		links.Player(e.SteamID, e.Name),
+		formatFloat(e.Value),
+		links.Match(e.MatchID, e.MatchID),
+		e.Map,
+		e.Tier,
+		e.PlayedAt.Format("2006-01-02"),
+	}
+}
+
+// WritePeakRecords writes every player's single-match bests to a CSV file.
+func WritePeakRecords(path string, store *archive.PeakStore, links Links) error {
+	var rows [][]string
+	for _, id := range store.PlayerIDs() {
+		for _, stat := range archive.PeakStats {
+			if e, ok := store.Players[id][stat]; ok {
+				rows = append(rows, peakRow(stat, e, links))
+			}
+		}
+	}
+	return writeCSV(path, peakHeader, rows)
+}
+
+// WriteLeagueRecords writes the league-wide single-match records to a CSV file.
+func WriteLeagueRecords(path string, store *archive.PeakStore, links Links) error {
+	var rows [][]string
+	for _, stat := range archive.PeakStats {
+		if e, ok := store.League[stat]; ok {
+			rows = append(rows, peakRow(stat, e, links))
+		}
+	}
+	return writeCSV(path, peakHeader, rows)
+}
@@ -0,0 +1,24 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/predict"
+)
+
+// WritePickemAccuracy writes the pick'em accuracy and calibration report to a JSON file.
+func WritePickemAccuracy(path string, acc predict.Accuracy) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(acc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal accuracy report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write accuracy report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// PistolTabKeys are the columns identifying a row of the pistol leaderboard
+// tab.
+var PistolTabKeys = []string{"Tier", "Steam ID"}
+
+// PistolLeaderboardTable lays the pistol leaderboard out as rows, for both
+// the CSV and the spreadsheet tab (see WriteTable). A side rating is blank when the player
+// has too few pistol rounds on that side.
+func PistolLeaderboardTable(entries []output.PistolEntry) (header []string, rows [][]string) {
+	header = []string{
+		"Tier", "Rank", "Steam ID", "Name", "Games", "Pistol Rounds", "Pistol Rating",
+		"Pistol Win Pct", "KPR", "ADR", "Survival Pct", "Multi Kill Pct",
+		"T Pistol Rounds", "T Pistol Rating", "T Pistol Win Pct",
+		"CT Pistol Rounds", "CT Pistol Rating", "CT Pistol Win Pct", "Final Rating",
+	}
+	sideRating := func(rating float64, rated bool) string {
+		if !rated {
+			return ""
+		}
+		return formatFloat(rating)
+	}
+	rows = make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Tier,
+			strconv.Itoa(e.Rank),
+			e.SteamID,
+			e.Name,
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.PistolRounds),
+			formatFloat(e.PistolRating),
+			formatFloat(e.PistolWinPct),
+			formatFloat(e.KPR),
+			formatFloat(e.ADR),
+			formatFloat(e.SurvivalPct),
+			formatFloat(e.MultiKillPct),
+			strconv.Itoa(e.TPistolRounds),
+			sideRating(e.TPistolRating, e.TRated),
+			formatFloat(e.TPistolWinPct),
+			strconv.Itoa(e.CTPistolRounds),
+			sideRating(e.CTPistolRating, e.CTRated),
+			formatFloat(e.CTPistolWinPct),
+			formatFloat(e.FinalRating),
+		})
+	}
+	return header, rows
+}
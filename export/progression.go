@@ -0,0 +1,31 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteSeasonProgression writes the week-by-week leaderboard snapshots to a
+// CSV file, one row per player per week, in the long format bar-chart-race
+// tools read: the player is the bar, the week the frame, the rating its
+// length.
+func WriteSeasonProgression(path string, entries []archive.ProgressionEntry) error {
+	header := []string{"Player", "Steam ID", "Tier", "Week", "Rating", "Rank", "Games", "Rounds"}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Name,
+			e.SteamID,
+			e.Tier,
+			e.Week,
+			formatFloat(e.Rating),
+			strconv.Itoa(e.Rank),
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.Rounds),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
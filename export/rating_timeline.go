@@ -0,0 +1,54 @@
+package export
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteRatingTimeline writes every player's per-round and running eco-rating
+// for a single match to a CSV file, ordered by round.
+func WriteRatingTimeline(path string, players map[uint64]*model.PlayerStats) error {
+	header := []string{
+		"Round", "Steam ID", "Name", "Team", "Side",
+		"Kills", "Damage", "Died", "KAST", "Probability Swing", "Eco Value",
+		"Round Rating", "Running Rating",
+	}
+
+	playerList := make([]*model.PlayerStats, 0, len(players))
+	for _, p := range players {
+		playerList = append(playerList, p)
+	}
+	sort.Slice(playerList, func(i, j int) bool {
+		return playerList[i].SteamID < playerList[j].SteamID
+	})
+
+	var rows [][]string
+	for _, p := range playerList {
+		for _, rb := range p.RoundBreakdowns {
+			rows = append(rows, []string{
+				strconv.Itoa(rb.RoundNumber),
+				p.SteamID,
+				p.Name,
+				p.TeamName,
+				rb.PlayerSide,
+				strconv.Itoa(rb.Kills),
+				strconv.Itoa(rb.Damage),
+				strconv.FormatBool(rb.Died),
+				strconv.FormatBool(rb.KAST),
+				formatFloat(rb.ProbabilitySwing),
+				formatFloat(rb.EcoValue),
+				formatFloat(rb.Rating),
+				formatFloat(rb.RunningRating),
+			})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		ri, _ := strconv.Atoi(rows[i][0])
+		rj, _ := strconv.Atoi(rows[j][0])
+		return ri < rj
+	})
+
+	return writeCSV(path, header, rows)
+}
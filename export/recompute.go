@@ -0,0 +1,35 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/archive"
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// WriteRecomputeMatrix writes the rating version comparison matrix: one row
+// per player with the archived rating, then the rating and rank under each
+// version.
+func WriteRecomputeMatrix(path string, versions []rating.Version, players []*archive.RecomputedPlayer) error {
+	header := []string{"Steam ID", "Name", "Games", "Rounds", "Archived Rating"}
+	for _, v := range versions {
+		header = append(header, v.Name, v.Name+" Rank")
+	}
+
+	rows := make([][]string, 0, len(players))
+	for _, p := range players {
+		row := []string{
+			p.SteamID,
+			p.Name,
+			strconv.Itoa(p.Games),
+			strconv.Itoa(p.Rounds),
+			formatFloat(p.Archived),
+		}
+		for i := range versions {
+			row = append(row, formatFloat(p.Ratings[i]), strconv.Itoa(p.Ranks[i]))
+		}
+		rows = append(rows, row)
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteRecordBook writes the league record book as JSON.
+func WriteRecordBook(path string, book archive.RecordBook) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal record book: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write record book: %w", err)
+	}
+	return nil
+}
+
+// WriteRecordBookCSV writes the league record book as a flat CSV table with
+// one row per record. Multi-game records list their matches separated by "; ".
+func WriteRecordBookCSV(path string, book archive.RecordBook) error {
+	header := []string{
+		"Record", "Tier", "Season", "Steam ID", "Name", "Value", "Matches", "Demo Links",
+	}
+
+	rows := make([][]string, 0, len(book.Records))
+	for _, r := range book.Records {
+		season := ""
+		if r.Season > 0 {
+			season = strconv.Itoa(r.Season)
+		}
+		matches := make([]string, 0, len(r.Matches))
+		links := make([]string, 0, len(r.Matches))
+		for _, m := range r.Matches {
+			matches = append(matches, m.MatchID)
+			if m.URL != "" {
+				links = append(links, m.URL)
+			}
+		}
+		rows = append(rows, []string{
+			r.Category,
+			r.Tier,
+			season,
+			r.SteamID,
+			r.Name,
+			formatFloat(r.Value),
+			strings.Join(matches, "; "),
+			strings.Join(links, "; "),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
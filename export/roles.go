@@ -0,0 +1,47 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteRoles writes each player's detected role per map, with the numbers
+// behind it and role-relative percentiles, to a CSV file.
+func WriteRoles(path string, roles []analysis.RoleAssignment) error {
+	header := []string{
+		"Map", "Steam ID", "Name", "Role", "Role Score", "Games", "Rounds",
+		"T Opening Attempts Per Round", "AWP Kill Share", "Utility Per Round",
+		"Avg T Teammate Distance", "CT Bomb Zone Pct",
+		"Rating", "ADR", "KPR", "KAST",
+		"Rating Percentile", "ADR Percentile", "KPR Percentile", "KAST Percentile",
+	}
+
+	rows := make([][]string, 0, len(roles))
+	for _, r := range roles {
+		rows = append(rows, []string{
+			r.Map,
+			r.SteamID,
+			r.Name,
+			string(r.Role),
+			formatFloat(r.RoleScore),
+			strconv.Itoa(r.Games),
+			strconv.Itoa(r.Rounds),
+			formatFloat(r.TOpeningAttemptsPerRound),
+			formatFloat(r.AWPKillShare),
+			formatFloat(r.UtilityPerRound),
+			formatFloat(r.AvgTTeammateDistance),
+			formatFloat(r.CTBombZonePct),
+			formatFloat(r.Rating),
+			formatFloat(r.ADR),
+			formatFloat(r.KPR),
+			formatFloat(r.KAST),
+			formatFloat(r.RatingPercentile),
+			formatFloat(r.ADRPercentile),
+			formatFloat(r.KPRPercentile),
+			formatFloat(r.KASTPercentile),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
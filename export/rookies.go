@@ -0,0 +1,64 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// WriteRookieLeaderboard writes the rookie-only leaderboard to a CSV file.
+func WriteRookieLeaderboard(path string, entries []output.RookieEntry) error {
+	header := []string{
+		"Tier", "Rank", "Steam ID", "Name", "Games", "Rounds",
+		"Rating", "ADR", "KAST", "Veteran Baseline", "Rating Vs Veteran",
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Tier,
+			strconv.Itoa(e.Rank),
+			e.SteamID,
+			e.Name,
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.Rounds),
+			formatFloat(e.Rating),
+			formatFloat(e.ADR),
+			formatFloat(e.KAST),
+			formatFloat(e.VeteranBaseline),
+			formatFloat(e.RatingVsVeteran),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
+
+// WriteRookieBaselines writes per-tier rookie-vs-veteran baselines to a CSV file.
+func WriteRookieBaselines(path string, baselines []output.RookieBaseline) error {
+	header := []string{
+		"Tier", "Rookies", "Veterans",
+		"Rookie Rating", "Veteran Rating", "Rating Delta",
+		"Rookie ADR", "Veteran ADR", "Rookie KAST", "Veteran KAST",
+		"Rookie KPR", "Veteran KPR",
+	}
+
+	rows := make([][]string, 0, len(baselines))
+	for _, b := range baselines {
+		rows = append(rows, []string{
+			b.Tier,
+			strconv.Itoa(b.Rookies),
+			strconv.Itoa(b.Veterans),
+			formatFloat(b.RookieRating),
+			formatFloat(b.VeteranRating),
+			formatFloat(b.RatingDelta),
+			formatFloat(b.RookieADR),
+			formatFloat(b.VeteranADR),
+			formatFloat(b.RookieKAST),
+			formatFloat(b.VeteranKAST),
+			formatFloat(b.RookieKPR),
+			formatFloat(b.VeteranKPR),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
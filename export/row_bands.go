@@ -0,0 +1,40 @@
+package export
+
+import (
+	"slices"
+
+	"github.com/ethsmith/eco-rating/sheets"
+)
+
+// tierRanking lists the competitive tiers from highest to lowest.
+var tierRanking = []string{"premier", "elite", "challenger", "contender", "prospect", "recruit"}
+
+// tierColors are the background colors for each tier's rows, lightest for
+// the lowest tier. Tiers not listed use defaultTierColor.
+var tierColors = map[string]string{
+	"premier":    "#F4CCCC",
+	"elite":      "#FCE5CD",
+	"challenger": "#FFF2CC",
+	"contender":  "#D9EAD3",
+	"prospect":   "#CFE2F3",
+	"recruit":    "#D9D2E9",
+}
+
+const defaultTierColor = "#EFEFEF"
+
+// tierRowColors colors each row of a sheet by the tier in its League Tier
+// column, so the tier-sorted leaderboard reads as one band per tier. The
+// colors follow the tier in the cell rather than row positions, so they stay
+// right when an upsert appends rows below the existing ones.
+func tierRowColors(header []string) []sheets.RowColor {
+	column := slices.Index(header, "League Tier")
+	if column < 0 {
+		return nil
+	}
+	colors := make([]sheets.RowColor, 0, len(tierRanking)+1)
+	for _, tier := range tierRanking {
+		colors = append(colors, sheets.RowColor{Column: column, Value: tier, Color: tierColors[tier]})
+	}
+	// Matches any other tier, so it goes last
+	return append(colors, sheets.RowColor{Column: column, Color: defaultTierColor})
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/archive"
+)
+
+// WriteSensitivity writes the weight sensitivity report, one row per weight
+// change, with the biggest leaderboard movers listed as "name from→to".
+func WriteSensitivity(path string, results []archive.SensitivityResult) error {
+	header := []string{"Weight", "Change", "Value", "Spearman", "Mean Rank Change", "Biggest Movers"}
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		movers := make([]string, 0, len(r.Movers))
+		for _, m := range r.Movers {
+			movers = append(movers, fmt.Sprintf("%s %d→%d", m.Name, m.From, m.To))
+		}
+		rows = append(rows, []string{
+			r.Weight,
+			fmt.Sprintf("%+.0f%%", r.Change*100),
+			strconv.FormatFloat(r.Value, 'g', 4, 64),
+			formatFloat(r.Spearman),
+			formatFloat(r.MeanRankChange),
+			strings.Join(movers, "; "),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
@@ -0,0 +1,250 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/metrics"
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/output"
+	"github.com/ethsmith/eco-rating/sheets"
+)
+
+// SheetTab is one tab of a spreadsheet export: a name and the column preset
+// it shows.
+type SheetTab struct {
+	Name    string
+	Columns string // Column preset; empty means full
+}
+
+// SheetsExportOption implements ExportOption by uploading stats to one or
+// more tabs of a spreadsheet, creating missing tabs.
+type SheetsExportOption struct {
+	Service sheets.Service // Google Sheets, or another backend such as sheets.CSVDir
+	Tabs    []SheetTab     // Tabs written on every export, in order
+	Mode    sheets.Mode    // How each tab is rewritten; see sheets.Mode
+	Links   Links          // Link templates for player names; the CSV export keeps them plain
+	Format  bool           // Freeze headers, format ratings and color Final Rating after each upload
+
+	CheckChanges  bool   // Compare each tab with its previous upload and report improbable jumps
+	BlockOnAlerts bool   // Upload no tab when any has jumps, so suspect stats aren't published
+	AlertsPath    string // CSV the jumps of the last export are written to ("" = log only)
+}
+
+// NewSheetsExportOption creates a SheetsExportOption writing and formatting
+// tabs.
+func NewSheetsExportOption(svc sheets.Service, mode sheets.Mode, tabs ...SheetTab) *SheetsExportOption {
+	return &SheetsExportOption{Service: svc, Tabs: tabs, Mode: mode, Format: true}
+}
+
+// Export uploads single-game player statistics, keyed by Steam ID.
+func (s *SheetsExportOption) Export(match model.MatchInfo, players map[uint64]*model.PlayerStats) error {
+	rows := singleGameRows(sortedPlayers(players))
+	return s.upload(getSingleGameHeader(), rows, []string{"Steam ID"}, PlayerStatsDictionary())
+}
+
+// ExportAggregated uploads aggregated statistics, keyed by Steam ID and league
+// tier since a player has one row per tier.
+func (s *SheetsExportOption) ExportAggregated(players map[string]*output.AggregatedStats) error {
+	rows := aggregatedRows(sortedAggregated(players))
+	return s.upload(getAggregatedHeader(), rows, []string{"Steam ID", "League Tier"}, AggregatedStatsDictionary())
+}
+
+// UploadStatsCSV uploads a stats CSV written by an earlier run, as that run
+// would have: aggregated stats, which have a Tier column, keyed by Steam ID
+// and league tier (or Tier, in files from before the League Tier column),
+// and single-game stats by Steam ID.
+func (s *SheetsExportOption) UploadStatsCSV(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open stats file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read stats file: %w", err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("stats file %s is empty", path)
+	}
+	header, rows := records[0], records[1:]
+	if !slices.Contains(header, "Steam ID") {
+		return fmt.Errorf("stats file %s is missing column %q", path, "Steam ID")
+	}
+	keys, dict := []string{"Steam ID"}, PlayerStatsDictionary()
+	switch {
+	case slices.Contains(header, "League Tier"):
+		keys, dict = append(keys, "League Tier"), AggregatedStatsDictionary()
+	case slices.Contains(header, "Tier"):
+		keys, dict = append(keys, "Tier"), AggregatedStatsDictionary()
+	}
+	return s.upload(header, rows, keys, dict)
+}
+
+// linkNames returns rows with each player's name linked to their page, for
+// headers with Steam ID and Name columns. rows is left unchanged.
+func (s *SheetsExportOption) linkNames(header []string, rows [][]string) [][]string {
+	idCol, nameCol := slices.Index(header, "Steam ID"), slices.Index(header, "Name")
+	if s.Links.PlayerURL == "" || idCol < 0 || nameCol < 0 {
+		return rows
+	}
+	linked := make([][]string, len(rows))
+	for i, row := range rows {
+		linked[i] = slices.Clone(row)
+		if idCol < len(row) && nameCol < len(row) {
+			linked[i][nameCol] = s.Links.Player(row[idCol], row[nameCol])
+		}
+	}
+	return linked
+}
+
+// ExportTable uploads a standalone table to the tab called name, formatted
+// like the stats tabs when Format is set. Change alerts don't apply to it.
+func (s *SheetsExportOption) ExportTable(name string, header []string, rows [][]string, keys []string) error {
+	table := sheets.Table{Header: header, Rows: rows, Keys: keys}
+	start := time.Now()
+	summary, err := sheets.Upload(s.Service, name, table, s.Mode)
+	metrics.UploadDuration.ObserveSince(start)
+	if err != nil {
+		return err
+	}
+	log.Printf("Sheet %q: %d cells updated, %d rows appended, %d rows removed (%s)", name, summary.UpdatedCells, summary.AppendedRows, summary.RemovedRows, s.Mode)
+	if s.Format {
+		return sheets.ApplyFormat(s.Service, name, sheetFormat(header, nil))
+	}
+	return nil
+}
+
+// upload writes each tab's columns of header and rows. Tabs whose preset
+// matches nothing but the identity columns in this export, such as the map
+// tab for a single game, are skipped. With CheckChanges, every tab is first
+// compared with its previous upload and the jumps found are logged; with
+// BlockOnAlerts, no tab is uploaded if any has a jump. With Format, each
+// header cell gets a note with its column's definition in dict.
+func (s *SheetsExportOption) upload(header []string, rows [][]string, keys []string, dict []DictionaryEntry) error {
+	rows = s.linkNames(header, rows)
+	type sheetTab struct {
+		name  string
+		table sheets.Table
+	}
+	var tabs []sheetTab
+	for _, tab := range s.Tabs {
+		tabHeader, tabRows, err := output.SelectColumns(header, rows, tab.Columns)
+		if err != nil {
+			return err
+		}
+		if hasStatColumns(tabHeader) {
+			tabs = append(tabs, sheetTab{tab.Name, sheets.Table{Header: tabHeader, Rows: tabRows, Keys: keys}})
+		}
+	}
+
+	var alerts []sheets.ChangeAlert
+	if s.CheckChanges {
+		seen := make(map[string]bool)
+		var blocked []string
+		for _, tab := range tabs {
+			tabAlerts, err := sheets.DetectChanges(s.Service, tab.name, tab.table)
+			if err != nil {
+				return err
+			}
+			for _, a := range tabAlerts {
+				// Tabs share columns; report each cell's jump once
+				if id := a.Key + "\x00" + a.Column; !seen[id] {
+					seen[id] = true
+					alerts = append(alerts, a)
+					log.Printf("Warning: %s %s jumped from %s to %s (score %.1f)", a.Key, a.Column, a.Old, a.New, a.Score)
+				}
+			}
+			if len(tabAlerts) > 0 {
+				blocked = append(blocked, tab.name)
+			}
+		}
+		if err := s.writeAlerts(alerts); err != nil {
+			return err
+		}
+		if s.BlockOnAlerts && len(blocked) > 0 {
+			return fmt.Errorf("no sheets uploaded: %d improbable changes since the last upload in %s", len(alerts), strings.Join(blocked, ", "))
+		}
+	}
+
+	for _, tab := range tabs {
+		start := time.Now()
+		summary, err := sheets.Upload(s.Service, tab.name, tab.table, s.Mode)
+		metrics.UploadDuration.ObserveSince(start)
+		if err != nil {
+			return err
+		}
+		log.Printf("Sheet %q: %d cells updated, %d rows appended, %d rows removed (%s)", tab.name, summary.UpdatedCells, summary.AppendedRows, summary.RemovedRows, s.Mode)
+		if s.Format {
+			if err := sheets.ApplyFormat(s.Service, tab.name, sheetFormat(tab.table.Header, dict)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeAlerts writes alerts to AlertsPath, if set. The file is rewritten even
+// when there are none, so it always describes the latest export.
+func (s *SheetsExportOption) writeAlerts(alerts []sheets.ChangeAlert) error {
+	if s.AlertsPath == "" {
+		return nil
+	}
+	rows := make([][]string, 0, len(alerts))
+	for _, a := range alerts {
+		rows = append(rows, []string{a.Sheet, a.Key, a.Column, a.Old, a.New, strconv.FormatFloat(a.Score, 'f', 1, 64)})
+	}
+	return writeCSV(s.AlertsPath, []string{"Sheet", "Row", "Column", "Previous", "New", "Score"}, rows)
+}
+
+// sheetFormat freezes the header, shows every rating column with two decimals
+// and colors Final Rating around the 1.00 average. Stats tabs, which come
+// with a data dictionary, also get each column's definition as a header note
+// and their column groups: each group's first column stays in view, its note
+// naming the group, and the rest collapse under it. The group holding the
+// identity columns isn't collapsible. Aggregated stats rows are colored by
+// their league tier.
+func sheetFormat(header []string, dict []DictionaryEntry) sheets.Format {
+	f := sheets.Format{FrozenRows: 1, ColorScaleMidpoint: 1}
+	if dict != nil {
+		f.HeaderNotes = HeaderNotes(header, dict)
+		for _, g := range ColumnGroups(header) {
+			if g.Start == 0 {
+				continue
+			}
+			f.HeaderNotes[g.Start] = strings.TrimSpace(g.Name + " columns\n" + f.HeaderNotes[g.Start])
+			// Groups that touch would merge into one
+			if g.End-g.Start > 1 {
+				f.ColumnGroups = append(f.ColumnGroups, sheets.Span{Start: g.Start + 1, End: g.End})
+			}
+		}
+		f.RowColors = tierRowColors(header)
+	}
+	for i, h := range header {
+		if strings.Contains(h, "Rating") {
+			f.DecimalColumns = append(f.DecimalColumns, i)
+		}
+		if h == "Final Rating" {
+			f.ColorScaleColumns = append(f.ColorScaleColumns, i)
+		}
+	}
+	return f
+}
+
+// hasStatColumns reports whether header has any column besides the identity
+// columns.
+func hasStatColumns(header []string) bool {
+	for _, h := range header {
+		if !output.IsIdentityColumn(h) {
+			return true
+		}
+	}
+	return false
+}
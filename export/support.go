@@ -0,0 +1,41 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// SupportTabKeys are the columns identifying a row of the support
+// leaderboard tab.
+var SupportTabKeys = []string{"Tier", "Steam ID"}
+
+// SupportLeaderboardTable lays the support leaderboard out as rows, for
+// both the CSV and the spreadsheet tab (see WriteTable).
+func SupportLeaderboardTable(entries []output.SupportEntry) (header []string, rows [][]string) {
+	header = []string{
+		"Tier", "Rank", "Steam ID", "Name", "Games", "Rounds", "Support Score",
+		"Utility Damage Per Round", "Flash Assists Per Round", "Traded Deaths Per Round",
+		"Saved Teammate Per Round", "Entry Trades Per Round", "Support Profile", "Final Rating",
+	}
+	rows = make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{
+			e.Tier,
+			strconv.Itoa(e.Rank),
+			e.SteamID,
+			e.Name,
+			strconv.Itoa(e.Games),
+			strconv.Itoa(e.Rounds),
+			formatFloat(e.SupportScore),
+			formatFloat(e.UtilityDamagePerRound),
+			formatFloat(e.FlashAssistsPerRound),
+			formatFloat(e.TradedDeathsPerRound),
+			formatFloat(e.SavedTeammatePerRound),
+			formatFloat(e.EntryTradesPerRound),
+			strconv.FormatBool(e.SupportProfile),
+			formatFloat(e.FinalRating),
+		})
+	}
+	return header, rows
+}
@@ -0,0 +1,32 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteSwingAudit writes a demo's per-event swing log as a JSON document with
+// the match ID, map and a top-level "rounds" list.
+func WriteSwingAudit(path, matchID, mapName string, rounds []model.SwingAuditRound) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if rounds == nil {
+		rounds = []model.SwingAuditRound{}
+	}
+	data, err := json.MarshalIndent(struct {
+		MatchID string                  `json:"match_id"`
+		Map     string                  `json:"map"`
+		Rounds  []model.SwingAuditRound `json:"rounds"`
+	}{matchID, mapName, rounds}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal swing audit: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write swing audit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteTeamStats writes team-level ratings to a CSV file, one row per team.
+func WriteTeamStats(path string, teams []*model.TeamStats) error {
+	header := []string{
+		"Tier", "Team", "Players", "Games", "Games Won", "Game Win %",
+		"Rounds", "Round Win %", "T Rounds", "T Round Win %", "CT Rounds", "CT Round Win %",
+		"Pistols", "Pistol Win %", "Pistol Conversions", "Pistol Conversion %", "Rounds Thrown",
+		"Opening Success %", "Traded Deaths %", "Trade Kills %",
+		"Utility Thrown/Round", "Utility Damage/Round", "Flash Assists/Round",
+		"Eco Rating",
+	}
+
+	rows := make([][]string, 0, len(teams))
+	for _, t := range teams {
+		rows = append(rows, []string{
+			t.Tier,
+			t.Name,
+			strconv.Itoa(len(t.Players)),
+			strconv.Itoa(t.GamesPlayed),
+			strconv.Itoa(t.GamesWon),
+			formatFloat(t.GameWinPct),
+			strconv.Itoa(t.RoundsPlayed),
+			formatFloat(t.RoundWinPct),
+			strconv.Itoa(t.TRoundsPlayed),
+			formatFloat(t.TRoundWinPct),
+			strconv.Itoa(t.CTRoundsPlayed),
+			formatFloat(t.CTRoundWinPct),
+			strconv.Itoa(t.PistolRoundsPlayed),
+			formatFloat(t.PistolWinPct),
+			strconv.Itoa(t.PistolConversions),
+			formatFloat(t.PistolConversionPct),
+			strconv.Itoa(t.RoundsThrown),
+			formatFloat(t.OpeningSuccessPct),
+			formatFloat(t.TradedDeathsPct),
+			formatFloat(t.TradeKillsPct),
+			formatFloat(t.UtilityThrownPerRound),
+			formatFloat(t.UtilityDamagePerRound),
+			formatFloat(t.FlashAssistsPerRound),
+			formatFloat(t.EcoRating),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
+
+// WriteTeamStatsJSON writes team-level ratings as a JSON document with a
+// top-level "teams" section.
+func WriteTeamStatsJSON(path string, teams []*model.TeamStats) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		Teams []*model.TeamStats `json:"teams"`
+	}{teams}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal team stats: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write team stats: %w", err)
+	}
+	return nil
+}
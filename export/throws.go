@@ -0,0 +1,30 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// WriteThrows writes thrown-round descriptors as a JSON document with a
+// top-level "throws" list.
+func WriteThrows(path string, throws []model.ThrowRound) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	if throws == nil {
+		throws = []model.ThrowRound{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Throws []model.ThrowRound `json:"throws"`
+	}{throws}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal throws: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write throws: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package export
+
+import (
+	"github.com/ethsmith/eco-rating/analysis"
+)
+
+// WriteZoneTendencies writes each player's share of round time per named zone,
+// by map and side, to a CSV file.
+func WriteZoneTendencies(path string, tendencies []analysis.ZoneTendency) error {
+	header := []string{"Map", "Steam ID", "Name", "Side", "Zone", "Seconds", "Time Pct"}
+
+	rows := make([][]string, 0, len(tendencies))
+	for _, t := range tendencies {
+		rows = append(rows, []string{
+			t.Map,
+			t.SteamID,
+			t.Name,
+			t.Side,
+			t.Zone,
+			formatFloat(t.Seconds),
+			formatFloat(t.Pct),
+		})
+	}
+
+	return writeCSV(path, header, rows)
+}
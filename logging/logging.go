@@ -0,0 +1,88 @@
+// Package logging configures the process-wide structured logger. Records
+// carry key-value context such as the demo file, map and round, and are
+// written to stderr as text or JSON at a configurable level.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Output formats accepted by Setup.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// warningPrefix marks a standard log message as a warning.
+const warningPrefix = "Warning: "
+
+// NewHandler creates a handler writing to w at level (debug, info, warn or
+// error) in format (text or json).
+func NewHandler(w io.Writer, level, format string) (slog.Handler, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q (valid: debug, info, warn, error)", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return slog.NewTextHandler(w, opts), nil
+	case FormatJSON:
+		return slog.NewJSONHandler(w, opts), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q (valid: text, json)", format)
+}
+
+// Setup makes a stderr handler at level in format slog's default, and
+// routes the standard log package through it so every log.Printf becomes a
+// structured record too.
+func Setup(level, format string) error {
+	h, err := NewHandler(os.Stderr, level, format)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(h))
+	log.SetFlags(0)
+	log.SetOutput(NewWriter(slog.Default(), nil))
+	return nil
+}
+
+// NewWriter returns a writer that records each message written to it, such
+// as a standard logger's output, through l. Records keep l's attributes
+// (e.g. the demo from slog.With("demo", name)) and, when attrs isn't nil,
+// add the ones it returns at the time of the write, such as the current
+// round. Messages starting with "Warning: " are recorded at warn level, the
+// rest at info.
+func NewWriter(l *slog.Logger, attrs func() []any) io.Writer {
+	return &bridge{logger: l, attrs: attrs}
+}
+
+// bridge turns standard log output into records for logger.
+type bridge struct {
+	logger *slog.Logger
+	attrs  func() []any
+}
+
+func (b *bridge) Write(p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+	level := slog.LevelInfo
+	if rest, ok := strings.CutPrefix(msg, warningPrefix); ok {
+		msg, level = rest, slog.LevelWarn
+	}
+	ctx := context.Background()
+	if !b.logger.Enabled(ctx, level) {
+		return len(p), nil
+	}
+	var args []any
+	if b.attrs != nil {
+		args = b.attrs()
+	}
+	b.logger.Log(ctx, level, msg, args...)
+	return len(p), nil
+}
@@ -15,6 +15,14 @@
 //
 //	eco-rating -demo=path/to/demo.dem              # Single demo
 //	eco-rating -cumulative -tier=contender         # Cumulative mode
+//
+// The common modes are also subcommands (cli.go):
+//
+//	eco-rating parse path/to/demo.dem              # Single demo
+//	eco-rating aggregate ./demos -tier=contender   # Cumulative mode over a local directory
+//	eco-rating upload stats.csv --sheet=<id>       # Upload an earlier run's stats
+//	eco-rating explain demo.dem --player=<name>    # Rating breakdown for one player
+//	eco-rating serve :8080                         # REST API
 package main
 
 import (
@@ -22,21 +30,35 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethsmith/eco-rating/analysis"
+	"github.com/ethsmith/eco-rating/archive"
 	"github.com/ethsmith/eco-rating/bucket"
 	"github.com/ethsmith/eco-rating/config"
 	"github.com/ethsmith/eco-rating/downloader"
 	"github.com/ethsmith/eco-rating/export"
+	"github.com/ethsmith/eco-rating/logging"
+	"github.com/ethsmith/eco-rating/metrics"
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/output"
 	"github.com/ethsmith/eco-rating/parser"
+	"github.com/ethsmith/eco-rating/predict"
+	"github.com/ethsmith/eco-rating/rating"
 	"github.com/ethsmith/eco-rating/rating/probability"
+	"github.com/ethsmith/eco-rating/server"
+	"github.com/ethsmith/eco-rating/sheets"
 )
 
 // main initializes the application, parses command-line flags, loads configuration,
@@ -47,10 +69,101 @@ func main() {
 	tier := flag.String("tier", "", "Tier to filter demos (challenger, contender, elite, premier, prospect, recruit)")
 	demoPath := flag.String("demo", "", "Path to a single demo file to parse")
 	demoURL := flag.String("url", "", "URL to a single demo file (.dem or .zip) to download and parse")
+	streamSource := flag.String("stream", "", "HTTP(S) URL or s3://bucket/key of a demo (.dem, .dem.gz or .dem.bz2) to parse as it downloads, without saving it")
 	demoDir := flag.String("demo-dir", "", "Directory for downloaded demos")
+	aggregateDir := flag.String("aggregate", "", "Aggregate every .dem file under this directory like cumulative mode, instead of fetching demos from the bucket (-tier names the tier, default all)")
+	uploadPath := flag.String("upload", "", "Upload a stats CSV written by an earlier run to the spreadsheet (requires -sheet-id or -sheet-dir)")
+	explainDemo := flag.String("explain", "", "Parse this demo and print what drove -player's rating")
+	explainPlayer := flag.String("player", "", "Player whose rating -explain breaks down, by Steam ID or name")
 	outputPath := flag.String("output", "stats.csv", "Output path for exported stats (CSV)")
+	columns := flag.String("columns", "", "Stats CSV column preset: core, overview, utility, awp, maps, sides, overtime, or full")
+	sheetID := flag.String("sheet-id", "", "Also upload stats to this Google spreadsheet (overrides config)")
+	sheetDir := flag.String("sheet-dir", "", "Write the spreadsheet upload as one CSV per tab in this directory instead of to Google Sheets")
+	sheetMode := flag.String("sheet-mode", "", "Spreadsheet upload mode: replace (clear and rewrite) or upsert (update changed cells, keep manual columns)")
+	jsonOutput := flag.String("json", "", "Also write aggregated stats as nested JSON to this path (cumulative mode)")
+	clutchesPath := flag.String("clutches", "", "Write a descriptor for every 1vX attempt (enemies, time left, bomb, kill sequence) to this JSON file")
+	grenadesPath := flag.String("grenades", "", "Write every grenade throw (thrower, origin, trajectory, detonation, players affected) keyed by map to this JSON file")
+	discordWebhook := flag.String("discord-webhook", "", "Post a match summary (scoreline, top ratings, MVP, clutches) to this Discord webhook after each demo is parsed")
+	datasetDir := flag.String("dataset", "", "Write the anonymized public dataset (rounds.csv, players.csv, data_dictionary.csv) to this directory")
+	datasetSalt := flag.String("dataset-salt", "", "Secret salt for the public dataset's anonymized IDs (overrides config)")
+	openingWeaponsPath := flag.String("opening-weapons", "", "Write each player's opening duels by weapon matchup (AWP vs rifle, rifle vs rifle, pistol involved) to this CSV file")
+	duosPath := flag.String("entry-duos", "", "Write each player's entry synergy with every teammate (who trades their opening deaths, win rate when both take the first fights) to this CSV file")
+	buysPath := flag.String("buy-tendencies", "", "Write each player's buy tendencies (AWP frequency by start-of-round money, armor-skip rate, force-buy guns) to this CSV file")
+	duelsPath := flag.String("duels", "", "Write head-to-head kill records (player A killed player B N times) per match and across the season to this JSON file")
+	zonesPath := flag.String("zone-tendencies", "", "Write each player's share of round time in each named map zone, by map and side, to this CSV file")
+	anchoringPath := flag.String("anchoring", "", "Write a CT anchoring leaderboard per map (site holds survived, multi-kills while anchoring, retake delay generated) for detected anchors to this CSV file")
+	rolesPath := flag.String("roles", "", "Write each player's detected role per map (entry, lurker, AWPer, support, anchor) with role-relative percentiles to this CSV file")
+	throwsPath := flag.String("throws", "", "Write every thrown round (lost after passing 90% win probability) with its collapse events to this JSON file")
+	convergenceReport := flag.String("convergence-report", "", "Write how each season-wide rating solve converged to this CSV (cumulative mode)")
+	demoErrors := flag.String("demo-errors", "", "Write the report of demos that failed to download or parse, or were only partly parsed, to this CSV (cumulative mode)")
+	milestonesPath := flag.String("milestones", "", "Write career milestones reached in cumulative mode to this CSV (requires -archive)")
+	roundImportanceModel := flag.String("round-importance", "", "Round importance model weighing swing and clutch credit by the score: flat or leverage (\"\" = use config)")
+	exitFragPenalty := flag.Float64("exit-frag-penalty", -1, "Probability swing taken from the killer per exit frag, e.g. 0.02 (0 = no penalty, -1 = use config)")
+	pistolLeaderboard := flag.String("pistol-leaderboard", "", "Write a leaderboard ranking players by pistol round rating, with T and CT splits, to this CSV (cumulative mode; config: pistol.min_rounds, default 8)")
+	entryLeaderboard := flag.String("entry-leaderboard", "", "Write a leaderboard ranking qualified players by entry score (opening attempts, opening success, traded opening deaths, 5v4 conversion) to this CSV (cumulative mode)")
+	supportLeaderboard := flag.String("support-leaderboard", "", "Write a leaderboard ranking qualified players by support score (utility, flash assists, trades, entry trades) to this CSV (cumulative mode)")
+	tradeWindow := flag.Float64("trade-window", -1, "Seconds after a death a kill on the killer still counts as a trade, e.g. 3 (-1 = use config)")
+	demoSourceName := flag.String("demo-source", "", "Platform the demos were recorded on, which sets knife-round and restart handling: faceit, esea, valve, ebot or unknown (default: detect per demo)")
+	tradeProximity := flag.Float64("trade-proximity", -1, "Maximum distance in units a teammate can be from a death to have a chance to trade it (-1 = use config)")
+	killQualityWeight := flag.Float64("kill-quality-weight", -1, "How far the opponent-adjusted rating follows kill quality, e.g. 0.5 (0 = equal to final rating, -1 = use config)")
+	closeMatchWeight := flag.Float64("close-match-weight", 0, "Weight of close games (decided by 3 or fewer rounds, or OT) in aggregated ratings, e.g. 1.5 (0 = use config)")
+	minGames := flag.Int("min-games", -1, "Games in a tier a player needs to be ranked; others are flagged not qualified (-1 = use config)")
+	minRounds := flag.Int("min-rounds", -1, "Rounds in a tier a player needs to be ranked; others are flagged not qualified (-1 = use config)")
+	workers := flag.Int("workers", -1, "Number of demos to parse in parallel in cumulative mode (0 = one per CPU core)")
 	useStdin := flag.Bool("stdin", false, "Read demo data from stdin (for piping demo files)")
-	flag.Parse()
+	draftValues := flag.Bool("draft-values", false, "Export preseason draft values in cumulative mode")
+	crossTier := flag.Bool("cross-tier", false, "Export tier-normalized ratings for players in multiple tiers")
+	rookies := flag.Bool("rookies", false, "Export the rookie leaderboard and rookie-vs-veteran baselines in cumulative mode")
+	igl := flag.Bool("igl", false, "Export IGL-adjusted ratings for the in-game leaders in config (cumulative mode)")
+	peaks := flag.Bool("peaks", false, "Track per-player and league single-match records across runs (cumulative mode)")
+	recordBook := flag.Bool("record-book", false, "Write the league record book from the archive in cumulative mode (requires -archive)")
+	teams := flag.Bool("teams", false, "Export team-level ratings (side win rates, pistols, trades, utility) in cumulative mode")
+	awards := flag.Bool("awards", false, "Export award race standings (MVP, Best AWPer, Rookie of the Year) in cumulative mode")
+	predictPath := flag.String("predict", "", "Path to a fixture JSON file to predict per-map win probabilities")
+	ratingsPath := flag.String("ratings", "stats.csv", "Aggregated stats CSV used for predictions")
+	serveAddr := flag.String("serve", "", "Run the REST API on this address (e.g. :8080)")
+	metricsAddr := flag.String("metrics-addr", "", "Serve Prometheus metrics at /metrics on this address during a cumulative run (e.g. :9100)")
+	logLevel := flag.String("log-level", "", "Log level: debug, info, warn, or error (debug logs every kill, round swing, and rating component)")
+	logFormat := flag.String("log-format", "", "Log format: text or json")
+	metricsPush := flag.String("metrics-push", "", "Pushgateway URL to push Prometheus metrics to when a cumulative run ends")
+	playerURL := flag.String("player-url", "", "Player page URL template for sheet links, e.g. https://stats.example.com/players/{steam_id}")
+	matchURL := flag.String("match-url", "", "Match page URL template for sheet links, e.g. https://stats.example.com/matches/{match_id}")
+	demoIndex := flag.String("demo-index", "", "Demo index file (updated in cumulative mode, served at GET /demos)")
+	quickScan := flag.String("quick-scan", "", "Summarize a demo or every .dem file under a directory (map, teams, score, players) without full stat parsing")
+	catalogPath := flag.String("catalog", "demo_catalog.csv", "Output path for the -quick-scan demo catalog")
+	findDemos := flag.String("find-demos", "", "Search the demo index by match ID, map, or team and print matching entries")
+	pickemPath := flag.String("pickem", "", "Prediction history file: predictions are stored and resolved against parsed demos")
+	impactFeed := flag.String("impact-feed", "", "Write a round-by-round impact points CSV for a single demo")
+	swingAudit := flag.String("swing-audit", "", "Write every swing-affecting event of a single demo (type, player, win probability before and after, swing credited) to this JSON file")
+	ratingTimeline := flag.String("rating-timeline", "", "Write per-round and running ratings for each player in a single demo to this CSV")
+	archivePath := flag.String("archive", "", "Per-game archive file (updated in cumulative mode, read by caster notes)")
+	casterNotes := flag.String("caster-notes", "", "Path to a fixture JSON file to generate Markdown caster notes from the archive")
+	notesOutput := flag.String("notes-output", "caster_notes.md", "Output path for caster notes")
+	recompute := flag.String("recompute", "", "Re-rate every archived game under each registered rating version and write the comparison matrix CSV to this path")
+	sensitivity := flag.String("sensitivity", "", "Perturb each rating weight over the archive and write the leaderboard sensitivity report CSV to this path")
+	sensitivityStep := flag.Float64("sensitivity-step", 0.1, "Relative change applied to each weight in the sensitivity report (0.1 = ±10%)")
+	sensitivityMinGames := flag.Int("sensitivity-min-games", 3, "Minimum archived games for a player to count in the sensitivity report")
+	optimize := flag.String("optimize", "", "Fit rating weights to -optimize-target over the archive and write the candidate weights JSON to this path")
+	optimizeTarget := flag.String("optimize-target", archive.TargetWins, "Optimizer target: wins, hltv, or a CSV of Steam ID and value (e.g. MVP votes)")
+	optimizeMaxChange := flag.Float64("optimize-max-change", 0.5, "Largest relative change the optimizer may make to any weight (0.5 = ±50%)")
+	crossValidate := flag.String("cross-validate", "", "CSV export of an external rating source (e.g. Leetify) to compare against archived eco-ratings")
+	externalID := flag.String("external-id-column", "Steam ID", "Header of the Steam ID64 column in the -cross-validate file")
+	externalRating := flag.String("external-rating-column", "Rating", "Header of the rating column in the -cross-validate file")
+	crossValidateOutput := flag.String("cross-validate-output", "cross_validation.csv", "Output path for the cross-validation report")
+	calibrateMaps := flag.String("calibrate-maps", "", "Calibrate per-map rating baselines (KPR, DPR, ADR, KAST) from the archive and write them as JSON to this path")
+	calibrateTiers := flag.String("calibrate-tiers", "", "Calibrate per-tier rating baselines from the archive and write them as JSON (for tier_baselines in config) to this path")
+	calibrateMinGames := flag.Int("calibrate-min-games", 20, "Minimum archived games on a map or in a tier to calibrate its baselines")
+	mapBaselines := flag.String("map-baselines", "", "Per-map rating baselines JSON (from -calibrate-maps); maps not listed use the global baselines")
+	winTable := flag.String("win-table", "", "Empirical round win probability table JSON (from -train-win-table) to compute swing from; states it lacks use the heuristic model")
+	trainWinTable := flag.String("train-win-table", "", "Train an empirical round win probability table from this run's demos and write it as JSON to this path (cumulative mode)")
+	winTableMinSamples := flag.Int("win-table-min-samples", -1, "Minimum rounds a state must be seen in for a trained win probability table to use it (overrides config)")
+	identities := flag.String("identities", "", "Player identity mapping JSON; games on a player's alternate Steam accounts or names are aggregated under their canonical ID")
+	digest := flag.Bool("digest", false, "Generate the weekly Markdown digest from the archive")
+	digestWeek := flag.String("week", "", "Match week for the digest (e.g. M03, defaults to the latest archived week)")
+	digestOutput := flag.String("digest-output", "digest.md", "Output path for the weekly digest")
+	seasonProgression := flag.String("season-progression", "", "Write week-by-week leaderboard snapshots (player, week, rating, rank) from the archive to this CSV, for bar-chart-race visualizations")
+	progressionSeason := flag.Int("progression-season", 0, "Season for -season-progression (0 = the latest archived season)")
+	run := parseCommandLine()
 
 	cfgPath := *configPath
 	if cfgPath == "" {
@@ -70,6 +183,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if *logLevel != "" {
+		cfg.Logging.Level = *logLevel
+	}
+	if *logFormat != "" {
+		cfg.Logging.Format = *logFormat
+	}
+	if err := logging.Setup(cfg.Logging.Level, cfg.Logging.Format); err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
 
 	if *cumulative {
 		cfg.Cumulative = true
@@ -83,8 +205,296 @@ func main() {
 	if *demoPath != "" {
 		cfg.DemoPath = *demoPath
 	}
+	if *workers >= 0 {
+		cfg.Workers = *workers
+	}
+	if *closeMatchWeight > 0 {
+		cfg.CloseMatchWeight = *closeMatchWeight
+	}
+	if *exitFragPenalty >= 0 {
+		cfg.ExitFragPenalty = *exitFragPenalty
+	}
+	if *supportLeaderboard != "" {
+		cfg.Support.LeaderboardPath = *supportLeaderboard
+	}
+	if *entryLeaderboard != "" {
+		cfg.Entry.LeaderboardPath = *entryLeaderboard
+	}
+	if *pistolLeaderboard != "" {
+		cfg.Pistol.LeaderboardPath = *pistolLeaderboard
+	}
+	if *tradeWindow >= 0 {
+		cfg.Trades.WindowSeconds = *tradeWindow
+	}
+	if *tradeProximity >= 0 {
+		cfg.Trades.ProximityUnits = *tradeProximity
+	}
+	if *demoSourceName != "" {
+		cfg.DemoSource = *demoSourceName
+	}
+	if *killQualityWeight >= 0 {
+		cfg.KillQualityWeight = *killQualityWeight
+	}
+	if *draftValues {
+		cfg.DraftValue.Enabled = true
+	}
+	if *crossTier {
+		cfg.CrossTier.Enabled = true
+	}
+	if *rookies {
+		cfg.RookieReport.Enabled = true
+	}
+	if *igl {
+		cfg.IGL.Enabled = true
+	}
+	if *peaks {
+		cfg.Peaks.Enabled = true
+	}
+	if *recordBook {
+		cfg.RecordBook.Enabled = true
+	}
+	if *teams {
+		cfg.Teams.Enabled = true
+	}
+	if *awards {
+		cfg.Awards.Enabled = true
+	}
+	if *pickemPath != "" {
+		cfg.PickemHistory = *pickemPath
+	}
+	if *impactFeed != "" {
+		cfg.ImpactFeed = *impactFeed
+	}
+	if *ratingTimeline != "" {
+		cfg.RatingTimeline = *ratingTimeline
+	}
+	if *swingAudit != "" {
+		cfg.SwingAudit = *swingAudit
+	}
+	if *archivePath != "" {
+		cfg.ArchivePath = *archivePath
+	}
+	if *demoIndex != "" {
+		cfg.DemoIndex = *demoIndex
+	}
+	if *playerURL != "" {
+		cfg.Links.PlayerURL = *playerURL
+	}
+	if *matchURL != "" {
+		cfg.Links.MatchURL = *matchURL
+	}
+	if *jsonOutput != "" {
+		cfg.JSONOutput = *jsonOutput
+	}
+	if *columns != "" {
+		cfg.Columns = *columns
+	}
+	if cfg.Columns != "" && !output.ValidColumnPreset(cfg.Columns) {
+		log.Fatalf("Invalid column preset %q (valid: %s)", cfg.Columns, strings.Join(output.ColumnPresetNames(), ", "))
+	}
+	if *roundImportanceModel != "" {
+		cfg.RoundImportance.Model = *roundImportanceModel
+	}
+	if _, err := rating.NewImportanceModel(cfg.RoundImportance.Model, cfg.RoundImportance.Strength); err != nil {
+		log.Fatalf("Invalid round_importance: %v", err)
+	}
+	if _, err := parser.ParseDemoSource(cfg.DemoSource); err != nil {
+		log.Fatalf("Invalid demo_source: %v", err)
+	}
+	if *sheetID != "" {
+		cfg.Sheets.SpreadsheetID = *sheetID
+	}
+	if *sheetDir != "" {
+		cfg.Sheets.LocalDir = *sheetDir
+	}
+	if *sheetMode != "" {
+		cfg.Sheets.Mode = *sheetMode
+	}
+	if (cfg.Sheets.SpreadsheetID != "" || cfg.Sheets.LocalDir != "") && !sheets.ValidMode(cfg.Sheets.Mode) {
+		log.Fatalf("Invalid sheet mode %q (valid: replace, upsert)", cfg.Sheets.Mode)
+	}
+	if *clutchesPath != "" {
+		cfg.Clutches = *clutchesPath
+	}
+	if *grenadesPath != "" {
+		cfg.Grenades = *grenadesPath
+	}
+	if *discordWebhook != "" {
+		cfg.Discord.WebhookURL = *discordWebhook
+	}
+	if *datasetDir != "" {
+		cfg.Dataset.Dir = *datasetDir
+	}
+	if *datasetSalt != "" {
+		cfg.Dataset.Salt = *datasetSalt
+	}
+	if *throwsPath != "" {
+		cfg.Throws = *throwsPath
+	}
+	if *rolesPath != "" {
+		cfg.Roles = *rolesPath
+	}
+	if *anchoringPath != "" {
+		cfg.Anchoring = *anchoringPath
+	}
+	if *zonesPath != "" {
+		cfg.ZoneTendencies = *zonesPath
+	}
+	if *duelsPath != "" {
+		cfg.Duels = *duelsPath
+	}
+	if *buysPath != "" {
+		cfg.BuyTendencies = *buysPath
+	}
+	if *duosPath != "" {
+		cfg.EntryDuos = *duosPath
+	}
+	if *openingWeaponsPath != "" {
+		cfg.OpeningWeapons = *openingWeaponsPath
+	}
+	if *milestonesPath != "" {
+		cfg.Milestones = *milestonesPath
+	}
+	if *demoErrors != "" {
+		cfg.DemoErrors = *demoErrors
+	}
+	if *convergenceReport != "" {
+		cfg.Convergence.OutputPath = *convergenceReport
+	}
+	if *mapBaselines != "" {
+		cfg.MapBaselines = *mapBaselines
+	}
+	if *winTable != "" {
+		cfg.WinProbability.Table = *winTable
+	}
+	if *trainWinTable != "" {
+		cfg.WinProbability.TrainOutput = *trainWinTable
+	}
+	if *winTableMinSamples >= 0 {
+		cfg.WinProbability.MinSamples = *winTableMinSamples
+	}
+	if *identities != "" {
+		cfg.Identities = *identities
+	}
+	if *metricsAddr != "" {
+		cfg.Metrics.Addr = *metricsAddr
+	}
+	if *metricsPush != "" {
+		cfg.Metrics.PushURL = *metricsPush
+	}
+	if *minGames >= 0 {
+		cfg.Qualification.MinGames = *minGames
+	}
+	if *minRounds >= 0 {
+		cfg.Qualification.MinRounds = *minRounds
+	}
+	if cfg.MapBaselines != "" {
+		table, err := rating.LoadMapBaselines(cfg.MapBaselines)
+		if err != nil {
+			log.Fatalf("Failed to load map baselines: %v", err)
+		}
+		rating.SetMapBaselines(table)
+		log.Printf("Loaded rating baselines for %d maps from %s", len(table), cfg.MapBaselines)
+	}
+	if cfg.WinProbability.Table != "" {
+		table, err := probability.LoadWinProbabilityTable(cfg.WinProbability.Table)
+		if err != nil {
+			log.Fatalf("Failed to load win probability table: %v", err)
+		}
+		probability.SetWinProbabilityTable(table)
+		log.Printf("Loaded win probability table (%d states, %d rounds) from %s", len(table.Cells), table.Rounds, cfg.WinProbability.Table)
+	}
+	if len(cfg.TierBaselines) > 0 {
+		table := make(map[string]rating.Baselines, len(cfg.TierBaselines))
+		for tier, b := range cfg.TierBaselines {
+			table[strings.ToLower(tier)] = rating.Baselines{KPR: b.KPR, DPR: b.DPR, ADR: b.ADR, KAST: b.KAST}
+		}
+		rating.SetTierBaselines(table)
+	}
+
+	fileExporter := export.NewFileExportOptionWithColumns(*outputPath, cfg.Columns)
+	var exporter export.ExportOption = fileExporter
+	if cfg.Sheets.SpreadsheetID != "" || cfg.Sheets.LocalDir != "" {
+		exporter = export.NewMultiExportOption(fileExporter, newSheetsExporter(cfg))
+	}
+
+	// Handle a subcommand
+	if run != nil {
+		run(cfg, exporter)
+		return
+	}
+
+	// Handle fixture prediction from previously aggregated ratings
+	if *predictPath != "" {
+		runPredict(*predictPath, *ratingsPath, cfg.PickemHistory)
+		return
+	}
+
+	// Handle caster notes generation from the archive
+	if *casterNotes != "" {
+		runCasterNotes(*casterNotes, cfg.ArchivePath, *notesOutput)
+		return
+	}
+
+	// Handle weekly digest generation from the archive
+	if *digest {
+		runDigest(cfg.ArchivePath, *digestWeek, *digestOutput)
+		return
+	}
+
+	// Handle season progression snapshots from the archive
+	if *seasonProgression != "" {
+		runSeasonProgression(cfg.ArchivePath, *progressionSeason, *seasonProgression)
+		return
+	}
+
+	// Handle rating version comparison over the archive
+	if *recompute != "" {
+		runRecompute(cfg.ArchivePath, *recompute)
+		return
+	}
+
+	// Handle rating weight sensitivity analysis over the archive
+	if *sensitivity != "" {
+		runSensitivity(cfg, *sensitivity, *sensitivityStep, *sensitivityMinGames)
+		return
+	}
+
+	// Handle rating weight optimization over the archive
+	if *optimize != "" {
+		runOptimize(cfg, *optimize, *optimizeTarget, *optimizeMaxChange)
+		return
+	}
+
+	// Handle cross-validation against an external rating source
+	if *crossValidate != "" {
+		runCrossValidate(cfg, *crossValidate, *externalID, *externalRating, *crossValidateOutput)
+		return
+	}
+
+	// Handle per-map baseline calibration from the archive
+	if *calibrateMaps != "" || *calibrateTiers != "" {
+		runCalibrateBaselines(cfg.ArchivePath, *calibrateMaps, *calibrateTiers, *calibrateMinGames)
+		return
+	}
+
+	// Handle quick-scan cataloging of a demo dump
+	if *quickScan != "" {
+		runQuickScan(cfg, *quickScan, *catalogPath)
+		return
+	}
+
+	// Handle demo index search
+	if *findDemos != "" {
+		runFindDemos(cfg.DemoIndex, *findDemos)
+		return
+	}
 
-	exporter := export.NewFileExportOption(*outputPath)
+	// Handle REST API mode
+	if *serveAddr != "" {
+		runServer(*serveAddr, *ratingsPath, cfg.DemoIndex)
+		return
+	}
 
 	// Handle URL-based single demo parsing
 	if *demoURL != "" {
@@ -92,17 +502,41 @@ func main() {
 		return
 	}
 
+	// Handle streamed demo parsing
+	if *streamSource != "" {
+		parseSingleDemoFromStream(*streamSource, cfg, exporter)
+		return
+	}
+
 	// Handle stdin-based demo parsing (for demo-worker integration)
 	if *useStdin {
 		parseDemoFromStdin(cfg)
 		return
 	}
 
+	// Handle uploading an earlier run's stats
+	if *uploadPath != "" {
+		runUpload(cfg, *uploadPath)
+		return
+	}
+
+	// Handle explaining one player's rating in a demo
+	if *explainDemo != "" {
+		runExplain(cfg, *explainDemo, *explainPlayer)
+		return
+	}
+
 	// Validate mutually exclusive options
 	if cfg.CSCCompatibility && cfg.Cumulative {
 		log.Fatal("csc_compatibility and cumulative cannot both be true. CSC compatibility mode only works with single demo parsing.")
 	}
 
+	// Handle aggregation of a local demo directory
+	if *aggregateDir != "" {
+		runAggregate(cfg, *aggregateDir, exporter)
+		return
+	}
+
 	if cfg.Cumulative {
 		if cfg.Tier == "" {
 			log.Fatal("Tier must be specified in cumulative mode (use -tier flag or set in config)")
@@ -114,29 +548,47 @@ func main() {
 			}
 		}
 
-		runCumulativeMode(cfg, tiers, exporter)
+		if cfg.Metrics.Addr != "" {
+			go func() {
+				if err := metrics.Default.ListenAndServe(cfg.Metrics.Addr); err != nil {
+					log.Printf("Warning: Metrics listener stopped: %v", err)
+				}
+			}()
+			log.Printf("Serving metrics at %s/metrics", cfg.Metrics.Addr)
+		}
+		runCumulativeMode(cfg, tiers, "", exporter)
+		if cfg.Metrics.PushURL != "" {
+			if err := metrics.Default.Push(cfg.Metrics.PushURL, cfg.Metrics.Job); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
 		return
 	}
 
 	if cfg.DemoPath != "" {
-		demoPath := cfg.DemoPath
-		// Handle zip files - extract first
-		if strings.HasSuffix(strings.ToLower(demoPath), ".zip") {
-			dl := downloader.NewDownloader(cfg.DemoDir)
-			extracted, err := dl.Extract(demoPath)
-			if err != nil {
-				log.Fatalf("Failed to extract zip: %v", err)
-			}
-			demoPath = extracted
-		}
-		parseSingleDemo(demoPath, cfg, exporter)
+		parseLocalDemo(cfg.DemoPath, cfg, exporter)
 		return
 	}
 
+	printCommands(os.Stdout)
+	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  Cumulative mode: eco-rating -cumulative -tier=contender")
 	fmt.Println("  Single demo:     eco-rating -demo=path/to/demo.dem")
 	fmt.Println("  From URL:        eco-rating -url=https://example.com/demo.zip")
+	fmt.Println("  Stream:          eco-rating -stream=s3://bucket/demo.dem.gz")
+	fmt.Println("  Predict:         eco-rating -predict=fixture.json -ratings=stats.csv")
+	fmt.Println("  REST API:        eco-rating -serve=:8080 -ratings=stats.csv")
+	fmt.Println("  Weekly digest:   eco-rating -digest -archive=archive.json -week=M03")
+	fmt.Println("  Progression:     eco-rating -season-progression=progression.csv -archive=archive.json")
+	fmt.Println("  Find demos:      eco-rating -find-demos=de_nuke -demo-index=demos.json")
+	fmt.Println("  Catalog demos:   eco-rating -quick-scan=./dump -catalog=demo_catalog.csv")
+	fmt.Println("  Re-rate seasons: eco-rating -recompute=versions.csv -archive=archive.json")
+	fmt.Println("  Weight impact:   eco-rating -sensitivity=sensitivity.csv -archive=archive.json")
+	fmt.Println("  Fit weights:     eco-rating -optimize=weights.json -optimize-target=wins -archive=archive.json")
+	fmt.Println("  Cross-validate:  eco-rating -cross-validate=leetify.csv -archive=archive.json")
+	fmt.Println("  Map baselines:   eco-rating -calibrate-maps=map_baselines.json -archive=archive.json")
+	fmt.Println("  Tier baselines:  eco-rating -calibrate-tiers=tier_baselines.json -archive=archive.json")
 	fmt.Println("  Or set demo_path in config.json")
 	fmt.Println()
 	flag.PrintDefaults()
@@ -148,31 +600,250 @@ type ParseResult struct {
 	DemoKey   string                        // Unique identifier for the demo file
 	Players   map[uint64]*model.PlayerStats // Map of Steam ID to player statistics
 	MapName   string                        // Name of the map played (e.g., de_dust2)
+	Match     model.MatchInfo               // Map, teams, final score, tick rate and duration
 	Tier      string                        // Competitive tier (e.g., contender, elite)
-	Logs      string                        // Debug/parsing logs if enabled
 	Collector *probability.DataCollector    // Probability data collected from this demo
+	PlayedAt  time.Time                     // When the demo was recorded (zero if unknown)
+	Path      string                        // Local path of the parsed .dem file
+	SHA256    string                        // Hex SHA-256 of the .dem file (only when the demo index is enabled)
+	Size      int64                         // Size of the .dem file in bytes (only when the demo index is enabled)
 	Error     error                         // Any error encountered during parsing
 }
 
+// logger returns the default logger tagged with the result's demo, tier and map.
+func (r ParseResult) logger() *slog.Logger {
+	return slog.With("demo", r.DemoKey, "tier", r.Tier, "map", r.MapName)
+}
+
 // downloadedDemo represents a demo file that has been downloaded and extracted.
 type downloadedDemo struct {
-	Key  string // Original bucket key/path for the demo
-	Path string // Local filesystem path to the extracted .dem file
+	Key      string    // Original bucket key/path for the demo
+	Path     string    // Local filesystem path to the extracted .dem file
+	PlayedAt time.Time // Upload time from the bucket listing (zero if unknown)
+}
+
+// gameTrackers holds the optional stores updated with every parsed game in
+// cumulative mode. Nil fields are disabled.
+type gameTrackers struct {
+	history    *predict.History
+	archive    *archive.Archive
+	milestones *archive.MilestoneTracker
+	peaks      *archive.PeakStore
+	demos      *archive.DemoIndex
+	teams      *output.TeamAggregator
+	clutches   []model.ClutchDescriptor
+	keepClutch bool // Collect clutch descriptors into clutches
+	throws     []model.ThrowRound
+	keepThrows bool // Collect thrown rounds into throws
+	grenades   []model.GrenadeThrow
+	keepNades  bool // Collect grenade throws into grenades
+	roles      *analysis.RoleDetector
+	anchors    *analysis.AnchorLeaderboard
+	zones      *analysis.ZoneTendencies
+	duels      *analysis.DuelMatrix
+	buys       *analysis.BuyTendencies
+	duos       *analysis.EntryDuos
+	openings   *analysis.OpeningMatchups
+	discord    *output.DiscordNotifier
+	anon       *output.Anonymizer // Non-nil when collecting the public dataset
+	dataset    *output.Table
+	issues     []model.DemoIssue // Demos that failed to download or parse, or were only partly parsed
+	baseURL    string            // Bucket URL used to build demo download links
+}
+
+// observe updates every enabled tracker with a parsed game.
+func (t *gameTrackers) observe(result ParseResult) {
+	if t.history != nil {
+		t.history.ResolveFromPlayers(result.Players, result.MapName, result.PlayedAt)
+	}
+	if t.teams != nil {
+		t.teams.AddGame(result.Players, result.Tier)
+	}
+	if t.keepClutch {
+		t.clutches = append(t.clutches, output.CollectClutches(result.DemoKey, result.MapName, result.Players)...)
+	}
+	if t.keepThrows {
+		t.throws = append(t.throws, output.CollectThrows(result.DemoKey, result.MapName, result.Players)...)
+	}
+	if t.keepNades {
+		t.grenades = append(t.grenades, output.CollectGrenades(result.DemoKey, result.MapName, result.Players)...)
+	}
+	if t.roles != nil {
+		t.roles.AddGame(result.MapName, result.Players)
+	}
+	if t.anchors != nil {
+		t.anchors.AddGame(result.MapName, result.Players)
+	}
+	if t.zones != nil {
+		t.zones.AddGame(result.MapName, result.Players)
+	}
+	if t.duels != nil {
+		t.duels.AddGame(result.DemoKey, result.MapName, result.Players)
+	}
+	if t.buys != nil {
+		t.buys.AddGame(result.Players)
+	}
+	if t.duos != nil {
+		t.duos.AddGame(result.Players)
+	}
+	if t.openings != nil {
+		t.openings.AddGame(result.Players)
+	}
+	if t.anon != nil {
+		t.dataset.AppendDatasetRounds(output.CollectDatasetRounds(t.anon, result.DemoKey, result.MapName, result.Tier, result.Players))
+	}
+	if t.discord != nil {
+		postMatchSummary(result.logger(), t.discord, result.DemoKey, result.MapName, result.Players)
+	}
+	if t.demos != nil {
+		entry := archive.NewDemoEntry(result.DemoKey, result.MapName, result.Tier, result.PlayedAt, result.Players)
+		entry.Path = result.Path
+		entry.SHA256 = result.SHA256
+		entry.Size = result.Size
+		if t.baseURL != "" {
+			entry.URL = strings.TrimSuffix(t.baseURL, "/") + "/" + result.DemoKey
+		}
+		t.demos.Add(entry)
+	}
+	if t.archive == nil && t.milestones == nil && t.peaks == nil {
+		return
+	}
+
+	record := archive.NewGameRecord(result.Match, result.Tier, result.Players)
+	if t.archive != nil {
+		t.archive.Add(record)
+	}
+	if t.milestones != nil {
+		for _, m := range t.milestones.Observe(record) {
+			result.logger().Info("Milestone", "milestone", m.Message, "match", m.MatchID)
+		}
+	}
+	if t.peaks != nil {
+		for _, r := range t.peaks.Observe(record) {
+			result.logger().Info("League record", "player", r.Name, "stat", r.Stat, "value", r.Value, "match", r.MatchID)
+		}
+	}
 }
 
 // runCumulativeMode processes all demos for the specified tiers from the cloud bucket.
 // It downloads demos, parses them in parallel, aggregates statistics across all games,
 // and exports the final results. This is the primary mode for batch processing.
-func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.ExportOption) {
+// When localDir is set, every .dem file under it is aggregated as the only
+// tier instead, and the bucket isn't used.
+func runCumulativeMode(cfg *config.Config, tiers []string, localDir string, exporter export.ExportOption) {
 	log.Printf("Running in cumulative mode for tiers: %v", tiers)
 
 	client := bucket.NewClient(cfg.BaseURL)
 	client.IgnoreScrims = cfg.IgnoreScrims
 	dl := downloader.NewDownloader(cfg.DemoDir)
 	aggregator := output.NewAggregatorWithOptions(cfg.KDPRModifier)
+	aggregator.SetCloseMatchWeight(cfg.CloseMatchWeight)
+	aggregator.SetKillQualityWeight(cfg.KillQualityWeight)
+	aggregator.SetSupportProfiles(cfg.Support.Players, cfg.Support.AutoDetect)
+	aggregator.SetSolverOptions(output.SolverOptions{
+		MaxIterations: cfg.Convergence.MaxIterations,
+		Tolerance:     cfg.Convergence.Tolerance,
+	})
+	aggregator.SetQualification(output.QualificationRules{
+		MinGames:  cfg.Qualification.MinGames,
+		MinRounds: cfg.Qualification.MinRounds,
+	})
+	goals := parseGoals(cfg.Goals.Players)
+	if cfg.Identities != "" {
+		ids, err := output.LoadIdentities(cfg.Identities)
+		if err != nil {
+			log.Fatalf("Failed to load identities: %v", err)
+		}
+		aggregator.SetIdentities(ids)
+		log.Printf("Loaded identities for %d players from %s", ids.Len(), cfg.Identities)
+	}
 	probCollector := probability.NewDataCollector()
+	history := loadPickemHistory(cfg)
+	gameArchive := loadArchive(cfg)
+	trackers := &gameTrackers{
+		history: history,
+		archive: gameArchive,
+		peaks:   loadPeakStore(cfg),
+		demos:   loadDemoIndex(cfg.DemoIndex),
+		baseURL: cfg.BaseURL,
+	}
+	if gameArchive != nil {
+		trackers.milestones = archive.NewMilestoneTracker(archive.DefaultMilestoneRules())
+		trackers.milestones.Seed(gameArchive)
+	}
+	if cfg.Teams.Enabled {
+		trackers.teams = output.NewTeamAggregator()
+	}
+	if cfg.Clutches != "" {
+		trackers.keepClutch = true
+	}
+	trackers.keepThrows = cfg.Throws != ""
+	trackers.keepNades = cfg.Grenades != ""
+	if cfg.Roles != "" {
+		trackers.roles = analysis.NewRoleDetector()
+	}
+	if cfg.Anchoring != "" {
+		trackers.anchors = analysis.NewAnchorLeaderboard()
+	}
+	if cfg.ZoneTendencies != "" {
+		trackers.zones = analysis.NewZoneTendencies()
+	}
+	if cfg.Duels != "" || cfg.Sheets.DuelsTab != "" {
+		trackers.duels = analysis.NewDuelMatrix()
+	}
+	if cfg.BuyTendencies != "" {
+		trackers.buys = analysis.NewBuyTendencies()
+	}
+	if cfg.EntryDuos != "" {
+		trackers.duos = analysis.NewEntryDuos()
+	}
+	if cfg.OpeningWeapons != "" {
+		trackers.openings = analysis.NewOpeningMatchups()
+	}
+	if cfg.Discord.WebhookURL != "" {
+		discord, err := output.NewDiscordNotifier(cfg.Discord.WebhookURL, cfg.Discord.PostedPath)
+		if err != nil {
+			log.Printf("Warning: Discord match summaries disabled: %v", err)
+		} else {
+			trackers.discord = discord
+		}
+	}
+	if cfg.Dataset.Dir != "" {
+		if cfg.Dataset.Salt == "" {
+			log.Fatalf("Public dataset needs a salt (-dataset-salt); unsalted IDs can be reversed by hashing known Steam IDs")
+		}
+		trackers.anon = output.NewAnonymizer(cfg.Dataset.Salt)
+		trackers.dataset = output.NewTable(output.DatasetRound{})
+	}
 
-	for _, prefix := range cfg.Prefixes {
+	// week is the latest match week among the demos' keys, which the goals
+	// history is recorded under.
+	week := ""
+
+	// parseTier parses one tier's demos into the aggregator.
+	parseTier := func(tier, aggTier string, downloadedDemos []downloadedDemo) {
+		successCount := parseDemosToAggregator(cfg, downloadedDemos, aggregator, probCollector, trackers, aggTier)
+		for _, demo := range downloadedDemos {
+			if w := archive.ParseWeek(demo.Key); w != "" && (week == "" || archive.CompareWeeks(w, week) > 0) {
+				week = w
+			}
+		}
+
+		log.Printf("Completed processing %d/%d demos for %s", successCount, len(downloadedDemos), tier)
+	}
+
+	prefixes := cfg.Prefixes
+	if localDir != "" {
+		downloadedDemos, err := localDemos(localDir)
+		if err != nil {
+			log.Fatalf("Failed to list demos: %v", err)
+		}
+		log.Printf("Found %d demos in %s, starting parallel parsing...", len(downloadedDemos), localDir)
+		parseTier(tiers[0], tiers[0], downloadedDemos)
+		prefixes = nil
+	}
+
+	for _, prefix := range prefixes {
 		log.Printf("\n=== Processing prefix: %s ===", prefix)
 
 		for _, tier := range tiers {
@@ -212,43 +883,95 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 
 			log.Printf("Downloading demos...")
 			for i, demo := range demos {
-				log.Printf("[%d/%d] Downloading: %s", i+1, len(demos), demo.Key)
+				demoLog := slog.With("demo", demo.Key, "tier", aggTier)
+				demoLog.Info("Downloading demo", "progress", fmt.Sprintf("%d/%d", i+1, len(demos)))
 
 				url := client.GetDownloadURL(demo.Key)
 				demoPath, err := dl.DownloadAndExtract(url)
 				if err != nil {
-					log.Printf("  Error downloading: %v", err)
+					demoLog.Warn("Failed to download demo", "err", err)
+					trackers.issues = append(trackers.issues, model.DemoIssue{
+						Demo: demo.Key, Tier: aggTier, Kind: string(parser.KindUnreadable), Error: err.Error(),
+					})
 					continue
 				}
 
-				downloadedDemos = append(downloadedDemos, downloadedDemo{Key: demo.Key, Path: demoPath})
+				playedAt, _ := time.Parse(time.RFC3339, demo.LastModified)
+				downloadedDemos = append(downloadedDemos, downloadedDemo{Key: demo.Key, Path: demoPath, PlayedAt: playedAt})
 			}
 
 			log.Printf("Downloaded %d demos for %s, starting parallel parsing...", len(downloadedDemos), tier)
-
-			successCount, allLogs := parseDemosToAggregator(cfg, downloadedDemos, aggregator, probCollector, aggTier)
-
-			if len(allLogs) > 0 {
-				log.Printf("\n========== PARSING LOGS (%s) ==========", tier)
-				for _, logOutput := range allLogs {
-					fmt.Println(logOutput)
-				}
-				log.Printf("========== END LOGS ==========\n")
-			}
-
-			log.Printf("Completed processing %d/%d demos for %s", successCount, len(downloadedDemos), tier)
+			parseTier(tier, aggTier, downloadedDemos)
 		}
 	}
 
 	aggregator.Finalize()
+	savePickemHistory(cfg, history)
+	if gameArchive != nil {
+		if err := gameArchive.Save(cfg.ArchivePath); err != nil {
+			log.Printf("Warning: Failed to save archive: %v", err)
+		} else {
+			log.Printf("Archive saved to %s (%d games)", cfg.ArchivePath, len(gameArchive.Games))
+		}
+	}
+	savePeakStore(cfg, trackers.peaks)
+	if trackers.demos != nil {
+		if err := trackers.demos.Save(cfg.DemoIndex); err != nil {
+			log.Printf("Warning: Failed to save demo index: %v", err)
+		} else {
+			log.Printf("Demo index saved to %s (%d demos)", cfg.DemoIndex, len(trackers.demos.Demos))
+		}
+	}
+	if cfg.RecordBook.Enabled {
+		writeRecordBook(cfg, gameArchive)
+	}
+	if trackers.milestones != nil && cfg.Milestones != "" {
+		reached := trackers.milestones.Milestones()
+		if err := export.WriteMilestones(cfg.Milestones, reached); err != nil {
+			log.Printf("Warning: Failed to export milestones: %v", err)
+		} else {
+			log.Printf("%d milestones saved to %s", len(reached), cfg.Milestones)
+		}
+	}
+
+	if cfg.DemoErrors != "" {
+		if err := export.WriteDemoIssues(cfg.DemoErrors, trackers.issues); err != nil {
+			log.Printf("Warning: Failed to export demo error report: %v", err)
+		} else {
+			log.Printf("Error report for %d demos saved to %s", len(trackers.issues), cfg.DemoErrors)
+		}
+	}
 
 	results := aggregator.GetResults()
+	convergence := aggregator.ConvergenceReports()
+	for _, r := range convergence {
+		warnUnconverged(r)
+	}
+	rookieSet := loadRookies(cfg, gameArchive)
+	output.MarkRookies(results, rookieSet)
+
+	if cfg.WinProbability.TrainOutput != "" {
+		table := probCollector.BuildWinProbabilityTable(cfg.WinProbability.MinSamples)
+		if err := probability.SaveWinProbabilityTable(cfg.WinProbability.TrainOutput, table); err != nil {
+			log.Printf("Warning: Failed to save win probability table: %v", err)
+		} else {
+			log.Printf("Win probability table saved to %s (%d states from %d rounds)", cfg.WinProbability.TrainOutput, len(table.Cells), table.Rounds)
+		}
+	}
 
 	if cfg.GenerateFiles {
 		if err := exporter.ExportAggregated(results); err != nil {
 			log.Fatalf("Failed to export aggregated stats: %v", err)
 		}
 
+		if cfg.JSONOutput != "" {
+			if err := output.WriteJSON(cfg.JSONOutput, results); err != nil {
+				log.Printf("Warning: Failed to export JSON stats: %v", err)
+			} else {
+				log.Printf("JSON stats saved to %s", cfg.JSONOutput)
+			}
+		}
+
 		// Save probability data
 		rounds, kills := probCollector.GetStats()
 		if rounds > 0 {
@@ -260,54 +983,466 @@ func runCumulativeMode(cfg *config.Config, tiers []string, exporter export.Expor
 			}
 		}
 
-		log.Printf("\nAggregated stats for %d players across %d tiers exported successfully", len(results), len(tiers))
-	} else {
-		log.Printf("\nProcessed %d players across %d tiers (file generation disabled)", len(results), len(tiers))
-	}
-}
+		if cfg.DraftValue.Enabled {
+			values := output.ComputeDraftValues(results, draftValueWeights(cfg.DraftValue))
+			if err := export.WriteDraftValues(cfg.DraftValue.OutputPath, values); err != nil {
+				log.Printf("Warning: Failed to export draft values: %v", err)
+			} else {
+				log.Printf("Draft values for %d players saved to %s", len(values), cfg.DraftValue.OutputPath)
+			}
+		}
 
-// parseDemosToAggregator processes multiple demos in parallel using a worker pool.
-// It returns the count of successfully parsed demos and collected log output.
-// The number of workers is capped at 8 or the number of CPU cores, whichever is lower.
-func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo, aggregator *output.Aggregator, probCollector *probability.DataCollector, tier string) (int, []string) {
-	numWorkers := cfg.Workers
-	if numWorkers <= 0 {
-		numWorkers = runtime.NumCPU()
-	}
-	log.Printf("Using %d parallel workers", numWorkers)
+		if cfg.CrossTier.Enabled {
+			strength := cfg.CrossTier.TierStrength
+			if cfg.CrossTier.EstimateStrength {
+				estimates, report := output.EstimateTierStrength(results, output.TierStrengthOptions{
+					Priors:           cfg.CrossTier.TierStrength,
+					AnchorTier:       "premier",
+					MinRoundsPerTier: cfg.CrossTier.MinRoundsPerTier,
+					PriorWeight:      cfg.CrossTier.PriorWeight,
+					PlayIns:          tierMatchups(gameArchive),
+					Solver: output.SolverOptions{
+						MaxIterations: cfg.Convergence.MaxIterations,
+						Tolerance:     cfg.Convergence.Tolerance,
+					},
+				})
+				strength = output.TierStrengthCoefficients(estimates)
+				warnUnconverged(report)
+				convergence = append(convergence, report)
+				if err := export.WriteTierStrength(cfg.CrossTier.StrengthOutputPath, estimates); err != nil {
+					log.Printf("Warning: Failed to export tier strength: %v", err)
+				} else {
+					log.Printf("Tier strength estimates saved to %s", cfg.CrossTier.StrengthOutputPath)
+				}
+			}
+			ratings := output.ComputeCrossTierRatings(results, strength)
+			if err := export.WriteCrossTierRatings(cfg.CrossTier.OutputPath, ratings); err != nil {
+				log.Printf("Warning: Failed to export cross-tier ratings: %v", err)
+			} else {
+				log.Printf("Cross-tier ratings for %d players saved to %s", len(ratings), cfg.CrossTier.OutputPath)
+			}
+		}
 
-	jobs := make(chan downloadedDemo, len(downloadedDemos))
-	results := make(chan ParseResult, len(downloadedDemos))
+		if cfg.Convergence.OutputPath != "" {
+			if err := export.WriteConvergenceReport(cfg.Convergence.OutputPath, convergence); err != nil {
+				log.Printf("Warning: Failed to export convergence report: %v", err)
+			} else {
+				log.Printf("Convergence report for %d solves saved to %s", len(convergence), cfg.Convergence.OutputPath)
+			}
+		}
 
-	var wg sync.WaitGroup
-	for w := 0; w < numWorkers; w++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for job := range jobs {
-				players, mapName, logs, collector, err := parseDemoWithLogs(job.Path, cfg.EnableLogging, cfg.KDPRModifier)
-				// Determine tier from demo filename: team_ prefix = scrim, otherwise = regulation
-				demoTier := tier
-				if strings.Contains(strings.ToLower(job.Key), "team_") {
-					demoTier = "scrim"
-				} else if tier == "all" {
+		if cfg.RookieReport.Enabled {
+			leaderboard := output.ComputeRookieLeaderboard(results, cfg.RookieReport.MinRounds)
+			if err := export.WriteRookieLeaderboard(cfg.RookieReport.LeaderboardPath, leaderboard); err != nil {
+				log.Printf("Warning: Failed to export rookie leaderboard: %v", err)
+			} else {
+				log.Printf("Rookie leaderboard for %d players saved to %s", len(leaderboard), cfg.RookieReport.LeaderboardPath)
+			}
+			baselines := output.ComputeRookieBaselines(results, cfg.RookieReport.MinRounds)
+			if err := export.WriteRookieBaselines(cfg.RookieReport.BaselinesPath, baselines); err != nil {
+				log.Printf("Warning: Failed to export rookie baselines: %v", err)
+			} else {
+				log.Printf("Rookie baselines saved to %s", cfg.RookieReport.BaselinesPath)
+			}
+		}
+
+		if cfg.Support.LeaderboardPath != "" || cfg.Sheets.SupportTab != "" {
+			exportSupportLeaderboard(cfg, exporter, results)
+		}
+
+		if cfg.Entry.LeaderboardPath != "" || cfg.Sheets.EntryTab != "" {
+			exportEntryLeaderboard(cfg, exporter, results)
+		}
+
+		if cfg.Pistol.LeaderboardPath != "" || cfg.Sheets.PistolTab != "" {
+			exportPistolLeaderboard(cfg, exporter, results)
+		}
+
+		if goals != nil {
+			goalsWeek := week
+			if goalsWeek == "" {
+				goalsWeek = time.Now().Format("2006-01-02") // Demo keys without a match week are recorded by run date
+			}
+			attainment := output.EvaluateGoals(results, goals)
+			if err := export.WriteGoals(cfg.Goals.OutputPath, goalsWeek, attainment); err != nil {
+				log.Printf("Warning: Failed to export goals: %v", err)
+			} else {
+				log.Printf("Attainment of %d player goals for %s added to %s", len(attainment), goalsWeek, cfg.Goals.OutputPath)
+			}
+		}
+
+		if cfg.IGL.Enabled {
+			igls := make(map[string]string, len(cfg.IGL.Rosters))
+			for roster, steamID := range cfg.IGL.Rosters {
+				igls[steamID] = roster
+			}
+			for _, steamID := range cfg.IGL.Players {
+				if _, ok := igls[steamID]; !ok {
+					igls[steamID] = ""
+				}
+			}
+			ratings := output.ComputeIGLRatings(results, igls, cfg.IGL.Adjustment)
+			if err := export.WriteIGLRatings(cfg.IGL.OutputPath, ratings); err != nil {
+				log.Printf("Warning: Failed to export IGL ratings: %v", err)
+			} else {
+				log.Printf("IGL-adjusted ratings (%d IGLs) saved to %s", len(igls), cfg.IGL.OutputPath)
+			}
+		}
+
+		if trackers.teams != nil {
+			exportTeamStats(cfg, trackers.teams)
+		}
+
+		if trackers.keepClutch {
+			output.SortClutches(trackers.clutches)
+			if err := export.WriteClutches(cfg.Clutches, trackers.clutches); err != nil {
+				log.Printf("Warning: Failed to export clutches: %v", err)
+			} else {
+				log.Printf("%d clutch descriptors saved to %s", len(trackers.clutches), cfg.Clutches)
+			}
+		}
+
+		if trackers.keepThrows {
+			output.SortThrows(trackers.throws)
+			if err := export.WriteThrows(cfg.Throws, trackers.throws); err != nil {
+				log.Printf("Warning: Failed to export throws: %v", err)
+			} else {
+				log.Printf("%d thrown rounds saved to %s", len(trackers.throws), cfg.Throws)
+			}
+		}
+
+		if trackers.roles != nil {
+			roles := trackers.roles.Results()
+			if err := export.WriteRoles(cfg.Roles, roles); err != nil {
+				log.Printf("Warning: Failed to export roles: %v", err)
+			} else {
+				log.Printf("Detected roles for %d player-maps saved to %s", len(roles), cfg.Roles)
+			}
+		}
+
+		if trackers.anchors != nil {
+			anchors := trackers.anchors.Results()
+			if err := export.WriteAnchorLeaderboard(cfg.Anchoring, anchors); err != nil {
+				log.Printf("Warning: Failed to export anchoring leaderboard: %v", err)
+			} else {
+				log.Printf("Anchoring leaderboard for %d player-maps saved to %s", len(anchors), cfg.Anchoring)
+			}
+		}
+
+		if trackers.zones != nil {
+			tendencies := trackers.zones.Results()
+			if err := export.WriteZoneTendencies(cfg.ZoneTendencies, tendencies); err != nil {
+				log.Printf("Warning: Failed to export zone tendencies: %v", err)
+			} else {
+				log.Printf("Zone tendencies (%d rows) saved to %s", len(tendencies), cfg.ZoneTendencies)
+			}
+		}
+
+		if trackers.duels != nil {
+			exportDuels(cfg, exporter, trackers.duels)
+		}
+
+		if trackers.buys != nil {
+			profiles := trackers.buys.Results()
+			if err := export.WriteBuyTendencies(cfg.BuyTendencies, profiles); err != nil {
+				log.Printf("Warning: Failed to export buy tendencies: %v", err)
+			} else {
+				log.Printf("Buy tendencies for %d players saved to %s", len(profiles), cfg.BuyTendencies)
+			}
+		}
+
+		if trackers.duos != nil {
+			duos := trackers.duos.Results()
+			if err := export.WriteEntryDuos(cfg.EntryDuos, duos); err != nil {
+				log.Printf("Warning: Failed to export entry duos: %v", err)
+			} else {
+				log.Printf("Entry synergy for %d player pairs saved to %s", len(duos), cfg.EntryDuos)
+			}
+		}
+
+		if trackers.openings != nil {
+			matchups := trackers.openings.Results()
+			if err := export.WriteOpeningMatchups(cfg.OpeningWeapons, matchups); err != nil {
+				log.Printf("Warning: Failed to export opening weapon matchups: %v", err)
+			} else {
+				log.Printf("Opening duels by weapon matchup (%d rows) saved to %s", len(matchups), cfg.OpeningWeapons)
+			}
+		}
+
+		if trackers.keepNades {
+			byMap := output.GrenadesByMap(trackers.grenades)
+			if err := export.WriteGrenades(cfg.Grenades, byMap); err != nil {
+				log.Printf("Warning: Failed to export grenades: %v", err)
+			} else {
+				log.Printf("%d grenade throws across %d maps saved to %s", len(trackers.grenades), len(byMap), cfg.Grenades)
+			}
+		}
+
+		if trackers.anon != nil {
+			players := output.NewDatasetPlayers(trackers.anon, results)
+			if err := export.WriteDataset(cfg.Dataset.Dir, trackers.dataset, players); err != nil {
+				log.Printf("Warning: Failed to export public dataset: %v", err)
+			} else {
+				log.Printf("Public dataset (%d player rounds, %d players) saved to %s", trackers.dataset.Len(), len(players), cfg.Dataset.Dir)
+			}
+		}
+
+		if cfg.Awards.Enabled {
+			exportAwardStandings(cfg, results, rookieSet)
+		}
+
+		log.Printf("\nAggregated stats for %d players across %d tiers exported successfully", len(results), len(tiers))
+	} else {
+		log.Printf("\nProcessed %d players across %d tiers (file generation disabled)", len(results), len(tiers))
+	}
+}
+
+// runAggregate aggregates every .dem file under dir like cumulative mode,
+// as one tier named by cfg.Tier (default all).
+func runAggregate(cfg *config.Config, dir string, exporter export.ExportOption) {
+	tier := strings.ToLower(cfg.Tier)
+	if tier == "" {
+		tier = "all"
+	}
+	if !config.IsStandardTier(tier) && !config.IsAllTier(tier) {
+		log.Fatalf("Invalid tier '%s' for a demo directory. Valid tiers: %v or all", tier, config.ValidTiers())
+	}
+	runCumulativeMode(cfg, []string{tier}, dir, exporter)
+}
+
+// localDemos lists every .dem file under dir for aggregation, keyed by its
+// path relative to dir so season and week folders (s19/M03/...) are read
+// like bucket keys. PlayedAt is left unset: demos don't record when they
+// were played, and a file's modification time is when it was copied. So
+// duplicate demos of a match are found by their fingerprint alone, with no
+// time window, and the games are archived undated, in key order.
+func localDemos(dir string) ([]downloadedDemo, error) {
+	var demos []downloadedDemo
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".dem") {
+			return nil
+		}
+		key, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		demos = append(demos, downloadedDemo{Key: filepath.ToSlash(key), Path: p})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(demos) == 0 {
+		return nil, fmt.Errorf("no .dem files found in %s", dir)
+	}
+	return demos, nil
+}
+
+// tierMatchups returns the archived games between teams from different tiers
+// for the tier strength estimate, or nil without an archive.
+func tierMatchups(gameArchive *archive.Archive) []output.TierMatchup {
+	if gameArchive == nil {
+		return nil
+	}
+	var matchups []output.TierMatchup
+	for _, p := range gameArchive.PlayIns() {
+		matchups = append(matchups, output.TierMatchup{
+			Tiers:       p.Tiers,
+			RoundsWon:   p.RoundsWon,
+			HomeRatings: p.HomeRatings,
+		})
+	}
+	return matchups
+}
+
+// draftValueWeights converts the draft value config section into aggregator weights.
+func draftValueWeights(dv config.DraftValueConfig) output.DraftValueWeights {
+	return output.DraftValueWeights{
+		RatingWeight:      dv.RatingWeight,
+		ConsistencyWeight: dv.ConsistencyWeight,
+		MapPoolWeight:     dv.MapPoolWeight,
+		TierMultipliers:   dv.TierMultipliers,
+		MinMapGames:       dv.MinMapGames,
+		MapPool:           dv.MapPool,
+		ConsistencyStdDev: dv.ConsistencyStdDev,
+		Scale:             dv.Scale,
+	}
+}
+
+// loadRookies returns the first-season players: the configured rookie list
+// plus, when enabled, players first seen in the archive this season. The
+// current season is the highest season found in the configured prefixes.
+func loadRookies(cfg *config.Config, gameArchive *archive.Archive) map[string]bool {
+	rookies := make(map[string]bool)
+	for _, id := range cfg.Rookies {
+		rookies[id] = true
+	}
+	if !cfg.RookieReport.DetectFromArchive || gameArchive == nil {
+		return rookies
+	}
+
+	season := 0
+	for _, prefix := range cfg.Prefixes {
+		season = max(season, archive.ParseSeason(prefix))
+	}
+	if season == 0 {
+		log.Printf("Warning: Could not determine the current season from prefixes; skipping rookie detection")
+		return rookies
+	}
+	for id := range gameArchive.FirstSeasonPlayers(season) {
+		rookies[id] = true
+	}
+	return rookies
+}
+
+// exportAwardStandings writes a standings file for each award race to the
+// configured awards directory.
+func exportAwardStandings(cfg *config.Config, results map[string]*output.AggregatedStats, rookies map[string]bool) {
+	elig := output.AwardEligibility{Rookies: rookies}
+	if len(cfg.Awards.Rostered) > 0 {
+		elig.Rostered = make(map[string]bool)
+		for _, id := range cfg.Awards.Rostered {
+			elig.Rostered[id] = true
+		}
+	}
+
+	for _, award := range output.DefaultAwards(cfg.Awards.MinRounds, cfg.Awards.MinAWPKillsPct) {
+		standings := output.ComputeAwardStandings(results, award, elig)
+		path := filepath.Join(cfg.Awards.OutputDir, award.Slug+".csv")
+		if err := export.WriteAwardStandings(path, award.ScoreLabel, standings); err != nil {
+			log.Printf("Warning: Failed to export %s standings: %v", award.Name, err)
+		} else {
+			log.Printf("%s standings saved to %s", award.Name, path)
+		}
+	}
+}
+
+// postMatchSummary posts a parsed game's summary to Discord. Failures are
+// logged and don't stop parsing.
+func postMatchSummary(logger *slog.Logger, discord *output.DiscordNotifier, matchID, mapName string, players map[uint64]*model.PlayerStats) {
+	if err := discord.PostMatchSummary(output.NewMatchSummary(matchID, mapName, players)); err != nil {
+		logger.Warn("Failed to post match summary", "err", err)
+	}
+}
+
+// exportTeamStats finalizes the team aggregator and writes the CSV and,
+// when configured, JSON team rating exports.
+func exportTeamStats(cfg *config.Config, teams *output.TeamAggregator) {
+	teams.Finalize()
+	results := teams.GetResults()
+	if err := export.WriteTeamStats(cfg.Teams.OutputPath, results); err != nil {
+		log.Printf("Warning: Failed to export team stats: %v", err)
+		return
+	}
+	log.Printf("Team ratings for %d teams saved to %s", len(results), cfg.Teams.OutputPath)
+	if cfg.Teams.JSONPath != "" {
+		if err := export.WriteTeamStatsJSON(cfg.Teams.JSONPath, results); err != nil {
+			log.Printf("Warning: Failed to export team stats JSON: %v", err)
+		}
+	}
+}
+
+// parseGoals parses the configured per-player goals, exiting on an invalid
+// one. It returns nil when no goals are set.
+func parseGoals(players map[string][]string) map[string][]output.Goal {
+	if len(players) == 0 {
+		return nil
+	}
+	goals := make(map[string][]output.Goal, len(players))
+	for steamID, list := range players {
+		for _, s := range list {
+			g, err := output.ParseGoal(s)
+			if err != nil {
+				log.Fatalf("Invalid goal for %s: %v", steamID, err)
+			}
+			goals[steamID] = append(goals[steamID], g)
+		}
+	}
+	return goals
+}
+
+// parseDemosToAggregator processes multiple demos in parallel using a worker pool.
+// It returns the count of successfully parsed demos and collected log output.
+// The number of workers comes from cfg.Workers (0 = one per CPU core). Results are
+// merged on this goroutine only, so the aggregator needs no locking; a demo that
+// fails or panics is reported and skipped without aborting the batch. Results are
+// merged in match ID (demo key) order whatever order the workers finish in, so the
+// same demos always sum in the same order and give bit-identical exports.
+// Each parsed demo is also passed to the enabled trackers (pick'em history,
+// archive, milestones, peak records, demo index, team ratings, clutches, throws, grenades, Discord summaries).
+// A demo that repeats rounds of a restored match merged before it is parsed again without them.
+func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo, aggregator *output.Aggregator, probCollector *probability.DataCollector, trackers *gameTrackers, tier string) int {
+	numWorkers := cfg.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	log.Printf("Using %d parallel workers", numWorkers)
+
+	ordered := slices.Clone(downloadedDemos)
+	slices.SortStableFunc(ordered, func(a, b downloadedDemo) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	type orderedResult struct {
+		order int // Position of the demo in ordered
+		ParseResult
+	}
+	jobs := make(chan int, len(ordered))
+	results := make(chan orderedResult, len(ordered))
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for order := range jobs {
+				job := ordered[order]
+				start := time.Now()
+				players, match, collector, err := parseDemoFile(job.Path, job.Key, cfg, tier, 0, 0)
+				fatal := err
+				if parser.IsPartial(err) {
+					fatal = nil // The stats up to the failure are kept
+				}
+				metrics.ObserveParse(start, match.Team1Score+match.Team2Score, fatal)
+				match.MatchID = job.Key
+				match.StartTime = job.PlayedAt
+				var hash string
+				var size int64
+				if fatal == nil && cfg.DemoIndex != "" {
+					var hashErr error
+					if hash, size, hashErr = archive.HashFile(job.Path); hashErr != nil {
+						slog.Warn("Failed to hash demo", "demo", job.Key, "tier", tier, "err", hashErr)
+					}
+				}
+				// Determine tier from demo filename: team_ prefix = scrim, otherwise = regulation
+				demoTier := tier
+				if strings.Contains(strings.ToLower(job.Key), "team_") {
+					demoTier = "scrim"
+				} else if tier == "all" {
 					demoTier = "regulation"
 				}
-				results <- ParseResult{
+				results <- orderedResult{order, ParseResult{
 					DemoKey:   job.Key,
 					Players:   players,
-					MapName:   mapName,
+					MapName:   match.Map,
+					Match:     match,
 					Tier:      demoTier,
-					Logs:      logs,
 					Collector: collector,
+					PlayedAt:  job.PlayedAt,
+					Path:      job.Path,
+					SHA256:    hash,
+					Size:      size,
 					Error:     err,
-				}
+				}}
 			}
 		}()
 	}
 
-	for _, demo := range downloadedDemos {
-		jobs <- demo
+	for order := range ordered {
+		jobs <- order
 	}
 	close(jobs)
 
@@ -316,18 +1451,41 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 		close(results)
 	}()
 
-	var allLogs []string
+	var failed []string
+	var excluded model.RoundExclusions
+	coverage := output.NewMatchCoverage()
+	deduper := output.NewMatchDeduper()
+	var duplicates []string
 	successCount := 0
 	processedCount := 0
 
-	for result := range results {
+	merge := func(result ParseResult) {
 		processedCount++
 		if result.Error != nil {
-			log.Printf("[%d/%d] Parse error for %s: %v", processedCount, len(downloadedDemos), result.DemoKey, result.Error)
-			continue
+			trackers.issues = append(trackers.issues, parser.NewDemoIssue(result.DemoKey, result.Tier, result.Error))
+		}
+		progress := fmt.Sprintf("%d/%d", processedCount, len(downloadedDemos))
+		if result.Error != nil && !parser.IsPartial(result.Error) {
+			result.logger().Error("Parse failed", "progress", progress, "err", result.Error)
+			failed = append(failed, result.DemoKey)
+			return
+		}
+
+		if demo, ok := deduper.Duplicate(result.Match, result.PlayedAt); ok {
+			result.logger().Warn("Skipping duplicate demo of a match already merged", "duplicate_of", demo, "progress", progress)
+			duplicates = append(duplicates, result.DemoKey)
+			return
 		}
+		deduper.Add(result.DemoKey, result.Match, result.PlayedAt)
+
+		if from, to, demo, ok := coverage.Duplicates(result.Match, result.PlayedAt); ok {
+			result = reparseDuplicateRounds(result, from, to, demo, cfg, tier)
+		}
+		coverage.Add(result.DemoKey, result.Match, result.PlayedAt)
 
 		aggregator.AddGame(result.Players, result.MapName, result.Tier)
+		trackers.observe(result)
+		excluded.Add(result.Match.Excluded)
 
 		// Merge probability data from this demo
 		if result.Collector != nil {
@@ -335,14 +1493,63 @@ func parseDemosToAggregator(cfg *config.Config, downloadedDemos []downloadedDemo
 		}
 
 		successCount++
-		log.Printf("[%d/%d] Parsed: %s (map: %s, players: %d)", processedCount, len(downloadedDemos), result.DemoKey, result.MapName, len(result.Players))
+		if result.Error != nil {
+			result.logger().Warn("Parsed partial demo", "players", len(result.Players), "progress", progress, "err", result.Error)
+		} else {
+			result.logger().Info("Parsed demo", "players", len(result.Players), "excluded_rounds", result.Match.Excluded.Total(),
+				"progress", progress)
+		}
+	}
 
-		if result.Logs != "" {
-			allLogs = append(allLogs, fmt.Sprintf("=== %s ===\n%s", result.DemoKey, result.Logs))
+	// Hold results that finish early until every demo before them is merged.
+	pending := make(map[int]ParseResult)
+	next := 0
+	for r := range results {
+		pending[r.order] = r.ParseResult
+		for {
+			result, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			merge(result)
 		}
 	}
 
-	return successCount, allLogs
+	if len(failed) > 0 {
+		log.Printf("%d demo(s) failed to parse and were skipped: %s", len(failed), strings.Join(failed, ", "))
+	}
+	if len(duplicates) > 0 {
+		log.Printf("%d demo(s) were duplicates of matches already merged and were skipped: %s", len(duplicates), strings.Join(duplicates, ", "))
+	}
+	if n := excluded.Total(); n > 0 {
+		log.Printf("Excluded %d rounds that weren't live across %d demos: %s", n, successCount, excluded)
+	}
+
+	return successCount
+}
+
+// reparseDuplicateRounds parses a demo again without the rounds, starting
+// with from to to-1 rounds won, that the demo named other already counts:
+// the match was restored from a round backup and both demos recorded them.
+// If the demo fails to parse again it is kept whole.
+func reparseDuplicateRounds(result ParseResult, from, to int, other string, cfg *config.Config, tier string) ParseResult {
+	result.logger().Info("Demo repeats rounds of a restored match, counting them once",
+		"other", other, "rounds", to-from, "from_round", from+1)
+	players, match, collector, err := parseDemoFile(result.Path, result.DemoKey, cfg, tier, from, to)
+	if err != nil && !parser.IsPartial(err) {
+		result.logger().Warn("Failed to parse restored demo again, counting it whole", "err", err)
+		return result
+	}
+	match.MatchID = result.Match.MatchID
+	match.StartTime = result.Match.StartTime
+	result.Players = players
+	result.MapName = match.Map
+	result.Match = match
+	result.Collector = collector
+	result.Error = err
+	return result
 }
 
 // parseSingleDemoFromURL downloads a demo from a URL and parses it.
@@ -371,32 +1578,99 @@ func parseSingleDemoFromURL(url string, cfg *config.Config, exporter export.Expo
 	parseSingleDemo(demoPath, cfg, exporter)
 }
 
+// parseSingleDemoFromStream parses a demo as it downloads from an HTTP(S)
+// URL or S3 path, decompressing .gz and .bz2 demos on the fly.
+func parseSingleDemoFromStream(source string, cfg *config.Config, exporter export.ExportOption) {
+	log.Printf("Streaming demo from: %s", source)
+	demo, err := downloader.OpenStream(source)
+	if err != nil {
+		log.Fatalf("Failed to open demo stream: %v", err)
+	}
+	defer demo.Close()
+
+	// Name the match after the demo file without its compression suffix
+	name := path.Base(strings.SplitN(source, "?", 2)[0])
+	for _, ext := range []string{".gz", ".bz2"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	parseDemoReader(demo, name, time.Time{}, cfg, exporter)
+}
+
+// parseLocalDemo parses a .dem file, or the demo in a .zip file, and
+// exports the results.
+func parseLocalDemo(demoPath string, cfg *config.Config, exporter export.ExportOption) {
+	if strings.HasSuffix(strings.ToLower(demoPath), ".zip") {
+		dl := downloader.NewDownloader(cfg.DemoDir)
+		extracted, err := dl.Extract(demoPath)
+		if err != nil {
+			log.Fatalf("Failed to extract zip: %v", err)
+		}
+		demoPath = extracted
+	}
+	parseSingleDemo(demoPath, cfg, exporter)
+}
+
 // parseSingleDemo parses a single demo file and exports the results.
-// This is used when the -demo flag is provided or demo_path is set in config.
-// When CSCCompatibility is enabled, outputs demoScrape2-compatible JSON to stdout.
+// Local demos (the parse command, -demo or demo_path) get here through
+// parseLocalDemo.
 func parseSingleDemo(demoPath string, cfg *config.Config, exporter export.ExportOption) {
 	demo, err := os.Open(demoPath)
 	if err != nil {
 		log.Fatalf("Failed to open demo: %v", err)
 	}
 	defer demo.Close()
+	// Left undated like aggregated local demos: the file's modification
+	// time is when it was copied, not when the game was played
+	parseDemoReader(demo, filepath.Base(demoPath), time.Time{}, cfg, exporter)
+}
 
+// parseDemoReader parses demo data from r and exports the results, using
+// demoName to identify the match and startTime as its start in exports.
+// When CSCCompatibility is enabled, outputs demoScrape2-compatible JSON to stdout.
+func parseDemoReader(r io.Reader, demoName string, startTime time.Time, cfg *config.Config, exporter export.ExportOption) {
 	// Use buffered reader for better I/O performance on large demo files
-	bufferedReader := bufio.NewReaderSize(demo, 1024*1024) // 1MB buffer
+	bufferedReader := bufio.NewReaderSize(r, 1024*1024) // 1MB buffer
+
+	if err := parser.ValidateDemo(bufferedReader); err != nil {
+		log.Fatalf("Invalid demo %s: %v", demoName, err)
+	}
 
 	p := parser.NewDemoParserWithOptions(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
-	if err := p.Parse(); err != nil {
+	p.SetExitFragPenalty(cfg.ExitFragPenalty)
+	p.SetTradeDefinition(cfg.Trades.WindowSeconds, cfg.Trades.ProximityUnits)
+	p.SetImportanceModel(roundImportance(cfg))
+	p.SetSource(demoSource(cfg))
+	if !strings.Contains(cfg.Tier, ",") {
+		p.SetTier(strings.ToLower(cfg.Tier))
+	}
+	p.SetGrenadeLog(cfg.Grenades != "")
+	p.SetSwingAudit(cfg.SwingAudit != "")
+	logger := slog.With("demo", demoName)
+	p.SetStructuredLogger(logger)
+	if err := p.Parse(); err != nil && !parser.IsPartial(err) {
 		log.Fatalf("Failed to parse demo: %v", err)
 	}
+	logger = logger.With("map", p.GetMapName())
+	match := p.GetMatchInfo()
+	match.MatchID = demoName
+	match.StartTime = startTime
+	if n := match.Excluded.Total(); n > 0 {
+		logger.Info("Excluded rounds that weren't live", "rounds", n, "reasons", match.Excluded.String())
+	}
+
+	if history := loadPickemHistory(cfg); history != nil {
+		if n := history.ResolveFromPlayers(p.GetPlayers(), p.GetMapName(), startTime); n > 0 {
+			logger.Info("Resolved pick'em predictions", "predictions", n)
+		}
+		savePickemHistory(cfg, history)
+	}
 
 	// CSC Compatibility mode: output demoScrape2-compatible JSON
 	if cfg.CSCCompatibility {
 		players := p.GetPlayers()
-		mapName := p.GetMapName()
 		totalRounds := getTotalRounds(players)
-		tickRate := 64 // Default CS2 tick rate
 
-		game := export.ConvertToCSCGame(players, mapName, totalRounds, tickRate)
+		game := export.ConvertToCSCGame(players, match, totalRounds)
 
 		jsonData, err := json.MarshalIndent(game, "", "  ")
 		if err != nil {
@@ -407,12 +1681,120 @@ func parseSingleDemo(demoPath string, cfg *config.Config, exporter export.Export
 	}
 
 	if cfg.GenerateFiles {
-		if err := exporter.Export(p.GetPlayers()); err != nil {
+		if err := exporter.Export(match, p.GetPlayers()); err != nil {
 			log.Fatalf("Failed to export stats: %v", err)
 		}
-		log.Printf("Results exported successfully")
+		if cfg.ImpactFeed != "" {
+			if err := export.WriteImpactFeed(cfg.ImpactFeed, output.BuildImpactFeed(p.GetPlayers())); err != nil {
+				logger.Warn("Failed to export impact feed", "err", err)
+			} else {
+				logger.Info("Impact feed saved", "path", cfg.ImpactFeed)
+			}
+		}
+		if cfg.RatingTimeline != "" {
+			if err := export.WriteRatingTimeline(cfg.RatingTimeline, p.GetPlayers()); err != nil {
+				logger.Warn("Failed to export rating timeline", "err", err)
+			} else {
+				logger.Info("Rating timeline saved", "path", cfg.RatingTimeline)
+			}
+		}
+		if cfg.SwingAudit != "" {
+			rounds := p.GetSwingAudit()
+			if err := export.WriteSwingAudit(cfg.SwingAudit, demoName, p.GetMapName(), rounds); err != nil {
+				logger.Warn("Failed to export swing audit", "err", err)
+			} else {
+				logger.Info("Swing audit saved", "rounds", len(rounds), "path", cfg.SwingAudit)
+			}
+		}
+		if cfg.Clutches != "" {
+			clutches := output.CollectClutches(demoName, p.GetMapName(), p.GetPlayers())
+			if err := export.WriteClutches(cfg.Clutches, clutches); err != nil {
+				logger.Warn("Failed to export clutches", "err", err)
+			} else {
+				logger.Info("Clutch descriptors saved", "clutches", len(clutches), "path", cfg.Clutches)
+			}
+		}
+		if cfg.Throws != "" {
+			throws := output.CollectThrows(demoName, p.GetMapName(), p.GetPlayers())
+			if err := export.WriteThrows(cfg.Throws, throws); err != nil {
+				logger.Warn("Failed to export throws", "err", err)
+			} else {
+				logger.Info("Thrown rounds saved", "rounds", len(throws), "path", cfg.Throws)
+			}
+		}
+		if cfg.Roles != "" {
+			detector := analysis.NewRoleDetector()
+			detector.AddGame(p.GetMapName(), p.GetPlayers())
+			roles := detector.Results()
+			if err := export.WriteRoles(cfg.Roles, roles); err != nil {
+				logger.Warn("Failed to export roles", "err", err)
+			} else {
+				logger.Info("Detected roles saved", "players", len(roles), "path", cfg.Roles)
+			}
+		}
+		if cfg.ZoneTendencies != "" {
+			zones := analysis.NewZoneTendencies()
+			zones.AddGame(p.GetMapName(), p.GetPlayers())
+			tendencies := zones.Results()
+			if err := export.WriteZoneTendencies(cfg.ZoneTendencies, tendencies); err != nil {
+				logger.Warn("Failed to export zone tendencies", "err", err)
+			} else {
+				logger.Info("Zone tendencies saved", "rows", len(tendencies), "path", cfg.ZoneTendencies)
+			}
+		}
+		if cfg.Duels != "" || cfg.Sheets.DuelsTab != "" {
+			duels := analysis.NewDuelMatrix()
+			duels.AddGame(demoName, p.GetMapName(), p.GetPlayers())
+			exportDuels(cfg, exporter, duels)
+		}
+		if cfg.BuyTendencies != "" {
+			buys := analysis.NewBuyTendencies()
+			buys.AddGame(p.GetPlayers())
+			profiles := buys.Results()
+			if err := export.WriteBuyTendencies(cfg.BuyTendencies, profiles); err != nil {
+				logger.Warn("Failed to export buy tendencies", "err", err)
+			} else {
+				logger.Info("Buy tendencies saved", "players", len(profiles), "path", cfg.BuyTendencies)
+			}
+		}
+		if cfg.EntryDuos != "" {
+			entryDuos := analysis.NewEntryDuos()
+			entryDuos.AddGame(p.GetPlayers())
+			duos := entryDuos.Results()
+			if err := export.WriteEntryDuos(cfg.EntryDuos, duos); err != nil {
+				logger.Warn("Failed to export entry duos", "err", err)
+			} else {
+				logger.Info("Entry synergy saved", "pairs", len(duos), "path", cfg.EntryDuos)
+			}
+		}
+		if cfg.OpeningWeapons != "" {
+			openings := analysis.NewOpeningMatchups()
+			openings.AddGame(p.GetPlayers())
+			matchups := openings.Results()
+			if err := export.WriteOpeningMatchups(cfg.OpeningWeapons, matchups); err != nil {
+				logger.Warn("Failed to export opening weapon matchups", "err", err)
+			} else {
+				logger.Info("Opening duels by weapon matchup saved", "rows", len(matchups), "path", cfg.OpeningWeapons)
+			}
+		}
+		if cfg.Discord.WebhookURL != "" {
+			if discord, err := output.NewDiscordNotifier(cfg.Discord.WebhookURL, cfg.Discord.PostedPath); err != nil {
+				logger.Warn("Failed to post match summary", "err", err)
+			} else {
+				postMatchSummary(logger, discord, demoName, p.GetMapName(), p.GetPlayers())
+			}
+		}
+		if cfg.Grenades != "" {
+			grenades := output.CollectGrenades(demoName, p.GetMapName(), p.GetPlayers())
+			if err := export.WriteGrenades(cfg.Grenades, output.GrenadesByMap(grenades)); err != nil {
+				logger.Warn("Failed to export grenades", "err", err)
+			} else {
+				logger.Info("Grenade throws saved", "throws", len(grenades), "path", cfg.Grenades)
+			}
+		}
+		logger.Info("Results exported successfully")
 	} else {
-		log.Printf("Demo parsed successfully (file generation disabled)")
+		logger.Info("Demo parsed successfully (file generation disabled)")
 	}
 }
 
@@ -427,6 +1809,29 @@ func getTotalRounds(players map[uint64]*model.PlayerStats) int {
 	return maxRounds
 }
 
+// roundImportance returns the round importance model selected in cfg, which
+// main has already validated.
+func roundImportance(cfg *config.Config) rating.ImportanceModel {
+	m, err := rating.NewImportanceModel(cfg.RoundImportance.Model, cfg.RoundImportance.Strength)
+	if err != nil {
+		return rating.FlatImportance{}
+	}
+	return m
+}
+
+// demoSource returns the demo source set in cfg, which main has already
+// validated, or "" to detect it per demo.
+func demoSource(cfg *config.Config) parser.DemoSource {
+	if cfg.DemoSource == "" {
+		return ""
+	}
+	source, err := parser.ParseDemoSource(cfg.DemoSource)
+	if err != nil {
+		return ""
+	}
+	return source
+}
+
 // parseDemoFromStdin reads demo data from stdin and outputs CSC-compatible JSON.
 // This is designed for integration with demo-worker, which can pipe demo data directly.
 func parseDemoFromStdin(cfg *config.Config) {
@@ -434,18 +1839,28 @@ func parseDemoFromStdin(cfg *config.Config) {
 	bufferedReader := bufio.NewReaderSize(os.Stdin, 1024*1024) // 1MB buffer
 
 	p := parser.NewDemoParserWithOptions(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
-	if err := p.Parse(); err != nil {
+	p.SetExitFragPenalty(cfg.ExitFragPenalty)
+	p.SetTradeDefinition(cfg.Trades.WindowSeconds, cfg.Trades.ProximityUnits)
+	p.SetImportanceModel(roundImportance(cfg))
+	p.SetSource(demoSource(cfg))
+	if !strings.Contains(cfg.Tier, ",") {
+		p.SetTier(strings.ToLower(cfg.Tier))
+	}
+	p.SetStructuredLogger(slog.With("demo", "stdin"))
+	err := parser.ValidateDemo(bufferedReader)
+	if err == nil {
+		err = p.Parse()
+	}
+	if err != nil && !parser.IsPartial(err) {
 		// Output error as JSON for demo-worker compatibility
 		fmt.Fprintf(os.Stderr, "{\"error\": \"%s\"}\n", err.Error())
 		os.Exit(1)
 	}
 
 	players := p.GetPlayers()
-	mapName := p.GetMapName()
 	totalRounds := getTotalRounds(players)
-	tickRate := 64 // Default CS2 tick rate
 
-	game := export.ConvertToCSCGame(players, mapName, totalRounds, tickRate)
+	game := export.ConvertToCSCGame(players, p.GetMatchInfo(), totalRounds)
 
 	jsonData, err := json.Marshal(game)
 	if err != nil {
@@ -455,22 +1870,686 @@ func parseDemoFromStdin(cfg *config.Config) {
 	fmt.Println(string(jsonData))
 }
 
-// parseDemoWithLogs opens and parses a demo file, returning player stats, map name,
-// log output, probability collector, and any error. This is the core parsing function used by both modes.
-func parseDemoWithLogs(demoPath string, enableLogging bool, kdprModifier bool) (map[uint64]*model.PlayerStats, string, string, *probability.DataCollector, error) {
+// parseDemoFile opens and parses the demo file at demoPath, named key in logs, from tier, returning player
+// stats, match metadata, probability collector, and any error. This is the core parsing function used by both modes.
+// Demos that fail part way through return their partial stats with an error for which parser.IsPartial is true.
+// Rounds starting with duplicateFrom to duplicateTo-1 rounds won are skipped (see parser.SetDuplicateRounds).
+func parseDemoFile(demoPath, key string, cfg *config.Config, tier string, duplicateFrom, duplicateTo int) (players map[uint64]*model.PlayerStats, match model.MatchInfo, collector *probability.DataCollector, err error) {
+	// A corrupt demo can panic inside the demo library; report it as an error
+	// so one bad file doesn't take down the whole worker pool.
+	defer func() {
+		if r := recover(); r != nil {
+			players, match, collector = nil, model.MatchInfo{}, nil
+			err = &parser.DemoError{Kind: parser.KindPanic, Err: fmt.Errorf("parser panic: %v", r)}
+		}
+	}()
+
 	demo, err := os.Open(demoPath)
 	if err != nil {
-		return nil, "", "", nil, fmt.Errorf("failed to open demo: %w", err)
+		return nil, model.MatchInfo{}, nil, fmt.Errorf("failed to open demo: %w", err)
 	}
 	defer demo.Close()
 
 	// Use buffered reader for better I/O performance on large demo files (280-530MB)
 	bufferedReader := bufio.NewReaderSize(demo, 1024*1024) // 1MB buffer
+	if err := parser.ValidateDemo(bufferedReader); err != nil {
+		return nil, model.MatchInfo{}, nil, err
+	}
+
+	p := parser.NewDemoParserWithOptions(bufferedReader, cfg.EnableLogging, cfg.KDPRModifier)
+	p.SetExitFragPenalty(cfg.ExitFragPenalty)
+	p.SetTradeDefinition(cfg.Trades.WindowSeconds, cfg.Trades.ProximityUnits)
+	p.SetImportanceModel(roundImportance(cfg))
+	p.SetSource(demoSource(cfg))
+	p.SetTier(tier)
+	p.SetGrenadeLog(cfg.Grenades != "")
+	p.SetDuplicateRounds(duplicateFrom, duplicateTo)
+	p.SetStructuredLogger(slog.With("demo", key, "tier", tier))
+	err = p.Parse()
+	if err != nil && !parser.IsPartial(err) {
+		return nil, model.MatchInfo{}, nil, err
+	}
+
+	// A partial demo returns its stats along with the error
+	return p.GetPlayers(), p.GetMatchInfo(), p.GetCollector(), err
+}
+
+// runPredict loads aggregated ratings and prints per-map win probabilities for a fixture.
+// When historyPath is set, the prediction is also stored for pick'em accuracy tracking.
+func runPredict(fixturePath, ratingsPath, historyPath string) {
+	data, err := os.ReadFile(fixturePath)
+	if err != nil {
+		log.Fatalf("Failed to read fixture: %v", err)
+	}
+	var fixture predict.Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		log.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	ratings, err := predict.LoadRatingsCSV(ratingsPath)
+	if err != nil {
+		log.Fatalf("Failed to load ratings: %v", err)
+	}
+
+	prediction := predict.NewPredictor(ratings).Predict(fixture)
+	jsonData, err := json.MarshalIndent(prediction, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	fmt.Println(string(jsonData))
+
+	if historyPath != "" {
+		history, err := predict.LoadHistory(historyPath)
+		if err != nil {
+			log.Fatalf("Failed to load prediction history: %v", err)
+		}
+		history.Add(fixture, prediction)
+		if err := history.Save(historyPath); err != nil {
+			log.Fatalf("Failed to save prediction history: %v", err)
+		}
+		log.Printf("Stored %d map predictions in %s", len(prediction.Maps), historyPath)
+	}
+}
+
+// loadArchive loads the configured per-game archive, or returns nil when the
+// archive is disabled or can't be read.
+func loadArchive(cfg *config.Config) *archive.Archive {
+	if cfg.ArchivePath == "" {
+		return nil
+	}
+	a, err := archive.Load(cfg.ArchivePath)
+	if err != nil {
+		log.Printf("Warning: Failed to load archive: %v", err)
+		return nil
+	}
+	return a
+}
+
+// loadPeakStore loads the single-match record store when peak tracking is
+// enabled, returning nil otherwise or on error.
+func loadPeakStore(cfg *config.Config) *archive.PeakStore {
+	if !cfg.Peaks.Enabled {
+		return nil
+	}
+	store, err := archive.LoadPeakStore(cfg.Peaks.StorePath)
+	if err != nil {
+		log.Printf("Warning: Failed to load peak records: %v", err)
+		return nil
+	}
+	return store
+}
+
+// newSheetsExporter connects to the configured spreadsheet, or the local CSV
+// stand-in, for uploading stats to the configured view tabs and a raw tab
+// with the same columns as the CSV export.
+func newSheetsExporter(cfg *config.Config) *export.SheetsExportOption {
+	var svc sheets.Service
+	if cfg.Sheets.LocalDir != "" {
+		svc = sheets.NewCSVDir(cfg.Sheets.LocalDir)
+	} else {
+		client, err := sheets.NewClientWithOptions(cfg.Sheets.SpreadsheetID, sheets.ClientOptions{
+			Credentials:       append([]string{cfg.Sheets.Credentials}, cfg.Sheets.CredentialsPool...),
+			RequestsPerMinute: cfg.Sheets.RequestsPerMinute,
+		})
+		if err != nil {
+			log.Fatalf("Failed to set up spreadsheet upload: %v", err)
+		}
+		svc = client
+	}
+	var tabs []export.SheetTab
+	for _, t := range cfg.Sheets.Tabs {
+		if !output.ValidColumnPreset(t.Columns) {
+			log.Fatalf("Invalid column preset %q for sheet tab %q (valid: %s)", t.Columns, t.Name, strings.Join(output.ColumnPresetNames(), ", "))
+		}
+		tabs = append(tabs, export.SheetTab{Name: t.Name, Columns: t.Columns})
+	}
+	tabs = append(tabs, export.SheetTab{Name: cfg.Sheets.Sheet, Columns: cfg.Columns})
+	exporter := export.NewSheetsExportOption(svc, sheets.Mode(cfg.Sheets.Mode), tabs...)
+	exporter.Links = sheetLinks(cfg)
+	exporter.Format = cfg.Sheets.Format
+	exporter.CheckChanges = cfg.Sheets.ChangeAlerts
+	exporter.AlertsPath = cfg.Sheets.AlertsPath
+	exporter.BlockOnAlerts = cfg.Sheets.BlockOnAlerts
+	return exporter
+}
+
+// exportDuels writes the duel matrix JSON and uploads the season's
+// head-to-head records to the duels tab, as configured.
+func exportDuels(cfg *config.Config, exporter export.ExportOption, matrix *analysis.DuelMatrix) {
+	season := matrix.Season()
+	if cfg.Duels != "" {
+		if err := export.WriteDuels(cfg.Duels, season, matrix.Matches()); err != nil {
+			log.Printf("Warning: Failed to export duels: %v", err)
+		} else {
+			log.Printf("Head-to-head records (%d pairs) saved to %s", len(season), cfg.Duels)
+		}
+	}
+	if cfg.Sheets.DuelsTab == "" {
+		return
+	}
+	header, rows := export.DuelsTable(season)
+	uploadTable(exporter, "duels", cfg.Sheets.DuelsTab, header, rows, export.DuelsTabKeys)
+}
+
+// exportSupportLeaderboard writes the support leaderboard CSV and uploads it
+// to the support tab, as configured.
+func exportSupportLeaderboard(cfg *config.Config, exporter export.ExportOption, results map[string]*output.AggregatedStats) {
+	header, rows := export.SupportLeaderboardTable(output.ComputeSupportLeaderboard(results))
+	exportLeaderboard(exporter, "support", cfg.Support.LeaderboardPath, cfg.Sheets.SupportTab, header, rows, export.SupportTabKeys)
+}
+
+// exportEntryLeaderboard writes the entry leaderboard CSV and uploads it
+// to its spreadsheet tab, whichever are configured.
+func exportEntryLeaderboard(cfg *config.Config, exporter export.ExportOption, results map[string]*output.AggregatedStats) {
+	header, rows := export.EntryLeaderboardTable(output.ComputeEntryLeaderboard(results))
+	exportLeaderboard(exporter, "entry", cfg.Entry.LeaderboardPath, cfg.Sheets.EntryTab, header, rows, export.EntryTabKeys)
+}
+
+// exportPistolLeaderboard writes the pistol leaderboard CSV and uploads it
+// to its spreadsheet tab, whichever are configured.
+func exportPistolLeaderboard(cfg *config.Config, exporter export.ExportOption, results map[string]*output.AggregatedStats) {
+	entries := output.ComputePistolLeaderboard(results, cfg.Pistol.MinRounds, cfg.Pistol.MinSideRounds)
+	header, rows := export.PistolLeaderboardTable(entries)
+	exportLeaderboard(exporter, "pistol", cfg.Pistol.LeaderboardPath, cfg.Sheets.PistolTab, header, rows, export.PistolTabKeys)
+}
+
+// exportLeaderboard writes a leaderboard table to csvPath and uploads it to
+// tab, skipping whichever is empty. name is the leaderboard's config prefix,
+// e.g. "entry" for entry_tab.
+func exportLeaderboard(exporter export.ExportOption, name, csvPath, tab string, header []string, rows [][]string, keys []string) {
+	if csvPath != "" {
+		if err := export.WriteTable(csvPath, header, rows); err != nil {
+			log.Printf("Warning: Failed to export %s leaderboard: %v", name, err)
+		} else {
+			log.Printf("%s leaderboard for %d players saved to %s", strings.ToUpper(name[:1])+name[1:], len(rows), csvPath)
+		}
+	}
+	if tab != "" {
+		uploadTable(exporter, name, tab, header, rows, keys)
+	}
+}
+
+// uploadTable uploads a table to its own spreadsheet tab, warning when the
+// exporter has no spreadsheet to upload to. name is the table's config
+// prefix, e.g. "duels" for duels_tab.
+func uploadTable(exporter export.ExportOption, name, tab string, header []string, rows [][]string, keys []string) {
+	tables, ok := exporter.(export.TableExporter)
+	if !ok || !export.ExportsTables(exporter) {
+		log.Printf("Warning: %s_tab is set but no spreadsheet is configured", name)
+		return
+	}
+	if err := tables.ExportTable(tab, header, rows, keys); err != nil {
+		log.Printf("Warning: Failed to upload %s tab: %v", name, err)
+	}
+}
+
+// sheetLinks builds the hyperlink templates for sheet uploads and the peak
+// record CSVs. Without a match
+// page template, matches link to their demo when a demo index is kept.
+func sheetLinks(cfg *config.Config) export.Links {
+	links := export.Links{PlayerURL: cfg.Links.PlayerURL, MatchURL: cfg.Links.MatchURL}
+	if links.MatchURL == "" && cfg.DemoIndex != "" && cfg.BaseURL != "" {
+		links.MatchURL = strings.TrimSuffix(cfg.BaseURL, "/") + "/{match_id}"
+	}
+	return links
+}
+
+// savePeakStore persists the record store and writes the per-player and
+// league record exports.
+func savePeakStore(cfg *config.Config, store *archive.PeakStore) {
+	if store == nil {
+		return
+	}
+	if err := store.Save(cfg.Peaks.StorePath); err != nil {
+		log.Printf("Warning: Failed to save peak records: %v", err)
+		return
+	}
+	if err := export.WritePeakRecords(cfg.Peaks.OutputPath, store, sheetLinks(cfg)); err != nil {
+		log.Printf("Warning: Failed to export peak records: %v", err)
+	} else {
+		log.Printf("Peak records for %d players saved to %s", len(store.Players), cfg.Peaks.OutputPath)
+	}
+	if err := export.WriteLeagueRecords(cfg.Peaks.LeagueOutputPath, store, sheetLinks(cfg)); err != nil {
+		log.Printf("Warning: Failed to export league records: %v", err)
+	} else {
+		log.Printf("League records saved to %s", cfg.Peaks.LeagueOutputPath)
+	}
+}
+
+// writeRecordBook rebuilds the league record book from the archive and
+// writes the JSON and CSV exports.
+func writeRecordBook(cfg *config.Config, gameArchive *archive.Archive) {
+	if gameArchive == nil {
+		log.Printf("Warning: The record book requires an archive (use -archive flag or set archive_path in config)")
+		return
+	}
+	book := archive.BuildRecordBook(gameArchive, cfg.BaseURL)
+	if err := export.WriteRecordBook(cfg.RecordBook.OutputPath, book); err != nil {
+		log.Printf("Warning: Failed to export record book: %v", err)
+		return
+	}
+	log.Printf("Record book (%d records) saved to %s", len(book.Records), cfg.RecordBook.OutputPath)
+	if cfg.RecordBook.CSVPath != "" {
+		if err := export.WriteRecordBookCSV(cfg.RecordBook.CSVPath, book); err != nil {
+			log.Printf("Warning: Failed to export record book CSV: %v", err)
+		}
+	}
+}
+
+// loadDemoIndex loads the demo index at path, returning nil when path is
+// empty or the index can't be read.
+func loadDemoIndex(path string) *archive.DemoIndex {
+	if path == "" {
+		return nil
+	}
+	demos, err := archive.LoadDemoIndex(path)
+	if err != nil {
+		log.Printf("Warning: Failed to load demo index: %v", err)
+		return nil
+	}
+	return demos
+}
+
+// runFindDemos prints the demo index entries matching text as JSON.
+func runFindDemos(indexPath, text string) {
+	if indexPath == "" {
+		log.Fatal("Searching demos requires a demo index (use -demo-index flag or set demo_index in config)")
+	}
+	demos, err := archive.LoadDemoIndex(indexPath)
+	if err != nil {
+		log.Fatalf("Failed to load demo index: %v", err)
+	}
+	matches := demos.Query(archive.DemoQuery{Text: text})
+	jsonData, err := json.MarshalIndent(matches, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to marshal JSON: %v", err)
+	}
+	fmt.Println(string(jsonData))
+	log.Printf("%d of %d demos matched %q", len(matches), len(demos.Demos), text)
+}
+
+// runCasterNotes generates Markdown pre-game notes for a fixture from the archive.
+func runCasterNotes(fixturePath, archivePath, outputPath string) {
+	if archivePath == "" {
+		log.Fatal("Caster notes require an archive (use -archive flag or set archive_path in config)")
+	}
+	data, err := os.ReadFile(fixturePath)
+	if err != nil {
+		log.Fatalf("Failed to read fixture: %v", err)
+	}
+	var fixture predict.Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		log.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	a, err := archive.Load(archivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	if err := export.WriteCasterNotes(outputPath, a, fixture); err != nil {
+		log.Fatalf("Failed to write caster notes: %v", err)
+	}
+	log.Printf("Caster notes saved to %s", outputPath)
+}
+
+// runQuickScan summarizes the demo at target, or every .dem file under it
+// when it's a directory, in parallel and writes the catalog to outputPath.
+func runQuickScan(cfg *config.Config, target, outputPath string) {
+	var files []string
+	err := filepath.WalkDir(target, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".dem") {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to list demos: %v", err)
+	}
+	if len(files) == 0 {
+		log.Fatalf("No .dem files found in %s", target)
+	}
+
+	numWorkers := cfg.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+	}
+	start := time.Now()
+	summaries := make([]model.DemoSummary, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				summaries[i] = scanDemo(files[i])
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-	p := parser.NewDemoParserWithOptions(bufferedReader, enableLogging, kdprModifier)
-	if err := p.Parse(); err != nil {
-		return nil, "", "", nil, fmt.Errorf("failed to parse demo: %w", err)
+	failed := 0
+	for _, s := range summaries {
+		if s.Error != "" {
+			failed++
+		}
 	}
+	if err := export.WriteDemoCatalog(outputPath, summaries); err != nil {
+		log.Fatalf("Failed to write demo catalog: %v", err)
+	}
+	log.Printf("Scanned %d demos (%d with errors) in %s; catalog saved to %s",
+		len(files), failed, time.Since(start).Round(time.Second), outputPath)
+}
 
-	return p.GetPlayers(), p.GetMapName(), p.GetLogs(), p.GetCollector(), nil
+// scanDemo quick-scans one demo file, recording any error in the summary.
+func scanDemo(path string) model.DemoSummary {
+	f, err := os.Open(path)
+	if err != nil {
+		return model.DemoSummary{File: path, Error: err.Error()}
+	}
+	defer f.Close()
+	summary, err := parser.QuickScan(f)
+	summary.File = path
+	if err != nil {
+		slog.Warn("Quick scan failed", "demo", path, "err", err)
+		summary.Error = err.Error()
+	}
+	return summary
+}
+
+// runDigest writes the weekly Markdown digest for a match week from the archive.
+// When week is empty the latest archived week is used.
+func runDigest(archivePath, week, outputPath string) {
+	if archivePath == "" {
+		log.Fatal("The digest requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(archivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	if week == "" {
+		weeks := a.Weeks()
+		if len(weeks) == 0 {
+			log.Fatal("No match weeks found in the archive")
+		}
+		week = weeks[len(weeks)-1]
+	}
+	if err := export.WriteDigest(outputPath, a, strings.ToUpper(week)); err != nil {
+		log.Fatalf("Failed to write digest: %v", err)
+	}
+	log.Printf("Digest for %s saved to %s", strings.ToUpper(week), outputPath)
+}
+
+// runSeasonProgression writes a season's week-by-week leaderboard snapshots
+// from the archive. When season is 0 the latest archived season is used.
+func runSeasonProgression(archivePath string, season int, outputPath string) {
+	if archivePath == "" {
+		log.Fatal("Season progression requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(archivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	if season == 0 {
+		season = a.LatestSeason()
+	}
+	entries := a.Progression(season)
+	if len(entries) == 0 {
+		log.Fatal("No ranked players found in the archived match weeks")
+	}
+	if err := export.WriteSeasonProgression(outputPath, entries); err != nil {
+		log.Fatalf("Failed to write season progression: %v", err)
+	}
+	log.Printf("%d weekly leaderboard rows saved to %s", len(entries), outputPath)
+}
+
+// runUpload uploads a stats CSV written by an earlier run to the configured
+// spreadsheet.
+func runUpload(cfg *config.Config, statsPath string) {
+	if cfg.Sheets.SpreadsheetID == "" && cfg.Sheets.LocalDir == "" {
+		log.Fatal("Uploading requires a spreadsheet (use -sheet-id or -sheet-dir flag or set sheets.spreadsheet_id in config)")
+	}
+	if err := newSheetsExporter(cfg).UploadStatsCSV(statsPath); err != nil {
+		log.Fatalf("Failed to upload stats: %v", err)
+	}
+	log.Printf("Stats from %s uploaded", statsPath)
+}
+
+// runExplain parses a demo and prints what drove one player's rating: the
+// summary explanation, then each rating component against its baseline.
+// The player is matched by Steam ID, then by name ignoring case.
+func runExplain(cfg *config.Config, demoPath, player string) {
+	if player == "" {
+		log.Fatal("Explaining a rating requires a player (use -player flag with a Steam ID or name)")
+	}
+	players, match, _, err := parseDemoFile(demoPath, filepath.Base(demoPath), cfg, strings.ToLower(cfg.Tier), 0, 0)
+	if err != nil && !parser.IsPartial(err) {
+		log.Fatalf("Failed to parse demo: %v", err)
+	}
+	if err != nil {
+		slog.Warn("Parsed partial demo", "demo", filepath.Base(demoPath), "map", match.Map, "err", err)
+	}
+
+	var found *model.PlayerStats
+	names := make([]string, 0, len(players))
+	for _, p := range players {
+		names = append(names, p.Name)
+		if p.SteamID == player || (found == nil && strings.EqualFold(p.Name, player)) {
+			found = p
+		}
+	}
+	if found == nil {
+		slices.Sort(names)
+		log.Fatalf("Player %q not found in %s (players: %s)", player, demoPath, strings.Join(names, ", "))
+	}
+
+	b := found.RatingBreakdown
+	fmt.Printf("%s (%s) on %s: %.2f rating over %d rounds\n", found.Name, found.TeamName, match.Map, found.FinalRating, found.RoundsPlayed)
+	if explanation := output.ExplainRating(found); explanation != "" {
+		fmt.Println(explanation)
+	}
+	fmt.Println()
+	fmt.Printf("  %-28s %+.3f\n", "Baseline", b.Baseline)
+	for _, c := range []model.RatingComponent{b.KPRDPR, b.ADR, b.KAST, b.ProbabilitySwing} {
+		fmt.Printf("  %-28s %+.3f  (%.3f vs baseline %.3f, x%.2f)", c.Metric, c.Contribution, c.Value, c.Baseline, c.Multiplier)
+		if c.Notes != "" {
+			fmt.Printf("  %s", c.Notes)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("  %-28s %.3f\n", "Unclamped rating", b.UnclampedRating)
+	fmt.Printf("  %-28s %.3f\n", "Final rating", b.FinalRating)
+	if b.Formula != "" {
+		fmt.Printf("\n%s\n", b.Formula)
+	}
+}
+
+// runRecompute re-rates every archived game under each registered rating
+// version and writes the player-by-version comparison matrix.
+func runRecompute(archivePath, outputPath string) {
+	if archivePath == "" {
+		log.Fatal("Recomputing ratings requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(archivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	versions := rating.Versions()
+	players := a.Recompute(versions)
+	if err := export.WriteRecomputeMatrix(outputPath, versions, players); err != nil {
+		log.Fatalf("Failed to write comparison matrix: %v", err)
+	}
+	log.Printf("%d players from %d games re-rated under %d versions, saved to %s", len(players), len(a.Games), len(versions), outputPath)
+}
+
+// runSensitivity moves each rating weight up and down by step, re-rates the
+// archive, and writes how much each change reorders the leaderboard.
+func runSensitivity(cfg *config.Config, outputPath string, step float64, minGames int) {
+	if cfg.ArchivePath == "" {
+		log.Fatal("The sensitivity report requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(cfg.ArchivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	results := a.Sensitivity(rating.DefaultWeights(), step, minGames, cfg.KDPRModifier, 5)
+	if err := export.WriteSensitivity(outputPath, results); err != nil {
+		log.Fatalf("Failed to write sensitivity report: %v", err)
+	}
+	log.Printf("Sensitivity of %d weight changes over %d games saved to %s", len(results), len(a.Games), outputPath)
+}
+
+// runOptimize fits the rating weights to a target signal over the archive and
+// writes the candidate weights. Targets other than "wins" and "hltv" are read
+// as a per-player CSV.
+func runOptimize(cfg *config.Config, outputPath, target string, maxChange float64) {
+	if cfg.ArchivePath == "" {
+		log.Fatal("The optimizer requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(cfg.ArchivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	var values map[string]float64
+	if target != archive.TargetWins && target != archive.TargetHLTV {
+		if values, err = archive.LoadPlayerValues(target); err != nil {
+			log.Fatalf("Failed to load optimizer target: %v", err)
+		}
+	}
+	result, err := a.Optimize(rating.DefaultWeights(), target, values, maxChange, cfg.KDPRModifier)
+	if err != nil {
+		log.Fatalf("Optimization failed: %v", err)
+	}
+	if err := export.WriteCandidateWeights(outputPath, result); err != nil {
+		log.Fatalf("Failed to write candidate weights: %v", err)
+	}
+	log.Printf("Fit to %s improved from %.3f to %.3f over %d samples; candidate weights saved to %s",
+		target, result.BaselineScore, result.Score, result.Samples, outputPath)
+}
+
+// runCrossValidate compares archived eco-ratings with an external rating
+// source and writes the correlation and outlier report.
+func runCrossValidate(cfg *config.Config, externalPath, idColumn, ratingColumn, outputPath string) {
+	if cfg.ArchivePath == "" {
+		log.Fatal("Cross-validation requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(cfg.ArchivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	external, err := archive.LoadExternalRatings(externalPath, idColumn, ratingColumn)
+	if err != nil {
+		log.Fatalf("Failed to load external ratings: %v", err)
+	}
+	cv := a.CrossValidate(external, 3, 2)
+	if err := export.WriteCrossValidation(outputPath, cv); err != nil {
+		log.Fatalf("Failed to write cross-validation report: %v", err)
+	}
+	log.Printf("%d players matched (pearson %.3f, spearman %.3f, %d outliers); report saved to %s",
+		len(cv.Players), cv.Pearson, cv.Spearman, cv.Outliers, outputPath)
+}
+
+// runCalibrateBaselines computes per-map and per-tier rating baselines from
+// the archive and writes whichever were requested: map baselines for use
+// with -map-baselines, tier baselines for tier_baselines in config.
+func runCalibrateBaselines(archivePath, mapsPath, tiersPath string, minGames int) {
+	if archivePath == "" {
+		log.Fatal("Baseline calibration requires an archive (use -archive flag or set archive_path in config)")
+	}
+	a, err := archive.Load(archivePath)
+	if err != nil {
+		log.Fatalf("Failed to load archive: %v", err)
+	}
+	if mapsPath != "" {
+		table := a.MapBaselines(minGames)
+		if err := export.WriteBaselines(mapsPath, table); err != nil {
+			log.Fatalf("Failed to write map baselines: %v", err)
+		}
+		log.Printf("Baselines for %d maps (at least %d games each) saved to %s", len(table), minGames, mapsPath)
+	}
+	if tiersPath != "" {
+		table := a.TierBaselines(minGames)
+		if err := export.WriteBaselines(tiersPath, table); err != nil {
+			log.Fatalf("Failed to write tier baselines: %v", err)
+		}
+		log.Printf("Baselines for %d tiers (at least %d games each) saved to %s", len(table), minGames, tiersPath)
+	}
+}
+
+// loadPickemHistory loads the prediction history configured for pick'em tracking,
+// or returns nil when tracking is disabled or the file can't be read.
+func loadPickemHistory(cfg *config.Config) *predict.History {
+	if cfg.PickemHistory == "" {
+		return nil
+	}
+	history, err := predict.LoadHistory(cfg.PickemHistory)
+	if err != nil {
+		log.Printf("Warning: Failed to load prediction history: %v", err)
+		return nil
+	}
+	return history
+}
+
+// savePickemHistory saves resolved predictions and writes the accuracy report
+// next to the history file.
+func savePickemHistory(cfg *config.Config, history *predict.History) {
+	if history == nil {
+		return
+	}
+	if err := history.Save(cfg.PickemHistory); err != nil {
+		log.Printf("Warning: Failed to save prediction history: %v", err)
+		return
+	}
+
+	acc := history.Evaluate()
+	ext := filepath.Ext(cfg.PickemHistory)
+	reportPath := strings.TrimSuffix(cfg.PickemHistory, ext) + "_accuracy.json"
+	if err := export.WritePickemAccuracy(reportPath, acc); err != nil {
+		log.Printf("Warning: Failed to export pick'em accuracy: %v", err)
+		return
+	}
+	log.Printf("Pick'em accuracy: %d/%d correct (%.1f%%), Brier %.3f - saved to %s",
+		acc.Correct, acc.Resolved, acc.Accuracy*100, acc.BrierScore, reportPath)
+}
+
+// runServer loads aggregated ratings and, when configured, the demo index, and
+// serves the REST API until the process exits. Without a demo index the
+// ratings are required; with one, missing ratings only disable /predict.
+func runServer(addr, ratingsPath, demoIndexPath string) {
+	demos := loadDemoIndex(demoIndexPath)
+	if demos != nil {
+		log.Printf("Loaded demo index with %d demos from %s", len(demos.Demos), demoIndexPath)
+	}
+
+	var predictor *predict.Predictor
+	ratings, err := predict.LoadRatingsCSV(ratingsPath)
+	switch {
+	case err == nil:
+		log.Printf("Loaded ratings for %d players from %s", len(ratings), ratingsPath)
+		predictor = predict.NewPredictor(ratings)
+	case demos != nil:
+		log.Printf("Warning: Failed to load ratings, /predict is disabled: %v", err)
+	default:
+		log.Fatalf("Failed to load ratings: %v", err)
+	}
+
+	srv := server.NewServerWithOptions(predictor, demos)
+	if err := srv.ListenAndServe(addr); err != nil {
+		log.Fatalf("Server stopped: %v", err)
+	}
+}
+
+// warnUnconverged logs a solve that hit its iteration limit before
+// converging; its values are usable but not settled.
+func warnUnconverged(r output.ConvergenceReport) {
+	if !r.Converged {
+		log.Printf("Warning: %s solve did not converge in %d iterations (last change %.2g)", r.Solve, r.Iterations, r.FinalChange)
+	}
 }
@@ -0,0 +1,31 @@
+package metrics
+
+import "time"
+
+// Pipeline metrics, registered in Default.
+var (
+	DemosParsed = Default.NewCounter("eco_rating_demos_parsed_total",
+		"Demos parsed successfully.")
+	ParseFailures = Default.NewCounter("eco_rating_demo_parse_failures_total",
+		"Demos that failed to parse and were skipped.")
+	ParseDuration = Default.NewHistogram("eco_rating_demo_parse_duration_seconds",
+		"Time to parse one demo, successful or not.",
+		[]float64{1, 2.5, 5, 10, 20, 30, 60, 120, 300})
+	RoundsProcessed = Default.NewCounter("eco_rating_rounds_processed_total",
+		"Rounds in successfully parsed demos.")
+	UploadDuration = Default.NewHistogram("eco_rating_upload_duration_seconds",
+		"Time to upload one spreadsheet tab.",
+		[]float64{0.5, 1, 2.5, 5, 10, 30, 60, 120})
+)
+
+// ObserveParse records one demo parse that started at start, covered rounds
+// rounds, and failed when err is non-nil.
+func ObserveParse(start time.Time, rounds int, err error) {
+	ParseDuration.ObserveSince(start)
+	if err != nil {
+		ParseFailures.Inc()
+		return
+	}
+	DemosParsed.Inc()
+	RoundsProcessed.Add(float64(rounds))
+}
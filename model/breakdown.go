@@ -28,6 +28,8 @@ type RatingBreakdown struct {
 type RoundSwingBreakdown struct {
 	RoundNumber      int                 `json:"round_number"`
 	ProbabilitySwing float64             `json:"probability_swing"`
+	Importance       float64             `json:"importance"`    // Round importance weight already applied to the swing
+	EconomySwing     float64             `json:"economy_swing"` // Next-round win probability effect
 	PlayerSide       string              `json:"player_side"`
 	IsPistolRound    bool                `json:"is_pistol_round"`
 	TeamWon          bool                `json:"team_won"`
@@ -46,6 +48,19 @@ type RoundSwingBreakdown struct {
 	AntiEcoKill      bool                `json:"anti_eco_kill"`
 	EntryFragger     bool                `json:"entry_fragger"`
 	Survived         bool                `json:"survived"`
+	Thrown           bool                `json:"thrown"`       // Team lost after passing the throw threshold
+	GarbageTime      bool                `json:"garbage_time"` // Played after the match was decided
+	BuyType          string              `json:"buy_type"`     // Team's buy: pistol, eco, force or full
+	Died             bool                `json:"died"`
+	KAST             bool                `json:"kast"`
+	EcoValue         float64             `json:"eco_value"`
+	Rating           float64             `json:"rating"`         // Eco-rating for this round alone
+	RunningRating    float64             `json:"running_rating"` // Eco-rating over the match up to this round
+	StartMoney       int                 `json:"start_money"`    // Money before buying
+	PrimaryWeapon    string              `json:"primary_weapon"` // Best gun at the end of freeze time, a pistol if nothing better ("" = none)
+	Armor            int                 `json:"armor"`          // Armor at the end of freeze time
+	TradedBy         uint64              `json:"traded_by"`      // SteamID64 of the teammate who traded this death (0 = untraded)
+	EntryContact     bool                `json:"entry_contact"`  // Killed or died in the round's first kills
 	ImpactFactors    []string            `json:"impact_factors"`
 	Contributions    []SwingContribution `json:"contributions"`
 }
@@ -55,6 +70,8 @@ func NewRoundSwingBreakdown(roundNumber int, stats *RoundStats) RoundSwingBreakd
 	breakdown := RoundSwingBreakdown{
 		RoundNumber:      roundNumber,
 		ProbabilitySwing: stats.ProbabilitySwing,
+		Importance:       stats.Importance,
+		EconomySwing:     stats.EconomySwing,
 		PlayerSide:       stats.PlayerSide,
 		IsPistolRound:    stats.IsPistolRound,
 		TeamWon:          stats.TeamWon,
@@ -73,6 +90,17 @@ func NewRoundSwingBreakdown(roundNumber int, stats *RoundStats) RoundSwingBreakd
 		AntiEcoKill:      stats.AntiEcoKill,
 		EntryFragger:     stats.EntryFragger,
 		Survived:         stats.Survived,
+		Thrown:           stats.ThrownRound,
+		GarbageTime:      stats.GarbageTime,
+		BuyType:          stats.BuyType,
+		StartMoney:       stats.StartMoney,
+		PrimaryWeapon:    stats.PrimaryWeapon,
+		Armor:            stats.Armor,
+		TradedBy:         stats.TradedBy,
+		EntryContact:     stats.EntryContact,
+		Died:             stats.DeathTime > 0,
+		KAST:             stats.GotKill || stats.GotAssist || stats.Survived || stats.Traded,
+		EcoValue:         stats.EconImpact,
 		Contributions:    stats.SwingContributions,
 	}
 
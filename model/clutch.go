@@ -0,0 +1,44 @@
+package model
+
+// ClutchDescriptor describes a single 1vX attempt: the situation when the
+// player was left alone, the kills that followed, and the result. MatchID and
+// Map are filled in by the caller when descriptors are collected across demos.
+type ClutchDescriptor struct {
+	MatchID       string        `json:"match_id,omitempty"`
+	Map           string        `json:"map,omitempty"`
+	RoundNumber   int           `json:"round_number"`
+	SteamID       string        `json:"steam_id"`
+	Name          string        `json:"name"`
+	Side          string        `json:"side"`
+	Opponents     int           `json:"opponents"` // X in 1vX
+	Health        int           `json:"health"`
+	Armor         int           `json:"armor"`
+	Weapon        string        `json:"weapon"`
+	StartTime     float64       `json:"start_time"`     // Seconds into the round when the clutch began
+	TimeRemaining float64       `json:"time_remaining"` // Round or bomb timer left when the clutch began
+	BombState     string        `json:"bomb_state"`     // "not_planted" or "planted" when the clutch began
+	Enemies       []ClutchEnemy `json:"enemies"`
+	Kills         []ClutchKill  `json:"kills"`
+	ClutchKills   int           `json:"clutch_kills"` // Kills by the clutcher after the clutch began
+	Won           bool          `json:"won"`
+	Survived      bool          `json:"survived"`
+}
+
+// ClutchEnemy is an opponent alive when the clutch began.
+type ClutchEnemy struct {
+	SteamID string `json:"steam_id"`
+	Name    string `json:"name"`
+	Weapon  string `json:"weapon"`
+	Health  int    `json:"health"`
+	Armor   int    `json:"armor"`
+	HasKit  bool   `json:"has_kit,omitempty"`
+}
+
+// ClutchKill is one kill in the clutch sequence, either by or of the clutcher.
+type ClutchKill struct {
+	Time     float64 `json:"time"`   // Seconds into the round
+	Killer   string  `json:"killer"` // Empty for a death with no attacker, such as the bomb
+	Victim   string  `json:"victim"`
+	Weapon   string  `json:"weapon"`
+	Headshot bool    `json:"headshot,omitempty"`
+}
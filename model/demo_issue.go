@@ -0,0 +1,12 @@
+package model
+
+// DemoIssue is one demo in a batch run that couldn't be parsed in full, for
+// the per-file error report.
+type DemoIssue struct {
+	Demo    string `json:"demo"`    // Bucket key or file name
+	Tier    string `json:"tier"`    // Tier the demo was processed for
+	Kind    string `json:"kind"`    // unreadable, not_demo, not_cs2, truncated, corrupt or panic
+	Partial bool   `json:"partial"` // Stats up to the failure were kept
+	Rounds  int    `json:"rounds"`  // Rounds kept when partial
+	Error   string `json:"error"`
+}
@@ -0,0 +1,17 @@
+package model
+
+// DemoSummary is the match summary of a demo from a quick scan, enough to
+// catalog or triage a demo dump without parsing full stats.
+type DemoSummary struct {
+	File    string          `json:"file"`
+	Match   MatchInfo       `json:"match"`
+	Players []SummaryPlayer `json:"players"`
+	Error   string          `json:"error,omitempty"` // Why the scan failed or stopped early
+}
+
+// SummaryPlayer is one player seen in a quick scan.
+type SummaryPlayer struct {
+	SteamID string `json:"steam_id"`
+	Name    string `json:"name"`
+	Team    string `json:"team"`
+}
@@ -0,0 +1,34 @@
+package model
+
+// Position is a point in world coordinates.
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// GrenadeThrow describes one thrown grenade: who threw it and from where,
+// its flight path, where it went off and who it hit.
+type GrenadeThrow struct {
+	MatchID        string          `json:"match_id,omitempty"`
+	Map            string          `json:"map,omitempty"`
+	RoundNumber    int             `json:"round_number"`
+	Time           float64         `json:"time"` // Seconds into the round when thrown
+	Type           string          `json:"type"` // "flash", "smoke", "he", "molotov", "incendiary", "decoy"
+	Thrower        string          `json:"thrower"`
+	ThrowerSteamID string          `json:"thrower_steam_id"`
+	Side           string          `json:"side"`
+	Origin         Position        `json:"origin"`
+	Detonation     *Position       `json:"detonation,omitempty"` // Nil if the projectile was never destroyed
+	Trajectory     []Position      `json:"trajectory,omitempty"`
+	Affected       []GrenadeVictim `json:"affected"`
+}
+
+// GrenadeVictim is a player flashed or damaged by a grenade.
+type GrenadeVictim struct {
+	Name          string  `json:"name"`
+	SteamID       string  `json:"steam_id"`
+	Enemy         bool    `json:"enemy"`
+	Damage        int     `json:"damage,omitempty"`
+	FlashDuration float64 `json:"flash_duration,omitempty"` // Seconds
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// MatchInfo is the match-level metadata of one demo. The map, teams, score,
+// tick rate and duration come from the demo itself; MatchID and StartTime
+// are filled in by the caller, since a demo doesn't record its own file name
+// or a wall-clock start time.
+type MatchInfo struct {
+	MatchID         string    `json:"match_id"`
+	Map             string    `json:"map"`
+	Team1           string    `json:"team1"` // Winning team (or the CT side at the end on a draw)
+	Team2           string    `json:"team2"`
+	Team1Score      int       `json:"team1_score"`
+	Team2Score      int       `json:"team2_score"`
+	StartTime       time.Time `json:"start_time"`       // Bucket upload time; zero if unknown, as for local files
+	TickRate        float64   `json:"tick_rate"`        // Server tick rate
+	DurationSeconds float64   `json:"duration_seconds"` // In-game time covered by the demo
+	Source          string    `json:"source,omitempty"` // Platform the demo was recorded on, e.g. "faceit"
+
+	Excluded    RoundExclusions `json:"excluded_rounds"` // Rounds played in the demo but not counted, by reason
+	StartRounds int             `json:"start_rounds"`    // Rounds already won going into the first live round; non-zero when recording began mid-match, e.g. after a backup restore
+	Progression string          `json:"progression"`     // Winning side of each counted round in order: T, C, or D for a round without a winner
+}
+
+// RoundExclusions counts the rounds a demo played that aren't counted in
+// any stat, by why they were left out.
+type RoundExclusions struct {
+	Knife     int `json:"knife"`     // Knife rounds for sides
+	Warmup    int `json:"warmup"`    // Warmup rounds and rounds before the match started
+	Restarted int `json:"restarted"` // Counted rounds discarded when the game restarted to 0-0
+	Replayed  int `json:"replayed"`  // Counted rounds rolled back by a round backup restore and played again
+	Duplicate int `json:"duplicate"` // Rounds another demo of the same match counts, recorded again after a backup restore
+}
+
+// Total returns the number of excluded rounds.
+func (e RoundExclusions) Total() int {
+	return e.Knife + e.Warmup + e.Restarted + e.Replayed + e.Duplicate
+}
+
+// Add adds other's counts to e.
+func (e *RoundExclusions) Add(other RoundExclusions) {
+	e.Knife += other.Knife
+	e.Warmup += other.Warmup
+	e.Restarted += other.Restarted
+	e.Replayed += other.Replayed
+	e.Duplicate += other.Duplicate
+}
+
+// String lists the counts by reason, e.g. "1 knife, 2 warmup, 0 restarted,
+// 0 replayed, 0 duplicate".
+func (e RoundExclusions) String() string {
+	return fmt.Sprintf("%d knife, %d warmup, %d restarted, %d replayed, %d duplicate",
+		e.Knife, e.Warmup, e.Restarted, e.Replayed, e.Duplicate)
+}
@@ -30,220 +30,353 @@ type MultiKillStats struct {
 // - Side-specific stats (T/CT)
 // - Calculated ratings and percentages
 type PlayerStats struct {
-	SteamID  string `json:"steam_id"`
-	Name     string `json:"name"`
-	TeamName string `json:"team_name"`
-
-	RoundsPlayed        int     `json:"rounds_played"`
-	RoundsWon           int     `json:"rounds_won"`
-	RoundsLost          int     `json:"rounds_lost"`
-	Kills               int     `json:"kills"`
-	Assists             int     `json:"assists"`
-	Deaths              int     `json:"deaths"`
-	Damage              int     `json:"damage"`
-	OpeningKills        int     `json:"opening_kills"`
-	ADR                 float64 `json:"adr"`
-	KPR                 float64 `json:"kpr"`
-	DPR                 float64 `json:"dpr"`
-	Headshots           int     `json:"headshots"`
-	HeadshotPct         float64 `json:"headshot_pct"`
-	TotalTimeToKill     float64 `json:"-"`
-	KillsWithTTK        int     `json:"-"`
-	AvgTimeToKill       float64 `json:"avg_time_to_kill"`
-	PerfectKills        int     `json:"perfect_kills"`
-	TradeDenials        int     `json:"trade_denials"`
-	TradedDeaths        int     `json:"traded_deaths"`
-	RoundsWithKill      int     `json:"rounds_with_kill"`
-	RoundsWithMultiKill int     `json:"rounds_with_multi_kill"`
-	KillsInWonRounds    int     `json:"kills_in_won_rounds"`
-	DamageInWonRounds   int     `json:"damage_in_won_rounds"`
-	AWPKills            int     `json:"awp_kills"`
-	AWPKillsPerRound    float64 `json:"awp_kills_per_round"`
-	RoundsWithAWPKill   int     `json:"rounds_with_awp_kill"`
-	AWPMultiKillRounds  int     `json:"awp_multi_kill_rounds"`
-	AWPOpeningKills     int     `json:"awp_opening_kills"`
+	SteamID  string `json:"steam_id" desc:"Player's Steam ID64"`
+	Name     string `json:"name" desc:"Player's in-game name"`
+	TeamName string `json:"team_name" desc:"Team name in the demo"`
+
+	RoundsPlayed           int     `json:"rounds_played" desc:"Rounds played"`
+	RoundsWon              int     `json:"rounds_won" desc:"Rounds the player's team won"`
+	RoundsLost             int     `json:"rounds_lost" desc:"Rounds the player's team lost"`
+	Kills                  int     `json:"kills" desc:"Kills"`
+	Assists                int     `json:"assists" desc:"Assists"`
+	Deaths                 int     `json:"deaths" desc:"Deaths"`
+	Damage                 int     `json:"damage" desc:"Damage dealt to enemies"`
+	OpeningKills           int     `json:"opening_kills" desc:"Rounds with the round's first kill"`
+	ADR                    float64 `json:"adr" desc:"Average damage per round" formula:"damage / rounds_played"`
+	KPR                    float64 `json:"kpr" desc:"Kills per round" formula:"kills / rounds_played"`
+	DPR                    float64 `json:"dpr" desc:"Deaths per round" formula:"deaths / rounds_played"`
+	Headshots              int     `json:"headshots" desc:"Headshot kills"`
+	HeadshotPct            float64 `json:"headshot_pct" desc:"Share of kills that were headshots" formula:"headshots / kills"`
+	TotalTimeToKill        float64 `json:"-"`
+	KillsWithTTK           int     `json:"-"`
+	AvgTimeToKill          float64 `json:"avg_time_to_kill" desc:"Average seconds from first damage on a victim to the kill" formula:"total time to kill / kills with a time to kill"`
+	TotalSightToKill       float64 `json:"-"`
+	KillsWithSightTime     int     `json:"-"`
+	AvgSightToKill         float64 `json:"avg_sight_to_kill" desc:"Average seconds from first seeing the victim to the kill" formula:"total sight to kill / kills with a sighting"`
+	Duels                  int     `json:"duels" desc:"Kills and deaths where either player damaged the other in the engagement"`
+	FirstDamageDuels       int     `json:"first_damage_duels" desc:"Duels where the player dealt the first damage"`
+	FirstDamagePct         float64 `json:"first_damage_pct" desc:"Share of duels where the player dealt the first damage" formula:"first_damage_duels / duels"`
+	PerfectKills           int     `json:"perfect_kills" desc:"Headshot kills counted in the eco-kill pass"`
+	TradeDenials           int     `json:"trade_denials" desc:"Kills on an enemy who had just killed a teammate"`
+	TradedDeaths           int     `json:"traded_deaths" desc:"Deaths avenged by a teammate within the trade window"`
+	RoundsWithKill         int     `json:"rounds_with_kill" desc:"Rounds with at least one kill"`
+	RoundsWithMultiKill    int     `json:"rounds_with_multi_kill" desc:"Rounds with two or more kills"`
+	MultiKillPoints        int     `json:"multi_kill_points" desc:"Sum of kills squared over 2k+ rounds" formula:"sum(kills^2) over rounds with kills >= 2"`
+	WeightedMultiKills     float64 `json:"weighted_multi_kills" desc:"Multi-kill points scaled by the round state the kills came in" formula:"parser/multi_kill_context.go multiKillContextWeight"`
+	LeverageMultiKills     float64 `json:"leverage_multi_kills" desc:"Multi-kill points scaled by the leverage of the round they came in" formula:"sum(kills^2 * round leverage) over rounds with kills >= 2"`
+	MultiKillContextWeight float64 `json:"multi_kill_context_weight" desc:"Average context weight of the player's multi-kills" formula:"weighted_multi_kills / multi_kill_points"`
+	MultiKillSwing         float64 `json:"multi_kill_swing" desc:"Raw win probability gained by kills in 2k+ rounds"`
+	KillsInWonRounds       int     `json:"kills_in_won_rounds" desc:"Kills in rounds the team won"`
+	DamageInWonRounds      int     `json:"damage_in_won_rounds" desc:"Damage in rounds the team won"`
+	DeathsInWonRounds      int     `json:"deaths_in_won_rounds" desc:"Deaths in rounds the team won"`
+	UtilDamageInWonRounds  int     `json:"utility_damage_in_won_rounds" desc:"Grenade damage in rounds the team won"`
+	KillsInLostRounds      int     `json:"kills_in_lost_rounds" desc:"Kills in rounds the team lost"`
+	DeathsInLostRounds     int     `json:"deaths_in_lost_rounds" desc:"Deaths in rounds the team lost"`
+	DamageInLostRounds     int     `json:"damage_in_lost_rounds" desc:"Damage in rounds the team lost"`
+	UtilDamageInLostRounds int     `json:"utility_damage_in_lost_rounds" desc:"Grenade damage in rounds the team lost"`
+	AWPKills               int     `json:"awp_kills" desc:"Kills with the AWP"`
+	AWPKillsPerRound       float64 `json:"awp_kills_per_round" desc:"AWP kills per round" formula:"awp_kills / rounds_played"`
+	RoundsWithAWPKill      int     `json:"rounds_with_awp_kill" desc:"Rounds with at least one AWP kill"`
+	AWPMultiKillRounds     int     `json:"awp_multi_kill_rounds" desc:"Rounds with two or more AWP kills"`
+	AWPOpeningKills        int     `json:"awp_opening_kills" desc:"Opening kills with the AWP"`
+
+	// Engagement ranges, in game units: close under 500, long 1500 and up
+	KillDistanceTotal     float64 `json:"-"`
+	DeathDistanceTotal    float64 `json:"-"`
+	AvgKillDistance       float64 `json:"avg_kill_distance" desc:"Average distance of gun and knife kills, in game units" formula:"kill distance total / (close + mid + long range kills)"`
+	AvgDeathDistance      float64 `json:"avg_death_distance" desc:"Average distance of deaths to guns and knives, in game units" formula:"death distance total / (close + mid + long range deaths)"`
+	CloseRangeKills       int     `json:"close_range_kills" desc:"Kills under 500 units"`
+	MidRangeKills         int     `json:"mid_range_kills" desc:"Kills from 500 to 1500 units"`
+	LongRangeKills        int     `json:"long_range_kills" desc:"Kills at 1500 units or more"`
+	CloseRangeDeaths      int     `json:"close_range_deaths" desc:"Deaths under 500 units"`
+	MidRangeDeaths        int     `json:"mid_range_deaths" desc:"Deaths from 500 to 1500 units"`
+	LongRangeDeaths       int     `json:"long_range_deaths" desc:"Deaths at 1500 units or more"`
+	LongRangeAWPKills     int     `json:"long_range_awp_kills" desc:"AWP kills at 1500 units or more"`
+	CloseRangeRifleDeaths int     `json:"close_range_rifle_deaths" desc:"Deaths to an assault rifle under 500 units"`
 
 	MultiKillsRaw [6]int         `json:"-"`
-	MultiKills    MultiKillStats `json:"multi_kills"`
-
-	RoundImpact                float64 `json:"round_impact"`
-	Survival                   float64 `json:"survival"`
-	KAST                       float64 `json:"kast"`
-	EconImpact                 float64 `json:"econ_impact"`
-	EcoKillValue               float64 `json:"eco_kill_value"`
-	EcoDeathValue              float64 `json:"eco_death_value"`
-	DuelSwing                  float64 `json:"duel_swing"`
-	DuelSwingPerRound          float64 `json:"duel_swing_per_round"`
-	ClutchRounds               int     `json:"clutch_rounds"`
-	ClutchWins                 int     `json:"clutch_wins"`
-	SavedByTeammate            int     `json:"saved_by_teammate"`
-	SavedTeammate              int     `json:"saved_teammate"`
-	OpeningDeaths              int     `json:"opening_deaths"`
-	OpeningDeathsTraded        int     `json:"opening_deaths_traded"`
-	SupportRounds              int     `json:"support_rounds"`
-	AssistedKills              int     `json:"assisted_kills"`
-	TradeKills                 int     `json:"trade_kills"`
-	FastTrades                 int     `json:"fast_trades"`
-	ManAdvantageKills          int     `json:"man_advantage_kills"`
-	ManAdvantageKillsPct       float64 `json:"man_advantage_kills_pct"`
-	ManDisadvantageDeaths      int     `json:"man_disadvantage_deaths"`
-	ManDisadvantageDeathsPct   float64 `json:"man_disadvantage_deaths_pct"`
-	OpeningAttempts            int     `json:"opening_attempts"`
-	OpeningSuccesses           int     `json:"opening_successes"`
-	RoundsWonAfterOpening      int     `json:"rounds_won_after_opening"`
-	AttackRounds               int     `json:"attack_rounds"`
-	Clutch1v1Attempts          int     `json:"clutch_1v1_attempts"`
-	Clutch1v1Wins              int     `json:"clutch_1v1_wins"`
+	MultiKills    MultiKillStats `json:"multi_kills" desc:"Rounds by kill count (1k to 5k)"`
+
+	RoundImpact                float64 `json:"round_impact" desc:"Sum of economy-adjusted kill values" formula:"sum(rating.EcoKillValue)"`
+	Survival                   float64 `json:"survival" desc:"Share of rounds survived" formula:"rounds survived / rounds_played"`
+	KAST                       float64 `json:"kast" desc:"Share of rounds with a kill, assist, survival or trade" formula:"KAST rounds / rounds_played"`
+	EconImpact                 float64 `json:"econ_impact" desc:"Sum of economy-adjusted kill values" formula:"sum(rating.EcoKillValue)"`
+	EcoKillValue               float64 `json:"eco_kill_value" desc:"Sum of economy-adjusted kill values" formula:"sum(rating.EcoKillValue)"`
+	EcoDeathValue              float64 `json:"eco_death_value" desc:"Sum of economy-adjusted death penalties" formula:"sum(rating.EcoDeathPenalty)"`
+	DuelSwing                  float64 `json:"duel_swing" desc:"Net economy-adjusted duel value" formula:"eco_kill_value - eco_death_value"`
+	DuelSwingPerRound          float64 `json:"duel_swing_per_round" desc:"Net economy-adjusted duel value per round" formula:"duel_swing / rounds_played"`
+	ClutchRounds               int     `json:"clutch_rounds" desc:"Rounds the player was last alive against one or more enemies"`
+	ClutchWins                 int     `json:"clutch_wins" desc:"Clutch rounds won"`
+	WeightedClutchWins         float64 `json:"weighted_clutch_wins" desc:"Clutch wins weighted by round importance" formula:"sum(round importance of clutches won)"`
+	LeverageClutchWins         float64 `json:"leverage_clutch_wins" desc:"Clutch wins weighted by the leverage of the round they came in" formula:"sum(round leverage of clutches won)"`
+	SavedByTeammate            int     `json:"saved_by_teammate" desc:"Rounds the player's death was avenged"`
+	SavedTeammate              int     `json:"saved_teammate" desc:"Kills that avenged a teammate's death"`
+	OpeningDeaths              int     `json:"opening_deaths" desc:"Rounds with the round's first death"`
+	OpeningDeathsTraded        int     `json:"opening_deaths_traded" desc:"Opening deaths avenged within the trade window"`
+	EntryTrades                int     `json:"entry_trades" desc:"Kills that traded a teammate's opening death"`
+	SupportRounds              int     `json:"support_rounds" desc:"Rounds with an assist or flash assist"`
+	AssistedKills              int     `json:"assisted_kills" desc:"Assists credited in side stats"`
+	TradeKills                 int     `json:"trade_kills" desc:"Kills avenging a teammate within the trade window"`
+	FastTrades                 int     `json:"fast_trades" desc:"Trade kills within 2 seconds of the teammate's death"`
+	ManAdvantageKills          int     `json:"man_advantage_kills" desc:"Kills that put the team ahead in players alive"`
+	ManAdvantageKillsPct       float64 `json:"man_advantage_kills_pct" desc:"Share of kills that created a man advantage" formula:"man_advantage_kills / kills"`
+	ManDisadvantageDeaths      int     `json:"man_disadvantage_deaths" desc:"Deaths that put the team behind in players alive"`
+	ManDisadvantageDeathsPct   float64 `json:"man_disadvantage_deaths_pct" desc:"Share of deaths that created a man disadvantage" formula:"man_disadvantage_deaths / deaths"`
+	OpeningAttempts            int     `json:"opening_attempts" desc:"Opening duels taken (first kill or first death)"`
+	OpeningSuccesses           int     `json:"opening_successes" desc:"Opening duels won"`
+	RoundsWonAfterOpening      int     `json:"rounds_won_after_opening" desc:"Rounds won after the player got the opening kill"`
+	AttackRounds               int     `json:"attack_rounds" desc:"Rounds with at least one kill"`
+	Clutch1v1Attempts          int     `json:"clutch_1v1_attempts" desc:"1v1 clutches"`
+	Clutch1v1Wins              int     `json:"clutch_1v1_wins" desc:"1v1 clutches won"`
 	TotalTimeAlive             float64 `json:"-"`
-	TimeAlivePerRound          float64 `json:"time_alive_per_round"`
-	LastAliveRounds            int     `json:"last_alive_rounds"`
-	SavesOnLoss                int     `json:"saves_on_loss"`
-	UtilityDamage              int     `json:"utility_damage"`
-	UtilityKills               int     `json:"utility_kills"`
-	FlashesThrown              int     `json:"flashes_thrown"`
-	FlashAssists               int     `json:"flash_assists"`
+	TimeAlivePerRound          float64 `json:"time_alive_per_round" desc:"Average seconds alive per round" formula:"total time alive / rounds_played"`
+	LastAliveRounds            int     `json:"last_alive_rounds" desc:"Rounds the player was the team's last player alive"`
+	SavesOnLoss                int     `json:"saves_on_loss" desc:"Lost rounds the player survived"`
+	UtilityDamage              int     `json:"utility_damage" desc:"Damage dealt with grenades"`
+	UtilityKills               int     `json:"utility_kills" desc:"Kills with grenades"`
+	FlashesThrown              int     `json:"flashes_thrown" desc:"Flashbangs thrown"`
+	FlashAssists               int     `json:"flash_assists" desc:"Kills on enemies the player flashed"`
 	EnemyFlashDuration         float64 `json:"-"`
-	EnemyFlashDurationPerRound float64 `json:"enemy_flash_duration_per_round"`
-	TeamFlashCount             int     `json:"team_flash_count"`
+	EnemyFlashDurationPerRound float64 `json:"enemy_flash_duration_per_round" desc:"Seconds of enemy blindness caused per round" formula:"enemy flash duration / rounds_played"`
+	TeamFlashCount             int     `json:"team_flash_count" desc:"Teammates flashed"`
 	TeamFlashDuration          float64 `json:"-"`
-	TeamFlashDurationPerRound  float64 `json:"team_flash_duration_per_round"`
-	ExitFrags                  int     `json:"exit_frags"`
-	AWPDeaths                  int     `json:"awp_deaths"`
-	AWPDeathsNoKill            int     `json:"awp_deaths_no_kill"`
-	KnifeKills                 int     `json:"knife_kills"`
-	PistolVsRifleKills         int     `json:"pistol_vs_rifle_kills"`
-	EarlyDeaths                int     `json:"early_deaths"`
-	LowBuyKills                int     `json:"low_buy_kills"`
-	LowBuyKillsPct             float64 `json:"low_buy_kills_pct"`
-	DisadvantagedBuyKills      int     `json:"disadvantaged_buy_kills"`
-	DisadvantagedBuyKillsPct   float64 `json:"disadvantaged_buy_kills_pct"`
-	PistolRoundsPlayed         int     `json:"pistol_rounds_played"`
-	PistolRoundKills           int     `json:"pistol_round_kills"`
-	PistolRoundDeaths          int     `json:"pistol_round_deaths"`
-	PistolRoundDamage          int     `json:"pistol_round_damage"`
-	PistolRoundsWon            int     `json:"pistol_rounds_won"`
-	PistolRoundSurvivals       int     `json:"pistol_round_survivals"`
-	PistolRoundMultiKills      int     `json:"pistol_round_multi_kills"`
-	PistolRoundRating          float64 `json:"pistol_round_rating"`
-	HLTVRating                 float64 `json:"hltv_rating"`
-	TRoundsPlayed              int     `json:"t_rounds_played"`
-	TKills                     int     `json:"t_kills"`
-	TDeaths                    int     `json:"t_deaths"`
-	TDamage                    int     `json:"t_damage"`
-	TSurvivals                 int     `json:"t_survivals"`
-	TRoundsWithMultiKill       int     `json:"t_rounds_with_multi_kill"`
-	TEcoKillValue              float64 `json:"t_eco_kill_value"`
-	TProbabilitySwing          float64 `json:"t_probability_swing"`
-	TKAST                      float64 `json:"t_kast"`
+	TeamFlashDurationPerRound  float64 `json:"team_flash_duration_per_round" desc:"Seconds of teammate blindness caused per round" formula:"team flash duration / rounds_played"`
+	ExitFrags                  int     `json:"exit_frags" desc:"Kills after the round could no longer change" formula:"parser/exit_frags.go isExitFrag"`
+	AWPDeaths                  int     `json:"awp_deaths" desc:"Deaths while holding an AWP"`
+	AWPDeathsNoKill            int     `json:"awp_deaths_no_kill" desc:"Deaths while holding an AWP without an AWP kill that round"`
+	KnifeKills                 int     `json:"knife_kills" desc:"Kills with a knife"`
+	PistolVsRifleKills         int     `json:"pistol_vs_rifle_kills" desc:"Pistol kills on enemies holding a rifle"`
+	EarlyDeaths                int     `json:"early_deaths" desc:"Deaths in the first 30 seconds of a round"`
+	EcoRounds                  int     `json:"eco_rounds" desc:"Rounds the team played on an eco" formula:"parser/economy_tracker.go ClassifyBuy"` // Rounds the team played on an eco
+	EcoRoundKills              int     `json:"eco_round_kills" desc:"Kills in eco rounds"`                                                         // Kills in those rounds
+	EcoRoundWins               int     `json:"eco_round_wins" desc:"Eco rounds won"`
+	EcoRoundWinPct             float64 `json:"eco_round_win_pct" desc:"Share of eco rounds won" formula:"eco_round_wins / eco_rounds"`
+	ForceBuyRounds             int     `json:"force_buy_rounds" desc:"Rounds the team force-bought" formula:"parser/economy_tracker.go ClassifyBuy"`
+	ForceBuyWins               int     `json:"force_buy_wins" desc:"Force-buy rounds won"`
+	ForceBuyWinPct             float64 `json:"force_buy_win_pct" desc:"Share of force-buy rounds won" formula:"force_buy_wins / force_buy_rounds"`
+	FullBuyRounds              int     `json:"full_buy_rounds" desc:"Rounds the team full-bought" formula:"parser/economy_tracker.go ClassifyBuy"`
+	FullBuyWins                int     `json:"full_buy_wins" desc:"Full-buy rounds won"`
+	FullBuyWinPct              float64 `json:"full_buy_win_pct" desc:"Share of full-buy rounds won" formula:"full_buy_wins / full_buy_rounds"`
+	MoneySaved                 int     `json:"money_saved" desc:"Equipment value kept by surviving lost rounds"` // Equipment value kept by surviving lost rounds
+	LowBuyKills                int     `json:"low_buy_kills" desc:"Kills with a kill value below 1 (worse equipment than the victim)" formula:"rating.EcoKillValue < 1"`
+	LowBuyKillsPct             float64 `json:"low_buy_kills_pct" desc:"Share of kills that were low-buy kills" formula:"low_buy_kills / kills"`
+	DisadvantagedBuyKills      int     `json:"disadvantaged_buy_kills" desc:"Kills at a clear equipment disadvantage" formula:"rating.EcoKillValue <= 0.85"`
+	DisadvantagedBuyKillsPct   float64 `json:"disadvantaged_buy_kills_pct" desc:"Share of kills at a clear equipment disadvantage" formula:"disadvantaged_buy_kills / kills"`
+	PistolRoundsPlayed         int     `json:"pistol_rounds_played" desc:"Pistol rounds played"`
+	PistolRoundKills           int     `json:"pistol_round_kills" desc:"Kills in pistol rounds"`
+	PistolRoundDeaths          int     `json:"pistol_round_deaths" desc:"Deaths in pistol rounds"`
+	PistolRoundDamage          int     `json:"pistol_round_damage" desc:"Damage in pistol rounds"`
+	PistolRoundsWon            int     `json:"pistol_rounds_won" desc:"Pistol rounds won"`
+	PistolRoundSurvivals       int     `json:"pistol_round_survivals" desc:"Pistol rounds survived"`
+	PistolRoundMultiKills      int     `json:"pistol_round_multi_kills" desc:"Pistol rounds with two or more kills"`
+	PistolRoundRating          float64 `json:"pistol_round_rating" desc:"Rating over pistol rounds against pistol-specific baselines" formula:"rating/pistol.go ComputePistolRating"`
+	HLTVRating                 float64 `json:"hltv_rating" desc:"HLTV 2.0 rating" formula:"rating/hltv.go ComputeHLTVRating"`
+	TRoundsPlayed              int     `json:"t_rounds_played" desc:"T-side rounds played"`
+	TKills                     int     `json:"t_kills" desc:"T-side kills"`
+	TDeaths                    int     `json:"t_deaths" desc:"T-side deaths"`
+	TDamage                    int     `json:"t_damage" desc:"T-side damage"`
+	TSurvivals                 int     `json:"t_survivals" desc:"T-side rounds survived"`
+	TRoundsWithMultiKill       int     `json:"t_rounds_with_multi_kill" desc:"T-side rounds with two or more kills"`
+	TEcoKillValue              float64 `json:"t_eco_kill_value" desc:"T-side economy-adjusted kill value"`
+	TProbabilitySwing          float64 `json:"t_probability_swing" desc:"T-side probability swing"`
+	TKAST                      float64 `json:"t_kast" desc:"T-side KAST rounds"`
 	TMultiKills                [6]int  `json:"-"`
-	TClutchRounds              int     `json:"t_clutch_rounds"`
-	TClutchWins                int     `json:"t_clutch_wins"`
-	TManAdvantageKills         int     `json:"t_man_advantage_kills"`
-	TManAdvantageKillsPct      float64 `json:"t_man_advantage_kills_pct"`
-	TManDisadvantageDeaths     int     `json:"t_man_disadvantage_deaths"`
-	TManDisadvantageDeathsPct  float64 `json:"t_man_disadvantage_deaths_pct"`
-	TRating                    float64 `json:"t_rating"`
-	TEcoRating                 float64 `json:"t_eco_rating"`
-	CTRoundsPlayed             int     `json:"ct_rounds_played"`
-	CTKills                    int     `json:"ct_kills"`
-	CTDeaths                   int     `json:"ct_deaths"`
-	CTDamage                   int     `json:"ct_damage"`
-	CTSurvivals                int     `json:"ct_survivals"`
-	CTRoundsWithMultiKill      int     `json:"ct_rounds_with_multi_kill"`
-	CTEcoKillValue             float64 `json:"ct_eco_kill_value"`
-	CTProbabilitySwing         float64 `json:"ct_probability_swing"`
-	CTKAST                     float64 `json:"ct_kast"`
+	TClutchRounds              int     `json:"t_clutch_rounds" desc:"T-side clutch rounds"`
+	TClutchWins                int     `json:"t_clutch_wins" desc:"T-side clutches won"`
+	TManAdvantageKills         int     `json:"t_man_advantage_kills" desc:"T-side man advantage kills"`
+	TManAdvantageKillsPct      float64 `json:"t_man_advantage_kills_pct" desc:"Share of T-side kills that created a man advantage" formula:"t_man_advantage_kills / t_kills"`
+	TManDisadvantageDeaths     int     `json:"t_man_disadvantage_deaths" desc:"T-side man disadvantage deaths"`
+	TManDisadvantageDeathsPct  float64 `json:"t_man_disadvantage_deaths_pct" desc:"Share of T-side deaths that created a man disadvantage" formula:"t_man_disadvantage_deaths / t_deaths"`
+	TRating                    float64 `json:"t_rating" desc:"HLTV-style rating on the T side" formula:"rating/hltv.go ComputeSideHLTVRating"`
+	TEcoRating                 float64 `json:"t_eco_rating" desc:"Eco-rating on the T side" formula:"rating/rating.go ComputeRoundsRating"`
+	CTRoundsPlayed             int     `json:"ct_rounds_played" desc:"CT-side rounds played"`
+	CTKills                    int     `json:"ct_kills" desc:"CT-side kills"`
+	CTDeaths                   int     `json:"ct_deaths" desc:"CT-side deaths"`
+	CTDamage                   int     `json:"ct_damage" desc:"CT-side damage"`
+	CTSurvivals                int     `json:"ct_survivals" desc:"CT-side rounds survived"`
+	CTRoundsWithMultiKill      int     `json:"ct_rounds_with_multi_kill" desc:"CT-side rounds with two or more kills"`
+	CTEcoKillValue             float64 `json:"ct_eco_kill_value" desc:"CT-side economy-adjusted kill value"`
+	CTProbabilitySwing         float64 `json:"ct_probability_swing" desc:"CT-side probability swing"`
+	CTKAST                     float64 `json:"ct_kast" desc:"CT-side KAST rounds"`
 	CTMultiKills               [6]int  `json:"-"`
-	CTClutchRounds             int     `json:"ct_clutch_rounds"`
-	CTClutchWins               int     `json:"ct_clutch_wins"`
-	CTManAdvantageKills        int     `json:"ct_man_advantage_kills"`
-	CTManAdvantageKillsPct     float64 `json:"ct_man_advantage_kills_pct"`
-	CTManDisadvantageDeaths    int     `json:"ct_man_disadvantage_deaths"`
-	CTManDisadvantageDeathsPct float64 `json:"ct_man_disadvantage_deaths_pct"`
-	CTRating                   float64 `json:"ct_rating"`
-	CTEcoRating                float64 `json:"ct_eco_rating"`
+	CTClutchRounds             int     `json:"ct_clutch_rounds" desc:"CT-side clutch rounds"`
+	CTClutchWins               int     `json:"ct_clutch_wins" desc:"CT-side clutches won"`
+	CTManAdvantageKills        int     `json:"ct_man_advantage_kills" desc:"CT-side man advantage kills"`
+	CTManAdvantageKillsPct     float64 `json:"ct_man_advantage_kills_pct" desc:"Share of CT-side kills that created a man advantage" formula:"ct_man_advantage_kills / ct_kills"`
+	CTManDisadvantageDeaths    int     `json:"ct_man_disadvantage_deaths" desc:"CT-side man disadvantage deaths"`
+	CTManDisadvantageDeathsPct float64 `json:"ct_man_disadvantage_deaths_pct" desc:"Share of CT-side deaths that created a man disadvantage" formula:"ct_man_disadvantage_deaths / ct_deaths"`
+	CTRating                   float64 `json:"ct_rating" desc:"HLTV-style rating on the CT side" formula:"rating/hltv.go ComputeSideHLTVRating"`
+	CTEcoRating                float64 `json:"ct_eco_rating" desc:"Eco-rating on the CT side" formula:"rating/rating.go ComputeRoundsRating"`
+	OTRoundsPlayed             int     `json:"ot_rounds_played" desc:"Overtime rounds played"`
+	OTKills                    int     `json:"ot_kills" desc:"Overtime kills"`
+	OTDeaths                   int     `json:"ot_deaths" desc:"Overtime deaths"`
+	OTDamage                   int     `json:"ot_damage" desc:"Overtime damage"`
+	OTSurvivals                int     `json:"ot_survivals" desc:"Overtime rounds survived"`
+	OTRoundsWithMultiKill      int     `json:"ot_rounds_with_multi_kill" desc:"Overtime rounds with two or more kills"`
+	OTEcoKillValue             float64 `json:"ot_eco_kill_value" desc:"Overtime economy-adjusted kill value"`
+	OTProbabilitySwing         float64 `json:"ot_probability_swing" desc:"Overtime probability swing"`
+	OTKAST                     float64 `json:"ot_kast" desc:"Overtime KAST rounds"`
+	OTMultiKills               [6]int  `json:"-"`
+	OTClutchRounds             int     `json:"ot_clutch_rounds" desc:"Overtime clutch rounds"`
+	OTClutchWins               int     `json:"ot_clutch_wins" desc:"Overtime clutches won"`
+	OTRating                   float64 `json:"ot_rating" desc:"HLTV-style rating in overtime rounds" formula:"rating/hltv.go ComputeSideHLTVRating"`
+	OTEcoRating                float64 `json:"ot_eco_rating" desc:"Eco-rating in overtime rounds" formula:"rating/rating.go ComputeRoundsRating"`
 
-	FinalRating float64 `json:"final_rating"`
+	// Pistol rounds by side (parser/side_stats.go)
+	TPistolRoundsPlayed     int `json:"t_pistol_rounds_played" desc:"T-side pistol rounds played"`
+	TPistolRoundKills       int `json:"t_pistol_round_kills" desc:"Kills in T-side pistol rounds"`
+	TPistolRoundDamage      int `json:"t_pistol_round_damage" desc:"Damage in T-side pistol rounds"`
+	TPistolRoundSurvivals   int `json:"t_pistol_round_survivals" desc:"T-side pistol rounds survived"`
+	TPistolRoundMultiKills  int `json:"t_pistol_round_multi_kills" desc:"T-side pistol rounds with two or more kills"`
+	TPistolRoundsWon        int `json:"t_pistol_rounds_won" desc:"T-side pistol rounds won"`
+	CTPistolRoundsPlayed    int `json:"ct_pistol_rounds_played" desc:"CT-side pistol rounds played"`
+	CTPistolRoundKills      int `json:"ct_pistol_round_kills" desc:"Kills in CT-side pistol rounds"`
+	CTPistolRoundDamage     int `json:"ct_pistol_round_damage" desc:"Damage in CT-side pistol rounds"`
+	CTPistolRoundSurvivals  int `json:"ct_pistol_round_survivals" desc:"CT-side pistol rounds survived"`
+	CTPistolRoundMultiKills int `json:"ct_pistol_round_multi_kills" desc:"CT-side pistol rounds with two or more kills"`
+	CTPistolRoundsWon       int `json:"ct_pistol_rounds_won" desc:"CT-side pistol rounds won"`
+
+	FinalRating float64 `json:"final_rating" desc:"Eco-rating: probability swing, ADR and KAST against baselines" formula:"rating/rating.go ComputeFinalRating"`
 
 	// Clutch breakdown by opponent count (demoScrape2 compatibility)
-	Clutch1v2Attempts int `json:"clutch_1v2_attempts"`
-	Clutch1v2Wins     int `json:"clutch_1v2_wins"`
-	Clutch1v3Attempts int `json:"clutch_1v3_attempts"`
-	Clutch1v3Wins     int `json:"clutch_1v3_wins"`
-	Clutch1v4Attempts int `json:"clutch_1v4_attempts"`
-	Clutch1v4Wins     int `json:"clutch_1v4_wins"`
-	Clutch1v5Attempts int `json:"clutch_1v5_attempts"`
-	Clutch1v5Wins     int `json:"clutch_1v5_wins"`
+	Clutch1v2Attempts int `json:"clutch_1v2_attempts" desc:"1v2 clutches"`
+	Clutch1v2Wins     int `json:"clutch_1v2_wins" desc:"1v2 clutches won"`
+	Clutch1v3Attempts int `json:"clutch_1v3_attempts" desc:"1v3 clutches"`
+	Clutch1v3Wins     int `json:"clutch_1v3_wins" desc:"1v3 clutches won"`
+	Clutch1v4Attempts int `json:"clutch_1v4_attempts" desc:"1v4 clutches"`
+	Clutch1v4Wins     int `json:"clutch_1v4_wins" desc:"1v4 clutches won"`
+	Clutch1v5Attempts int `json:"clutch_1v5_attempts" desc:"1v5 clutches"`
+	Clutch1v5Wins     int `json:"clutch_1v5_wins" desc:"1v5 clutches won"`
 
 	// Utility tracking (demoScrape2 compatibility)
-	SmokesThrown     int `json:"smokes_thrown"`
-	HEsThrown        int `json:"hes_thrown"`
-	MolotovsThrown   int `json:"molotovs_thrown"`
-	TotalNadesThrown int `json:"total_nades_thrown"`
-	HEDamage         int `json:"he_damage"`
-	FireDamage       int `json:"fire_damage"`
+	SmokesThrown     int `json:"smokes_thrown" desc:"Smokes thrown"`
+	HEsThrown        int `json:"hes_thrown" desc:"HE grenades thrown"`
+	MolotovsThrown   int `json:"molotovs_thrown" desc:"Molotovs and incendiaries thrown"`
+	TotalNadesThrown int `json:"total_nades_thrown" desc:"Grenades thrown" formula:"smokes + flashes + HEs + molotovs"`
+	HEDamage         int `json:"he_damage" desc:"Damage dealt with HE grenades"`
+	FireDamage       int `json:"fire_damage" desc:"Damage dealt with molotovs and incendiaries"`
+
+	// Zone control (parser/zone_control.go)
+	MolotovZoneDamage      int     `json:"molotov_zone_damage" desc:"Damage enemies took from the player's team while standing in the player's molotov"`
+	MolotovZoneKills       int     `json:"molotov_zone_kills" desc:"Enemies killed while standing in the player's molotov"`
+	MolotovDenialTime      float64 `json:"molotov_denial_time" desc:"Enemy-seconds spent within 400 units of the player's burning molotov without entering it"`
+	ExecuteSmokes          int     `json:"execute_smokes" desc:"T-side smokes up when the bomb was planted"`
+	SmokeSightlinesBlocked int     `json:"smoke_sightlines_blocked" desc:"CT kill sightlines from the match that the player's execute smokes cut"`
+
+	// Positioning (parser/positioning.go), sampled every second of live round time
+	TTeammateDistanceTotal  float64 `json:"-"`
+	TPositionSamples        int     `json:"-"`
+	CTTeammateDistanceTotal float64 `json:"-"`
+	CTPositionSamples       int     `json:"-"`
+	CTBombZoneSamples       int     `json:"-"`
+	AvgTTeammateDistance    float64 `json:"avg_t_teammate_distance" desc:"Average distance to the nearest living teammate on the T side, in game units" formula:"T teammate distance total / T position samples"`
+	AvgCTTeammateDistance   float64 `json:"avg_ct_teammate_distance" desc:"Average distance to the nearest living teammate on the CT side, in game units" formula:"CT teammate distance total / CT position samples"`
+	CTBombZonePct           float64 `json:"ct_bomb_zone_pct" desc:"Share of CT-side samples spent inside a bomb site" formula:"CT bomb zone samples / CT position samples"`
+
+	// Site anchoring (parser/anchoring.go), for the anchoring leaderboard
+	AnchorRounds         int     `json:"-"` // CT rounds spent mostly on a bomb site
+	AnchorRoundsSurvived int     `json:"-"`
+	AnchorMultiKills     int     `json:"-"` // Anchor rounds with 2+ kills
+	AnchorSiteContacts   int     `json:"-"` // Anchor rounds where a T reached the player's site before a plant
+	AnchorRetakeDelay    float64 `json:"-"` // Seconds from those contacts to the plant, or to the round being decided without one
+
+	// Mid-round calling proxy (parser/team_swing.go)
+	TeamSwingWhileAlive         float64 `json:"team_swing_while_alive" desc:"Change in the team's round win probability over kills and bomb events while the player was alive"`
+	TeamSwingWhileAlivePerRound float64 `json:"team_swing_while_alive_per_round" desc:"Team swing while alive per round" formula:"team_swing_while_alive / rounds_played"`
 
 	// Damage tracking (demoScrape2 compatibility)
-	DamageTaken    int     `json:"damage_taken"`
-	DamagePerRound float64 `json:"damage_per_round"` // Same as ADR but explicit
+	DamageTaken    int     `json:"damage_taken" desc:"Damage taken"`
+	DamagePerRound float64 `json:"damage_per_round" desc:"Average damage per round (same as ADR)" formula:"damage / rounds_played"` // Same as ADR but explicit
 
 	// Average Time to Death - derived from TimeAlivePerRound
 	// ATD = average time survived in rounds where player died
 	TotalDeathTime  float64 `json:"-"`
 	DeathTimeRounds int     `json:"-"`
-	AvgTimeToDeath  float64 `json:"avg_time_to_death"`
+	AvgTimeToDeath  float64 `json:"avg_time_to_death" desc:"Average seconds into the round of the player's deaths"`
 
 	// Side-specific opening duels (demoScrape2 compatibility)
-	TOpeningKills   int `json:"t_opening_kills"`
-	TOpeningDeaths  int `json:"t_opening_deaths"`
-	CTOpeningKills  int `json:"ct_opening_kills"`
-	CTOpeningDeaths int `json:"ct_opening_deaths"`
+	TOpeningKills   int `json:"t_opening_kills" desc:"T-side opening kills"`
+	TOpeningDeaths  int `json:"t_opening_deaths" desc:"T-side opening deaths"`
+	CTOpeningKills  int `json:"ct_opening_kills" desc:"CT-side opening kills"`
+	CTOpeningDeaths int `json:"ct_opening_deaths" desc:"CT-side opening deaths"`
 
 	// Round Win Shares (RWS) - contribution to round wins
-	RoundWinShares float64 `json:"round_win_shares"`
+	RoundWinShares float64 `json:"round_win_shares" desc:"Round win shares (CSC export only; not computed)"`
 
 	// Enemies flashed count (separate from flash assists)
-	EnemiesFlashed int `json:"enemies_flashed"`
-
-	RoundsWithKillPct          float64 `json:"rounds_with_kill_pct"`
-	KillsPerRoundWin           float64 `json:"kills_per_round_win"`
-	RoundsWithMultiKillPct     float64 `json:"rounds_with_multi_kill_pct"`
-	DamagePerRoundWin          float64 `json:"damage_per_round_win"`
-	SavedByTeammatePerRound    float64 `json:"saved_by_teammate_per_round"`
-	TradedDeathsPerRound       float64 `json:"traded_deaths_per_round"`
-	TradedDeathsPct            float64 `json:"traded_deaths_pct"`
-	OpeningDeathsTradedPct     float64 `json:"opening_deaths_traded_pct"`
-	AssistsPerRound            float64 `json:"assists_per_round"`
-	SupportRoundsPct           float64 `json:"support_rounds_pct"`
-	SavedTeammatePerRound      float64 `json:"saved_teammate_per_round"`
-	TradeKillsPerRound         float64 `json:"trade_kills_per_round"`
-	TradeKillsPct              float64 `json:"trade_kills_pct"`
-	AssistedKillsPct           float64 `json:"assisted_kills_pct"`
-	DamagePerKill              float64 `json:"damage_per_kill"`
-	OpeningKillsPerRound       float64 `json:"opening_kills_per_round"`
-	OpeningDeathsPerRound      float64 `json:"opening_deaths_per_round"`
-	OpeningAttemptsPct         float64 `json:"opening_attempts_pct"`
-	OpeningSuccessPct          float64 `json:"opening_success_pct"`
-	WinPctAfterOpeningKill     float64 `json:"win_pct_after_opening_kill"`
-	AttacksPerRound            float64 `json:"attacks_per_round"`
-	ClutchPointsPerRound       float64 `json:"clutch_points_per_round"`
-	LastAlivePct               float64 `json:"last_alive_pct"`
-	Clutch1v1WinPct            float64 `json:"clutch_1v1_win_pct"`
-	SavesPerRoundLoss          float64 `json:"saves_per_round_loss"`
-	AWPKillsPct                float64 `json:"awp_kills_pct"`
-	RoundsWithAWPKillPct       float64 `json:"rounds_with_awp_kill_pct"`
-	AWPMultiKillRoundsPerRound float64 `json:"awp_multi_kill_rounds_per_round"`
-	AWPOpeningKillsPerRound    float64 `json:"awp_opening_kills_per_round"`
-	UtilityDamagePerRound      float64 `json:"utility_damage_per_round"`
-	UtilityKillsPer100Rounds   float64 `json:"utility_kills_per_100_rounds"`
-	FlashesThrownPerRound      float64 `json:"flashes_thrown_per_round"`
-	FlashAssistsPerRound       float64 `json:"flash_assists_per_round"`
+	EnemiesFlashed int `json:"enemies_flashed" desc:"Enemies flashed"`
+
+	RoundsWithKillPct          float64 `json:"rounds_with_kill_pct" desc:"Share of rounds with a kill" formula:"rounds_with_kill / rounds_played"`
+	KillsPerRoundWin           float64 `json:"kills_per_round_win" desc:"Kills per won round" formula:"kills_in_won_rounds / rounds_won"`
+	RoundsWithMultiKillPct     float64 `json:"rounds_with_multi_kill_pct" desc:"Share of rounds with two or more kills" formula:"rounds_with_multi_kill / rounds_played"`
+	DamagePerRoundWin          float64 `json:"damage_per_round_win" desc:"Damage per won round" formula:"damage_in_won_rounds / rounds_won"`
+	DeathsPerRoundWin          float64 `json:"deaths_per_round_win" desc:"Deaths per won round" formula:"deaths_in_won_rounds / rounds_won"`
+	UtilDamagePerRoundWin      float64 `json:"utility_damage_per_round_win" desc:"Grenade damage per won round" formula:"utility_damage_in_won_rounds / rounds_won"`
+	KillsPerRoundLoss          float64 `json:"kills_per_round_loss" desc:"Kills per lost round" formula:"kills_in_lost_rounds / rounds_lost"`
+	DeathsPerRoundLoss         float64 `json:"deaths_per_round_loss" desc:"Deaths per lost round" formula:"deaths_in_lost_rounds / rounds_lost"`
+	DamagePerRoundLoss         float64 `json:"damage_per_round_loss" desc:"Damage per lost round" formula:"damage_in_lost_rounds / rounds_lost"`
+	UtilDamagePerRoundLoss     float64 `json:"utility_damage_per_round_loss" desc:"Grenade damage per lost round" formula:"utility_damage_in_lost_rounds / rounds_lost"`
+	SavedByTeammatePerRound    float64 `json:"saved_by_teammate_per_round" desc:"Avenged deaths per round" formula:"saved_by_teammate / rounds_played"`
+	TradedDeathsPerRound       float64 `json:"traded_deaths_per_round" desc:"Traded deaths per round" formula:"traded_deaths / rounds_played"`
+	TradedDeathsPct            float64 `json:"traded_deaths_pct" desc:"Share of deaths that were traded" formula:"traded_deaths / deaths"`
+	OpeningDeathsTradedPct     float64 `json:"opening_deaths_traded_pct" desc:"Share of opening deaths that were traded" formula:"opening_deaths_traded / opening_deaths"`
+	AssistsPerRound            float64 `json:"assists_per_round" desc:"Assists per round" formula:"assists / rounds_played"`
+	SupportRoundsPct           float64 `json:"support_rounds_pct" desc:"Share of rounds with an assist or flash assist" formula:"support_rounds / rounds_played"`
+	SavedTeammatePerRound      float64 `json:"saved_teammate_per_round" desc:"Avenging kills per round" formula:"saved_teammate / rounds_played"`
+	TradeKillsPerRound         float64 `json:"trade_kills_per_round" desc:"Trade kills per round" formula:"trade_kills / rounds_played"`
+	TradeKillsPct              float64 `json:"trade_kills_pct" desc:"Share of kills that were trades" formula:"trade_kills / kills"`
+	AssistedKillsPct           float64 `json:"assisted_kills_pct" desc:"Assisted kills relative to kills" formula:"assisted_kills / kills"`
+	DamagePerKill              float64 `json:"damage_per_kill" desc:"Damage dealt per kill" formula:"damage / kills"`
+	OpeningKillsPerRound       float64 `json:"opening_kills_per_round" desc:"Opening kills per round" formula:"opening_kills / rounds_played"`
+	OpeningDeathsPerRound      float64 `json:"opening_deaths_per_round" desc:"Opening deaths per round" formula:"opening_deaths / rounds_played"`
+	OpeningAttemptsPct         float64 `json:"opening_attempts_pct" desc:"Share of rounds with an opening duel" formula:"opening_attempts / rounds_played"`
+	OpeningSuccessPct          float64 `json:"opening_success_pct" desc:"Share of opening duels won" formula:"opening_successes / opening_attempts"`
+	WinPctAfterOpeningKill     float64 `json:"win_pct_after_opening_kill" desc:"Share of opening-kill rounds the team won" formula:"rounds_won_after_opening / opening_kills"`
+	AttacksPerRound            float64 `json:"attacks_per_round" desc:"Rounds with a kill, per round" formula:"attack_rounds / rounds_played"`
+	ClutchPointsPerRound       float64 `json:"clutch_points_per_round" desc:"Clutches won per round" formula:"clutch_wins / rounds_played"`
+	LastAlivePct               float64 `json:"last_alive_pct" desc:"Share of rounds the player was last alive" formula:"last_alive_rounds / rounds_played"`
+	Clutch1v1WinPct            float64 `json:"clutch_1v1_win_pct" desc:"Share of 1v1 clutches won" formula:"clutch_1v1_wins / clutch_1v1_attempts"`
+	SavesPerRoundLoss          float64 `json:"saves_per_round_loss" desc:"Saves per lost round" formula:"saves_on_loss / rounds_lost"`
+	AWPKillsPct                float64 `json:"awp_kills_pct" desc:"Share of kills with the AWP" formula:"awp_kills / kills"`
+	RoundsWithAWPKillPct       float64 `json:"rounds_with_awp_kill_pct" desc:"Share of rounds with an AWP kill" formula:"rounds_with_awp_kill / rounds_played"`
+	AWPMultiKillRoundsPerRound float64 `json:"awp_multi_kill_rounds_per_round" desc:"AWP multi-kill rounds per round" formula:"awp_multi_kill_rounds / rounds_played"`
+	AWPOpeningKillsPerRound    float64 `json:"awp_opening_kills_per_round" desc:"AWP opening kills per round" formula:"awp_opening_kills / rounds_played"`
+	UtilityDamagePerRound      float64 `json:"utility_damage_per_round" desc:"Grenade damage per round" formula:"utility_damage / rounds_played"`
+	UtilityKillsPer100Rounds   float64 `json:"utility_kills_per_100_rounds" desc:"Grenade kills per 100 rounds" formula:"utility_kills * 100 / rounds_played"`
+	FlashesThrownPerRound      float64 `json:"flashes_thrown_per_round" desc:"Flashbangs thrown per round" formula:"flashes_thrown / rounds_played"`
+	FlashAssistsPerRound       float64 `json:"flash_assists_per_round" desc:"Flash assists per round" formula:"flash_assists / rounds_played"`
 
 	// Probability-based swing metrics (new for v3.0)
-	ProbabilitySwing         float64               `json:"probability_swing"`           // Cumulative win probability contribution
-	ProbabilitySwingPerRound float64               `json:"probability_swing_per_round"` // Average swing per round
-	EcoAdjustedKills         float64               `json:"eco_adjusted_kills"`          // Kills weighted by duel difficulty
-	SwingRating              float64               `json:"swing_rating"`                // Swing contribution to final rating
+	ProbabilitySwing         float64               `json:"probability_swing" desc:"Cumulative round win probability change credited to the player" formula:"rating/swing"`                        // Cumulative win probability contribution
+	ProbabilitySwingPerRound float64               `json:"probability_swing_per_round" desc:"Average probability swing per round" formula:"probability_swing / rounds_played"`                    // Average swing per round
+	EcoAdjustedKills         float64               `json:"eco_adjusted_kills" desc:"Kills weighted by duel difficulty" formula:"sum(swing economy multiplier)"`                                   // Kills weighted by duel difficulty
+	SwingRating              float64               `json:"swing_rating" desc:"Probability swing as a rating, clamped to 0.5-1.5" formula:"1 + probability_swing_per_round * 10"`                  // Swing contribution to final rating
+	EconomySwing             float64               `json:"economy_swing" desc:"Next-round win probability change credited through the economy" formula:"parser/economy_forecast.go"`              // Next-round win probability effect through the economy
+	EconomySwingPerRound     float64               `json:"economy_swing_per_round" desc:"Average economy swing per round" formula:"economy_swing / rounds_played"`                                // Average economy swing per round
+	RoundsThrown             int                   `json:"rounds_thrown" desc:"Rounds lost after the team passed 90% win probability" formula:"parser/throw_detector.go"`                         // Rounds lost after the team passed the throw threshold
+	ThrowDeaths              int                   `json:"throw_deaths" desc:"Thrown rounds where the player's death lowered the team's win probability"`                                         // Thrown rounds where the player's death lowered the win probability
+	ThrowProbabilityLost     float64               `json:"throw_probability_lost" desc:"Win probability lost by those deaths"`                                                                    // Win probability lost by those deaths
+	GarbageTimeRounds        int                   `json:"garbage_time_rounds" desc:"Rounds started with the leader on 10+ rounds and 8+ ahead" formula:"parser/garbage_time.go isGarbageTime"`   // Rounds started with the match already decided
+	GarbageTimeKills         int                   `json:"garbage_time_kills" desc:"Kills in garbage-time rounds"`                                                                                // Kills in garbage-time rounds
+	GarbageTimeDamage        int                   `json:"garbage_time_damage" desc:"Damage in garbage-time rounds"`                                                                              // Damage in garbage-time rounds
+	RatingExclGarbageTime    float64               `json:"rating_excl_garbage_time" desc:"Eco-rating over rounds outside garbage time" formula:"parser/garbage_time.go computeGarbageTimeRating"` // Eco-rating over the rounds before garbage time
+	Throws                   []ThrowRound          `json:"-"`
+	Grenades                 []GrenadeThrow        `json:"-"`
 	RoundBreakdowns          []RoundSwingBreakdown `json:"-"`
+	Clutches                 []ClutchDescriptor    `json:"-"`
 	RatingBreakdown          RatingBreakdown       `json:"-"`
+	KillsByVictim            map[uint64]int        `json:"-"` // Kills on each victim by SteamID, for kill quality
+	TZoneTime                map[string]float64    `json:"-"` // Seconds of live T-side round time in each named map zone
+	CTZoneTime               map[string]float64    `json:"-"` // Seconds of live CT-side round time in each named map zone
+	OpeningWinsByMatchup     map[string]int        `json:"-"` // Opening duels won, by weapon matchup, e.g. "awp_vs_rifle"
+	OpeningLossesByMatchup   map[string]int        `json:"-"` // Opening duels lost, by weapon matchup from the player's side
+
+	// Stat padding (parser/stat_padding.go): kills that flatter K/D more than
+	// they help win rounds
+	KillsVsEco           int     `json:"kills_vs_eco" desc:"Kills on players whose team was on an eco, or who had only a pistol outside pistol rounds" formula:"parser/stat_padding.go isKillVsEco"`
+	KillsVsEcoPct        float64 `json:"kills_vs_eco_pct" desc:"Share of kills against ecos and pistol buys" formula:"kills_vs_eco / kills"`
+	DecidedRoundKills    int     `json:"decided_round_kills" desc:"Exit frags and kills in garbage-time rounds"`
+	DecidedRoundKillsPct float64 `json:"decided_round_kills_pct" desc:"Share of kills in already decided rounds" formula:"decided_round_kills / kills"`
+	PaddingKills         int     `json:"padding_kills" desc:"Kills against an eco, in an already decided round, or both"`
+	StatPaddingIndex     float64 `json:"stat_padding_index" desc:"Share of kills against ecos or in already decided rounds; read K/D with more skepticism the higher it is" formula:"padding_kills / kills"`
 }
@@ -47,7 +47,9 @@ type RoundStats struct {
 	ClutchAttempt      bool
 	ClutchWon          bool
 	ClutchSize         int
-	ClutchEnteredSize  int // Number of enemies when player entered clutch (0 = not in clutch)
+	ClutchEnteredSize  int               // Number of enemies when player entered clutch (0 = not in clutch)
+	Clutch             *ClutchDescriptor // Clutch situation snapshot, set on entry
+	ClutchOver         bool              // Clutcher died; later kills aren't part of the sequence
 	SavedWeapons       bool
 	EcoKill            bool
 	AntiEcoKill        bool
@@ -79,6 +81,8 @@ type RoundStats struct {
 	HadAWP             bool
 	LostAWP            bool
 	IsPistolRound      bool
+	IsOvertime         bool
+	Importance         float64 // Round importance weight applied to the round's swing
 	PlayerSide         string
 
 	// Utility tracking per round (demoScrape2 compatibility)
@@ -93,9 +97,33 @@ type RoundStats struct {
 
 	// Probability-based swing tracking (new for v3.0)
 	ProbabilitySwing   float64             // Win probability delta contribution
+	KillSwing          float64             // Raw win probability gained by this player's kills
 	LastDeathSwing     float64             // Most recent death swing (for trade refund calculation)
 	EquipmentValue     float64             // Player's equipment value at round start
 	SwingContributions []SwingContribution // Detailed swing events for this round
+
+	// Economy forecast tracking: next-round win probability effect
+	EconomySwing   float64 // Change in the team's next-round win probability credited to this player
+	DeathEquipment float64 // Equipment value carried when the player died
+	KilledBy       uint64  // SteamID64 of the killer (0 = survived or no killer)
+
+	ThrownRound bool   // Player's team lost after passing the throw win probability threshold
+	GarbageTime bool   // Round started with the match already decided
+	BuyType     string // Team's buy this round: "pistol", "eco", "force" or "full"
+
+	// Loadout snapshot at the end of freeze time
+	StartMoney    int    // Money before buying: cash left plus money spent this round
+	PrimaryWeapon string // Best gun held, e.g. "AWP", or the pistol if nothing better ("" = none)
+	Armor         int    // Armor points
+
+	TradedBy     uint64 // SteamID64 of the teammate who traded this player's death (0 = untraded)
+	EntryContact bool   // Killed or died in the round's first kills
+
+	// Site anchoring (parser/anchoring.go)
+	CTSamples     int     // Position samples taken alive on the CT side
+	SiteSamples   int     // CT samples taken inside a bomb site
+	SiteContact   bool    // A T reached the site the player held before a plant
+	SiteContactAt float64 // Time in round of that first contact
 }
 
 // SwingContribution captures a single event's impact on probability swing.
@@ -0,0 +1,31 @@
+package model
+
+// SwingAuditRound is one round's swing-affecting events in the order they
+// happened, for checking the swing model against concrete rounds.
+type SwingAuditRound struct {
+	RoundNumber       int               `json:"round_number"`
+	StartTProbability float64           `json:"start_t_probability"` // T-side win probability at freeze time end
+	EndTProbability   float64           `json:"end_t_probability"`   // T-side win probability after the last event
+	Winner            string            `json:"winner,omitempty"`    // "T" or "CT"
+	Events            []SwingAuditEvent `json:"events"`
+}
+
+// SwingAuditEvent is one event that moved the round win probability.
+// Probabilities are from Player's side; Credit is the swing the rating
+// credited to Player for it, after economy adjustment, sharing with damage
+// and flash contributors, and the round's importance weight. A victim's charge is as of the kill, before
+// any refund for the death being traded.
+type SwingAuditEvent struct {
+	Time              float64 `json:"time"` // Seconds into the round
+	Type              string  `json:"type"` // "kill", "bomb_plant", "bomb_defuse", "defuse_denial"
+	Player            string  `json:"player"`
+	PlayerSteamID     string  `json:"player_steam_id"`
+	Side              string  `json:"side"`
+	Opponent          string  `json:"opponent,omitempty"` // Victim of a kill
+	OpponentSteamID   string  `json:"opponent_steam_id,omitempty"`
+	ProbabilityBefore float64 `json:"probability_before"`
+	ProbabilityAfter  float64 `json:"probability_after"`
+	Delta             float64 `json:"delta"`
+	Credit            float64 `json:"credit"`
+	OpponentCredit    float64 `json:"opponent_credit,omitempty"` // Swing charged to the victim (negative)
+}
@@ -0,0 +1,56 @@
+package model
+
+// TeamStats contains aggregated statistics for one team. Raw counts are
+// summed across games; the rates below them are derived by the aggregator
+// once all games are added.
+type TeamStats struct {
+	Name    string   `json:"name"`
+	Tier    string   `json:"tier"`
+	Players []string `json:"players"` // Steam IDs that played for the team
+
+	GamesPlayed int `json:"games_played"`
+	GamesWon    int `json:"games_won"`
+
+	RoundsPlayed   int `json:"rounds_played"`
+	RoundsWon      int `json:"rounds_won"`
+	TRoundsPlayed  int `json:"t_rounds_played"`
+	TRoundsWon     int `json:"t_rounds_won"`
+	CTRoundsPlayed int `json:"ct_rounds_played"`
+	CTRoundsWon    int `json:"ct_rounds_won"`
+
+	PistolRoundsPlayed int `json:"pistol_rounds_played"`
+	PistolRoundsWon    int `json:"pistol_rounds_won"`
+	PistolConversions  int `json:"pistol_conversions"` // Won pistols followed by a won second round
+	RoundsThrown       int `json:"rounds_thrown"`      // Rounds lost after passing the throw win probability threshold
+
+	Kills         int `json:"kills"`
+	Deaths        int `json:"deaths"`
+	OpeningKills  int `json:"opening_kills"`
+	OpeningDeaths int `json:"opening_deaths"`
+	TradeKills    int `json:"trade_kills"`
+	TradedDeaths  int `json:"traded_deaths"`
+
+	FlashesThrown  int `json:"flashes_thrown"`
+	SmokesThrown   int `json:"smokes_thrown"`
+	HEsThrown      int `json:"hes_thrown"`
+	MolotovsThrown int `json:"molotovs_thrown"`
+	FlashAssists   int `json:"flash_assists"`
+	UtilityDamage  int `json:"utility_damage"`
+
+	PlayerRounds int     `json:"-"` // Rounds summed over players, used to weight ratings
+	RatingSum    float64 `json:"-"` // Sum of player rating * rounds
+
+	GameWinPct            float64 `json:"game_win_pct"`
+	RoundWinPct           float64 `json:"round_win_pct"`
+	TRoundWinPct          float64 `json:"t_round_win_pct"`
+	CTRoundWinPct         float64 `json:"ct_round_win_pct"`
+	PistolWinPct          float64 `json:"pistol_win_pct"`
+	PistolConversionPct   float64 `json:"pistol_conversion_pct"`
+	OpeningSuccessPct     float64 `json:"opening_success_pct"`
+	TradedDeathsPct       float64 `json:"traded_deaths_pct"`
+	TradeKillsPct         float64 `json:"trade_kills_pct"`
+	UtilityThrownPerRound float64 `json:"utility_thrown_per_round"`
+	UtilityDamagePerRound float64 `json:"utility_damage_per_round"`
+	FlashAssistsPerRound  float64 `json:"flash_assists_per_round"`
+	EcoRating             float64 `json:"eco_rating"` // Rounds-weighted average of the players' final ratings
+}
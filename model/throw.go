@@ -0,0 +1,25 @@
+package model
+
+// ThrowRound describes a round a team lost after its win probability passed
+// the throw threshold, with the events that brought the probability down.
+type ThrowRound struct {
+	MatchID         string       `json:"match_id,omitempty"`
+	Map             string       `json:"map,omitempty"`
+	RoundNumber     int          `json:"round_number"`
+	Team            string       `json:"team"`
+	Side            string       `json:"side"`
+	PeakProbability float64      `json:"peak_probability"`
+	PeakTime        float64      `json:"peak_time"` // Seconds into the round
+	Collapse        []ThrowEvent `json:"collapse"`
+}
+
+// ThrowEvent is one event after the peak that lowered the throwing team's
+// win probability. Player is on the throwing team (the victim of a kill, or
+// empty for bomb events); Opponent is the player on the other side.
+type ThrowEvent struct {
+	Time     float64 `json:"time"`
+	Type     string  `json:"type"` // "death", "bomb_plant", "bomb_defuse"
+	Player   string  `json:"player,omitempty"`
+	Opponent string  `json:"opponent,omitempty"`
+	Drop     float64 `json:"drop"` // Win probability lost by the throwing team
+}
@@ -0,0 +1,142 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AwardCriteria are the qualification rules a player must meet to be ranked
+// in an award race.
+type AwardCriteria struct {
+	MinRounds      int     // Rounds played in the tier
+	RequireRoster  bool    // Player must be on an active roster
+	RookiesOnly    bool    // Player must be in their first season
+	MinAWPKillsPct float64 // Share of kills with the AWP (0-1)
+}
+
+// Award is an end-of-season award race. Qualified players are ranked by Score.
+type Award struct {
+	Name       string
+	Slug       string // File-safe identifier used for the standings export
+	ScoreLabel string
+	Criteria   AwardCriteria
+	Score      func(p *AggregatedStats) float64
+}
+
+// DefaultAwards returns the MVP, Best AWPer, and Rookie of the Year races.
+// Every race requires minRounds in the tier and an active roster spot.
+func DefaultAwards(minRounds int, minAWPKillsPct float64) []Award {
+	return []Award{
+		{
+			Name:       "MVP",
+			Slug:       "mvp",
+			ScoreLabel: "Final Rating",
+			Criteria:   AwardCriteria{MinRounds: minRounds, RequireRoster: true},
+			Score:      func(p *AggregatedStats) float64 { return p.FinalRating },
+		},
+		{
+			Name:       "Best AWPer",
+			Slug:       "best_awper",
+			ScoreLabel: "AWP Kills/Round",
+			Criteria:   AwardCriteria{MinRounds: minRounds, RequireRoster: true, MinAWPKillsPct: minAWPKillsPct},
+			Score:      func(p *AggregatedStats) float64 { return p.AWPKillsPerRound },
+		},
+		{
+			Name:       "Rookie of the Year",
+			Slug:       "rookie_of_the_year",
+			ScoreLabel: "Final Rating",
+			Criteria:   AwardCriteria{MinRounds: minRounds, RequireRoster: true, RookiesOnly: true},
+			Score:      func(p *AggregatedStats) float64 { return p.FinalRating },
+		},
+	}
+}
+
+// AwardEligibility lists the players that satisfy roster and rookie status.
+// A nil Rostered set means roster status isn't checked.
+type AwardEligibility struct {
+	Rostered map[string]bool // SteamIDs on an active roster
+	Rookies  map[string]bool // SteamIDs in their first season
+}
+
+// AwardStanding is one player's position in an award race. Players that don't
+// yet qualify are listed after the qualified field with the reason.
+type AwardStanding struct {
+	Award     string  `json:"award"`
+	Tier      string  `json:"tier"`
+	Rank      int     `json:"rank"` // 0 when not qualified
+	SteamID   string  `json:"steam_id"`
+	Name      string  `json:"name"`
+	Games     int     `json:"games"`
+	Rounds    int     `json:"rounds"`
+	Score     float64 `json:"score"`
+	Qualified bool    `json:"qualified"`
+	Reason    string  `json:"reason,omitempty"`
+}
+
+// ComputeAwardStandings ranks every player in each tier for an award.
+// Players blocked by roster or rookie status are omitted; players short on
+// rounds or AWP share are listed as unqualified so the race can be followed
+// during the season. Scrim games never count toward awards.
+func ComputeAwardStandings(players map[string]*AggregatedStats, award Award, elig AwardEligibility) []AwardStanding {
+	var standings []AwardStanding
+	for key, p := range players {
+		tier := tierFromKey(key)
+		if tier == "scrim" {
+			continue
+		}
+		if award.Criteria.RequireRoster && elig.Rostered != nil && !elig.Rostered[p.SteamID] {
+			continue
+		}
+		if award.Criteria.RookiesOnly && !elig.Rookies[p.SteamID] {
+			continue
+		}
+
+		var reasons []string
+		if p.RoundsPlayed < award.Criteria.MinRounds {
+			reasons = append(reasons, fmt.Sprintf("needs %d more rounds", award.Criteria.MinRounds-p.RoundsPlayed))
+		}
+		if award.Criteria.MinAWPKillsPct > 0 && p.AWPKillsPct < award.Criteria.MinAWPKillsPct {
+			reasons = append(reasons, fmt.Sprintf("AWP kill share %.0f%% below %.0f%%",
+				p.AWPKillsPct*100, award.Criteria.MinAWPKillsPct*100))
+		}
+
+		standings = append(standings, AwardStanding{
+			Award:     award.Name,
+			Tier:      tier,
+			SteamID:   p.SteamID,
+			Name:      p.Name,
+			Games:     p.GamesCount,
+			Rounds:    p.RoundsPlayed,
+			Score:     award.Score(p),
+			Qualified: len(reasons) == 0,
+			Reason:    strings.Join(reasons, "; "),
+		})
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Tier != b.Tier {
+			return a.Tier < b.Tier
+		}
+		if a.Qualified != b.Qualified {
+			return a.Qualified
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.SteamID < b.SteamID
+	})
+
+	rank, tier := 0, ""
+	for i := range standings {
+		if standings[i].Tier != tier {
+			rank, tier = 0, standings[i].Tier
+		}
+		if standings[i].Qualified {
+			rank++
+			standings[i].Rank = rank
+		}
+	}
+	return standings
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// CollectClutches returns every clutch attempt in a game, tagged with the
+// match and map.
+func CollectClutches(matchID, mapName string, players map[uint64]*model.PlayerStats) []model.ClutchDescriptor {
+	var clutches []model.ClutchDescriptor
+	for _, p := range players {
+		for _, c := range p.Clutches {
+			c.MatchID = matchID
+			c.Map = mapName
+			clutches = append(clutches, c)
+		}
+	}
+	SortClutches(clutches)
+	return clutches
+}
+
+// SortClutches orders clutches as "clutch of the week" candidates: wins
+// first, then more opponents, more clutch kills, and less time remaining.
+func SortClutches(clutches []model.ClutchDescriptor) {
+	sort.SliceStable(clutches, func(i, j int) bool {
+		a, b := clutches[i], clutches[j]
+		if a.Won != b.Won {
+			return a.Won
+		}
+		if a.Opponents != b.Opponents {
+			return a.Opponents > b.Opponents
+		}
+		if a.ClutchKills != b.ClutchKills {
+			return a.ClutchKills > b.ClutchKills
+		}
+		if a.TimeRemaining != b.TimeRemaining {
+			return a.TimeRemaining < b.TimeRemaining
+		}
+		if a.MatchID != b.MatchID {
+			return a.MatchID < b.MatchID
+		}
+		return a.RoundNumber < b.RoundNumber
+	})
+}
@@ -0,0 +1,101 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Table holds rows of one struct type column by column: each field becomes
+// a typed slice, named by its csv tag, so large per-round datasets stay in
+// one compact layout until they are exported.
+//
+// Fields may be bool, int, float64 or string.
+type Table struct {
+	rowType reflect.Type
+	columns []*Column
+	rows    int
+}
+
+// Column is one field of a Table's rows. Only the slice matching Kind is
+// populated.
+type Column struct {
+	Name    string
+	Kind    reflect.Kind
+	Bools   []bool
+	Ints    []int
+	Floats  []float64
+	Strings []string
+}
+
+// NewTable creates an empty table for rows shaped like row, which must be a
+// struct whose fields all have a supported type.
+func NewTable(row any) *Table {
+	t := reflect.TypeOf(row)
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("output: table row must be a struct, got %s", t))
+	}
+	table := &Table{rowType: t}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch f.Type.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Float64, reflect.String:
+		default:
+			panic(fmt.Sprintf("output: unsupported table column %s of type %s", f.Name, f.Type))
+		}
+		name := f.Tag.Get("csv")
+		if name == "" {
+			name = f.Name
+		}
+		table.columns = append(table.columns, &Column{Name: name, Kind: f.Type.Kind()})
+	}
+	return table
+}
+
+// Append adds a row, which must be of the table's row type.
+func (t *Table) Append(row any) {
+	v := reflect.ValueOf(row)
+	if v.Type() != t.rowType {
+		panic(fmt.Sprintf("output: appending %s to a table of %s", v.Type(), t.rowType))
+	}
+	for i, c := range t.columns {
+		f := v.Field(i)
+		switch c.Kind {
+		case reflect.Bool:
+			c.Bools = append(c.Bools, f.Bool())
+		case reflect.Int:
+			c.Ints = append(c.Ints, int(f.Int()))
+		case reflect.Float64:
+			c.Floats = append(c.Floats, f.Float())
+		case reflect.String:
+			c.Strings = append(c.Strings, f.String())
+		}
+	}
+	t.rows++
+}
+
+// AppendDatasetRounds adds dataset rounds to a table created for
+// DatasetRound.
+func (t *Table) AppendDatasetRounds(rows []DatasetRound) {
+	for _, r := range rows {
+		t.Append(r)
+	}
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return t.rows
+}
+
+// Columns returns the table's columns in field order.
+func (t *Table) Columns() []*Column {
+	return t.columns
+}
+
+// ColumnNames returns the column names in field order.
+func (t *Table) ColumnNames() []string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.Name
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"math"
+	"sort"
+)
+
+// Default fixed-point solve settings.
+const (
+	DefaultSolverIterations = 100
+	DefaultSolverTolerance  = 1e-6
+)
+
+// SolverOptions bounds a fixed-point solve.
+type SolverOptions struct {
+	MaxIterations int     // Iterations before giving up (0 = DefaultSolverIterations)
+	Tolerance     float64 // Largest value change at which the solve stops (0 = DefaultSolverTolerance)
+}
+
+// ConvergenceReport records how a fixed-point solve went.
+type ConvergenceReport struct {
+	Solve         string    `json:"solve"`
+	Values        int       `json:"values"`
+	Iterations    int       `json:"iterations"`
+	Converged     bool      `json:"converged"`
+	FinalChange   float64   `json:"final_change"`
+	Tolerance     float64   `json:"tolerance"`
+	MaxIterations int       `json:"max_iterations"`
+	Changes       []float64 `json:"changes"` // Largest value change after each iteration
+}
+
+// SolveFixedPoint iterates step from seed until no value moves by more than
+// the tolerance, or the iteration limit is hit. Each step receives the
+// current values and returns the next ones; keys step leaves out keep their
+// value.
+//
+// The solve is deterministic: it starts from seed rather than anything
+// random, and measures changes in sorted key order. step must itself be
+// deterministic, so it shouldn't accumulate floats in map order.
+func SolveFixedPoint(name string, seed map[string]float64, step func(map[string]float64) map[string]float64, opts SolverOptions) (map[string]float64, ConvergenceReport) {
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultSolverIterations
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = DefaultSolverTolerance
+	}
+	report := ConvergenceReport{
+		Solve:         name,
+		Values:        len(seed),
+		Tolerance:     opts.Tolerance,
+		MaxIterations: opts.MaxIterations,
+	}
+
+	keys := make([]string, 0, len(seed))
+	values := make(map[string]float64, len(seed))
+	for k, v := range seed {
+		keys = append(keys, k)
+		values[k] = v
+	}
+	sort.Strings(keys)
+
+	for report.Iterations < opts.MaxIterations {
+		next := step(values)
+		maxChange := 0.0
+		for _, k := range keys {
+			v, ok := next[k]
+			if !ok {
+				continue
+			}
+			maxChange = math.Max(maxChange, math.Abs(v-values[k]))
+			values[k] = v
+		}
+		report.Iterations++
+		report.Changes = append(report.Changes, maxChange)
+		report.FinalChange = maxChange
+		if maxChange < opts.Tolerance {
+			report.Converged = true
+			break
+		}
+	}
+	return values, report
+}
+
+// sortedPlayerKeys returns the aggregator's player keys in sorted order, for
+// solves that must not depend on map order.
+func sortedPlayerKeys(players map[string]*AggregatedStats) []string {
+	keys := make([]string, 0, len(players))
+	for key := range players {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
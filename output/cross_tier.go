@@ -0,0 +1,81 @@
+package output
+
+import (
+	"sort"
+)
+
+// CrossTierRating combines a player's ratings from every tier they played in.
+// BlendedRating is the rounds-weighted raw rating; NormalizedRating applies the
+// tier strength coefficients first so subs can be compared across tiers.
+type CrossTierRating struct {
+	SteamID          string             `json:"steam_id"`
+	Name             string             `json:"name"`
+	Tiers            []string           `json:"tiers"`
+	Games            int                `json:"games"`
+	RoundsPlayed     int                `json:"rounds_played"`
+	TierRatings      map[string]float64 `json:"tier_ratings"`
+	TierRounds       map[string]int     `json:"tier_rounds"`
+	BlendedRating    float64            `json:"blended_rating"`
+	NormalizedRating float64            `json:"normalized_rating"`
+}
+
+// ComputeCrossTierRatings groups aggregated entries by SteamID and computes a
+// tier-normalized combined rating for each player. strength maps each tier to
+// its coefficient relative to premier; a rating earned in a tier is multiplied
+// by it to express it in premier-equivalent units. Tiers missing from strength
+// use a coefficient of 1.0. Results are sorted by normalized rating.
+func ComputeCrossTierRatings(players map[string]*AggregatedStats, strength map[string]float64) []CrossTierRating {
+	bySteamID := make(map[string]*CrossTierRating)
+	rawSum := make(map[string]float64)
+	normSum := make(map[string]float64)
+
+	for key, p := range players {
+		if p.RoundsPlayed == 0 {
+			continue
+		}
+		tier := tierFromKey(key)
+
+		ct, ok := bySteamID[p.SteamID]
+		if !ok {
+			ct = &CrossTierRating{
+				SteamID:     p.SteamID,
+				Name:        p.Name,
+				TierRatings: make(map[string]float64),
+				TierRounds:  make(map[string]int),
+			}
+			bySteamID[p.SteamID] = ct
+		}
+
+		coefficient := 1.0
+		if c, ok := strength[tier]; ok {
+			coefficient = c
+		}
+
+		ct.Tiers = append(ct.Tiers, tier)
+		ct.Games += p.GamesCount
+		ct.RoundsPlayed += p.RoundsPlayed
+		ct.TierRatings[tier] = p.FinalRating
+		ct.TierRounds[tier] = p.RoundsPlayed
+
+		rounds := float64(p.RoundsPlayed)
+		rawSum[p.SteamID] += p.FinalRating * rounds
+		normSum[p.SteamID] += p.FinalRating * coefficient * rounds
+	}
+
+	results := make([]CrossTierRating, 0, len(bySteamID))
+	for steamID, ct := range bySteamID {
+		rounds := float64(ct.RoundsPlayed)
+		ct.BlendedRating = rawSum[steamID] / rounds
+		ct.NormalizedRating = normSum[steamID] / rounds
+		sort.Strings(ct.Tiers)
+		results = append(results, *ct)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].NormalizedRating != results[j].NormalizedRating {
+			return results[i].NormalizedRating > results[j].NormalizedRating
+		}
+		return results[i].SteamID < results[j].SteamID
+	})
+	return results
+}
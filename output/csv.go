@@ -0,0 +1,135 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FullColumnPreset keeps every column of a stats export.
+const FullColumnPreset = "full"
+
+// identityColumns lead every preset so rows can be joined back to players.
+var identityColumns = []string{"Steam ID", "Name", "Tier", "League Tier", "Qualified", "Games", "Rounds Played"}
+
+// ColumnPresets are the named column subsets available for CSV exports.
+// Columns are matched by header name and kept in the export's original order;
+// names missing from a header (e.g. "Tier" in single-game exports) are skipped.
+var ColumnPresets = map[string][]string{
+	"core": {
+		"Final Rating", "HLTV Rating", "Rounds Won", "Rounds Lost",
+		"Kills", "Assists", "Deaths", "ADR", "KPR", "DPR", "KAST", "Survival",
+		"Headshot Pct", "Opening Kills", "Opening Deaths",
+		"Probability Swing Per Round", "Clutch Wins", "T Rating", "CT Rating",
+	},
+	"utility": {
+		"Final Rating", "Utility Damage", "Utility Damage Per Round",
+		"Utility Kills", "Utility Kills Per 100 Rounds",
+		"Flashes Thrown", "Flashes Thrown Per Round", "Flash Assists", "Flash Assists Per Round",
+		"Enemy Flash Duration Per Round", "Enemies Flashed",
+		"Team Flash Count", "Team Flash Duration Per Round",
+		"Smokes Thrown", "HEs Thrown", "Molotovs Thrown", "Total Nades Thrown",
+		"HE Damage", "Fire Damage",
+		"Molotov Zone Damage", "Molotov Zone Kills", "Molotov Denial Time",
+		"Execute Smokes", "Smoke Sightlines Blocked",
+	},
+	"awp": {
+		"Final Rating", "Kills", "AWP Kills", "AWP Kills Per Round", "AWP Kills Pct",
+		"Rounds With AWP Kill", "Rounds With AWP Kill Pct",
+		"AWP Multi Kill Rounds", "AWP Multi Kill Rounds Per Round",
+		"AWP Opening Kills", "AWP Opening Kills Per Round",
+		"AWP Deaths", "AWP Deaths No Kill", "Long Range AWP Kills",
+	},
+	"overview": {
+		"Final Rating", "HLTV Rating", "ADR", "KAST", "KPR", "DPR",
+		"Opening Kills Per Round", "Probability Swing Per Round",
+	},
+	"maps": {
+		"Ancient Rating", "Ancient Games", "Anubis Rating", "Anubis Games",
+		"Dust2 Rating", "Dust2 Games", "Inferno Rating", "Inferno Games",
+		"Mirage Rating", "Mirage Games", "Nuke Rating", "Nuke Games",
+		"Overpass Rating", "Overpass Games",
+	},
+	"sides": {
+		"T Rounds Played", "T Rating", "T Eco Rating", "T KAST", "T Kills", "T Deaths",
+		"T Opening Kills", "T Opening Deaths",
+		"CT Rounds Played", "CT Rating", "CT Eco Rating", "CT KAST", "CT Kills", "CT Deaths",
+		"CT Opening Kills", "CT Opening Deaths",
+	},
+	"overtime": {
+		"Final Rating", "Rounds Won", "Rounds Lost",
+		"OT Rounds Played", "OT Rating", "OT Eco Rating", "OT Kills", "OT Deaths",
+		"OT Damage", "OT KAST", "OT Clutch Rounds", "OT Clutch Wins",
+	},
+	FullColumnPreset: nil,
+}
+
+// IsIdentityColumn reports whether header is one of the identity columns
+// every preset keeps.
+func IsIdentityColumn(header string) bool {
+	for _, c := range identityColumns {
+		if c == header {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnPresetNames returns the available preset names, sorted.
+func ColumnPresetNames() []string {
+	names := make([]string, 0, len(ColumnPresets))
+	for name := range ColumnPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidColumnPreset reports whether name is a known column preset.
+func ValidColumnPreset(name string) bool {
+	_, ok := ColumnPresets[name]
+	return ok
+}
+
+// SelectColumns reduces a header and its rows to the columns in preset.
+// An empty preset is treated as "full".
+func SelectColumns(header []string, rows [][]string, preset string) ([]string, [][]string, error) {
+	if preset == "" {
+		preset = FullColumnPreset
+	}
+	columns, ok := ColumnPresets[preset]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown column preset %q (valid: %v)", preset, ColumnPresetNames())
+	}
+	if columns == nil {
+		return header, rows, nil
+	}
+
+	wanted := make(map[string]bool, len(identityColumns)+len(columns))
+	for _, c := range identityColumns {
+		wanted[c] = true
+	}
+	for _, c := range columns {
+		wanted[c] = true
+	}
+	var indices []int
+	for i, h := range header {
+		if wanted[h] {
+			indices = append(indices, i)
+		}
+	}
+
+	selectRow := func(row []string) []string {
+		out := make([]string, len(indices))
+		for i, idx := range indices {
+			if idx < len(row) {
+				out[i] = row[idx]
+			}
+		}
+		return out
+	}
+	selected := make([][]string, len(rows))
+	for i, row := range rows {
+		selected[i] = selectRow(row)
+	}
+	return selectRow(header), selected, nil
+}
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// Anonymizer replaces Steam IDs and match IDs in the public dataset with
+// salted hashes, so rows can be joined across files without identifying
+// anyone. Keep the salt private and stable between releases.
+type Anonymizer struct {
+	salt string
+}
+
+// NewAnonymizer creates an anonymizer with the given salt.
+func NewAnonymizer(salt string) *Anonymizer {
+	return &Anonymizer{salt: salt}
+}
+
+// ID returns the anonymized form of id.
+func (a *Anonymizer) ID(id string) string {
+	sum := sha256.Sum256([]byte(a.salt + id))
+	return hex.EncodeToString(sum[:8])
+}
+
+// DatasetRound is one player's round in the public dataset. The desc tags
+// become the data dictionary.
+type DatasetRound struct {
+	MatchID          string  `csv:"match_id" desc:"Anonymized match identifier"`
+	Map              string  `csv:"map" desc:"Map name"`
+	Tier             string  `csv:"tier" desc:"Competitive tier"`
+	Round            int     `csv:"round" desc:"Round number within the match, starting at 1"`
+	PlayerID         string  `csv:"player_id" desc:"Anonymized player identifier, stable across matches"`
+	Side             string  `csv:"side" desc:"Player's side: T or CT"`
+	BuyType          string  `csv:"buy_type" desc:"Team's buy: pistol, eco, force or full"`
+	PistolRound      bool    `csv:"pistol_round" desc:"First round of a half"`
+	GarbageTime      bool    `csv:"garbage_time" desc:"Round started with the leader on 10+ rounds and 8+ ahead"`
+	TeamWon          bool    `csv:"team_won" desc:"Player's team won the round"`
+	Kills            int     `csv:"kills" desc:"Kills"`
+	Assists          int     `csv:"assists" desc:"Assists"`
+	Damage           int     `csv:"damage" desc:"Damage dealt to enemies"`
+	Survived         bool    `csv:"survived" desc:"Player was alive at round end"`
+	KAST             bool    `csv:"kast" desc:"Kill, assist, survived or traded"`
+	OpeningKill      bool    `csv:"opening_kill" desc:"Got the round's first kill"`
+	OpeningDeath     bool    `csv:"opening_death" desc:"Was the round's first death"`
+	TradeKill        bool    `csv:"trade_kill" desc:"Avenged a teammate within the trade window"`
+	TradeDeath       bool    `csv:"trade_death" desc:"Death was avenged within the trade window"`
+	ClutchAttempt    bool    `csv:"clutch_attempt" desc:"Was last alive against one or more enemies"`
+	ClutchWon        bool    `csv:"clutch_won" desc:"Won the clutch"`
+	BombPlanted      bool    `csv:"bomb_planted" desc:"Planted the bomb"`
+	BombDefused      bool    `csv:"bomb_defused" desc:"Defused the bomb"`
+	ProbabilitySwing float64 `csv:"probability_swing" desc:"Change in round win probability credited to the player"`
+	EconomySwing     float64 `csv:"economy_swing" desc:"Change in next-round win probability credited to the player"`
+	Rating           float64 `csv:"rating" desc:"Eco-rating for this round alone"`
+}
+
+// DatasetPlayer is one player's aggregate line in the public dataset.
+type DatasetPlayer struct {
+	PlayerID            string  `csv:"player_id" desc:"Anonymized player identifier, matches rounds.csv"`
+	Tier                string  `csv:"tier" desc:"Competitive tier"`
+	Games               int     `csv:"games" desc:"Games played"`
+	Rounds              int     `csv:"rounds" desc:"Rounds played"`
+	Kills               int     `csv:"kills" desc:"Kills"`
+	Deaths              int     `csv:"deaths" desc:"Deaths"`
+	Assists             int     `csv:"assists" desc:"Assists"`
+	ADR                 float64 `csv:"adr" desc:"Average damage per round"`
+	KAST                float64 `csv:"kast" desc:"Share of rounds with a kill, assist, survival or trade (0-1)"`
+	OpeningKills        int     `csv:"opening_kills" desc:"Rounds with the first kill"`
+	OpeningDeaths       int     `csv:"opening_deaths" desc:"Rounds with the first death"`
+	TradeKills          int     `csv:"trade_kills" desc:"Kills avenging a teammate within the trade window"`
+	ClutchRounds        int     `csv:"clutch_rounds" desc:"Clutch situations (1vX)"`
+	ClutchWins          int     `csv:"clutch_wins" desc:"Clutch situations won"`
+	UtilityDamage       int     `csv:"utility_damage" desc:"Damage dealt with grenades"`
+	SwingPerRound       float64 `csv:"probability_swing_per_round" desc:"Average round win probability change credited per round"`
+	EconomySwing        float64 `csv:"economy_swing_per_round" desc:"Average next-round win probability change credited per round"`
+	HLTVRating          float64 `csv:"hltv_rating" desc:"HLTV 2.0 rating"`
+	EcoRating           float64 `csv:"eco_rating" desc:"Eco-rating (probability swing, ADR and KAST; see rating/rating.go)"`
+	RatingStdDev        float64 `csv:"rating_std_dev" desc:"Standard deviation of per-game eco-ratings"`
+	ExclGarbageTime     float64 `csv:"rating_excl_garbage_time" desc:"Eco-rating over rounds outside garbage time"`
+	MultiKillWeight     float64 `csv:"multi_kill_context_weight" desc:"Context-weighted multi-kill points over raw multi-kill points"`
+	RoundsWithKill      int     `csv:"rounds_with_kill" desc:"Rounds with at least one kill"`
+	RoundsWithMultiKill int     `csv:"rounds_with_multi_kill" desc:"Rounds with two or more kills"`
+}
+
+// CollectDatasetRounds returns every player round of a game as anonymized
+// dataset rows, ordered by round then player.
+func CollectDatasetRounds(anon *Anonymizer, matchID, mapName, tier string, players map[uint64]*model.PlayerStats) []DatasetRound {
+	matchID = anon.ID(matchID)
+	var rows []DatasetRound
+	for _, p := range players {
+		playerID := anon.ID(p.SteamID)
+		for _, rb := range p.RoundBreakdowns {
+			rows = append(rows, DatasetRound{
+				MatchID:          matchID,
+				Map:              mapName,
+				Tier:             tier,
+				Round:            rb.RoundNumber,
+				PlayerID:         playerID,
+				Side:             rb.PlayerSide,
+				BuyType:          rb.BuyType,
+				PistolRound:      rb.IsPistolRound,
+				GarbageTime:      rb.GarbageTime,
+				TeamWon:          rb.TeamWon,
+				Kills:            rb.Kills,
+				Assists:          rb.Assists,
+				Damage:           rb.Damage,
+				Survived:         rb.Survived,
+				KAST:             rb.KAST,
+				OpeningKill:      rb.OpeningKill,
+				OpeningDeath:     rb.OpeningDeath,
+				TradeKill:        rb.TradeKill,
+				TradeDeath:       rb.TradeDeath,
+				ClutchAttempt:    rb.ClutchAttempt,
+				ClutchWon:        rb.ClutchWon,
+				BombPlanted:      rb.BombPlanted,
+				BombDefused:      rb.BombDefused,
+				ProbabilitySwing: rb.ProbabilitySwing,
+				EconomySwing:     rb.EconomySwing,
+				Rating:           rb.Rating,
+			})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Round != rows[j].Round {
+			return rows[i].Round < rows[j].Round
+		}
+		return rows[i].PlayerID < rows[j].PlayerID
+	})
+	return rows
+}
+
+// NewDatasetPlayers converts aggregated stats into anonymized dataset rows,
+// ordered by player then tier.
+func NewDatasetPlayers(anon *Anonymizer, results map[string]*AggregatedStats) []DatasetPlayer {
+	rows := make([]DatasetPlayer, 0, len(results))
+	for key, s := range results {
+		rows = append(rows, DatasetPlayer{
+			PlayerID:            anon.ID(s.SteamID),
+			Tier:                tierFromKey(key), // s.Tier holds the team name, which would identify the player
+			Games:               s.GamesCount,
+			Rounds:              s.RoundsPlayed,
+			Kills:               s.Kills,
+			Deaths:              s.Deaths,
+			Assists:             s.Assists,
+			ADR:                 s.ADR,
+			KAST:                s.KAST,
+			OpeningKills:        s.OpeningKills,
+			OpeningDeaths:       s.OpeningDeaths,
+			TradeKills:          s.TradeKills,
+			ClutchRounds:        s.ClutchRounds,
+			ClutchWins:          s.ClutchWins,
+			UtilityDamage:       s.UtilityDamage,
+			SwingPerRound:       s.ProbabilitySwingPerRound,
+			EconomySwing:        s.EconomySwingPerRound,
+			HLTVRating:          s.HLTVRating,
+			EcoRating:           s.FinalRating,
+			RatingStdDev:        s.RatingStdDev,
+			ExclGarbageTime:     s.RatingExclGarbageTime,
+			MultiKillWeight:     s.MultiKillContextWeight,
+			RoundsWithKill:      s.RoundsWithKill,
+			RoundsWithMultiKill: s.RoundsWithMultiKill,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].PlayerID != rows[j].PlayerID {
+			return rows[i].PlayerID < rows[j].PlayerID
+		}
+		return rows[i].Tier < rows[j].Tier
+	})
+	return rows
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// DuplicateWindow is how far apart two demos with the same fingerprint can
+// be recorded and still be one match uploaded or recorded twice. A starting
+// estimate: re-uploads of a match land within the same week or the next.
+const DuplicateWindow = 14 * 24 * time.Hour
+
+// MatchFingerprint identifies a match by its map, teams, the rounds won
+// before the demo started and who won each round after, or returns "" when
+// the demo has no team names or no counted rounds to tell it apart by. Two
+// demos of one match share a fingerprint however they were recorded; two
+// matches sharing one would have to play out round for round the same.
+func MatchFingerprint(match model.MatchInfo) string {
+	key := matchKey(match)
+	if key == "" || match.Progression == "" {
+		return ""
+	}
+	return key + "|" + strconv.Itoa(match.StartRounds) + "|" + match.Progression
+}
+
+// fingerprintedDemo is a merged demo with a fingerprint.
+type fingerprintedDemo struct {
+	demo     string
+	playedAt time.Time
+}
+
+// MatchDeduper finds demos of a match already merged, so a match uploaded
+// twice or recorded by two sources is counted once.
+type MatchDeduper struct {
+	seen map[string][]fingerprintedDemo
+}
+
+// NewMatchDeduper creates an empty match deduper.
+func NewMatchDeduper() *MatchDeduper {
+	return &MatchDeduper{seen: make(map[string][]fingerprintedDemo)}
+}
+
+// Duplicate returns the name of a merged demo with the same fingerprint
+// recorded within DuplicateWindow (or with either time unknown). ok is false
+// when the demo is of a match not merged yet.
+func (m *MatchDeduper) Duplicate(match model.MatchInfo, playedAt time.Time) (demo string, ok bool) {
+	fp := MatchFingerprint(match)
+	if fp == "" {
+		return "", false
+	}
+	for _, d := range m.seen[fp] {
+		if !playedAt.IsZero() && !d.playedAt.IsZero() {
+			if gap := playedAt.Sub(d.playedAt); gap > DuplicateWindow || gap < -DuplicateWindow {
+				continue
+			}
+		}
+		return d.demo, true
+	}
+	return "", false
+}
+
+// Add records a merged demo's fingerprint.
+func (m *MatchDeduper) Add(demo string, match model.MatchInfo, playedAt time.Time) {
+	fp := MatchFingerprint(match)
+	if fp == "" {
+		return
+	}
+	m.seen[fp] = append(m.seen[fp], fingerprintedDemo{demo: demo, playedAt: playedAt})
+}
@@ -0,0 +1,275 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// discordEmbedColor is the sidebar color of match summary embeds.
+const discordEmbedColor = 0x2ecc71
+
+// discordPostInterval is the least time between two webhook posts. Discord
+// allows a channel about 30 webhook messages a minute; a cumulative run
+// parsing many demos would otherwise hit 429 Too Many Requests.
+const discordPostInterval = 2 * time.Second
+
+// TeamScore is one team's rounds won in a match.
+type TeamScore struct {
+	Name   string
+	Rounds int
+}
+
+// MatchSummary is the content of a Discord match summary post.
+type MatchSummary struct {
+	MatchID  string
+	Map      string
+	Teams    []TeamScore              // Winner first
+	Top      []*model.PlayerStats     // Three highest final ratings
+	MVP      *model.PlayerStats       // Highest-rated player on the winning team
+	Clutches []model.ClutchDescriptor // Won clutches against two or more, biggest first
+}
+
+// NewMatchSummary builds a match summary from a parsed game.
+func NewMatchSummary(matchID, mapName string, players map[uint64]*model.PlayerStats) MatchSummary {
+	s := MatchSummary{MatchID: matchID, Map: mapName}
+
+	scores := make(map[string]int)
+	ranked := make([]*model.PlayerStats, 0, len(players))
+	for _, p := range players {
+		if rounds, ok := scores[p.TeamName]; !ok || p.RoundsWon > rounds {
+			scores[p.TeamName] = p.RoundsWon
+		}
+		ranked = append(ranked, p)
+		for _, c := range p.Clutches {
+			if c.Won && c.Opponents >= 2 {
+				s.Clutches = append(s.Clutches, c)
+			}
+		}
+	}
+
+	for name, rounds := range scores {
+		s.Teams = append(s.Teams, TeamScore{Name: name, Rounds: rounds})
+	}
+	sort.Slice(s.Teams, func(i, j int) bool {
+		if s.Teams[i].Rounds != s.Teams[j].Rounds {
+			return s.Teams[i].Rounds > s.Teams[j].Rounds
+		}
+		return s.Teams[i].Name < s.Teams[j].Name
+	})
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].FinalRating != ranked[j].FinalRating {
+			return ranked[i].FinalRating > ranked[j].FinalRating
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	s.Top = ranked[:min(3, len(ranked))]
+	if len(s.Teams) > 0 {
+		for _, p := range ranked {
+			if p.TeamName == s.Teams[0].Name {
+				s.MVP = p
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(s.Clutches, func(i, j int) bool {
+		if s.Clutches[i].Opponents != s.Clutches[j].Opponents {
+			return s.Clutches[i].Opponents > s.Clutches[j].Opponents
+		}
+		return s.Clutches[i].RoundNumber < s.Clutches[j].RoundNumber
+	})
+	return s
+}
+
+// teamLabel names a team for display, since players without a known team
+// share the empty name.
+func teamLabel(name string) string {
+	if name == "" {
+		return "Unknown"
+	}
+	return name
+}
+
+// Scoreline formats the result as "Team A 13 - 10 Team B".
+func (s MatchSummary) Scoreline() string {
+	if len(s.Teams) == 2 {
+		a, b := s.Teams[0], s.Teams[1]
+		return fmt.Sprintf("%s %d - %d %s", teamLabel(a.Name), a.Rounds, b.Rounds, teamLabel(b.Name))
+	}
+	parts := make([]string, 0, len(s.Teams))
+	for _, t := range s.Teams {
+		parts = append(parts, fmt.Sprintf("%s %d", teamLabel(t.Name), t.Rounds))
+	}
+	return strings.Join(parts, " / ")
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+}
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+// message builds the webhook payload for the summary.
+func (s MatchSummary) message() discordMessage {
+	embed := discordEmbed{
+		Title:       fmt.Sprintf("%s — %s", s.Map, s.MatchID),
+		Description: "**" + s.Scoreline() + "**",
+		Color:       discordEmbedColor,
+	}
+
+	if len(s.Top) > 0 {
+		var lines []string
+		for i, p := range s.Top {
+			lines = append(lines, fmt.Sprintf("%d. %s (%s) — %.2f", i+1, p.Name, teamLabel(p.TeamName), p.FinalRating))
+		}
+		embed.Fields = append(embed.Fields, discordField{Name: "Top ratings", Value: strings.Join(lines, "\n")})
+	}
+	if s.MVP != nil {
+		embed.Fields = append(embed.Fields, discordField{
+			Name:  "MVP",
+			Value: fmt.Sprintf("%s — %.2f rating, %d/%d K/D, %.0f ADR\n_%s_", s.MVP.Name, s.MVP.FinalRating, s.MVP.Kills, s.MVP.Deaths, s.MVP.ADR, ExplainRating(s.MVP)),
+		})
+	}
+	if len(s.Clutches) > 0 {
+		var lines []string
+		for _, c := range s.Clutches[:min(5, len(s.Clutches))] {
+			lines = append(lines, fmt.Sprintf("%s 1v%d (round %d, %s)", c.Name, c.Opponents, c.RoundNumber, c.Weapon))
+		}
+		embed.Fields = append(embed.Fields, discordField{Name: "Notable clutches", Value: strings.Join(lines, "\n")})
+	}
+	return discordMessage{Embeds: []discordEmbed{embed}}
+}
+
+// DiscordNotifier posts match summaries to a Discord webhook. Posted match
+// IDs are kept in a file so a match is posted once across runs, and posts
+// are spaced discordPostInterval apart.
+type DiscordNotifier struct {
+	webhookURL string
+	client     *http.Client
+	postedPath string          // "" = posted matches aren't remembered across runs
+	posted     map[string]bool // Match IDs already posted
+	lastPost   time.Time
+}
+
+// NewDiscordNotifier creates a notifier for the given webhook URL, loading
+// the match IDs already posted from postedPath. A missing file means
+// nothing has been posted yet.
+func NewDiscordNotifier(webhookURL, postedPath string) (*DiscordNotifier, error) {
+	n := &DiscordNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		postedPath: postedPath,
+		posted:     make(map[string]bool),
+	}
+	if postedPath == "" {
+		return n, nil
+	}
+	data, err := os.ReadFile(postedPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return n, nil
+		}
+		return nil, fmt.Errorf("failed to read posted matches: %w", err)
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("failed to parse posted matches: %w", err)
+	}
+	for _, id := range ids {
+		n.posted[id] = true
+	}
+	return n, nil
+}
+
+// PostMatchSummary sends the summary to the webhook as an embed, unless its
+// match was posted before. A 429 response is retried once after the wait
+// Discord asks for.
+func (n *DiscordNotifier) PostMatchSummary(s MatchSummary) error {
+	if n.posted[s.MatchID] {
+		return nil
+	}
+	body, err := json.Marshal(s.message())
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord message: %w", err)
+	}
+	retryAfter, err := n.post(body)
+	if err == nil && retryAfter > 0 {
+		time.Sleep(retryAfter)
+		retryAfter, err = n.post(body)
+		if err == nil && retryAfter > 0 {
+			err = fmt.Errorf("discord webhook is still rate limited")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	n.posted[s.MatchID] = true
+	return n.savePosted()
+}
+
+// post sends one webhook request, waiting out discordPostInterval since the
+// previous one. It returns how long to wait before retrying when Discord
+// answers 429 Too Many Requests.
+func (n *DiscordNotifier) post(body []byte) (time.Duration, error) {
+	if wait := discordPostInterval - time.Since(n.lastPost); wait > 0 {
+		time.Sleep(wait)
+	}
+	n.lastPost = time.Now()
+	resp, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to post to discord: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := discordPostInterval
+		if secs, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs * float64(time.Second))
+		}
+		return retryAfter, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("discord webhook returned %s", resp.Status)
+	}
+	return 0, nil
+}
+
+// savePosted writes the posted match IDs to postedPath, sorted.
+func (n *DiscordNotifier) savePosted() error {
+	if n.postedPath == "" {
+		return nil
+	}
+	ids := make([]string, 0, len(n.posted))
+	for id := range n.posted {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	data, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal posted matches: %w", err)
+	}
+	if err := os.WriteFile(n.postedPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write posted matches: %w", err)
+	}
+	return nil
+}
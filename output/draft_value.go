@@ -0,0 +1,110 @@
+package output
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultActiveDutyMaps is the map pool the aggregated export writes map
+// columns for, and the draft value's map pool when none is set.
+var DefaultActiveDutyMaps = []string{
+	"de_ancient", "de_anubis", "de_dust2", "de_inferno", "de_mirage", "de_nuke", "de_overpass",
+}
+
+// DefaultConsistencyStdDev is the rating standard deviation at which the draft
+// value's consistency score reaches 0 when none is set.
+const DefaultConsistencyStdDev = 0.5
+
+// DraftValueWeights controls how the draft value score is composed.
+// The score is (RatingWeight*rating + ConsistencyWeight*consistency +
+// MapPoolWeight*mapPoolBreadth) * tierMultiplier * Scale.
+type DraftValueWeights struct {
+	RatingWeight      float64            // Weight applied to the aggregated final rating
+	ConsistencyWeight float64            // Weight applied to the consistency score (0-1)
+	MapPoolWeight     float64            // Weight applied to the map pool breadth (0-1)
+	TierMultipliers   map[string]float64 // Multiplier per tier (unknown tiers use 1.0)
+	MinMapGames       int                // Games required on a map for it to count toward breadth
+	MapPool           []string           // Maps counted toward breadth (nil = DefaultActiveDutyMaps)
+	ConsistencyStdDev float64            // Rating standard deviation scored as 0 consistency (0 = DefaultConsistencyStdDev)
+	Scale             float64            // Final scale applied to the weighted sum
+}
+
+// DraftValue is a player's draft value score along with every formula input.
+type DraftValue struct {
+	SteamID        string  `json:"steam_id"`
+	Name           string  `json:"name"`
+	Tier           string  `json:"tier"`
+	Games          int     `json:"games"`
+	Rating         float64 `json:"rating"`
+	RatingStdDev   float64 `json:"rating_std_dev"`
+	Consistency    float64 `json:"consistency"`
+	MapsPlayed     int     `json:"maps_played"`
+	MapPoolBreadth float64 `json:"map_pool_breadth"`
+	TierMultiplier float64 `json:"tier_multiplier"`
+	Value          float64 `json:"value"`
+}
+
+// ComputeDraftValues scores every aggregated player and returns them sorted by
+// value, highest first. Finalize must be called before this.
+// The tier is taken from the aggregator key rather than AggregatedStats.Tier,
+// which may have been replaced with the player's team name.
+func ComputeDraftValues(players map[string]*AggregatedStats, w DraftValueWeights) []DraftValue {
+	mapPool := w.MapPool
+	if len(mapPool) == 0 {
+		mapPool = DefaultActiveDutyMaps
+	}
+	stdDevScale := w.ConsistencyStdDev
+	if stdDevScale <= 0 {
+		stdDevScale = DefaultConsistencyStdDev
+	}
+	values := make([]DraftValue, 0, len(players))
+	for key, p := range players {
+		tier := tierFromKey(key)
+		if tier == "" {
+			tier = p.Tier
+		}
+
+		multiplier := 1.0
+		if m, ok := w.TierMultipliers[tier]; ok {
+			multiplier = m
+		}
+
+		// Consistency maps a rating standard deviation of 0 to 1.0 and
+		// anything at or above the scale to 0.
+		consistency := math.Max(0, 1-p.RatingStdDev/stdDevScale)
+		if p.GamesCount < 2 {
+			consistency = 0
+		}
+
+		mapsPlayed := 0
+		for _, mapName := range mapPool {
+			if p.MapGamesPlayed[mapName] >= w.MinMapGames {
+				mapsPlayed++
+			}
+		}
+		breadth := float64(mapsPlayed) / float64(len(mapPool))
+
+		weighted := w.RatingWeight*p.FinalRating + w.ConsistencyWeight*consistency + w.MapPoolWeight*breadth
+		values = append(values, DraftValue{
+			SteamID:        p.SteamID,
+			Name:           p.Name,
+			Tier:           tier,
+			Games:          p.GamesCount,
+			Rating:         p.FinalRating,
+			RatingStdDev:   p.RatingStdDev,
+			Consistency:    consistency,
+			MapsPlayed:     mapsPlayed,
+			MapPoolBreadth: breadth,
+			TierMultiplier: multiplier,
+			Value:          weighted * multiplier * w.Scale,
+		})
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Value != values[j].Value {
+			return values[i].Value > values[j].Value
+		}
+		return values[i].SteamID < values[j].SteamID
+	})
+	return values
+}
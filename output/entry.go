@@ -0,0 +1,60 @@
+package output
+
+import "github.com/ethsmith/eco-rating/rating"
+
+// EntryFraggerEntry is a player's row in the entry leaderboard: the entry
+// score and the opening duel numbers it is built from.
+type EntryFraggerEntry struct {
+	Tier                   string  `json:"tier"`
+	Rank                   int     `json:"rank"`
+	SteamID                string  `json:"steam_id"`
+	Name                   string  `json:"name"`
+	Games                  int     `json:"games"`
+	Rounds                 int     `json:"rounds"`
+	EntryScore             float64 `json:"entry_score"`
+	OpeningAttempts        int     `json:"opening_attempts"`
+	OpeningAttemptsPct     float64 `json:"opening_attempts_pct"`
+	OpeningSuccessPct      float64 `json:"opening_success_pct"`
+	OpeningDeathsTradedPct float64 `json:"opening_deaths_traded_pct"`
+	WinPctAfterOpeningKill float64 `json:"win_pct_after_opening_kill"`
+	FinalRating            float64 `json:"final_rating"`
+}
+
+// ComputeEntryLeaderboard ranks qualified players within each tier by entry
+// score (see rating.ComputeEntryScore), a recognition path for entry
+// fraggers apart from final rating. Finalize must be called before this.
+func ComputeEntryLeaderboard(players map[string]*AggregatedStats) []EntryFraggerEntry {
+	var entries []EntryFraggerEntry
+	for key, p := range players {
+		if !p.Qualified || p.RoundsPlayed == 0 {
+			continue
+		}
+		entries = append(entries, EntryFraggerEntry{
+			Tier:    tierFromKey(key),
+			SteamID: p.SteamID,
+			Name:    p.Name,
+			Games:   p.GamesCount,
+			Rounds:  p.RoundsPlayed,
+			EntryScore: rating.ComputeEntryScore(rating.EntryInput{
+				RoundsPlayed:          p.RoundsPlayed,
+				OpeningAttempts:       p.OpeningAttempts,
+				OpeningSuccesses:      p.OpeningSuccesses,
+				OpeningKills:          p.OpeningKills,
+				OpeningDeaths:         p.OpeningDeaths,
+				OpeningDeathsTraded:   p.OpeningDeathsTraded,
+				RoundsWonAfterOpening: p.RoundsWonAfterOpening,
+			}),
+			OpeningAttempts:        p.OpeningAttempts,
+			OpeningAttemptsPct:     p.OpeningAttemptsPct,
+			OpeningSuccessPct:      p.OpeningSuccessPct,
+			OpeningDeathsTradedPct: p.OpeningDeathsTradedPct,
+			WinPctAfterOpeningKill: p.WinPctAfterOpeningKill,
+			FinalRating:            p.FinalRating,
+		})
+	}
+
+	RankWithinGroups(entries,
+		func(e *EntryFraggerEntry) (string, float64, string) { return e.Tier, e.EntryScore, e.SteamID },
+		func(e *EntryFraggerEntry) *int { return &e.Rank })
+	return entries
+}
@@ -0,0 +1,143 @@
+package output
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// ratingComponents lists the rating formula's own components in the order
+// explanations and training focus rank them on a tie, so ties favor what the
+// rating actually weighs. Anything else ranks after them.
+var ratingComponents = []string{"round impact", "damage", "KAST"}
+
+// componentRank returns component's position in ratingComponents, or
+// len(ratingComponents) when it isn't one of them.
+func componentRank(component string) int {
+	for i, c := range ratingComponents {
+		if c == component {
+			return i
+		}
+	}
+	return len(ratingComponents)
+}
+
+// explanationRule is one templated reason a rating went up or down. Its
+// deviation is how far the player was from average, positive when it helped
+// the rating; deviations smaller than threshold aren't mentioned.
+type explanationRule struct {
+	component string // Rating component the rule reads ("" = none)
+	better    string // Label when the stat helped
+	strong    string // Label when it helped by twice the threshold or more ("" = better)
+	worse     string // Label when it hurt ("" = only ever mentioned as a strength)
+	threshold float64
+	deviation func(p *model.PlayerStats) float64
+}
+
+// explanationRules are the reasons a rating can be explained by.
+var explanationRules = []explanationRule{
+	{
+		component: "round impact",
+		better:    "high round impact", strong: "game-changing round impact", worse: "low round impact",
+		threshold: 0.05, // 0.02 win probability per round
+		deviation: func(p *model.PlayerStats) float64 { return p.RatingBreakdown.ProbabilitySwing.Contribution },
+	},
+	{
+		component: "damage",
+		better:    "high damage", strong: "heavy damage output", worse: "low damage",
+		threshold: 0.1, // 10 ADR from baseline
+		deviation: func(p *model.PlayerStats) float64 { return p.RatingBreakdown.ADR.Contribution },
+	},
+	{
+		component: "KAST",
+		better:    "consistent round involvement", worse: "low KAST",
+		threshold: 0.02, // About 6% KAST from baseline
+		deviation: func(p *model.PlayerStats) float64 { return p.RatingBreakdown.KAST.Contribution },
+	},
+	{
+		better: "winning opening duels", strong: "elite opening duels", worse: "lost opening duels",
+		threshold: 0.06, // About +1.5 net opening duels over a regulation game
+		deviation: func(p *model.PlayerStats) float64 {
+			return float64(p.OpeningKills-p.OpeningDeaths) / float64(p.RoundsPlayed)
+		},
+	},
+	{
+		better: "staying alive", worse: "high deaths",
+		threshold: 0.1,
+		deviation: func(p *model.PlayerStats) float64 {
+			return rating.BaselineDPR - float64(p.Deaths)/float64(p.RoundsPlayed)
+		},
+	},
+	{
+		better: "clutch wins", threshold: 1,
+		deviation: func(p *model.PlayerStats) float64 { return float64(p.ClutchWins) },
+	},
+	{
+		better: "multi-kill rounds", threshold: 1,
+		deviation: func(p *model.PlayerStats) float64 {
+			m := p.MultiKills
+			return float64(m.ThreeK + m.FourK + m.FiveK)
+		},
+	},
+}
+
+// explanationFactor is a rule that fired, with how many thresholds past
+// average the player was.
+type explanationFactor struct {
+	label     string
+	component string
+	strength  float64
+}
+
+// ExplainRating summarizes what drove a player's game rating from the rating
+// breakdown and a few headline stats, e.g. "Rating driven by elite opening
+// duels (+) and high damage (+), hurt by high deaths (−)". At most two
+// reasons are given each way.
+func ExplainRating(p *model.PlayerStats) string {
+	if p.RoundsPlayed == 0 {
+		return ""
+	}
+	var helped, hurt []explanationFactor
+	for _, r := range explanationRules {
+		ratio := r.deviation(p) / r.threshold
+		switch {
+		case ratio >= 1:
+			label := r.better
+			if ratio >= 2 && r.strong != "" {
+				label = r.strong
+			}
+			helped = append(helped, explanationFactor{label, r.component, ratio})
+		case ratio <= -1 && r.worse != "":
+			hurt = append(hurt, explanationFactor{r.worse, r.component, -ratio})
+		}
+	}
+
+	var parts []string
+	if len(helped) > 0 {
+		parts = append(parts, "driven by "+joinFactors(helped, "+"))
+	}
+	if len(hurt) > 0 {
+		parts = append(parts, "hurt by "+joinFactors(hurt, "−"))
+	}
+	if len(parts) == 0 {
+		return "Rating close to average across the board"
+	}
+	return "Rating " + strings.Join(parts, ", ")
+}
+
+// joinFactors lists the two strongest factors, each marked with sign.
+func joinFactors(factors []explanationFactor, sign string) string {
+	sort.SliceStable(factors, func(i, j int) bool {
+		if factors[i].strength != factors[j].strength {
+			return factors[i].strength > factors[j].strength
+		}
+		return componentRank(factors[i].component) < componentRank(factors[j].component)
+	})
+	labels := make([]string, 0, 2)
+	for _, f := range factors[:min(2, len(factors))] {
+		labels = append(labels, f.label+" ("+sign+")")
+	}
+	return strings.Join(labels, " and ")
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// perRoundSuffix turns a counting stat into a per-round rate in a goal, e.g.
+// awp_deaths_no_kill_per_round.
+const perRoundSuffix = "_per_round"
+
+// Goal is a target for one aggregated stat, e.g. "kast >= 0.72". Stat is an
+// AggregatedStats JSON field name; counting stats can take a _per_round
+// suffix to be divided by rounds played.
+type Goal struct {
+	Stat   string
+	Op     string // One of >=, >, <=, <
+	Target float64
+}
+
+// String returns the goal as written in config.
+func (g Goal) String() string {
+	return fmt.Sprintf("%s %s %s", g.Stat, g.Op, strconv.FormatFloat(g.Target, 'f', -1, 64))
+}
+
+// ParseGoal parses a goal written as "<stat> <op> <target>", with op one of
+// >=, >, <=, < (≥ and ≤ are accepted too). The stat must exist.
+func ParseGoal(s string) (Goal, error) {
+	s = strings.NewReplacer("≥", ">=", "≤", "<=").Replace(s)
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return Goal{}, fmt.Errorf("goal %q is not \"<stat> <op> <target>\"", s)
+	}
+	g := Goal{Stat: fields[0], Op: fields[1]}
+	switch g.Op {
+	case ">=", ">", "<=", "<":
+	default:
+		return Goal{}, fmt.Errorf("goal %q has unknown operator %q", s, g.Op)
+	}
+	target, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return Goal{}, fmt.Errorf("goal %q has invalid target: %w", s, err)
+	}
+	g.Target = target
+	if _, err := goalStat(&AggregatedStats{}, g.Stat); err != nil {
+		return Goal{}, err
+	}
+	return g, nil
+}
+
+// Met reports whether actual reaches the goal.
+func (g Goal) Met(actual float64) bool {
+	switch g.Op {
+	case ">=":
+		return actual >= g.Target
+	case ">":
+		return actual > g.Target
+	case "<=":
+		return actual <= g.Target
+	default:
+		return actual < g.Target
+	}
+}
+
+// GoalResult is one player's progress on one goal in one tier.
+type GoalResult struct {
+	SteamID string
+	Name    string
+	Tier    string
+	Goal    Goal
+	Actual  float64
+	Met     bool
+	Margin  float64 // How far past the target, positive when met with room to spare
+}
+
+// EvaluateGoals checks each player's goals, keyed by Steam ID, against their
+// aggregated stats in every tier they played. Results are sorted by player,
+// tier and goal order. Finalize must be called before this.
+func EvaluateGoals(players map[string]*AggregatedStats, goals map[string][]Goal) []GoalResult {
+	keys := make([]string, 0, len(players))
+	for key, p := range players {
+		if len(goals[p.SteamID]) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var results []GoalResult
+	for _, key := range keys {
+		p := players[key]
+		for _, g := range goals[p.SteamID] {
+			actual, _ := goalStat(p, g.Stat) // Stats were checked by ParseGoal
+			margin := actual - g.Target
+			if g.Op == "<=" || g.Op == "<" {
+				margin = -margin
+			}
+			results = append(results, GoalResult{
+				SteamID: p.SteamID,
+				Name:    p.Name,
+				Tier:    tierFromKey(key),
+				Goal:    g,
+				Actual:  actual,
+				Met:     g.Met(actual),
+				Margin:  margin,
+			})
+		}
+	}
+	return results
+}
+
+// goalStat reads the numeric AggregatedStats field whose JSON name is stat,
+// dividing by rounds played when stat has the _per_round suffix and no
+// field of that exact name exists.
+func goalStat(p *AggregatedStats, stat string) (float64, error) {
+	if v, ok := numericField(p, stat); ok {
+		return v, nil
+	}
+	if base, ok := strings.CutSuffix(stat, perRoundSuffix); ok {
+		if v, ok := numericField(p, base); ok {
+			if p.RoundsPlayed == 0 {
+				return 0, nil
+			}
+			return v / float64(p.RoundsPlayed), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown goal stat %q", stat)
+}
+
+// numericField returns the int or float field of p with JSON name name.
+func numericField(p *AggregatedStats, name string) (float64, bool) {
+	v := reflect.ValueOf(p).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Int, reflect.Int64:
+			return float64(f.Int()), true
+		case reflect.Float64:
+			return f.Float(), true
+		}
+		return 0, false
+	}
+	return 0, false
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// CollectGrenades returns every grenade thrown in a game, tagged with the
+// match and map, in round and throw order.
+func CollectGrenades(matchID, mapName string, players map[uint64]*model.PlayerStats) []model.GrenadeThrow {
+	var grenades []model.GrenadeThrow
+	for _, p := range players {
+		for _, g := range p.Grenades {
+			g.MatchID = matchID
+			g.Map = mapName
+			grenades = append(grenades, g)
+		}
+	}
+	sortGrenades(grenades)
+	return grenades
+}
+
+// GrenadesByMap groups grenade throws by map, each map's throws ordered by
+// match, round and throw time.
+func GrenadesByMap(grenades []model.GrenadeThrow) map[string][]model.GrenadeThrow {
+	byMap := make(map[string][]model.GrenadeThrow)
+	for _, g := range grenades {
+		byMap[g.Map] = append(byMap[g.Map], g)
+	}
+	for _, throws := range byMap {
+		sortGrenades(throws)
+	}
+	return byMap
+}
+
+func sortGrenades(grenades []model.GrenadeThrow) {
+	sort.SliceStable(grenades, func(i, j int) bool {
+		a, b := grenades[i], grenades[j]
+		if a.MatchID != b.MatchID {
+			return a.MatchID < b.MatchID
+		}
+		if a.RoundNumber != b.RoundNumber {
+			return a.RoundNumber < b.RoundNumber
+		}
+		return a.Time < b.Time
+	})
+}
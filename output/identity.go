@@ -0,0 +1,98 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IdentityAccounts lists the Steam accounts and in-game names one player
+// plays under. The first Steam ID is the canonical one stats are reported
+// under.
+type IdentityAccounts struct {
+	SteamIDs []string `json:"steam_ids"`
+	Names    []string `json:"names"` // Matched, case-insensitively, only for accounts not in any steam_ids
+}
+
+// Identities maps alternate accounts and names to each player's canonical
+// Steam ID and display name, so aggregation merges a player's games however
+// they were played.
+type Identities struct {
+	bySteamID map[string]identity
+	byName    map[string]identity
+}
+
+// identity is a player's canonical Steam ID and display name.
+type identity struct {
+	steamID string
+	name    string
+}
+
+// NewIdentities builds the mapping from a table keyed by canonical display
+// name. Each player needs at least one Steam ID, and no account or name may
+// belong to two players.
+func NewIdentities(players map[string]IdentityAccounts) (*Identities, error) {
+	ids := &Identities{bySteamID: make(map[string]identity), byName: make(map[string]identity)}
+	for name, accounts := range players {
+		if len(accounts.SteamIDs) == 0 {
+			return nil, fmt.Errorf("identity %q has no steam_ids", name)
+		}
+		id := identity{steamID: accounts.SteamIDs[0], name: name}
+		for _, steamID := range accounts.SteamIDs {
+			if other, ok := ids.bySteamID[steamID]; ok && other != id {
+				return nil, fmt.Errorf("steam ID %s is listed for both %q and %q", steamID, other.name, name)
+			}
+			ids.bySteamID[steamID] = id
+		}
+		for _, alias := range accounts.Names {
+			key := strings.ToLower(strings.TrimSpace(alias))
+			if other, ok := ids.byName[key]; ok && other != id {
+				return nil, fmt.Errorf("name %q is listed for both %q and %q", alias, other.name, name)
+			}
+			ids.byName[key] = id
+		}
+	}
+	return ids, nil
+}
+
+// LoadIdentities reads an identity mapping, keyed by canonical display name,
+// from a JSON file:
+//
+//	{"Alice": {"steam_ids": ["76561198000000001", "76561198000000002"], "names": ["al1ce"]}}
+func LoadIdentities(path string) (*Identities, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identities: %w", err)
+	}
+	var players map[string]IdentityAccounts
+	if err := json.Unmarshal(data, &players); err != nil {
+		return nil, fmt.Errorf("failed to parse identities: %w", err)
+	}
+	return NewIdentities(players)
+}
+
+// Len returns the number of players with a mapped identity.
+func (ids *Identities) Len() int {
+	players := make(map[identity]bool)
+	for _, id := range ids.bySteamID {
+		players[id] = true
+	}
+	return len(players)
+}
+
+// Resolve returns the canonical Steam ID and display name for an account
+// seen under name. Accounts are matched by Steam ID first, then by name;
+// unmapped accounts are returned unchanged.
+func (ids *Identities) Resolve(steamID, name string) (string, string) {
+	if ids == nil {
+		return steamID, name
+	}
+	if id, ok := ids.bySteamID[steamID]; ok {
+		return id.steamID, id.name
+	}
+	if id, ok := ids.byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return id.steamID, id.name
+	}
+	return steamID, name
+}
@@ -0,0 +1,71 @@
+package output
+
+import "sort"
+
+// IGLRating is a player's rating alongside the IGL-adjusted view.
+// Non-IGLs have an adjustment of 0 so both ranks can be compared in one table.
+type IGLRating struct {
+	Tier           string  `json:"tier"`
+	SteamID        string  `json:"steam_id"`
+	Name           string  `json:"name"`
+	Roster         string  `json:"roster,omitempty"`
+	IGL            bool    `json:"igl"`
+	Games          int     `json:"games"`
+	Rating         float64 `json:"rating"`
+	Adjustment     float64 `json:"adjustment"`
+	AdjustedRating float64 `json:"adjusted_rating"`
+	Rank           int     `json:"rank"`
+	AdjustedRank   int     `json:"adjusted_rank"`
+
+	// TeamSwingPerRound is the team's win probability swing while the
+	// player was alive, per round: a mid-round calling proxy that, unlike
+	// the rating, credits the team's kills as well as the player's own.
+	TeamSwingPerRound float64 `json:"team_swing_while_alive_per_round"`
+}
+
+// ComputeIGLRatings builds the IGL-adjusted rating view for every player.
+// igls maps an IGL's SteamID to their roster name. Ranks are within a tier.
+func ComputeIGLRatings(players map[string]*AggregatedStats, igls map[string]string, adjustment float64) []IGLRating {
+	ratings := make([]IGLRating, 0, len(players))
+	for key, p := range players {
+		r := IGLRating{
+			Tier:    tierFromKey(key),
+			SteamID: p.SteamID,
+			Name:    p.Name,
+			Games:   p.GamesCount,
+			Rating:  p.FinalRating,
+
+			TeamSwingPerRound: p.TeamSwingWhileAlivePerRound,
+		}
+		if roster, ok := igls[p.SteamID]; ok {
+			r.IGL = true
+			r.Roster = roster
+			r.Adjustment = adjustment
+		}
+		r.AdjustedRating = r.Rating + r.Adjustment
+		ratings = append(ratings, r)
+	}
+
+	rank := func(value func(r IGLRating) float64, set func(r *IGLRating, rank int)) {
+		sort.Slice(ratings, func(i, j int) bool {
+			if ratings[i].Tier != ratings[j].Tier {
+				return ratings[i].Tier < ratings[j].Tier
+			}
+			if value(ratings[i]) != value(ratings[j]) {
+				return value(ratings[i]) > value(ratings[j])
+			}
+			return ratings[i].SteamID < ratings[j].SteamID
+		})
+		n, tier := 0, ""
+		for i := range ratings {
+			if ratings[i].Tier != tier {
+				n, tier = 0, ratings[i].Tier
+			}
+			n++
+			set(&ratings[i], n)
+		}
+	}
+	rank(func(r IGLRating) float64 { return r.Rating }, func(r *IGLRating, n int) { r.Rank = n })
+	rank(func(r IGLRating) float64 { return r.AdjustedRating }, func(r *IGLRating, n int) { r.AdjustedRank = n })
+	return ratings
+}
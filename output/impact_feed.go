@@ -0,0 +1,113 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// ImpactPointsPerSwing converts probability swing (0-1 scale) into impact
+// points, so one point equals one percentage point of round win probability.
+const ImpactPointsPerSwing = 100.0
+
+// ImpactPoint is one player's contribution in one round, with running totals
+// for the match and the current half.
+type ImpactPoint struct {
+	RoundNumber  int     `json:"round_number"`
+	Half         int     `json:"half"`
+	SteamID      string  `json:"steam_id"`
+	Name         string  `json:"name"`
+	Team         string  `json:"team"`
+	Side         string  `json:"side"`
+	Kills        int     `json:"kills"`
+	Assists      int     `json:"assists"`
+	Damage       int     `json:"damage"`
+	BombPlanted  bool    `json:"bomb_planted"`
+	BombDefused  bool    `json:"bomb_defused"`
+	Points       float64 `json:"points"`
+	MatchPoints  float64 `json:"match_points"`
+	HalfPoints   float64 `json:"half_points"`
+	HalfRank     int     `json:"half_rank"`
+	ImpactFactor string  `json:"impact_factor"`
+}
+
+// HalfForRound returns the 1-based half a round belongs to. Regulation halves
+// are 12 rounds; each overtime is split into two halves of 3 rounds.
+func HalfForRound(roundNumber int) int {
+	if roundNumber <= rating.RegulationRounds {
+		return (roundNumber-1)/rating.RoundsPerHalf + 1
+	}
+	otHalf := rating.OvertimeLength / 2
+	return 3 + (roundNumber-rating.RegulationRounds-1)/otHalf
+}
+
+// BuildImpactFeed flattens every player's round breakdowns into a time series
+// ordered by round. HalfRank is the player's position in the half-to-date
+// leaderboard after that round, so the feed can drive live "player of the
+// half" graphics.
+func BuildImpactFeed(players map[uint64]*model.PlayerStats) []ImpactPoint {
+	var feed []ImpactPoint
+	for _, p := range players {
+		for _, rb := range p.RoundBreakdowns {
+			factor := ""
+			if len(rb.ImpactFactors) > 0 {
+				factor = rb.ImpactFactors[0]
+			}
+			feed = append(feed, ImpactPoint{
+				RoundNumber:  rb.RoundNumber,
+				Half:         HalfForRound(rb.RoundNumber),
+				SteamID:      p.SteamID,
+				Name:         p.Name,
+				Team:         p.TeamName,
+				Side:         rb.PlayerSide,
+				Kills:        rb.Kills,
+				Assists:      rb.Assists,
+				Damage:       rb.Damage,
+				BombPlanted:  rb.BombPlanted,
+				BombDefused:  rb.BombDefused,
+				Points:       rb.ProbabilitySwing * ImpactPointsPerSwing,
+				ImpactFactor: factor,
+			})
+		}
+	}
+
+	sort.Slice(feed, func(i, j int) bool {
+		if feed[i].RoundNumber != feed[j].RoundNumber {
+			return feed[i].RoundNumber < feed[j].RoundNumber
+		}
+		return feed[i].SteamID < feed[j].SteamID
+	})
+
+	matchTotals := make(map[string]float64)
+	halfTotals := make(map[string]float64)
+	currentHalf := 0
+	for start := 0; start < len(feed); {
+		end := start
+		for end < len(feed) && feed[end].RoundNumber == feed[start].RoundNumber {
+			end++
+		}
+		if feed[start].Half != currentHalf {
+			currentHalf = feed[start].Half
+			halfTotals = make(map[string]float64)
+		}
+
+		for i := start; i < end; i++ {
+			matchTotals[feed[i].SteamID] += feed[i].Points
+			halfTotals[feed[i].SteamID] += feed[i].Points
+			feed[i].MatchPoints = matchTotals[feed[i].SteamID]
+			feed[i].HalfPoints = halfTotals[feed[i].SteamID]
+		}
+		for i := start; i < end; i++ {
+			rank := 1
+			for id, total := range halfTotals {
+				if id != feed[i].SteamID && total > feed[i].HalfPoints {
+					rank++
+				}
+			}
+			feed[i].HalfRank = rank
+		}
+		start = end
+	}
+	return feed
+}
@@ -0,0 +1,165 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// JSONExport is the document written by WriteJSON. Players are ordered by
+// SteamID; each player carries one entry per tier they played in.
+type JSONExport struct {
+	Players []JSONPlayer `json:"players"`
+}
+
+// JSONPlayer groups a player's aggregated stats across tiers.
+type JSONPlayer struct {
+	SteamID string     `json:"steam_id"`
+	Name    string     `json:"name"`
+	Tiers   []JSONTier `json:"tiers"`
+}
+
+// JSONTier holds one player's stats for a single tier, with the full stat
+// columns under Stats, nested per-map and per-side views, and the player's
+// training focus within the tier.
+type JSONTier struct {
+	Tier          string              `json:"tier"`
+	Team          string              `json:"team,omitempty"`
+	Stats         *AggregatedStats    `json:"stats"`
+	Maps          map[string]JSONMap  `json:"maps"`
+	Sides         map[string]JSONSide `json:"sides"`
+	TrainingFocus []FocusSuggestion   `json:"training_focus,omitempty"`
+}
+
+// JSONMap is a player's performance on a single map.
+type JSONMap struct {
+	Games  int     `json:"games"`
+	Rating float64 `json:"rating"`
+}
+
+// JSONSide is a player's performance on one side (T or CT).
+type JSONSide struct {
+	RoundsPlayed             int     `json:"rounds_played"`
+	Kills                    int     `json:"kills"`
+	Deaths                   int     `json:"deaths"`
+	Damage                   int     `json:"damage"`
+	Survivals                int     `json:"survivals"`
+	RoundsWithMultiKill      int     `json:"rounds_with_multi_kill"`
+	OpeningKills             int     `json:"opening_kills"`
+	OpeningDeaths            int     `json:"opening_deaths"`
+	EcoKillValue             float64 `json:"eco_kill_value"`
+	ProbabilitySwing         float64 `json:"probability_swing"`
+	KAST                     float64 `json:"kast"`
+	ClutchRounds             int     `json:"clutch_rounds"`
+	ClutchWins               int     `json:"clutch_wins"`
+	ManAdvantageKills        int     `json:"man_advantage_kills"`
+	ManAdvantageKillsPct     float64 `json:"man_advantage_kills_pct"`
+	ManDisadvantageDeaths    int     `json:"man_disadvantage_deaths"`
+	ManDisadvantageDeathsPct float64 `json:"man_disadvantage_deaths_pct"`
+	Rating                   float64 `json:"rating"`
+	EcoRating                float64 `json:"eco_rating"`
+}
+
+// BuildJSONExport nests finalized aggregated stats by player, tier, map, and side.
+func BuildJSONExport(players map[string]*AggregatedStats) JSONExport {
+	keys := make([]string, 0, len(players))
+	for key := range players {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	focus := ComputeTrainingFocus(players)
+
+	var doc JSONExport
+	index := make(map[string]int)
+	for _, key := range keys {
+		agg := players[key]
+		idx, ok := index[agg.SteamID]
+		if !ok {
+			idx = len(doc.Players)
+			index[agg.SteamID] = idx
+			doc.Players = append(doc.Players, JSONPlayer{SteamID: agg.SteamID, Name: agg.Name})
+		}
+
+		tier := JSONTier{
+			Tier:  tierFromKey(key),
+			Stats: agg,
+			Maps:  make(map[string]JSONMap, len(agg.MapRatings)),
+			Sides: map[string]JSONSide{
+				"t": {
+					RoundsPlayed:             agg.TRoundsPlayed,
+					Kills:                    agg.TKills,
+					Deaths:                   agg.TDeaths,
+					Damage:                   agg.TDamage,
+					Survivals:                agg.TSurvivals,
+					RoundsWithMultiKill:      agg.TRoundsWithMultiKill,
+					OpeningKills:             agg.TOpeningKills,
+					OpeningDeaths:            agg.TOpeningDeaths,
+					EcoKillValue:             agg.TEcoKillValue,
+					ProbabilitySwing:         agg.TProbabilitySwing,
+					KAST:                     agg.TKAST,
+					ClutchRounds:             agg.TClutchRounds,
+					ClutchWins:               agg.TClutchWins,
+					ManAdvantageKills:        agg.TManAdvantageKills,
+					ManAdvantageKillsPct:     agg.TManAdvantageKillsPct,
+					ManDisadvantageDeaths:    agg.TManDisadvantageDeaths,
+					ManDisadvantageDeathsPct: agg.TManDisadvantageDeathsPct,
+					Rating:                   agg.TRating,
+					EcoRating:                agg.TEcoRating,
+				},
+				"ct": {
+					RoundsPlayed:             agg.CTRoundsPlayed,
+					Kills:                    agg.CTKills,
+					Deaths:                   agg.CTDeaths,
+					Damage:                   agg.CTDamage,
+					Survivals:                agg.CTSurvivals,
+					RoundsWithMultiKill:      agg.CTRoundsWithMultiKill,
+					OpeningKills:             agg.CTOpeningKills,
+					OpeningDeaths:            agg.CTOpeningDeaths,
+					EcoKillValue:             agg.CTEcoKillValue,
+					ProbabilitySwing:         agg.CTProbabilitySwing,
+					KAST:                     agg.CTKAST,
+					ClutchRounds:             agg.CTClutchRounds,
+					ClutchWins:               agg.CTClutchWins,
+					ManAdvantageKills:        agg.CTManAdvantageKills,
+					ManAdvantageKillsPct:     agg.CTManAdvantageKillsPct,
+					ManDisadvantageDeaths:    agg.CTManDisadvantageDeaths,
+					ManDisadvantageDeathsPct: agg.CTManDisadvantageDeathsPct,
+					Rating:                   agg.CTRating,
+					EcoRating:                agg.CTEcoRating,
+				},
+			},
+		}
+		if agg.Tier != tier.Tier {
+			tier.Team = agg.Tier
+		}
+		tier.TrainingFocus = focus[key]
+		for mapName, rating := range agg.MapRatings {
+			tier.Maps[mapName] = JSONMap{Games: agg.MapGamesPlayed[mapName], Rating: rating}
+		}
+		doc.Players[idx].Tiers = append(doc.Players[idx].Tiers, tier)
+	}
+
+	sort.Slice(doc.Players, func(i, j int) bool {
+		return doc.Players[i].SteamID < doc.Players[j].SteamID
+	})
+	return doc
+}
+
+// WriteJSON writes finalized aggregated stats to path as nested JSON.
+func WriteJSON(path string, players map[string]*AggregatedStats) error {
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	data, err := json.MarshalIndent(BuildJSONExport(players), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON export: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON export: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package output
+
+import "sort"
+
+// DefaultKillQualityWeight is how far the opponent-adjusted rating follows
+// kill quality by default.
+const DefaultKillQualityWeight = 0.5
+
+// SetKillQualityWeight sets how far OpponentAdjustedRating follows a
+// player's kill quality: 0 leaves it equal to the final rating, 1 scales the
+// final rating fully by kill quality relative to the tier's average.
+func (a *Aggregator) SetKillQualityWeight(weight float64) {
+	if weight < 0 {
+		weight = 0
+	}
+	a.killQualityWeight = weight
+}
+
+// computeKillQuality rates every player's kills by who they killed. A
+// player's KillQuality is the average rating of their victims, and their
+// OpponentAdjustedRating is the final rating scaled by KillQuality relative
+// to the tier's kill-weighted average victim rating.
+//
+// The victim ratings used are themselves opponent-adjusted, so the ratings
+// depend on each other; they are solved with SolveFixedPoint seeded with the
+// final ratings. Victims are looked up in the killer's tier and victims
+// without a rating are left out.
+func (a *Aggregator) computeKillQuality() {
+	keys := sortedPlayerKeys(a.Players)
+	seed := make(map[string]float64, len(keys))
+	for _, key := range keys {
+		seed[key] = a.Players[key].FinalRating
+	}
+
+	quality := make(map[string]float64, len(keys))
+	step := func(ratings map[string]float64) map[string]float64 {
+		// Kill-weighted average victim rating per tier
+		tierSum := make(map[string]float64)
+		tierKills := make(map[string]int)
+		for _, key := range keys {
+			tier := tierFromKey(key)
+			sum, kills := victimRatings(a.Players[key], tier, ratings)
+			if kills > 0 {
+				quality[key] = sum / float64(kills)
+			}
+			tierSum[tier] += sum
+			tierKills[tier] += kills
+		}
+
+		next := make(map[string]float64, len(keys))
+		for _, key := range keys {
+			p := a.Players[key]
+			next[key] = p.FinalRating
+			q, ok := quality[key]
+			tier := tierFromKey(key)
+			if !ok || tierKills[tier] == 0 || tierSum[tier] <= 0 {
+				continue
+			}
+			relative := q / (tierSum[tier] / float64(tierKills[tier]))
+			next[key] = p.FinalRating * (1 + a.killQualityWeight*(relative-1))
+		}
+		return next
+	}
+
+	adjusted, report := SolveFixedPoint("kill_quality", seed, step, a.solver)
+	a.convergence = append(a.convergence, report)
+
+	for _, key := range keys {
+		p := a.Players[key]
+		p.KillQuality = quality[key]
+		p.OpponentAdjustedRating = adjusted[key]
+	}
+}
+
+// victimRatings sums the ratings of a player's victims in tier, one per
+// kill, and counts the kills on rated victims. Victims are summed in sorted
+// order so the result doesn't depend on map order.
+func victimRatings(p *AggregatedStats, tier string, ratings map[string]float64) (float64, int) {
+	victims := make([]string, 0, len(p.killsByVictim))
+	for victim := range p.killsByVictim {
+		victims = append(victims, victim)
+	}
+	sort.Strings(victims)
+
+	var sum float64
+	var kills int
+	for _, victim := range victims {
+		r := ratings[victim+":"+tier]
+		if r <= 0 {
+			continue
+		}
+		n := p.killsByVictim[victim]
+		sum += r * float64(n)
+		kills += n
+	}
+	return sum, kills
+}
@@ -0,0 +1,92 @@
+package output
+
+import "github.com/ethsmith/eco-rating/rating"
+
+// PistolEntry is a player's row in the pistol leaderboard: the pistol round
+// rating overall and on each side, and the numbers behind it.
+type PistolEntry struct {
+	Tier          string  `json:"tier"`
+	Rank          int     `json:"rank"`
+	SteamID       string  `json:"steam_id"`
+	Name          string  `json:"name"`
+	Games         int     `json:"games"`
+	PistolRounds  int     `json:"pistol_rounds"`
+	PistolRating  float64 `json:"pistol_rating"`
+	PistolWinPct  float64 `json:"pistol_win_pct"`
+	KPR           float64 `json:"kpr"`
+	ADR           float64 `json:"adr"`
+	SurvivalPct   float64 `json:"survival_pct"`
+	MultiKillPct  float64 `json:"multi_kill_pct"`
+	TPistolRounds int     `json:"t_pistol_rounds"`
+	// TPistolRating and CTPistolRating are left at 0 with TRated and
+	// CTRated false when the side has fewer than the minimum side rounds.
+	TPistolRating  float64 `json:"t_pistol_rating"`
+	TRated         bool    `json:"t_rated"`
+	TPistolWinPct  float64 `json:"t_pistol_win_pct"`
+	CTPistolRounds int     `json:"ct_pistol_rounds"`
+	CTPistolRating float64 `json:"ct_pistol_rating"`
+	CTRated        bool    `json:"ct_rated"`
+	CTPistolWinPct float64 `json:"ct_pistol_win_pct"`
+	FinalRating    float64 `json:"final_rating"`
+}
+
+// ComputePistolLeaderboard ranks players with at least minRounds pistol
+// rounds within each tier by pistol round rating (see
+// rating.ComputePistolRating). Side ratings need minSideRounds pistol rounds
+// on that side. The pistol thresholds stand in for the usual qualification,
+// since a player can qualify on gun rounds with few pistol rounds. Finalize
+// must be called before this. Both minimums are at least one round.
+func ComputePistolLeaderboard(players map[string]*AggregatedStats, minRounds, minSideRounds int) []PistolEntry {
+	minRounds, minSideRounds = max(minRounds, 1), max(minSideRounds, 1)
+	var entries []PistolEntry
+	for key, p := range players {
+		if p.PistolRoundsPlayed < minRounds {
+			continue
+		}
+		rounds := float64(p.PistolRoundsPlayed)
+		e := PistolEntry{
+			Tier:           tierFromKey(key),
+			SteamID:        p.SteamID,
+			Name:           p.Name,
+			Games:          p.GamesCount,
+			PistolRounds:   p.PistolRoundsPlayed,
+			PistolRating:   p.PistolRoundRating,
+			PistolWinPct:   float64(p.PistolRoundsWon) / rounds,
+			KPR:            float64(p.PistolRoundKills) / rounds,
+			ADR:            float64(p.PistolRoundDamage) / rounds,
+			SurvivalPct:    float64(p.PistolRoundSurvivals) / rounds,
+			MultiKillPct:   float64(p.PistolRoundMultiKills) / rounds,
+			TPistolRounds:  p.TPistolRoundsPlayed,
+			TPistolWinPct:  safeDiv(p.TPistolRoundsWon, p.TPistolRoundsPlayed),
+			CTPistolRounds: p.CTPistolRoundsPlayed,
+			CTPistolWinPct: safeDiv(p.CTPistolRoundsWon, p.CTPistolRoundsPlayed),
+			FinalRating:    p.FinalRating,
+		}
+		if p.TPistolRoundsPlayed >= minSideRounds {
+			e.TRated = true
+			e.TPistolRating = rating.ComputePistolRating(rating.PistolInput{
+				RoundsPlayed:    p.TPistolRoundsPlayed,
+				Kills:           p.TPistolRoundKills,
+				Damage:          p.TPistolRoundDamage,
+				Survivals:       p.TPistolRoundSurvivals,
+				MultiKillRounds: p.TPistolRoundMultiKills,
+			})
+		}
+		if p.CTPistolRoundsPlayed >= minSideRounds {
+			e.CTRated = true
+			e.CTPistolRating = rating.ComputePistolRating(rating.PistolInput{
+				RoundsPlayed:    p.CTPistolRoundsPlayed,
+				Kills:           p.CTPistolRoundKills,
+				Damage:          p.CTPistolRoundDamage,
+				Survivals:       p.CTPistolRoundSurvivals,
+				MultiKillRounds: p.CTPistolRoundMultiKills,
+			})
+		}
+		entries = append(entries, e)
+	}
+
+	RankWithinGroups(entries,
+		func(e *PistolEntry) (string, float64, string) { return e.Tier, e.PistolRating, e.SteamID },
+		func(e *PistolEntry) *int { return &e.Rank })
+	return entries
+}
@@ -0,0 +1,19 @@
+package output
+
+// QualificationRules are the games and rounds a player needs in a tier to be
+// ranked on the leaderboard. Players below them are still exported, flagged
+// as not qualified and listed after the qualified players of their tier.
+type QualificationRules struct {
+	MinGames  int
+	MinRounds int
+}
+
+// Qualifies reports whether p meets the rules. Zero rules qualify everyone.
+func (r QualificationRules) Qualifies(p *AggregatedStats) bool {
+	return p.GamesCount >= r.MinGames && p.RoundsPlayed >= r.MinRounds
+}
+
+// SetQualification sets the rules Finalize marks players' Qualified flag by.
+func (a *Aggregator) SetQualification(rules QualificationRules) {
+	a.qualification = rules
+}
@@ -0,0 +1,29 @@
+package output
+
+import "sort"
+
+// RankWithinGroups orders leaderboard entries by group (a tier or map), then
+// by score, highest first, then by Steam ID, and numbers each entry's rank
+// within its group from 1. key returns an entry's group, score and Steam ID;
+// rank points at the entry's rank field.
+func RankWithinGroups[E any](entries []E, key func(*E) (group string, score float64, steamID string), rank func(*E) *int) {
+	sort.Slice(entries, func(i, j int) bool {
+		gi, si, idi := key(&entries[i])
+		gj, sj, idj := key(&entries[j])
+		if gi != gj {
+			return gi < gj
+		}
+		if si != sj {
+			return si > sj
+		}
+		return idi < idj
+	})
+	n, current := 0, ""
+	for i := range entries {
+		if group, _, _ := key(&entries[i]); group != current {
+			n, current = 0, group
+		}
+		n++
+		*rank(&entries[i]) = n
+	}
+}
@@ -0,0 +1,105 @@
+package output
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// RestoreWindow is how far apart two demos of the same teams on the same
+// map can be recorded and still be one match restored from a round backup.
+// A starting estimate: a crashed match is restored the same evening.
+const RestoreWindow = 12 * time.Hour
+
+// coveredMatch is the stretch of a match the demos merged so far count.
+type coveredMatch struct {
+	from, to int // Rounds starting with from to to-1 rounds won are counted
+	demo     string
+	playedAt time.Time
+}
+
+// MatchCoverage tracks which rounds of each match the merged demos count,
+// so a demo recorded again after a round backup restore doesn't count the
+// rounds it repeats twice.
+type MatchCoverage struct {
+	matches map[string][]*coveredMatch
+}
+
+// NewMatchCoverage creates an empty match coverage.
+func NewMatchCoverage() *MatchCoverage {
+	return &MatchCoverage{matches: make(map[string][]*coveredMatch)}
+}
+
+// matchKey identifies a match by map and teams, or returns "" when the demo
+// has no team names to tell its match apart by.
+func matchKey(match model.MatchInfo) string {
+	if match.Team1 == "" && match.Team2 == "" {
+		return ""
+	}
+	teams := []string{strings.ToLower(match.Team1), strings.ToLower(match.Team2)}
+	sort.Strings(teams)
+	return match.Map + "|" + teams[0] + "|" + teams[1]
+}
+
+// rounds returns the score totals the rounds a demo counts start from: from
+// the first live round to the last one completed.
+func rounds(match model.MatchInfo) (from, to int) {
+	return match.StartRounds, match.Team1Score + match.Team2Score
+}
+
+// find returns the covered match the demo belongs to, or nil. A demo belongs
+// to a match of the same map and teams recorded within RestoreWindow (or
+// with either time unknown) when one of the two starts mid-match, the
+// signature of a backup restore; two demos starting from 0-0 are two
+// matches.
+func (c *MatchCoverage) find(match model.MatchInfo, playedAt time.Time) *coveredMatch {
+	key := matchKey(match)
+	if key == "" {
+		return nil
+	}
+	from, _ := rounds(match)
+	for _, m := range c.matches[key] {
+		if from == 0 && m.from == 0 {
+			continue
+		}
+		if !playedAt.IsZero() && !m.playedAt.IsZero() {
+			if d := playedAt.Sub(m.playedAt); d > RestoreWindow || d < -RestoreWindow {
+				continue
+			}
+		}
+		return m
+	}
+	return nil
+}
+
+// Duplicates returns the rounds of the demo, as the range of score totals
+// they start from, that a demo merged before it already counts, and that
+// demo's name. ok is false when the demo repeats nothing.
+func (c *MatchCoverage) Duplicates(match model.MatchInfo, playedAt time.Time) (from, to int, demo string, ok bool) {
+	m := c.find(match, playedAt)
+	if m == nil {
+		return 0, 0, "", false
+	}
+	start, end := rounds(match)
+	from, to = max(start, m.from), min(end, m.to)
+	if from >= to {
+		return 0, 0, "", false
+	}
+	return from, to, m.demo, true
+}
+
+// Add records the rounds a merged demo counts.
+func (c *MatchCoverage) Add(demo string, match model.MatchInfo, playedAt time.Time) {
+	from, to := rounds(match)
+	if m := c.find(match, playedAt); m != nil {
+		m.from, m.to = min(m.from, from), max(m.to, to)
+		return
+	}
+	key := matchKey(match)
+	if key == "" {
+		return
+	}
+	c.matches[key] = append(c.matches[key], &coveredMatch{from: from, to: to, demo: demo, playedAt: playedAt})
+}
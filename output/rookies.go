@@ -0,0 +1,146 @@
+package output
+
+import "sort"
+
+// MarkRookies sets the Rookie flag on every aggregated entry whose SteamID is
+// in rookies.
+func MarkRookies(players map[string]*AggregatedStats, rookies map[string]bool) {
+	for _, p := range players {
+		p.Rookie = rookies[p.SteamID]
+	}
+}
+
+// RookieEntry is a first-season player's row in the rookie leaderboard, with
+// the player's rating relative to the tier's veteran baseline.
+type RookieEntry struct {
+	Tier            string  `json:"tier"`
+	Rank            int     `json:"rank"`
+	SteamID         string  `json:"steam_id"`
+	Name            string  `json:"name"`
+	Games           int     `json:"games"`
+	Rounds          int     `json:"rounds"`
+	Rating          float64 `json:"rating"`
+	ADR             float64 `json:"adr"`
+	KAST            float64 `json:"kast"`
+	VeteranBaseline float64 `json:"veteran_baseline"`
+	RatingVsVeteran float64 `json:"rating_vs_veteran"`
+}
+
+// RookieBaseline compares average rookie and veteran production in a tier.
+// Averages are rounds-weighted over players meeting the minimum rounds.
+type RookieBaseline struct {
+	Tier          string  `json:"tier"`
+	Rookies       int     `json:"rookies"`
+	Veterans      int     `json:"veterans"`
+	RookieRating  float64 `json:"rookie_rating"`
+	VeteranRating float64 `json:"veteran_rating"`
+	RatingDelta   float64 `json:"rating_delta"`
+	RookieADR     float64 `json:"rookie_adr"`
+	VeteranADR    float64 `json:"veteran_adr"`
+	RookieKAST    float64 `json:"rookie_kast"`
+	VeteranKAST   float64 `json:"veteran_kast"`
+	RookieKPR     float64 `json:"rookie_kpr"`
+	VeteranKPR    float64 `json:"veteran_kpr"`
+}
+
+// ComputeRookieBaselines returns rookie-vs-veteran averages per tier, sorted
+// by tier. MarkRookies and Finalize must be called before this.
+func ComputeRookieBaselines(players map[string]*AggregatedStats, minRounds int) []RookieBaseline {
+	type sums struct {
+		count                  int
+		rounds                 float64
+		rating, adr, kast, kpr float64
+	}
+	byTier := make(map[string]*[2]sums) // [0] rookies, [1] veterans
+
+	for key, p := range players {
+		if p.RoundsPlayed < minRounds || p.RoundsPlayed == 0 {
+			continue
+		}
+		tier := tierFromKey(key)
+		if byTier[tier] == nil {
+			byTier[tier] = &[2]sums{}
+		}
+		group := &byTier[tier][1]
+		if p.Rookie {
+			group = &byTier[tier][0]
+		}
+		w := float64(p.RoundsPlayed)
+		group.count++
+		group.rounds += w
+		group.rating += p.FinalRating * w
+		group.adr += p.ADR * w
+		group.kast += p.KAST * w
+		group.kpr += p.KPR * w
+	}
+
+	avg := func(total, rounds float64) float64 {
+		if rounds == 0 {
+			return 0
+		}
+		return total / rounds
+	}
+
+	baselines := make([]RookieBaseline, 0, len(byTier))
+	for tier, g := range byTier {
+		r, v := g[0], g[1]
+		b := RookieBaseline{
+			Tier:          tier,
+			Rookies:       r.count,
+			Veterans:      v.count,
+			RookieRating:  avg(r.rating, r.rounds),
+			VeteranRating: avg(v.rating, v.rounds),
+			RookieADR:     avg(r.adr, r.rounds),
+			VeteranADR:    avg(v.adr, v.rounds),
+			RookieKAST:    avg(r.kast, r.rounds),
+			VeteranKAST:   avg(v.kast, v.rounds),
+			RookieKPR:     avg(r.kpr, r.rounds),
+			VeteranKPR:    avg(v.kpr, v.rounds),
+		}
+		if r.count > 0 && v.count > 0 {
+			b.RatingDelta = b.RookieRating - b.VeteranRating
+		}
+		baselines = append(baselines, b)
+	}
+	sort.Slice(baselines, func(i, j int) bool {
+		return baselines[i].Tier < baselines[j].Tier
+	})
+	return baselines
+}
+
+// ComputeRookieLeaderboard ranks rookies meeting minRounds within each tier by
+// final rating. MarkRookies and Finalize must be called before this.
+func ComputeRookieLeaderboard(players map[string]*AggregatedStats, minRounds int) []RookieEntry {
+	veteranBaseline := make(map[string]float64)
+	for _, b := range ComputeRookieBaselines(players, minRounds) {
+		veteranBaseline[b.Tier] = b.VeteranRating
+	}
+
+	var entries []RookieEntry
+	for key, p := range players {
+		if !p.Rookie || p.RoundsPlayed < minRounds {
+			continue
+		}
+		tier := tierFromKey(key)
+		e := RookieEntry{
+			Tier:            tier,
+			SteamID:         p.SteamID,
+			Name:            p.Name,
+			Games:           p.GamesCount,
+			Rounds:          p.RoundsPlayed,
+			Rating:          p.FinalRating,
+			ADR:             p.ADR,
+			KAST:            p.KAST,
+			VeteranBaseline: veteranBaseline[tier],
+		}
+		if e.VeteranBaseline > 0 {
+			e.RatingVsVeteran = e.Rating - e.VeteranBaseline
+		}
+		entries = append(entries, e)
+	}
+
+	RankWithinGroups(entries,
+		func(e *RookieEntry) (string, float64, string) { return e.Tier, e.Rating, e.SteamID },
+		func(e *RookieEntry) *int { return &e.Rank })
+	return entries
+}
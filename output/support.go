@@ -0,0 +1,90 @@
+package output
+
+import "github.com/ethsmith/eco-rating/rating"
+
+// SetSupportProfiles sets who is rated on the support profile: the players
+// listed by Steam ID and, with autoDetect, anyone whose numbers fit it (see
+// rating.IsSupportProfile).
+func (a *Aggregator) SetSupportProfiles(steamIDs []string, autoDetect bool) {
+	a.supportPlayers = make(map[string]bool, len(steamIDs))
+	for _, id := range steamIDs {
+		a.supportPlayers[id] = true
+	}
+	a.supportAutoDetect = autoDetect
+}
+
+// applySupportProfile computes a player's support rating, which every
+// player gets so fraggers and supports can be compared on it, and marks
+// whether the support profile applies to them.
+func (a *Aggregator) applySupportProfile(agg *AggregatedStats) {
+	input := rating.SupportInput{
+		Rating:        agg.FinalRating,
+		RoundsPlayed:  agg.RoundsPlayed,
+		Kills:         agg.Kills,
+		UtilityDamage: agg.UtilityDamage,
+		FlashAssists:  agg.FlashAssists,
+		TradeKills:    agg.TradeKills,
+		TradedDeaths:  agg.TradedDeaths,
+	}
+	agg.SupportRating = rating.ComputeSupportRating(input)
+	agg.SupportProfile = a.supportPlayers[agg.SteamID] || (a.supportAutoDetect && rating.IsSupportProfile(input))
+}
+
+// SupportEntry is a player's row in the support leaderboard: the support
+// score and the per-round numbers it is built from.
+type SupportEntry struct {
+	Tier                  string  `json:"tier"`
+	Rank                  int     `json:"rank"`
+	SteamID               string  `json:"steam_id"`
+	Name                  string  `json:"name"`
+	Games                 int     `json:"games"`
+	Rounds                int     `json:"rounds"`
+	SupportScore          float64 `json:"support_score"`
+	UtilityDamagePerRound float64 `json:"utility_damage_per_round"`
+	FlashAssistsPerRound  float64 `json:"flash_assists_per_round"`
+	TradedDeathsPerRound  float64 `json:"traded_deaths_per_round"`
+	SavedTeammatePerRound float64 `json:"saved_teammate_per_round"`
+	EntryTradesPerRound   float64 `json:"entry_trades_per_round"`
+	SupportProfile        bool    `json:"support_profile"`
+	FinalRating           float64 `json:"final_rating"`
+}
+
+// ComputeSupportLeaderboard ranks qualified players within each tier by
+// support score (see rating.ComputeSupportScore), a recognition path apart
+// from final rating. Finalize must be called before this.
+func ComputeSupportLeaderboard(players map[string]*AggregatedStats) []SupportEntry {
+	var entries []SupportEntry
+	for key, p := range players {
+		if !p.Qualified || p.RoundsPlayed == 0 {
+			continue
+		}
+		rounds := float64(p.RoundsPlayed)
+		entries = append(entries, SupportEntry{
+			Tier:    tierFromKey(key),
+			SteamID: p.SteamID,
+			Name:    p.Name,
+			Games:   p.GamesCount,
+			Rounds:  p.RoundsPlayed,
+			SupportScore: rating.ComputeSupportScore(rating.SupportInput{
+				RoundsPlayed:   p.RoundsPlayed,
+				UtilityDamage:  p.UtilityDamage,
+				FlashAssists:   p.FlashAssists,
+				TradedDeaths:   p.TradedDeaths,
+				SavedTeammates: p.SavedTeammate,
+				EntryTrades:    p.EntryTrades,
+			}),
+			UtilityDamagePerRound: p.UtilityDamagePerRound,
+			FlashAssistsPerRound:  p.FlashAssistsPerRound,
+			TradedDeathsPerRound:  p.TradedDeathsPerRound,
+			SavedTeammatePerRound: p.SavedTeammatePerRound,
+			EntryTradesPerRound:   float64(p.EntryTrades) / rounds,
+			SupportProfile:        p.SupportProfile,
+			FinalRating:           p.FinalRating,
+		})
+	}
+
+	RankWithinGroups(entries,
+		func(e *SupportEntry) (string, float64, string) { return e.Tier, e.SupportScore, e.SteamID },
+		func(e *SupportEntry) *int { return &e.Rank })
+	return entries
+}
@@ -0,0 +1,174 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// teamKey identifies a team within a tier.
+type teamKey struct {
+	tier string
+	name string
+}
+
+// TeamAggregator accumulates team-level statistics across games. Players are
+// grouped into teams by their team name; players without one are ignored.
+type TeamAggregator struct {
+	teams   map[teamKey]*model.TeamStats
+	players map[teamKey]map[string]bool
+}
+
+// NewTeamAggregator creates an empty team aggregator.
+func NewTeamAggregator() *TeamAggregator {
+	return &TeamAggregator{
+		teams:   make(map[teamKey]*model.TeamStats),
+		players: make(map[teamKey]map[string]bool),
+	}
+}
+
+// teamRound is a single round from one team's perspective.
+type teamRound struct {
+	side   string
+	won    bool
+	pistol bool
+	thrown bool
+}
+
+// AddGame incorporates a parsed game into the team totals.
+func (t *TeamAggregator) AddGame(players map[uint64]*model.PlayerStats, tier string) {
+	rosters := make(map[string][]*model.PlayerStats)
+	for _, p := range players {
+		if p.TeamName != "" {
+			rosters[p.TeamName] = append(rosters[p.TeamName], p)
+		}
+	}
+	// Sum players in Steam ID order so the rating totals don't depend on
+	// map iteration order.
+	for _, roster := range rosters {
+		sort.Slice(roster, func(i, j int) bool { return roster[i].SteamID < roster[j].SteamID })
+	}
+
+	for name, roster := range rosters {
+		key := teamKey{tier: tier, name: name}
+		team := t.teams[key]
+		if team == nil {
+			team = &model.TeamStats{Name: name, Tier: tier}
+			t.teams[key] = team
+			t.players[key] = make(map[string]bool)
+		}
+
+		// Every player on the team sees the same rounds, so merge their
+		// breakdowns to cover rounds a disconnected player missed.
+		rounds := make(map[int]teamRound)
+		for _, p := range roster {
+			t.players[key][p.SteamID] = true
+			for _, rb := range p.RoundBreakdowns {
+				if _, ok := rounds[rb.RoundNumber]; !ok {
+					rounds[rb.RoundNumber] = teamRound{side: rb.PlayerSide, won: rb.TeamWon, pistol: rb.IsPistolRound, thrown: rb.Thrown}
+				}
+			}
+
+			team.Kills += p.Kills
+			team.Deaths += p.Deaths
+			team.OpeningKills += p.OpeningKills
+			team.OpeningDeaths += p.OpeningDeaths
+			team.TradeKills += p.TradeKills
+			team.TradedDeaths += p.TradedDeaths
+			team.FlashesThrown += p.FlashesThrown
+			team.SmokesThrown += p.SmokesThrown
+			team.HEsThrown += p.HEsThrown
+			team.MolotovsThrown += p.MolotovsThrown
+			team.FlashAssists += p.FlashAssists
+			team.UtilityDamage += p.UtilityDamage
+			team.PlayerRounds += p.RoundsPlayed
+			team.RatingSum += p.FinalRating * float64(p.RoundsPlayed)
+		}
+
+		won := 0
+		for number, r := range rounds {
+			team.RoundsPlayed++
+			if r.won {
+				won++
+				team.RoundsWon++
+			}
+			switch r.side {
+			case "T":
+				team.TRoundsPlayed++
+				if r.won {
+					team.TRoundsWon++
+				}
+			case "CT":
+				team.CTRoundsPlayed++
+				if r.won {
+					team.CTRoundsWon++
+				}
+			}
+			if r.thrown {
+				team.RoundsThrown++
+			}
+			if r.pistol {
+				team.PistolRoundsPlayed++
+				if r.won {
+					team.PistolRoundsWon++
+					if next, ok := rounds[number+1]; ok && next.won {
+						team.PistolConversions++
+					}
+				}
+			}
+		}
+
+		team.GamesPlayed++
+		if won*2 > len(rounds) {
+			team.GamesWon++
+		}
+	}
+}
+
+// Finalize computes the derived rates for every team. Call after all games
+// have been added.
+func (t *TeamAggregator) Finalize() {
+	for key, team := range t.teams {
+		team.Players = team.Players[:0]
+		for steamID := range t.players[key] {
+			team.Players = append(team.Players, steamID)
+		}
+		sort.Strings(team.Players)
+
+		team.GameWinPct = safeDiv(team.GamesWon, team.GamesPlayed)
+		team.RoundWinPct = safeDiv(team.RoundsWon, team.RoundsPlayed)
+		team.TRoundWinPct = safeDiv(team.TRoundsWon, team.TRoundsPlayed)
+		team.CTRoundWinPct = safeDiv(team.CTRoundsWon, team.CTRoundsPlayed)
+		team.PistolWinPct = safeDiv(team.PistolRoundsWon, team.PistolRoundsPlayed)
+		team.PistolConversionPct = safeDiv(team.PistolConversions, team.PistolRoundsWon)
+		team.OpeningSuccessPct = safeDiv(team.OpeningKills, team.OpeningKills+team.OpeningDeaths)
+		team.TradedDeathsPct = safeDiv(team.TradedDeaths, team.Deaths)
+		team.TradeKillsPct = safeDiv(team.TradeKills, team.Kills)
+		thrown := team.FlashesThrown + team.SmokesThrown + team.HEsThrown + team.MolotovsThrown
+		team.UtilityThrownPerRound = safeDiv(thrown, team.RoundsPlayed)
+		team.UtilityDamagePerRound = safeDiv(team.UtilityDamage, team.RoundsPlayed)
+		team.FlashAssistsPerRound = safeDiv(team.FlashAssists, team.RoundsPlayed)
+		if team.PlayerRounds > 0 {
+			team.EcoRating = team.RatingSum / float64(team.PlayerRounds)
+		}
+	}
+}
+
+// GetResults returns every team sorted by tier, then eco-rating (highest
+// first). Should be called after Finalize.
+func (t *TeamAggregator) GetResults() []*model.TeamStats {
+	teams := make([]*model.TeamStats, 0, len(t.teams))
+	for _, team := range t.teams {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Tier != teams[j].Tier {
+			return teams[i].Tier < teams[j].Tier
+		}
+		if teams[i].EcoRating != teams[j].EcoRating {
+			return teams[i].EcoRating > teams[j].EcoRating
+		}
+		return teams[i].Name < teams[j].Name
+	})
+	return teams
+}
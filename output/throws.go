@@ -0,0 +1,43 @@
+package output
+
+import (
+	"sort"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// CollectThrows returns every thrown round in a game, tagged with the match
+// and map. Each thrown round is stored on all of the team's players, so
+// duplicates are dropped.
+func CollectThrows(matchID, mapName string, players map[uint64]*model.PlayerStats) []model.ThrowRound {
+	type roundSide struct {
+		round int
+		side  string
+	}
+	seen := make(map[roundSide]bool)
+	var throws []model.ThrowRound
+	for _, p := range players {
+		for _, t := range p.Throws {
+			key := roundSide{t.RoundNumber, t.Side}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			t.MatchID = matchID
+			t.Map = mapName
+			throws = append(throws, t)
+		}
+	}
+	SortThrows(throws)
+	return throws
+}
+
+// SortThrows orders thrown rounds by match, then round number.
+func SortThrows(throws []model.ThrowRound) {
+	sort.SliceStable(throws, func(i, j int) bool {
+		if throws[i].MatchID != throws[j].MatchID {
+			return throws[i].MatchID < throws[j].MatchID
+		}
+		return throws[i].RoundNumber < throws[j].RoundNumber
+	})
+}
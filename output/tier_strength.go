@@ -0,0 +1,200 @@
+package output
+
+import (
+	"math"
+	"sort"
+)
+
+// TierStrengthEstimate is the estimated strength coefficient for one tier.
+// Coefficient is relative to the anchor tier (premier when present) and can be
+// passed directly to ComputeCrossTierRatings.
+type TierStrengthEstimate struct {
+	Tier          string  `json:"tier"`
+	Coefficient   float64 `json:"coefficient"`
+	Prior         float64 `json:"prior"`
+	SamplePlayers int     `json:"sample_players"`
+	SampleRounds  int     `json:"sample_rounds"`
+	PlayIns       int     `json:"play_ins"` // Games against teams from other tiers
+}
+
+// playInMinWinRate bounds the round win rate taken from a play-in, so a
+// 13-0 doesn't make the tier gap infinite. A starting estimate.
+const playInMinWinRate = 0.05
+
+// TierMatchup is a game between teams from two tiers, such as a play-in:
+// the rounds each team won and each team's usual rating in its own tier.
+type TierMatchup struct {
+	Tiers       [2]string
+	RoundsWon   [2]int
+	HomeRatings [2]float64
+}
+
+// TierStrengthOptions controls inter-tier strength estimation.
+type TierStrengthOptions struct {
+	Priors           map[string]float64 // Starting coefficients; also used when a tier has no overlap
+	AnchorTier       string             // Tier fixed at its prior (usually "premier")
+	MinRoundsPerTier int                // Rounds a player needs in each tier to be used as a sample
+	PriorWeight      float64            // Pseudo-rounds pulling each estimate toward its prior
+	PlayIns          []TierMatchup      // Games between teams from different tiers
+	Solver           SolverOptions      // Bounds the iterative solve
+}
+
+// tierObservation records that, for one player, log(c_a) - log(c_b) ≈ delta.
+type tierObservation struct {
+	a, b   string
+	delta  float64
+	weight float64
+}
+
+// EstimateTierStrength estimates tier strength coefficients from players who
+// appear in more than one tier. For a shared player the assumption is that
+// rating_a * c_a == rating_b * c_b, so each player contributes a log-ratio
+// observation weighted by the smaller of their two round counts. Each play-in
+// adds one more, weighted by its rounds: a team's strength is its home rating
+// times its tier's coefficient, and the share of rounds it won is its
+// strength over both teams' strengths. The weighted least squares solution
+// is found iteratively, within opts.Solver, with the anchor tier held fixed
+// and every tier regularized toward its prior; the report says how the solve
+// went.
+func EstimateTierStrength(players map[string]*AggregatedStats, opts TierStrengthOptions) ([]TierStrengthEstimate, ConvergenceReport) {
+	type tierSample struct {
+		rating float64
+		rounds int
+	}
+	bySteamID := make(map[string]map[string]tierSample)
+	for key, p := range players {
+		if p.RoundsPlayed < opts.MinRoundsPerTier || p.FinalRating <= 0 {
+			continue
+		}
+		tier := tierFromKey(key)
+		if _, ok := opts.Priors[tier]; !ok {
+			continue
+		}
+		if bySteamID[p.SteamID] == nil {
+			bySteamID[p.SteamID] = make(map[string]tierSample)
+		}
+		bySteamID[p.SteamID][tier] = tierSample{rating: p.FinalRating, rounds: p.RoundsPlayed}
+	}
+
+	samplePlayers := make(map[string]int)
+	sampleRounds := make(map[string]int)
+	steamIDs := make([]string, 0, len(bySteamID))
+	for steamID := range bySteamID {
+		steamIDs = append(steamIDs, steamID)
+	}
+	sort.Strings(steamIDs)
+	var observations []tierObservation
+	for _, steamID := range steamIDs {
+		tiers := bySteamID[steamID]
+		if len(tiers) < 2 {
+			continue
+		}
+		names := make([]string, 0, len(tiers))
+		for t := range tiers {
+			names = append(names, t)
+		}
+		sort.Strings(names)
+		for i, a := range names {
+			samplePlayers[a]++
+			sampleRounds[a] += tiers[a].rounds
+			for _, b := range names[i+1:] {
+				observations = append(observations, tierObservation{
+					a:      a,
+					b:      b,
+					delta:  math.Log(tiers[b].rating) - math.Log(tiers[a].rating),
+					weight: float64(min(tiers[a].rounds, tiers[b].rounds)),
+				})
+			}
+		}
+	}
+
+	playIns := make(map[string]int)
+	for _, m := range opts.PlayIns {
+		a, b := m.Tiers[0], m.Tiers[1]
+		_, okA := opts.Priors[a]
+		_, okB := opts.Priors[b]
+		rounds := m.RoundsWon[0] + m.RoundsWon[1]
+		if !okA || !okB || a == b || rounds == 0 || m.HomeRatings[0] <= 0 || m.HomeRatings[1] <= 0 {
+			continue
+		}
+		winRate := float64(m.RoundsWon[0]) / float64(rounds)
+		winRate = math.Min(math.Max(winRate, playInMinWinRate), 1-playInMinWinRate)
+		playIns[a]++
+		playIns[b]++
+		observations = append(observations, tierObservation{
+			a:      a,
+			b:      b,
+			delta:  math.Log(winRate/(1-winRate)) - math.Log(m.HomeRatings[0]/m.HomeRatings[1]),
+			weight: float64(rounds),
+		})
+	}
+
+	logCoef := make(map[string]float64, len(opts.Priors))
+	for tier, prior := range opts.Priors {
+		logCoef[tier] = math.Log(prior)
+	}
+
+	tierNames := make([]string, 0, len(opts.Priors))
+	for tier := range opts.Priors {
+		tierNames = append(tierNames, tier)
+	}
+	sort.Strings(tierNames)
+
+	// Each step is one Gauss-Seidel sweep: tiers are updated in name order,
+	// each seeing the tiers already updated in the sweep.
+	step := func(current map[string]float64) map[string]float64 {
+		logCoef := make(map[string]float64, len(current))
+		for tier, c := range current {
+			logCoef[tier] = c
+		}
+		for _, tier := range tierNames {
+			if tier == opts.AnchorTier {
+				continue
+			}
+			num := opts.PriorWeight * math.Log(opts.Priors[tier])
+			den := opts.PriorWeight
+			for _, o := range observations {
+				switch tier {
+				case o.a:
+					num += o.weight * (logCoef[o.b] + o.delta)
+					den += o.weight
+				case o.b:
+					num += o.weight * (logCoef[o.a] - o.delta)
+					den += o.weight
+				}
+			}
+			if den == 0 {
+				continue
+			}
+			logCoef[tier] = num / den
+		}
+		return logCoef
+	}
+	logCoef, report := SolveFixedPoint("tier_strength", logCoef, step, opts.Solver)
+
+	estimates := make([]TierStrengthEstimate, 0, len(tierNames))
+	for _, tier := range tierNames {
+		estimates = append(estimates, TierStrengthEstimate{
+			Tier:          tier,
+			Coefficient:   math.Exp(logCoef[tier]),
+			Prior:         opts.Priors[tier],
+			SamplePlayers: samplePlayers[tier],
+			SampleRounds:  sampleRounds[tier],
+			PlayIns:       playIns[tier],
+		})
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		return estimates[i].Coefficient > estimates[j].Coefficient
+	})
+	return estimates, report
+}
+
+// TierStrengthCoefficients converts estimates into the map form used by
+// ComputeCrossTierRatings.
+func TierStrengthCoefficients(estimates []TierStrengthEstimate) map[string]float64 {
+	coefficients := make(map[string]float64, len(estimates))
+	for _, e := range estimates {
+		coefficients[e.Tier] = e.Coefficient
+	}
+	return coefficients
+}
@@ -0,0 +1,195 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Training focus settings.
+const (
+	focusMinPool        = 10   // Qualified players a tier needs for percentiles to mean anything
+	focusMaxPercentile  = 0.25 // Only areas in a player's bottom quartile are suggested
+	focusMaxSuggestions = 3
+)
+
+// focusArea is one stat a player can work on, with the advice given when
+// they rank low in it. Its value is oriented so higher is always better.
+type focusArea struct {
+	component string // Rating component the area measures ("" = none)
+	name      string // As in "bottom decile in <name>"
+	advice    string
+	value     func(a *AggregatedStats) float64
+	applies   func(a *AggregatedStats) bool // nil = every player
+}
+
+// focusAreas are the stats training focus can suggest.
+var focusAreas = []focusArea{
+	{
+		component: "round impact",
+		name:      "round impact",
+		advice:    "take the fights that move the round: entries, trades and post-plant duels",
+		value:     func(a *AggregatedStats) float64 { return a.ProbabilitySwingPerRound },
+	},
+	{
+		component: "damage",
+		name:      "damage per round",
+		advice:    "find more damage each round, with utility before contact and a teammate to refrag",
+		value:     func(a *AggregatedStats) float64 { return a.ADR },
+	},
+	{
+		component: "KAST",
+		name:      "KAST",
+		advice:    "stay involved in every round: get the kill, the assist, the trade, or survive",
+		value:     func(a *AggregatedStats) float64 { return a.KAST },
+	},
+	{
+		name:   "deaths per round",
+		advice: "avoid dry peeks and re-peeks; fall back once the info is in",
+		value:  func(a *AggregatedStats) float64 { return -a.DPR },
+	},
+	{
+		name:   "traded deaths",
+		advice: "play closer to teammates so every death can be traded",
+		value:  func(a *AggregatedStats) float64 { return ratio(a.TradedDeaths, a.Deaths) },
+		applies: func(a *AggregatedStats) bool {
+			return a.Deaths > 0
+		},
+	},
+	{
+		name:   "opening duels",
+		advice: "take opening duels with a flash or a trade lined up, or leave them to the entry",
+		value:  func(a *AggregatedStats) float64 { return ratio(a.OpeningKills, a.OpeningKills+a.OpeningDeaths) },
+		applies: func(a *AggregatedStats) bool {
+			return a.OpeningKills+a.OpeningDeaths >= 10
+		},
+	},
+	{
+		name:   "utility damage",
+		advice: "use HEs and molotovs on stacked or planted positions for chip damage",
+		value:  func(a *AggregatedStats) float64 { return ratio(a.UtilityDamage, a.RoundsPlayed) },
+	},
+	{
+		name:   "flash assists",
+		advice: "learn pop flashes for the team's executes and retakes",
+		value:  func(a *AggregatedStats) float64 { return ratio(a.FlashAssists, a.RoundsPlayed) },
+	},
+	{
+		name:   "team flashes",
+		advice: "call flashes before throwing and check teammates' angles",
+		value:  func(a *AggregatedStats) float64 { return -a.TeamFlashDurationPerRound },
+	},
+	{
+		name:   "AWP deaths without a kill",
+		advice: "reposition after the first shot instead of holding the same angle",
+		value:  func(a *AggregatedStats) float64 { return -ratio(a.AWPDeathsNoKill, a.RoundsPlayed) },
+		applies: func(a *AggregatedStats) bool {
+			return a.AWPKills+a.AWPDeaths >= 10
+		},
+	},
+}
+
+// FocusSuggestion is one area a player should train, from how they rank
+// among the qualified players of their tier.
+type FocusSuggestion struct {
+	Area       string  `json:"area"`
+	Value      float64 `json:"value"`      // The player's value, oriented so higher is better
+	Percentile float64 `json:"percentile"` // Share of the tier's qualified players doing worse, 0-1
+	Advice     string  `json:"advice"`
+	Summary    string  `json:"summary"` // e.g. "Bottom decile in traded deaths — play closer to teammates"
+
+	component string
+}
+
+// ComputeTrainingFocus ranks improvement suggestions for every player, keyed
+// like players, from their percentiles among the qualified players of their
+// tier. Only bottom-quartile areas are suggested, weakest first, at most
+// three per player; tiers with fewer than 10 qualified players get none.
+// Finalize must be called before this.
+func ComputeTrainingFocus(players map[string]*AggregatedStats) map[string][]FocusSuggestion {
+	pools := make(map[string][]*AggregatedStats)
+	for key, p := range players {
+		if p.Qualified {
+			tier := tierFromKey(key)
+			pools[tier] = append(pools[tier], p)
+		}
+	}
+
+	focus := make(map[string][]FocusSuggestion)
+	for key, p := range players {
+		pool := pools[tierFromKey(key)]
+		if len(pool) < focusMinPool {
+			continue
+		}
+		var suggestions []FocusSuggestion
+		for _, area := range focusAreas {
+			if area.applies != nil && !area.applies(p) {
+				continue
+			}
+			value := area.value(p)
+			pct, ok := percentileIn(pool, area, value)
+			if !ok || pct >= focusMaxPercentile {
+				continue
+			}
+			suggestions = append(suggestions, FocusSuggestion{
+				component:  area.component,
+				Area:       area.name,
+				Value:      value,
+				Percentile: pct,
+				Advice:     area.advice,
+				Summary:    fmt.Sprintf("%s in %s — %s", percentileBand(pct), area.name, area.advice),
+			})
+		}
+		sort.SliceStable(suggestions, func(i, j int) bool {
+			if suggestions[i].Percentile != suggestions[j].Percentile {
+				return suggestions[i].Percentile < suggestions[j].Percentile
+			}
+			return componentRank(suggestions[i].component) < componentRank(suggestions[j].component)
+		})
+		if len(suggestions) > focusMaxSuggestions {
+			suggestions = suggestions[:focusMaxSuggestions]
+		}
+		if len(suggestions) > 0 {
+			focus[key] = suggestions
+		}
+	}
+	return focus
+}
+
+// percentileIn returns the share of pool players the area applies to whose
+// value is below value, counting ties as half, or false when fewer than
+// focusMinPool qualify.
+func percentileIn(pool []*AggregatedStats, area focusArea, value float64) (float64, bool) {
+	n, below := 0, 0.0
+	for _, other := range pool {
+		if area.applies != nil && !area.applies(other) {
+			continue
+		}
+		n++
+		switch v := area.value(other); {
+		case v < value:
+			below++
+		case v == value:
+			below += 0.5
+		}
+	}
+	if n < focusMinPool {
+		return 0, false
+	}
+	return below / float64(n), true
+}
+
+// percentileBand names the band a percentile falls in.
+func percentileBand(pct float64) string {
+	if pct < 0.1 {
+		return "Bottom decile"
+	}
+	return "Bottom quartile"
+}
+
+// ratio divides two counts, returning 0 when den is 0.
+func ratio(num, den int) float64 {
+	if den == 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// anchorSiteShare is the share of a CT round's position samples a player
+// must spend inside a bomb site for the round to count as anchoring it. It
+// is a starting estimate: a rotating player passes through a site but
+// doesn't spend half the round there.
+const anchorSiteShare = 0.5
+
+// sampleAnchor records a living CT player's position sample for the round's
+// anchor classification, and the first time a living T reaches the site the
+// player stands in before the bomb is planted.
+func (d *DemoParser) sampleAnchor(p *common.Player, alive []*common.Player) {
+	if p.IsBot {
+		return
+	}
+	round := d.state.ensureRound(p)
+	round.CTSamples++
+	if !p.IsInBombZone() {
+		return
+	}
+	round.SiteSamples++
+	if round.SiteContact || d.state.BombPlanted {
+		return
+	}
+	site := p.LastPlaceName()
+	for _, enemy := range alive {
+		if enemy.Team == common.TeamTerrorists && enemy.IsInBombZone() && enemy.LastPlaceName() == site {
+			round.SiteContact = true
+			round.SiteContactAt = d.timeInRound()
+			return
+		}
+	}
+}
+
+// tallyAnchorRounds credits each CT player who spent the round on a bomb
+// site with an anchor round: whether they survived it, whether they got a
+// multi-kill, and the retake delay they generated, from the first T on
+// their site to the plant, or to the round being decided when the plant
+// never came. Runs at round end, after survival is set.
+func (d *DemoParser) tallyAnchorRounds(ctx *roundEndContext) {
+	for steamID, round := range d.state.Round {
+		if round.PlayerSide != "CT" || round.CTSamples == 0 ||
+			float64(round.SiteSamples)/float64(round.CTSamples) < anchorSiteShare {
+			continue
+		}
+		ps := d.state.Players[steamID]
+		if ps == nil {
+			continue
+		}
+		ps.AnchorRounds++
+		if round.Survived {
+			ps.AnchorRoundsSurvived++
+		}
+		if round.Kills >= 2 {
+			ps.AnchorMultiKills++
+		}
+		if !round.SiteContact {
+			continue
+		}
+		end := ctx.roundDuration
+		switch {
+		case d.state.BombPlanted && d.state.BombPlantedAt >= round.SiteContactAt:
+			end = d.state.BombPlantedAt
+		case d.state.RoundDecided:
+			end = d.state.RoundDecidedAt
+		}
+		if end > round.SiteContactAt {
+			ps.AnchorSiteContacts++
+			ps.AnchorRetakeDelay += end - round.SiteContactAt
+		}
+	}
+}
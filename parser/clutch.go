@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating/probability"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// roundTimeSeconds is the round timer used to report time left in a clutch.
+const roundTimeSeconds = 115.0
+
+// timeRemaining returns the seconds left on the round timer, or on the bomb
+// timer once the bomb is planted.
+func (d *DemoParser) timeRemaining() float64 {
+	now := d.timeInRound()
+	if d.state.BombPlanted {
+		return math.Max(0, probability.BombTimerSeconds-(now-d.state.BombPlantedAt))
+	}
+	return math.Max(0, roundTimeSeconds-now)
+}
+
+// startClutchDescriptor snapshots the situation when clutcher is left alone
+// against the given enemies.
+func (d *DemoParser) startClutchDescriptor(clutcher *common.Player, enemies []*common.Player) *model.ClutchDescriptor {
+	now := d.timeInRound()
+	desc := &model.ClutchDescriptor{
+		RoundNumber: d.state.RoundNumber,
+		SteamID:     fmt.Sprintf("%d", clutcher.SteamID64),
+		Name:        clutcher.Name,
+		Side:        sideName(clutcher.Team),
+		Opponents:   len(enemies),
+		Health:      clutcher.Health(),
+		Armor:       clutcher.Armor(),
+		Weapon:      mainWeapon(clutcher),
+		StartTime:   now,
+		BombState:   "not_planted",
+	}
+	desc.TimeRemaining = d.timeRemaining()
+	if d.state.BombPlanted {
+		desc.BombState = "planted"
+	}
+	for _, e := range enemies {
+		desc.Enemies = append(desc.Enemies, model.ClutchEnemy{
+			SteamID: fmt.Sprintf("%d", e.SteamID64),
+			Name:    e.Name,
+			Weapon:  mainWeapon(e),
+			Health:  e.Health(),
+			Armor:   e.Armor(),
+			HasKit:  e.HasDefuseKit(),
+		})
+	}
+	return desc
+}
+
+// recordClutchKill appends a kill to the sequence of any clutch it belongs to:
+// kills by the clutcher and the clutcher's death. It runs for every death,
+// including ones with no attacker (the bomb, fall damage or the world), so
+// the clutcher's death always ends the sequence.
+func (d *DemoParser) recordClutchKill(ctx *killContext) {
+	for _, round := range d.state.Round {
+		desc := round.Clutch
+		if desc == nil || round.ClutchOver {
+			continue
+		}
+		byClutcher := ctx.attacker != nil && fmt.Sprintf("%d", ctx.attacker.SteamID64) == desc.SteamID
+		ofClutcher := fmt.Sprintf("%d", ctx.victim.SteamID64) == desc.SteamID
+		if !byClutcher && !ofClutcher {
+			continue
+		}
+		kill := model.ClutchKill{
+			Time:     ctx.timeInRound,
+			Victim:   ctx.victim.Name,
+			Headshot: ctx.event.IsHeadshot,
+		}
+		if ctx.attacker != nil {
+			kill.Killer = ctx.attacker.Name
+		}
+		if ctx.event.Weapon != nil {
+			kill.Weapon = ctx.event.Weapon.String()
+		}
+		desc.Kills = append(desc.Kills, kill)
+		if byClutcher {
+			desc.ClutchKills++
+		}
+		if ofClutcher {
+			round.ClutchOver = true
+		}
+	}
+}
+
+// mainWeapon returns the name of the player's best gun: a rifle, heavy
+// weapon, or SMG if they carry one, otherwise their pistol.
+func mainWeapon(p *common.Player) string {
+	best := bestGun(p)
+	if best == nil {
+		return ""
+	}
+	return best.String()
+}
+
+// bestGun returns the player's best gun, as for mainWeapon, or nil.
+func bestGun(p *common.Player) *common.Equipment {
+	var best *common.Equipment
+	for _, w := range p.Weapons() {
+		if w == nil || w.Class() > common.EqClassRifle {
+			continue
+		}
+		if best == nil || w.Class() > best.Class() {
+			best = w
+		}
+	}
+	return best
+}
+
+// sideName returns "T" or "CT" for a team.
+func sideName(team common.Team) string {
+	switch team {
+	case common.TeamTerrorists:
+		return "T"
+	case common.TeamCounterTerrorists:
+		return "CT"
+	}
+	return ""
+}
@@ -148,6 +148,17 @@ func (dt *DamageTracker) ClearVictimData(victimID uint64) {
 	delete(dt.flashedPlayers, victimID)
 }
 
+// EngagementStart returns when attacker's current engagement on victim
+// began, or false when attacker hasn't damaged victim within
+// EngagementTimeout of now.
+func (dt *DamageTracker) EngagementStart(attackerID, victimID uint64, now float64) (float64, bool) {
+	last, ok := dt.lastDamageTime[victimID][attackerID]
+	if !ok || now-last > EngagementTimeout {
+		return 0, false
+	}
+	return dt.firstDamageTime[victimID][attackerID], true
+}
+
 // GetTimeToKill returns the time between first damage and kill time.
 // Returns -1 if no prior damage was recorded (e.g., one-shot kill).
 func (dt *DamageTracker) GetTimeToKill(killerID, victimID uint64, killTime float64) float64 {
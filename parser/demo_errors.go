@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
+)
+
+// DemoErrorKind classifies why a demo couldn't be parsed in full.
+type DemoErrorKind string
+
+const (
+	KindUnreadable DemoErrorKind = "unreadable" // The file couldn't be opened or downloaded
+	KindNotDemo    DemoErrorKind = "not_demo"   // The file doesn't start with a demo header
+	KindNotCS2     DemoErrorKind = "not_cs2"    // A Source 1 (CS:GO) demo
+	KindTruncated  DemoErrorKind = "truncated"  // The demo ends mid-stream, e.g. an interrupted upload
+	KindCorrupt    DemoErrorKind = "corrupt"    // The demo library rejected the data
+	KindPanic      DemoErrorKind = "panic"      // The demo library panicked on the data
+)
+
+// Demo file stamps, the first 8 bytes of a demo.
+var (
+	cs2Stamp  = []byte("PBDEMS2\x00")
+	csgoStamp = []byte("HL2DEMO\x00")
+)
+
+// DemoError is a demo that couldn't be parsed in full. When Partial is set,
+// the stats of the first Rounds rounds were kept and are usable.
+type DemoError struct {
+	Kind    DemoErrorKind
+	Partial bool
+	Rounds  int
+	Err     error
+}
+
+func (e *DemoError) Error() string {
+	if e.Partial {
+		return fmt.Sprintf("%s demo, kept %d rounds: %v", e.Kind, e.Rounds, e.Err)
+	}
+	return fmt.Sprintf("%s demo: %v", e.Kind, e.Err)
+}
+
+func (e *DemoError) Unwrap() error {
+	return e.Err
+}
+
+// IsPartial reports whether err is a DemoError whose partial stats were kept.
+func IsPartial(err error) bool {
+	var de *DemoError
+	return errors.As(err, &de) && de.Partial
+}
+
+// ValidateDemo checks the demo header at the start of r without consuming
+// it, so empty files, other file types and CS:GO demos are rejected before
+// parsing starts.
+func ValidateDemo(r *bufio.Reader) error {
+	stamp, err := r.Peek(len(cs2Stamp))
+	switch {
+	case len(stamp) < len(cs2Stamp):
+		return &DemoError{Kind: KindTruncated, Err: fmt.Errorf("file is %d bytes, too short for a demo header: %w", len(stamp), err)}
+	case bytes.Equal(stamp, cs2Stamp):
+		return nil
+	case bytes.Equal(stamp, csgoStamp):
+		return &DemoError{Kind: KindNotCS2, Err: errors.New("CS:GO demos are not supported")}
+	}
+	return &DemoError{Kind: KindNotDemo, Err: fmt.Errorf("unknown file stamp %q", stamp)}
+}
+
+// classifyParseError wraps an error from the demo library in a DemoError.
+func classifyParseError(err error) *DemoError {
+	var de *DemoError
+	if errors.As(err, &de) {
+		return de
+	}
+	kind := KindCorrupt
+	switch {
+	case errors.Is(err, demoinfocs.ErrUnexpectedEndOfDemo):
+		kind = KindTruncated
+	case errors.Is(err, demoinfocs.ErrInvalidFileType):
+		kind = KindNotDemo
+	}
+	return &DemoError{Kind: kind, Err: err}
+}
+
+// NewDemoIssue builds the error report entry for a demo that failed with
+// err. Errors that aren't DemoErrors are classified as unreadable files
+// when they come from the filesystem and corrupt demos otherwise.
+func NewDemoIssue(demo, tier string, err error) model.DemoIssue {
+	issue := model.DemoIssue{Demo: demo, Tier: tier, Kind: string(KindCorrupt), Error: err.Error()}
+	var de *DemoError
+	switch {
+	case errors.As(err, &de):
+		issue.Kind = string(de.Kind)
+		issue.Partial = de.Partial
+		issue.Rounds = de.Rounds
+		issue.Error = de.Err.Error()
+	case errors.As(err, new(*fs.PathError)):
+		issue.Kind = string(KindUnreadable)
+	}
+	return issue
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
+	"github.com/ethsmith/eco-rating/rating/probability"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// economyPlayer pairs a player's end-of-round economy with their round stats.
+type economyPlayer struct {
+	id    uint64
+	round *model.RoundStats
+	probability.ForecastPlayer
+}
+
+// processEconomyForecast credits each player with how their round changed
+// their team's win probability in the *next* round through the economy.
+// A survivor is credited with the next-round probability their kept
+// equipment is worth to the team; a player who died loses the value their
+// equipment would have carried over, and their killer gains it. This is kept
+// apart from ProbabilitySwing, which only measures the current round.
+//
+// Rounds before a half or overtime reset are skipped since money resets.
+func (d *DemoParser) processEconomyForecast(ctx *roundEndContext) {
+	if ctx.winnerTeam != common.TeamTerrorists && ctx.winnerTeam != common.TeamCounterTerrorists {
+		return
+	}
+	if d.state.IsPistolRound {
+		d.state.LossStreak = make(map[common.Team]int)
+	}
+	d.state.LossStreak[ctx.winnerTeam] = 0
+	d.state.LossStreak[opposingTeam(ctx.winnerTeam)]++
+
+	if d.state.SwingTracker == nil || rating.IsPistolRound(d.state.RoundNumber+1) {
+		return
+	}
+	engine := d.state.SwingTracker.GetCalculator().GetProbabilityEngine()
+
+	teams := make(map[common.Team][]economyPlayer)
+	for _, p := range ctx.gs.Participants().Playing() {
+		if p.IsBot || (p.Team != common.TeamTerrorists && p.Team != common.TeamCounterTerrorists) {
+			continue
+		}
+		round := d.state.ensureRound(p)
+		ep := economyPlayer{id: p.SteamID64, round: round}
+		ep.Money = p.Money()
+		ep.Alive = p.IsAlive()
+		if ep.Alive {
+			ep.Equipment = float64(p.EquipmentValueCurrent())
+		} else {
+			ep.Equipment = round.DeathEquipment
+		}
+		teams[p.Team] = append(teams[p.Team], ep)
+	}
+
+	income := func(team common.Team) int {
+		return probability.RoundIncome(team == ctx.winnerTeam, d.state.LossStreak[team])
+	}
+	project := func(team common.Team, players []economyPlayer) float64 {
+		fp := make([]probability.ForecastPlayer, len(players))
+		for i, p := range players {
+			fp[i] = p.ForecastPlayer
+		}
+		return probability.ProjectTeamEquipment(fp, income(team))
+	}
+
+	for team, players := range teams {
+		enemy := opposingTeam(team)
+		enemyEquip := project(enemy, teams[enemy])
+		actual := engine.NextRoundWinProbability(team, project(team, players), enemyEquip, d.state.MapName)
+
+		for i := range players {
+			// Counterfactual: flip this player's survival and re-project.
+			flipped := append([]economyPlayer(nil), players...)
+			flipped[i].Alive = !flipped[i].Alive
+			other := engine.NextRoundWinProbability(team, project(team, flipped), enemyEquip, d.state.MapName)
+
+			p := players[i]
+			if p.Alive {
+				if gain := actual - other; gain > 0 {
+					d.addEconomySwing(p.round, gain, "save", "Equipment kept for next round")
+				}
+				continue
+			}
+			loss := other - actual
+			if loss <= 0 {
+				continue
+			}
+			d.addEconomySwing(p.round, -loss, "equipment_lost", "Equipment lost for next round")
+			if killer, ok := d.state.Round[p.round.KilledBy]; ok && p.round.KilledBy != 0 {
+				d.addEconomySwing(killer, loss, "equipment_denied", fmt.Sprintf("Denied %.0f equipment", p.Equipment))
+			}
+		}
+	}
+}
+
+// addEconomySwing adds a multi-round economy swing entry to a player's round.
+func (d *DemoParser) addEconomySwing(round *model.RoundStats, amount float64, kind, notes string) {
+	round.EconomySwing += amount
+	round.AddSwingContribution(model.SwingContribution{
+		Type:   "economy_" + kind,
+		Amount: amount,
+		Notes:  notes,
+	})
+}
+
+// opposingTeam returns the other playing side.
+func opposingTeam(team common.Team) common.Team {
+	if team == common.TeamTerrorists {
+		return common.TeamCounterTerrorists
+	}
+	return common.TeamTerrorists
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// Buy types recorded per team and round.
+const (
+	BuyPistol = "pistol"
+	BuyEco    = "eco"
+	BuyForce  = "force"
+	BuyFull   = "full"
+)
+
+// Average equipment value per player at the end of freeze time that separates
+// the buy types. Below EcoMaxEquipment is an eco, below ForceMaxEquipment a
+// force buy, anything above a full buy.
+const (
+	EcoMaxEquipment   = 1500.0
+	ForceMaxEquipment = 3500.0
+)
+
+// ClassifyBuy returns the buy type for a team's average equipment value.
+// Pistol rounds are always BuyPistol.
+func ClassifyBuy(avgEquipment float64, pistolRound bool) string {
+	switch {
+	case pistolRound:
+		return BuyPistol
+	case avgEquipment < EcoMaxEquipment:
+		return BuyEco
+	case avgEquipment < ForceMaxEquipment:
+		return BuyForce
+	default:
+		return BuyFull
+	}
+}
+
+// RoundEconomy is both teams' buy in one round.
+type RoundEconomy struct {
+	RoundNumber int     `json:"round_number"`
+	TBuy        string  `json:"t_buy"`
+	CTBuy       string  `json:"ct_buy"`
+	TEquipment  float64 `json:"t_equipment"`  // Average per player
+	CTEquipment float64 `json:"ct_equipment"` // Average per player
+}
+
+// EconomyTracker records each team's buy type per round from the equipment
+// value at the end of freeze time.
+type EconomyTracker struct {
+	rounds []RoundEconomy
+}
+
+// NewEconomyTracker creates an empty economy tracker.
+func NewEconomyTracker() *EconomyTracker {
+	return &EconomyTracker{}
+}
+
+// StartRound classifies both teams' buys for a new round from their total
+// equipment value and player counts.
+func (et *EconomyTracker) StartRound(roundNumber int, pistolRound bool, tEquipTotal, tPlayers, ctEquipTotal, ctPlayers int) {
+	round := RoundEconomy{RoundNumber: roundNumber}
+	if tPlayers > 0 {
+		round.TEquipment = float64(tEquipTotal) / float64(tPlayers)
+	}
+	if ctPlayers > 0 {
+		round.CTEquipment = float64(ctEquipTotal) / float64(ctPlayers)
+	}
+	round.TBuy = ClassifyBuy(round.TEquipment, pistolRound)
+	round.CTBuy = ClassifyBuy(round.CTEquipment, pistolRound)
+	et.rounds = append(et.rounds, round)
+}
+
+// BuyType returns the team's buy type in the current round, or "" before the
+// first round.
+func (et *EconomyTracker) BuyType(team common.Team) string {
+	if len(et.rounds) == 0 {
+		return ""
+	}
+	current := et.rounds[len(et.rounds)-1]
+	switch team {
+	case common.TeamTerrorists:
+		return current.TBuy
+	case common.TeamCounterTerrorists:
+		return current.CTBuy
+	}
+	return ""
+}
+
+// processEconomyStats records each player's buy type for the round and the
+// per-buy kill and win counts, plus the equipment kept by surviving a loss.
+func (d *DemoParser) processEconomyStats(ctx *roundEndContext) {
+	for _, p := range ctx.gs.Participants().Playing() {
+		if p.IsBot {
+			continue
+		}
+		ps := d.state.ensurePlayer(p)
+		round := d.state.ensureRound(p)
+		round.BuyType = d.state.EconomyTracker.BuyType(p.Team)
+
+		switch round.BuyType {
+		case BuyEco:
+			ps.EcoRounds++
+			ps.EcoRoundKills += round.Kills
+			if round.TeamWon {
+				ps.EcoRoundWins++
+			}
+		case BuyForce:
+			ps.ForceBuyRounds++
+			if round.TeamWon {
+				ps.ForceBuyWins++
+			}
+		case BuyFull:
+			ps.FullBuyRounds++
+			if round.TeamWon {
+				ps.FullBuyWins++
+			}
+		}
+
+		if p.IsAlive() && !round.TeamWon {
+			ps.MoneySaved += p.EquipmentValueCurrent()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// Engagement range buckets, in game units (roughly an inch each).
+const (
+	closeRangeUnits = 500.0  // Kills under this are close range
+	longRangeUnits  = 1500.0 // Kills at or over this are long range
+)
+
+// engagementRange is the range bucket of a kill.
+type engagementRange int
+
+const (
+	rangeClose engagementRange = iota
+	rangeMid
+	rangeLong
+)
+
+// rangeOf returns the bucket a kill distance falls in.
+func rangeOf(distance float64) engagementRange {
+	switch {
+	case distance < closeRangeUnits:
+		return rangeClose
+	case distance < longRangeUnits:
+		return rangeMid
+	}
+	return rangeLong
+}
+
+// isRifle reports whether a weapon is an assault rifle, leaving out the
+// snipers that share its equipment class.
+func isRifle(t common.EquipmentType) bool {
+	switch t {
+	case common.EqAWP, common.EqSSG08, common.EqScar20, common.EqG3SG1:
+		return false
+	}
+	return t.Class() == common.EqClassRifle
+}
+
+// processEngagementRange records the distance of a gun or knife kill for the
+// killer and the victim, measured between their positions in game units (the
+// kill event's own distance is in meters). Grenade kills and kills without a
+// distance are left out, since their distance says nothing about the duel.
+func (d *DemoParser) processEngagementRange(ctx *killContext) {
+	weapon := ctx.event.Weapon
+	if weapon == nil || ctx.attacker == nil || ctx.victim == nil || weapon.Class() == common.EqClassGrenade || weapon.Class() == common.EqClassUnknown {
+		return
+	}
+	distance := ctx.attacker.Position().Distance(ctx.victim.Position())
+	if distance <= 0 {
+		return
+	}
+
+	attacker := d.state.ensurePlayer(ctx.attacker)
+	victim := d.state.ensurePlayer(ctx.victim)
+	attacker.KillDistanceTotal += distance
+	victim.DeathDistanceTotal += distance
+
+	switch rangeOf(distance) {
+	case rangeClose:
+		attacker.CloseRangeKills++
+		victim.CloseRangeDeaths++
+		if isRifle(weapon.Type) {
+			victim.CloseRangeRifleDeaths++
+		}
+	case rangeMid:
+		attacker.MidRangeKills++
+		victim.MidRangeDeaths++
+	case rangeLong:
+		attacker.LongRangeKills++
+		victim.LongRangeDeaths++
+		if weapon.Type == common.EqAWP {
+			attacker.LongRangeAWPKills++
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// VisibilityTracker tracks, for each pair of enemies, when one first saw the
+// other in the current sighting. Crosshair placement shows in how quickly a
+// sighting becomes a kill.
+type VisibilityTracker struct {
+	// seenSince maps spotter SteamID -> spotted SteamID -> time the
+	// current sighting began
+	seenSince map[uint64]map[uint64]float64
+}
+
+// NewVisibilityTracker creates a new visibility tracker.
+func NewVisibilityTracker() *VisibilityTracker {
+	return &VisibilityTracker{seenSince: make(map[uint64]map[uint64]float64)}
+}
+
+// Reset clears all sightings for a new round.
+func (vt *VisibilityTracker) Reset() {
+	vt.seenSince = make(map[uint64]map[uint64]float64)
+}
+
+// Update records whether spotter can see spotted now. A sighting starts the
+// first time spotter sees spotted and ends when it loses sight of them.
+func (vt *VisibilityTracker) Update(spotterID, spottedID uint64, visible bool, timeInRound float64) {
+	if !visible {
+		delete(vt.seenSince[spotterID], spottedID)
+		return
+	}
+	if vt.seenSince[spotterID] == nil {
+		vt.seenSince[spotterID] = make(map[uint64]float64)
+	}
+	if _, ok := vt.seenSince[spotterID][spottedID]; !ok {
+		vt.seenSince[spotterID][spottedID] = timeInRound
+	}
+}
+
+// SeenSince returns when spotter's current sighting of spotted began, or
+// false when spotter can't see them.
+func (vt *VisibilityTracker) SeenSince(spotterID, spottedID uint64) (float64, bool) {
+	t, ok := vt.seenSince[spotterID][spottedID]
+	return t, ok
+}
+
+// registerVisibilityHandler tracks enemy sightings from the players' spotted
+// state.
+func (d *DemoParser) registerVisibilityHandler() {
+	d.parser.RegisterEventHandler(func(e events.PlayerSpottersChanged) {
+		if e.Spotted == nil || d.state.ShouldSkipEvent() || d.parser.GameState().IsWarmupPeriod() {
+			return
+		}
+		now := d.timeInRound()
+		for _, p := range d.parser.GameState().Participants().Playing() {
+			if p.Team == e.Spotted.Team || p.Team == common.TeamSpectators || p.Team == common.TeamUnassigned {
+				continue
+			}
+			d.state.Visibility.Update(p.SteamID64, e.Spotted.SteamID64, p.IsAlive() && e.Spotted.IsSpottedBy(p), now)
+		}
+	})
+}
+
+// processDuelTiming records how long the killer took from first seeing the
+// victim to the kill, and which of the two drew first blood in the duel.
+// It must run before the swing tracker clears the victim's damage.
+func (d *DemoParser) processDuelTiming(ctx *killContext) {
+	attackerID, victimID := ctx.attacker.SteamID64, ctx.victim.SteamID64
+	attacker := d.state.ensurePlayer(ctx.attacker)
+	victim := d.state.ensurePlayer(ctx.victim)
+
+	if seen, ok := d.state.Visibility.SeenSince(attackerID, victimID); ok {
+		attacker.TotalSightToKill += ctx.timeInRound - seen
+		attacker.KillsWithSightTime++
+	}
+
+	if d.state.SwingTracker == nil || !d.state.SwingTracker.IsEnabled() {
+		return
+	}
+	damage := d.state.SwingTracker.GetDamageTracker()
+	killerStart, killerHit := damage.EngagementStart(attackerID, victimID, ctx.timeInRound)
+	victimStart, victimHit := damage.EngagementStart(victimID, attackerID, ctx.timeInRound)
+	if !killerHit && !victimHit {
+		return
+	}
+	attacker.Duels++
+	victim.Duels++
+	switch {
+	case killerHit && (!victimHit || killerStart < victimStart):
+		attacker.FirstDamageDuels++
+	case victimHit && (!killerHit || victimStart < killerStart):
+		victim.FirstDamageDuels++
+	}
+}
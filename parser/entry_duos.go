@@ -0,0 +1,19 @@
+package parser
+
+// entryContactKills is how many of a round's first kills count as its entry
+// contact: the opening duel and the fight that follows it, where an entry
+// is traded or backed up. A starting estimate for 5v5 executes and retakes.
+const entryContactKills = 2
+
+// markEntryContact marks every player who killed or died in the round's
+// first entryContactKills kills.
+func (d *DemoParser) markEntryContact() {
+	kills := d.state.RoundEvents.kills
+	if len(kills) > entryContactKills {
+		kills = kills[:entryContactKills]
+	}
+	for _, k := range kills {
+		d.state.ensureRound(k.ctx.attacker).EntryContact = true
+		d.state.ensureRound(k.ctx.victim).EntryContact = true
+	}
+}
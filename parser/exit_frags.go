@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"log/slog"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating/probability"
+)
+
+// DefaultExitFragPenalty is the probability swing taken back from the killer
+// for each exit frag.
+const DefaultExitFragPenalty = 0.02
+
+// Times that decide whether a round can still be won.
+const (
+	kitDefuseSeconds = 5.0  // Fastest possible defuse
+	defuseSeconds    = 10.0 // Defuse without a kit
+	plantSeconds     = 3.2  // Time to plant the bomb
+)
+
+// SetExitFragPenalty sets the probability swing taken back from the killer for
+// each exit frag (0 disables the penalty).
+func (d *DemoParser) SetExitFragPenalty(penalty float64) {
+	d.exitFragPenalty = penalty
+}
+
+// classifyRoundKills is the round end pass over the round's buffered kills.
+// It marks exit frags and takes their penalty back, now that every event
+// that decided the round is known.
+func (d *DemoParser) classifyRoundKills() {
+	log := d.state.RoundEvents
+	for i, k := range log.kills {
+		if !isExitFrag(k, log.decidedBefore(i)) {
+			continue
+		}
+		k.ctx.isExitFrag = true
+		round := d.state.ensureRound(k.ctx.attacker)
+		round.IsExitFrag = true
+		round.ExitFrags++
+		if d.state.SwingTracker != nil {
+			d.applyExitFragPenalty(k.ctx)
+		}
+		d.logAt(slog.LevelDebug, "Exit frag",
+			"killer", k.ctx.attacker.Name, "victim", k.ctx.victim.Name,
+			"time_in_round", k.ctx.timeInRound)
+	}
+}
+
+// isExitFrag reports whether a kill could no longer change the round: the
+// round was already decided, the bomb had too little time left to be
+// defused even with a kit so the CTs were saving, or time was too short to
+// plant so the Ts were.
+func isExitFrag(k roundKill, decided bool) bool {
+	if decided {
+		return true
+	}
+	if k.bombPlanted {
+		return probability.BombTimerSeconds-(k.ctx.timeInRound-k.bombPlantedAt) < kitDefuseSeconds
+	}
+	return roundTimeSeconds-k.ctx.timeInRound < plantSeconds
+}
+
+// applyExitFragPenalty takes the exit frag penalty back from the killer's
+// round swing so stat-padding kills don't inflate the rating.
+func (d *DemoParser) applyExitFragPenalty(ctx *killContext) {
+	if !ctx.isExitFrag || d.exitFragPenalty <= 0 {
+		return
+	}
+	round := d.state.ensureRound(ctx.attacker)
+	round.ProbabilitySwing -= d.exitFragPenalty
+	round.AddSwingContribution(model.SwingContribution{
+		Type:        "exit_frag",
+		Amount:      -d.exitFragPenalty,
+		TimeInRound: ctx.timeInRound,
+		Opponent:    ctx.victim.Name,
+	})
+}
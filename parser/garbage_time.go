@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// Garbage time starts once the leading team has at least
+// GarbageTimeMinLeaderScore rounds and leads by at least GarbageTimeMinLead,
+// e.g. from 10-2 onward. Rounds played from that point are flagged so ratings
+// can be reported with and without them.
+const (
+	GarbageTimeMinLeaderScore = 10
+	GarbageTimeMinLead        = 8
+)
+
+// isGarbageTime reports whether a round starting at the given score is played
+// in an already decided match.
+func isGarbageTime(scoreA, scoreB int) bool {
+	leader, trailer := max(scoreA, scoreB), min(scoreA, scoreB)
+	return leader >= GarbageTimeMinLeaderScore && leader-trailer >= GarbageTimeMinLead
+}
+
+// computeGarbageTimeRating fills in the player's garbage-time round counts and
+// the eco-rating over the rounds played before the match was decided, rated
+// like FinalRating.
+func (d *DemoParser) computeGarbageTimeRating(p *model.PlayerStats) {
+	var rounds, kills, deaths, damage, kast int
+	var swing float64
+	for _, rb := range p.RoundBreakdowns {
+		if rb.GarbageTime {
+			p.GarbageTimeRounds++
+			p.GarbageTimeKills += rb.Kills
+			p.GarbageTimeDamage += rb.Damage
+			continue
+		}
+		rounds++
+		kills += rb.Kills
+		damage += rb.Damage
+		swing += rb.ProbabilitySwing
+		if rb.Died {
+			deaths++
+		}
+		if rb.KAST {
+			kast++
+		}
+	}
+
+	if p.GarbageTimeRounds == 0 {
+		p.RatingExclGarbageTime = p.FinalRating
+		return
+	}
+	p.RatingExclGarbageTime = rating.ComputeRoundsRating(rounds, kills, deaths, damage,
+		float64(kast), swing, d.state.MapName, d.tier, d.kdprModifier)
+}
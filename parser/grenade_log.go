@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// GrenadeLog records every grenade thrown in a match. Throws are kept by
+// projectile so detonation, flashes and damage can be attached after the
+// throw; they are handed to the throwers' stats once parsing finishes.
+type GrenadeLog struct {
+	throws       []*model.GrenadeThrow
+	throwers     []uint64
+	byProjectile map[int64]*model.GrenadeThrow
+	lastDamaging map[uint64]map[string]*model.GrenadeThrow // Latest HE / fire grenade per thrower
+}
+
+// NewGrenadeLog creates an empty grenade log.
+func NewGrenadeLog() *GrenadeLog {
+	return &GrenadeLog{
+		byProjectile: make(map[int64]*model.GrenadeThrow),
+		lastDamaging: make(map[uint64]map[string]*model.GrenadeThrow),
+	}
+}
+
+// count returns the number of logged throws, 0 for a disabled (nil) log.
+func (gl *GrenadeLog) count() int {
+	if gl == nil {
+		return 0
+	}
+	return len(gl.throws)
+}
+
+// grenadeType names a grenade for the log, or "" for non-grenades.
+func grenadeType(eq common.EquipmentType) string {
+	switch eq {
+	case common.EqFlash:
+		return "flash"
+	case common.EqSmoke:
+		return "smoke"
+	case common.EqHE:
+		return "he"
+	case common.EqMolotov:
+		return "molotov"
+	case common.EqIncendiary:
+		return "incendiary"
+	case common.EqDecoy:
+		return "decoy"
+	}
+	return ""
+}
+
+// damageGroup maps grenade types to the group their damage is attributed by.
+// Molotov and incendiary damage can't be told apart from damage events.
+func damageGroup(eq common.EquipmentType) string {
+	switch eq {
+	case common.EqHE:
+		return "he"
+	case common.EqMolotov, common.EqIncendiary:
+		return "fire"
+	}
+	return ""
+}
+
+// handleGrenadeLogThrow starts a log entry for a thrown grenade.
+func (d *DemoParser) handleGrenadeLogThrow(e events.GrenadeProjectileThrow) {
+	proj := e.Projectile
+	if d.state.GrenadeLog == nil || proj == nil || proj.Thrower == nil || proj.WeaponInstance == nil {
+		return
+	}
+	kind := grenadeType(proj.WeaponInstance.Type)
+	if kind == "" {
+		return
+	}
+	thrower := proj.Thrower
+	origin := thrower.Position()
+	throw := &model.GrenadeThrow{
+		RoundNumber:    d.state.RoundNumber,
+		Time:           d.timeInRound(),
+		Type:           kind,
+		Thrower:        thrower.Name,
+		ThrowerSteamID: strconv.FormatUint(thrower.SteamID64, 10),
+		Side:           sideName(thrower.Team),
+		Origin:         model.Position{X: origin.X, Y: origin.Y, Z: origin.Z},
+	}
+
+	gl := d.state.GrenadeLog
+	gl.throws = append(gl.throws, throw)
+	gl.throwers = append(gl.throwers, thrower.SteamID64)
+	gl.byProjectile[proj.UniqueID()] = throw
+	if group := damageGroup(proj.WeaponInstance.Type); group != "" {
+		if gl.lastDamaging[thrower.SteamID64] == nil {
+			gl.lastDamaging[thrower.SteamID64] = make(map[string]*model.GrenadeThrow)
+		}
+		gl.lastDamaging[thrower.SteamID64][group] = throw
+	}
+}
+
+// handleGrenadeLogDestroy stores the flight path and detonation point of a
+// logged grenade.
+func (d *DemoParser) handleGrenadeLogDestroy(e events.GrenadeProjectileDestroy) {
+	if d.state.GrenadeLog == nil || e.Projectile == nil {
+		return
+	}
+	throw := d.state.GrenadeLog.byProjectile[e.Projectile.UniqueID()]
+	if throw == nil || len(e.Projectile.Trajectory) == 0 {
+		return
+	}
+	throw.Trajectory = make([]model.Position, len(e.Projectile.Trajectory))
+	for i, entry := range e.Projectile.Trajectory {
+		throw.Trajectory[i] = model.Position{X: entry.Position.X, Y: entry.Position.Y, Z: entry.Position.Z}
+	}
+	end := throw.Trajectory[len(throw.Trajectory)-1]
+	throw.Detonation = &end
+	delete(d.state.GrenadeLog.byProjectile, e.Projectile.UniqueID())
+}
+
+// recordGrenadeFlash adds a flashed player to the flash that blinded them.
+func (d *DemoParser) recordGrenadeFlash(e events.PlayerFlashed) {
+	if d.state.GrenadeLog == nil || e.Projectile == nil || e.Player == nil || e.Attacker == nil {
+		return
+	}
+	throw := d.state.GrenadeLog.byProjectile[e.Projectile.UniqueID()]
+	if throw == nil {
+		return
+	}
+	throw.Affected = append(throw.Affected, model.GrenadeVictim{
+		Name:          e.Player.Name,
+		SteamID:       strconv.FormatUint(e.Player.SteamID64, 10),
+		Enemy:         e.Player.Team != e.Attacker.Team,
+		FlashDuration: e.FlashDuration().Seconds(),
+	})
+}
+
+// recordGrenadeDamage credits HE and fire damage to the attacker's latest
+// grenade of that kind, merging repeat hits on the same player.
+func (d *DemoParser) recordGrenadeDamage(e events.PlayerHurt, dmg int) {
+	if d.state.GrenadeLog == nil || e.Weapon == nil || e.Attacker == nil || e.Player == nil || dmg <= 0 {
+		return
+	}
+	group := damageGroup(e.Weapon.Type)
+	if group == "" {
+		return
+	}
+	throw := d.state.GrenadeLog.lastDamaging[e.Attacker.SteamID64][group]
+	if throw == nil || throw.RoundNumber != d.state.RoundNumber {
+		return
+	}
+	steamID := strconv.FormatUint(e.Player.SteamID64, 10)
+	for i := range throw.Affected {
+		if throw.Affected[i].SteamID == steamID {
+			throw.Affected[i].Damage += dmg
+			return
+		}
+	}
+	throw.Affected = append(throw.Affected, model.GrenadeVictim{
+		Name:    e.Player.Name,
+		SteamID: steamID,
+		Enemy:   e.Player.Team != e.Attacker.Team,
+		Damage:  dmg,
+	})
+}
+
+// flushGrenadeLog hands every logged grenade to its thrower's stats, in
+// throw order.
+func (d *DemoParser) flushGrenadeLog() {
+	gl := d.state.GrenadeLog
+	if gl == nil {
+		return
+	}
+	for i, throw := range gl.throws {
+		if ps := d.state.Players[gl.throwers[i]]; ps != nil {
+			ps.Grenades = append(ps.Grenades, *throw)
+		}
+	}
+}
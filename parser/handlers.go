@@ -9,11 +9,13 @@
 package parser
 
 import (
+	"log/slog"
+	"math"
+
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/rating"
 	"github.com/ethsmith/eco-rating/rating/probability"
 	"github.com/ethsmith/eco-rating/rating/swing"
-	"math"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs"
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -25,12 +27,17 @@ import (
 // This is the core of the parsing logic, delegating to focused handler methods.
 func (d *DemoParser) registerHandlers() {
 	d.registerMapHandler()
+	d.registerWarnHandler()
 	d.registerMatchHandlers()
+	d.registerSourceHandlers()
 	d.registerRoundLifecycleHandlers()
 	d.registerBombHandlers()
 	d.registerFlashHandlers()
 	d.registerKillHandler()
 	d.registerDamageHandler()
+	d.registerVisibilityHandler()
+	d.registerZoneControlHandlers()
+	d.registerPositionHandler()
 	d.registerRoundDecisionHandlers()
 	d.registerRoundEndHandler()
 }
@@ -79,6 +86,18 @@ func (d *DemoParser) registerMapHandler() {
 	})
 }
 
+// registerWarnHandler logs the demo library's non-fatal problems, such as
+// skipped malformed packets, which can explain odd stats in a damaged demo.
+func (d *DemoParser) registerWarnHandler() {
+	d.parser.RegisterEventHandler(func(e events.ParserWarn) {
+		level := slog.LevelWarn
+		if e.Type == events.WarnTypeUnknownProtobufMessage {
+			level = slog.LevelDebug // Routine after game updates
+		}
+		d.logAt(level, "Demo parser warning", "type", int(e.Type), "message", e.Message)
+	})
+}
+
 // registerMatchHandlers sets up match start/end detection.
 func (d *DemoParser) registerMatchHandlers() {
 	d.parser.RegisterEventHandler(func(e events.MatchStart) {
@@ -108,6 +127,7 @@ func (d *DemoParser) handleRoundStart() {
 	d.state.Round = make(map[uint64]*model.RoundStats)
 	d.state.RoundHasKill = false
 	d.state.TradeDetector.Reset()
+	d.state.RoundEvents.Reset()
 	d.state.RoundDecided = false
 	d.state.RoundDecidedAt = 0
 	d.state.BombPlanted = false
@@ -129,6 +149,14 @@ func (d *DemoParser) registerBombHandlers() {
 		d.handleBombDefused(e)
 	})
 
+	d.parser.RegisterEventHandler(func(e events.BombDefuseStart) {
+		d.handleBombDefuseStart(e)
+	})
+
+	d.parser.RegisterEventHandler(func(e events.BombDefuseAborted) {
+		d.handleBombDefuseAborted(e)
+	})
+
 	d.parser.RegisterEventHandler(func(e events.BombExplode) {
 		d.handleBombExplode()
 	})
@@ -144,14 +172,16 @@ func (d *DemoParser) handleBombPlanted(e events.BombPlanted) {
 	if d.collector != nil {
 		gs := d.parser.GameState()
 		tAlive, ctAlive := d.state.CountAlivePlayers(gs.Participants().Playing())
-		d.collector.RecordStateSnapshot(tAlive, ctAlive, false) // bomb not planted yet
+		d.collector.RecordStateSnapshot(tAlive, ctAlive, false, d.timeRemaining()) // bomb not planted yet
 	}
 
 	d.state.BombPlanted = true
+	d.state.BombPlantedAt = d.timeInRound()
 
 	planter := d.state.ensurePlayer(e.Player)
 	roundStats := d.state.ensureRound(e.Player)
 	roundStats.PlantedBomb = true
+	d.processExecuteSmokes()
 
 	// Track bomb plant swing
 	if d.state.SwingTracker != nil {
@@ -163,6 +193,8 @@ func (d *DemoParser) handleBombPlanted(e events.BombPlanted) {
 			Amount:      plantSwing,
 			TimeInRound: timeInRound,
 		})
+		d.observeThrowProbability("bomb_plant", map[common.Team]*common.Player{common.TeamTerrorists: e.Player})
+		d.auditSwing("bomb_plant", e.Player, nil, plantSwing, 0)
 	}
 
 	d.logger.LogBombPlant(d.state.RoundNumber, planter.Name)
@@ -178,7 +210,7 @@ func (d *DemoParser) handleBombDefused(e events.BombDefused) {
 	if d.collector != nil {
 		gs := d.parser.GameState()
 		tAlive, ctAlive := d.state.CountAlivePlayers(gs.Participants().Playing())
-		d.collector.RecordStateSnapshot(tAlive, ctAlive, true) // bomb is planted
+		d.collector.RecordStateSnapshot(tAlive, ctAlive, true, d.timeRemaining()) // bomb is planted
 	}
 
 	defuser := d.state.ensurePlayer(e.Player)
@@ -196,6 +228,8 @@ func (d *DemoParser) handleBombDefused(e events.BombDefused) {
 			Amount:      defuseSwing,
 			TimeInRound: timeInRound,
 		})
+		d.observeThrowProbability("bomb_defuse", map[common.Team]*common.Player{common.TeamCounterTerrorists: e.Player})
+		d.auditSwing("bomb_defuse", e.Player, nil, defuseSwing, 0)
 	}
 
 	d.logger.LogBombDefuse(d.state.RoundNumber, defuser.Name)
@@ -203,6 +237,24 @@ func (d *DemoParser) handleBombDefused(e events.BombDefused) {
 	// Mark round as decided - kills after defuse are exit frags
 	d.state.RoundDecided = true
 	d.state.RoundDecidedAt = timeInRound
+	d.state.RoundEvents.markDecided()
+}
+
+// handleBombDefuseStart records a defuse starting, so a kill that stops it
+// can be credited as a denial.
+func (d *DemoParser) handleBombDefuseStart(e events.BombDefuseStart) {
+	if d.state.ShouldSkipEvent() || e.Player == nil || d.state.SwingTracker == nil {
+		return
+	}
+	d.state.SwingTracker.StartDefuse(e.Player.SteamID64, e.HasKit, d.timeInRound())
+}
+
+// handleBombDefuseAborted records the defuser letting go of the bomb.
+func (d *DemoParser) handleBombDefuseAborted(e events.BombDefuseAborted) {
+	if d.state.ShouldSkipEvent() || e.Player == nil || d.state.SwingTracker == nil {
+		return
+	}
+	d.state.SwingTracker.AbortDefuse(e.Player.SteamID64, d.timeInRound())
 }
 
 // handleBombExplode marks the round as decided when the bomb explodes.
@@ -213,12 +265,13 @@ func (d *DemoParser) handleBombExplode() {
 	timeInRound := d.timeInRound()
 	d.state.RoundDecided = true
 	d.state.RoundDecidedAt = timeInRound
+	d.state.RoundEvents.markDecided()
 
 	// Record state snapshot at bomb explosion (e.g. 0v3_planted or 2v1_planted)
 	if d.collector != nil {
 		gs := d.parser.GameState()
 		tAlive, ctAlive := d.state.CountAlivePlayers(gs.Participants().Playing())
-		d.collector.RecordStateSnapshot(tAlive, ctAlive, true) // bomb is planted
+		d.collector.RecordStateSnapshot(tAlive, ctAlive, true, d.timeRemaining()) // bomb is planted
 	}
 
 	// Track bomb explode event
@@ -236,6 +289,10 @@ func (d *DemoParser) registerFlashHandlers() {
 	d.parser.RegisterEventHandler(func(e events.GrenadeProjectileThrow) {
 		d.handleGrenadeThrow(e)
 	})
+
+	d.parser.RegisterEventHandler(func(e events.GrenadeProjectileDestroy) {
+		d.handleGrenadeLogDestroy(e)
+	})
 }
 
 // handlePlayerFlashed processes a player flash event.
@@ -244,6 +301,8 @@ func (d *DemoParser) handlePlayerFlashed(e events.PlayerFlashed) {
 		return
 	}
 
+	d.recordGrenadeFlash(e)
+
 	if e.Attacker != nil && e.Player != nil {
 		roundStats := d.state.ensureRound(e.Attacker)
 		player := d.state.ensurePlayer(e.Attacker)
@@ -288,31 +347,68 @@ func (d *DemoParser) handleGrenadeThrow(e events.GrenadeProjectileThrow) {
 			player.MolotovsThrown++
 		}
 		player.TotalNadesThrown++
+		d.handleGrenadeLogThrow(e)
 	}
 }
 
-// handleFreezetimeEnd processes the end of freeze time, detecting knife rounds
-// and initializing round state for all participants.
+// handleFreezetimeEnd processes the end of freeze time, excluding rounds
+// that aren't live (warmup, knife rounds, restarts and replays) and
+// initializing round state for all participants.
 func (d *DemoParser) handleFreezetimeEnd() {
 	gs := d.parser.GameState()
 	if gs.IsWarmupPeriod() {
+		d.excluded.Warmup++
+		return
+	}
+	// Demos recorded mid-match never see the match start event
+	if gs.IsMatchStarted() {
+		d.state.MatchStarted = true
+	}
+	d.state.SkipRound = !d.state.MatchStarted
+	if d.state.SkipRound {
+		d.excluded.Warmup++
+		d.logAt(slog.LevelDebug, "Skipping round before match start", "source", string(d.Source()))
 		return
 	}
 	participants := gs.Participants().Playing()
-	if len(participants) > 0 {
-		firstPlayer := participants[0]
-		if firstPlayer.Money()+firstPlayer.MoneySpentThisRound() == 0 {
-			d.state.IsKnifeRound = true
-			d.logger.LogKnifeRound()
-			return
-		}
+	if d.sourceProfile().KnifeRounds && isKnifeRound(participants) {
+		d.state.IsKnifeRound = true
+		d.excluded.Knife++
+		d.logger.LogKnifeRound()
+		return
 	}
+	// A score that didn't move on since the last round went live means the
+	// game went back: a restart to 0-0 or a round backup restore.
+	scoreTotal := gs.TeamTerrorists().Score() + gs.TeamCounterTerrorists().Score()
+	if d.isRestart(gs.TeamTerrorists().Score(), gs.TeamCounterTerrorists().Score()) {
+		d.resetMatch()
+	} else if d.state.RoundNumber > 0 && scoreTotal <= d.lastScoreTotal {
+		d.restoreRound(scoreTotal)
+	}
+	d.lastScoreTotal = scoreTotal
+	if !d.sawLiveRound {
+		d.sawLiveRound = true
+		d.startRounds = scoreTotal
+	}
+	if scoreTotal >= d.duplicateFrom && scoreTotal < d.duplicateTo {
+		d.state.SkipRound = true
+		d.excluded.Duplicate++
+		d.logAt(slog.LevelDebug, "Skipping round counted from another demo of the match", "score_total", scoreTotal)
+		return
+	}
+	d.snapshotRound(scoreTotal)
 	d.state.IsKnifeRound = false
 	d.state.RoundNumber++
 
 	d.state.IsPistolRound = rating.IsPistolRound(d.state.RoundNumber)
+	d.state.GarbageTime = isGarbageTime(gs.TeamTerrorists().Score(), gs.TeamCounterTerrorists().Score())
+	d.state.Importance = d.importance.RoundImportance(gs.TeamTerrorists().Score(), gs.TeamCounterTerrorists().Score())
+	d.state.Leverage = rating.RoundLeverage(gs.TeamTerrorists().Score(), gs.TeamCounterTerrorists().Score())
 
 	d.state.RoundStartTime = d.currentTime()
+	d.state.TradeDetector.SetTickRate(d.tickRate())
+	d.state.Visibility.Reset()
+	d.state.ZoneControl.ResetRound()
 
 	for _, p := range participants {
 		if p.Team == common.TeamTerrorists {
@@ -339,7 +435,12 @@ func (d *DemoParser) handleFreezetimeEnd() {
 		d.state.ensurePlayer(p)
 		roundStats := d.state.ensureRound(p)
 		roundStats.IsPistolRound = d.state.IsPistolRound
+		roundStats.IsOvertime = rating.IsOvertimeRound(d.state.RoundNumber)
+		roundStats.GarbageTime = d.state.GarbageTime
 		roundStats.EquipmentValue = float64(p.EquipmentValueCurrent())
+		roundStats.StartMoney = p.Money() + p.MoneySpentThisRound()
+		roundStats.PrimaryWeapon = mainWeapon(p)
+		roundStats.Armor = p.Armor()
 
 		if p.Team == common.TeamTerrorists {
 			roundStats.PlayerSide = "T"
@@ -352,6 +453,8 @@ func (d *DemoParser) handleFreezetimeEnd() {
 		}
 	}
 
+	d.state.EconomyTracker.StartRound(d.state.RoundNumber, d.state.IsPistolRound, tEquipTotal, tAlive, ctEquipTotal, ctAlive)
+
 	// Cap at 5 per side as safety net (CS2 is 5v5)
 	if tAlive > 5 {
 		tAlive = 5
@@ -374,6 +477,8 @@ func (d *DemoParser) handleFreezetimeEnd() {
 			ctAvgEquip = float64(ctEquipTotal) / float64(ctAlive)
 		}
 		d.state.SwingTracker.SetEconomyFromValues(tAvgEquip, ctAvgEquip)
+		d.state.ThrowDetector.Reset(d.state.SwingTracker.GetCurrentWinProbability(common.TeamTerrorists))
+		d.state.SwingAudit.StartRound(d.state.RoundNumber, d.state.SwingTracker.GetCurrentWinProbability(common.TeamTerrorists))
 
 		// Store initial state for end-of-round calculation
 		d.state.RoundStartState = probability.NewRoundState(tAlive, ctAlive, d.state.MapName)
@@ -402,6 +507,8 @@ type killContext struct {
 	victimEquip   int
 	isTradeKill   bool
 	tradeSpeed    float64
+	isExitFrag    bool // Set by the round end pass
+	vsEco         bool // Victim was on an eco or a pistol buy
 }
 
 // handleKill processes a kill event, updating statistics for killer and victim.
@@ -426,11 +533,16 @@ func (d *DemoParser) handleKill(e events.Kill) {
 	d.state.TradeDetector.RecordKill(ctx.attacker, ctx.victim, ctx.currentTick)
 	d.recordKillForProbability(ctx)
 	d.processKillerStats(ctx)
+	d.processDuelTiming(ctx)
 	d.processWeaponStats(ctx)
+	d.processEngagementRange(ctx)
+	d.processZoneControlKill(ctx)
 	d.processOpeningKill(ctx)
+	d.processDefuseDenial(ctx)
 	d.processSwingTracking(ctx)
 	d.processEcoKillFlags(ctx)
 	d.processAssist(ctx)
+	d.state.RoundEvents.recordKill(ctx, d.state.BombPlanted, d.state.BombPlantedAt)
 }
 
 // shouldSkipKill returns true if the kill event should be ignored.
@@ -447,9 +559,8 @@ func (d *DemoParser) shouldSkipKill(e events.Kill) bool {
 
 // buildKillContext creates the context struct for a kill event.
 func (d *DemoParser) buildKillContext(e events.Kill) *killContext {
-	currentTick := d.parser.CurrentFrame()
-	currentTime := float64(currentTick) / float64(rating.TickRate)
-	timeInRound := currentTime - d.state.RoundStartTime
+	currentTick := d.currentTick()
+	timeInRound := d.timeInRound()
 
 	ctx := &killContext{
 		event:       e,
@@ -481,10 +592,15 @@ func (d *DemoParser) processVictimDeath(ctx *killContext) {
 	victim.Deaths++
 	victimRound := d.state.ensureRound(ctx.victim)
 	victimRound.DeathTime = ctx.timeInRound
+	victimRound.DeathEquipment = float64(ctx.victim.EquipmentValueCurrent())
+	if ctx.attacker != nil {
+		victimRound.KilledBy = ctx.attacker.SteamID64
+	}
 
 	// Check if this death puts a teammate into a clutch situation
 	// We need to check BEFORE the victim is marked dead in the game state
 	d.checkClutchEntry(ctx)
+	d.recordClutchKill(ctx)
 
 	for _, weapon := range ctx.victim.Weapons() {
 		if weapon.Type == common.EqAWP {
@@ -507,8 +623,14 @@ func (d *DemoParser) processTradeDetection(ctx *killContext) {
 			attackerStats := d.state.ensurePlayer(ctx.attacker)
 			attackerStats.TradeDenials++
 			attackerStats.SavedTeammate++
+			if tradeResult.WasOpeningDeath {
+				attackerStats.EntryTrades++
+			}
 			attackerRound := d.state.ensureRound(ctx.attacker)
 			attackerRound.SavedTeammate = true
+			if tradedRound, ok := d.state.Round[tradeResult.TradedPlayerID]; ok {
+				tradedRound.TradedBy = ctx.attacker.SteamID64
+			}
 
 			d.logger.LogTrade(d.state.RoundNumber, ctx.attacker.Name, tradeResult.TradedPlayerName, ctx.victim.Name)
 		}
@@ -544,7 +666,7 @@ func (d *DemoParser) recordKillForProbability(ctx *killContext) {
 	if ctAlive > 5 {
 		ctAlive = 5
 	}
-	d.collector.RecordStateSnapshot(tAlive, ctAlive, d.state.BombPlanted)
+	d.collector.RecordStateSnapshot(tAlive, ctAlive, d.state.BombPlanted, d.timeRemaining())
 	d.collector.RecordKill(float64(ctx.attackerEquip), float64(ctx.victimEquip))
 }
 
@@ -556,18 +678,19 @@ func (d *DemoParser) processKillerStats(ctx *killContext) {
 
 	d.logger.LogKill(d.state.RoundNumber, ctx.attacker.Name, ctx.victim.Name, ctx.attackerEquip, ctx.victimEquip, ctx.killValue)
 	d.logger.LogDeath(d.state.RoundNumber, ctx.victim.Name, ctx.attacker.Name, ctx.victimEquip, ctx.attackerEquip, ctx.deathPenalty)
+	d.logAt(slog.LevelDebug, "Kill",
+		"killer", ctx.attacker.Name, "victim", ctx.victim.Name,
+		"killer_equip", ctx.attackerEquip, "victim_equip", ctx.victimEquip,
+		"kill_value", ctx.killValue, "death_penalty", ctx.deathPenalty,
+		"time_in_round", ctx.timeInRound)
 
 	round.KillTimes = append(round.KillTimes, ctx.timeInRound)
 
-	if d.state.RoundDecided {
-		round.IsExitFrag = true
-		round.ExitFrags++
-	}
-
 	round.Kills++
 	round.GotKill = true
 	round.EconImpact += ctx.killValue
 	attacker.Kills++
+	attacker.KillsByVictim[ctx.victim.SteamID64]++
 	attacker.EcoKillValue += ctx.killValue
 	attacker.RoundImpact += ctx.killValue
 	attacker.EconImpact += ctx.killValue
@@ -704,10 +827,33 @@ func (d *DemoParser) processOpeningKill(ctx *killContext) {
 		victim.CTOpeningDeaths++
 	}
 
+	d.recordOpeningMatchup(ctx, attacker, victim)
+
 	d.state.RoundHasKill = true
 	d.logger.LogOpeningKill(d.state.RoundNumber, ctx.attacker.Name, ctx.victim.Name)
 }
 
+// processDefuseDenial credits the killer of a player who was defusing with
+// the share of the defuse's swing it had already earned, on top of the kill.
+func (d *DemoParser) processDefuseDenial(ctx *killContext) {
+	if d.state.SwingTracker == nil {
+		return
+	}
+	denial := d.state.SwingTracker.RecordDefuseDenial(ctx.attacker.SteamID64, ctx.victim.SteamID64, ctx.timeInRound)
+	if denial <= 0 {
+		return
+	}
+	round := d.state.ensureRound(ctx.attacker)
+	round.ProbabilitySwing += denial
+	round.AddSwingContribution(model.SwingContribution{
+		Type:        "defuse_denial",
+		Amount:      denial,
+		TimeInRound: ctx.timeInRound,
+		Opponent:    ctx.victim.Name,
+	})
+	d.auditSwing("defuse_denial", ctx.attacker, ctx.victim, denial, 0)
+}
+
 // processSwingTracking handles probability-based swing calculation.
 func (d *DemoParser) processSwingTracking(ctx *killContext) {
 	round := d.state.ensureRound(ctx.attacker)
@@ -729,8 +875,14 @@ func (d *DemoParser) processSwingTracking(ctx *killContext) {
 		ctx.isTradeKill, ctx.event.IsHeadshot,
 	)
 
+	d.observeThrowProbability("death", map[common.Team]*common.Player{
+		ctx.victim.Team:   ctx.victim,
+		ctx.attacker.Team: ctx.attacker,
+	})
+
 	swingResult := killResult.Swing
 	round.ProbabilitySwing += swingResult.KillerSwing
+	round.KillSwing += swingResult.RawSwing
 
 	victimRound := d.state.ensureRound(ctx.victim)
 	victimContribution := -swingResult.VictimSwing
@@ -758,6 +910,7 @@ func (d *DemoParser) processSwingTracking(ctx *killContext) {
 	victimRound.ProbabilitySwing += victimContribution
 	victimRound.LastDeathSwing = victimContribution
 	d.addKillSwingContribution(ctx, swingResult, victimContribution)
+	d.auditSwing("kill", ctx.attacker, ctx.victim, swingResult.KillerSwing, victimContribution)
 
 	// Credit damage contributors and flash assisters with their share of the kill swing
 	for contributorID, contributorSwing := range swingResult.ContributorSwings {
@@ -810,6 +963,7 @@ func (d *DemoParser) processEcoKillFlags(ctx *killContext) {
 	if equipRatio < 0.5 {
 		victimRound.AntiEcoKill = true
 	}
+	ctx.vsEco = d.isKillVsEco(ctx.victim)
 	if ctx.event.IsHeadshot {
 		attacker.PerfectKills++
 	}
@@ -846,6 +1000,7 @@ func (d *DemoParser) handlePlayerHurt(e events.PlayerHurt) {
 	}
 
 	dmg := int(e.HealthDamageTaken)
+	d.recordGrenadeDamage(e, dmg)
 
 	if e.Attacker.Team != e.Player.Team {
 		ps := d.state.ensurePlayer(e.Attacker)
@@ -872,6 +1027,7 @@ func (d *DemoParser) handlePlayerHurt(e events.PlayerHurt) {
 				ps.FireDamage += dmg
 			}
 		}
+		d.processMolotovZoneDamage(e, dmg)
 
 		// Track damage for swing attribution and TTK calculation
 		if d.state.SwingTracker != nil {
@@ -900,6 +1056,7 @@ func (d *DemoParser) handleRoundDecisionKill() {
 	if tAlive == 0 || ctAlive == 0 {
 		d.state.RoundDecided = true
 		d.state.RoundDecidedAt = d.timeInRound()
+		d.state.RoundEvents.markDecided()
 	}
 }
 
@@ -921,20 +1078,38 @@ type roundEndContext struct {
 
 // handleRoundEnd processes the end of a round, updating all player statistics.
 func (d *DemoParser) handleRoundEnd(e events.RoundEnd) {
-	if d.parser.GameState().IsWarmupPeriod() || d.state.IsKnifeRound {
+	if d.parser.GameState().IsWarmupPeriod() || d.state.IsKnifeRound || d.state.SkipRound {
 		return
 	}
 
 	ctx := d.buildRoundEndContext(e)
 
 	d.processRoundEndTrades()
+	d.classifyRoundKills()
+	d.tallyPaddingKills()
+	d.markEntryContact()
 	d.processMultiKills()
 	d.processSurvivalStats(ctx)
+	d.tallyAnchorRounds(ctx)
+	d.processEconomyStats(ctx)
 	d.processClutchDetection(ctx)
+	d.processEconomyForecast(ctx)
+	d.processThrows(ctx)
+	d.state.SwingAudit.EndRound(sideName(ctx.winnerTeam), d.state.Importance)
 	d.processProbabilitySwings(ctx)
+	for steamID, player := range d.state.Players {
+		round, ok := d.state.Round[steamID]
+		if !ok {
+			continue
+		}
+		d.logAt(slog.LevelDebug, "Round swing",
+			"player", player.Name, "swing", round.ProbabilitySwing,
+			"kills", round.Kills, "damage", round.Damage, "team_won", round.TeamWon)
+	}
 	d.updateSideStats()
 	d.incrementRoundsPlayed()
 	d.updateTeamScores(ctx.winnerTeam)
+	d.recordProgression(ctx.winnerTeam)
 	d.recordRoundEndProbability(ctx)
 
 	d.logger.LogRoundEnd(d.state.RoundNumber)
@@ -951,10 +1126,11 @@ func (d *DemoParser) buildRoundEndContext(e events.RoundEnd) *roundEndContext {
 		WithScores(d.state.TeamScore, d.state.EnemyScore).
 		WithRoundType(determineRoundType(d.state.RoundNumber)).
 		WithTimeRemaining(timeRemaining).
-		WithOvertime(d.state.RoundNumber > 30).
+		WithOvertime(rating.IsOvertimeRound(d.state.RoundNumber)).
 		WithMapSide(d.state.CurrentSide).
 		WithRoundDecision(d.state.RoundDecided, d.state.RoundDecidedAt).
 		CalculateImportance().
+		WithRoundImportance(d.state.Importance).
 		BuildFromRoundStats(d.state.Round)
 
 	return &roundEndContext{
@@ -968,12 +1144,17 @@ func (d *DemoParser) buildRoundEndContext(e events.RoundEnd) *roundEndContext {
 
 // processRoundEndTrades handles pending trades at round end.
 func (d *DemoParser) processRoundEndTrades() {
-	currentTick := d.parser.CurrentFrame()
+	currentTick := d.currentTick()
 	d.state.TradeDetector.ProcessRoundEndTrades(currentTick, d.state.Round)
 }
 
-// processMultiKills updates multi-kill statistics.
+// processMultiKills updates multi-kill statistics, weighting each 2k+ round
+// by the round state its kills came in.
 func (d *DemoParser) processMultiKills() {
+	var evenKillSwing float64
+	if d.state.SwingTracker != nil && d.state.SwingTracker.IsEnabled() {
+		evenKillSwing = d.state.SwingTracker.EvenKillSwing()
+	}
 	for steamID, roundStats := range d.state.Round {
 		player := d.state.Players[steamID]
 		if player == nil {
@@ -984,6 +1165,13 @@ func (d *DemoParser) processMultiKills() {
 			player.MultiKillsRaw[roundStats.Kills]++
 			d.logger.LogMultiKill(d.state.RoundNumber, player.Name, roundStats.Kills)
 		}
+		if roundStats.Kills >= 2 {
+			points := roundStats.Kills * roundStats.Kills
+			player.MultiKillPoints += points
+			player.WeightedMultiKills += float64(points) * multiKillContextWeight(roundStats.KillSwing, roundStats.Kills, evenKillSwing)
+			player.LeverageMultiKills += float64(points) * d.state.Leverage
+			player.MultiKillSwing += roundStats.KillSwing
+		}
 
 		if player.RoundsPlayed > 0 {
 			player.AWPKillsPerRound = float64(player.AWPKills) / float64(player.RoundsPlayed)
@@ -1080,6 +1268,7 @@ func (d *DemoParser) checkClutchEntry(ctx *killContext) {
 	var aliveTeammates int
 	var aliveEnemies int
 	var lastAliveTeammate *common.Player
+	var enemies []*common.Player
 
 	for _, p := range participants {
 		if p.SteamID64 == ctx.victim.SteamID64 {
@@ -1093,6 +1282,7 @@ func (d *DemoParser) checkClutchEntry(ctx *killContext) {
 			lastAliveTeammate = p
 		} else {
 			aliveEnemies++
+			enemies = append(enemies, p)
 		}
 	}
 
@@ -1103,6 +1293,7 @@ func (d *DemoParser) checkClutchEntry(ctx *killContext) {
 		// (use the highest enemy count - first entry into clutch)
 		if clutcherRound.ClutchEnteredSize == 0 {
 			clutcherRound.ClutchEnteredSize = aliveEnemies
+			clutcherRound.Clutch = d.startClutchDescriptor(lastAliveTeammate, enemies)
 		}
 	}
 }
@@ -1146,6 +1337,14 @@ func (d *DemoParser) recordClutchAttempt(ps *model.PlayerStats, round *model.Rou
 	if round.TeamWon {
 		round.ClutchWon = true
 		ps.ClutchWins++
+		ps.WeightedClutchWins += d.state.Importance
+		ps.LeverageClutchWins += d.state.Leverage
+	}
+
+	if desc := round.Clutch; desc != nil {
+		desc.Won = round.TeamWon
+		desc.Survived = round.Survived
+		ps.Clutches = append(ps.Clutches, *desc)
 	}
 }
 
@@ -1158,8 +1357,11 @@ func (d *DemoParser) processProbabilitySwings(ctx *roundEndContext) {
 		}
 
 		roundStats.MultiKillRound = roundStats.Kills
+		roundStats.Importance = d.state.Importance
+		roundStats.ProbabilitySwing *= roundStats.Importance
 
 		player.ProbabilitySwing += roundStats.ProbabilitySwing
+		player.EconomySwing += roundStats.EconomySwing
 		player.RoundBreakdowns = append(player.RoundBreakdowns, model.NewRoundSwingBreakdown(d.state.RoundNumber, roundStats))
 
 		if roundStats.PlayerSide == "T" {
@@ -1167,6 +1369,9 @@ func (d *DemoParser) processProbabilitySwings(ctx *roundEndContext) {
 		} else if roundStats.PlayerSide == "CT" {
 			player.CTProbabilitySwing += roundStats.ProbabilitySwing
 		}
+		if roundStats.IsOvertime {
+			player.OTProbabilitySwing += roundStats.ProbabilitySwing
+		}
 	}
 }
 
@@ -1208,6 +1413,19 @@ func (d *DemoParser) updateTeamScores(winnerTeam common.Team) {
 	}
 }
 
+// recordProgression appends the winner of the round ending to the match's
+// score progression.
+func (d *DemoParser) recordProgression(winner common.Team) {
+	side := byte('D')
+	switch winner {
+	case common.TeamTerrorists:
+		side = 'T'
+	case common.TeamCounterTerrorists:
+		side = 'C'
+	}
+	d.state.Progression = append(d.state.Progression, side)
+}
+
 // recordRoundEndProbability records round outcome for probability collection.
 func (d *DemoParser) recordRoundEndProbability(ctx *roundEndContext) {
 	if d.collector == nil {
@@ -1222,7 +1440,7 @@ func (d *DemoParser) recordRoundEndProbability(ctx *roundEndContext) {
 	// player alive states (engine resetting for next round), producing false
 	// Xv0 or 0vX snapshots.
 	if tAlive > 0 && ctAlive > 0 {
-		d.collector.RecordStateSnapshot(tAlive, ctAlive, d.state.BombPlanted)
+		d.collector.RecordStateSnapshot(tAlive, ctAlive, d.state.BombPlanted, d.timeRemaining())
 	}
 
 	d.collector.RecordRoundEnd(tAlive, ctAlive, d.state.BombPlanted, ctx.winnerTeam, d.state.MapName)
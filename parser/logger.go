@@ -10,6 +10,7 @@ package parser
 
 import (
 	"bytes"
+	"io"
 	"log"
 )
 
@@ -35,6 +36,7 @@ type ParserLogger interface {
 	LogKnifeRound()
 	LogWarmup()
 	Printf(format string, v ...interface{})
+	SetOutput(w io.Writer)
 }
 
 // noOpLogger is a no-op implementation that does nothing.
@@ -63,6 +65,7 @@ func (n *noOpLogger) LogBombDefuse(round int, defuser string) {}
 func (n *noOpLogger) LogKnifeRound()                          {}
 func (n *noOpLogger) LogWarmup()                              {}
 func (n *noOpLogger) Printf(format string, v ...interface{})  {}
+func (n *noOpLogger) SetOutput(w io.Writer)                   {}
 
 // sharedNoOpLogger is a singleton no-op logger to avoid allocations.
 var sharedNoOpLogger = &noOpLogger{}
@@ -102,6 +105,12 @@ func (l *Logger) ClearOutput() {
 	l.buffer.Reset()
 }
 
+// SetOutput sends each log line to w as it is written, as well as to the
+// buffer GetOutput returns.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.logger.SetOutput(io.MultiWriter(l.buffer, w))
+}
+
 // SetPlayerFilter sets the list of player names to include in logging.
 // Only events involving these players will be logged.
 func (l *Logger) SetPlayerFilter(players []string) {
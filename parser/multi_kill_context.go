@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"github.com/ethsmith/eco-rating/rating/probability"
+	"github.com/ethsmith/eco-rating/rating/swing"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// MaxMultiKillContextWeight caps how much a single multi-kill round can be
+// scaled up by its round state.
+const MaxMultiKillContextWeight = 3.0
+
+// EvenKillSwing returns the raw win probability swing of the first kill of a
+// full-buy 5v5 on the current map, averaged over both sides. Multi-kill
+// context weights are measured against it.
+func (st *SwingTracker) EvenKillSwing() float64 {
+	mapName := ""
+	if st.roundState != nil {
+		mapName = st.roundState.Map
+	}
+	state := probability.NewRoundState(5, 5, mapName)
+	tKill := st.calculator.CalculateSingleKillSwing(state, &swing.KillEvent{KillerSide: common.TeamTerrorists, VictimSide: common.TeamCounterTerrorists})
+	ctKill := st.calculator.CalculateSingleKillSwing(state, &swing.KillEvent{KillerSide: common.TeamCounterTerrorists, VictimSide: common.TeamTerrorists})
+	return (tKill + ctKill) / 2
+}
+
+// multiKillContextWeight scales a multi-kill round by how much its kills moved
+// the round: the average raw swing per kill relative to an even 5v5 kill. A
+// 3k in a 3v5 retake weighs well above 1, a 3k of exit frags near 0.
+func multiKillContextWeight(killSwing float64, kills int, evenKillSwing float64) float64 {
+	if kills == 0 || evenKillSwing <= 0 {
+		return 1
+	}
+	return min(killSwing/float64(kills)/evenKillSwing, MaxMultiKillContextWeight)
+}
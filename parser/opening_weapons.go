@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// Weapon categories opening duels are broken down by.
+const (
+	weaponAWP    = "awp"
+	weaponRifle  = "rifle" // Includes the scout and auto-snipers
+	weaponSMG    = "smg"
+	weaponHeavy  = "heavy" // Shotguns and machine guns
+	weaponPistol = "pistol"
+	weaponOther  = "other" // Knife, grenades, zeus
+)
+
+// weaponCategory returns the opening duel category of a weapon.
+func weaponCategory(w *common.Equipment) string {
+	if w == nil {
+		return weaponOther
+	}
+	if w.Type == common.EqAWP {
+		return weaponAWP
+	}
+	switch w.Class() {
+	case common.EqClassRifle:
+		return weaponRifle
+	case common.EqClassSMG:
+		return weaponSMG
+	case common.EqClassHeavy:
+		return weaponHeavy
+	case common.EqClassPistols:
+		return weaponPistol
+	}
+	return weaponOther
+}
+
+// recordOpeningMatchup counts the round's opening duel under its weapon
+// matchup for both players: "awp_vs_rifle" is a win for an AWPer over a
+// rifler and, from the rifler's side, "rifle_vs_awp" is a loss. The victim's
+// weapon is the one they held when they died, or their best gun if the
+// demo no longer shows one in hand.
+func (d *DemoParser) recordOpeningMatchup(ctx *killContext, attacker, victim *model.PlayerStats) {
+	killerWeapon := weaponCategory(ctx.event.Weapon)
+	held := ctx.victim.ActiveWeapon()
+	if held == nil {
+		held = bestGun(ctx.victim)
+	}
+	victimWeapon := weaponCategory(held)
+
+	if attacker.OpeningWinsByMatchup == nil {
+		attacker.OpeningWinsByMatchup = make(map[string]int)
+	}
+	if victim.OpeningLossesByMatchup == nil {
+		victim.OpeningLossesByMatchup = make(map[string]int)
+	}
+	attacker.OpeningWinsByMatchup[killerWeapon+"_vs_"+victimWeapon]++
+	victim.OpeningLossesByMatchup[victimWeapon+"_vs_"+killerWeapon]++
+}
@@ -9,11 +9,12 @@
 package parser
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 
+	"github.com/ethsmith/eco-rating/logging"
 	"github.com/ethsmith/eco-rating/model"
 	"github.com/ethsmith/eco-rating/rating"
 	"github.com/ethsmith/eco-rating/rating/probability"
@@ -27,8 +28,24 @@ type DemoParser struct {
 	parser       demoinfocs.Parser
 	state        *MatchState
 	logger       ParserLogger
+	structured   *slog.Logger // Warnings and debug records, tagged with map and round
 	collector    *probability.DataCollector
 	kdprModifier bool
+
+	exitFragPenalty float64
+	importance      rating.ImportanceModel
+	tier            string // Competitive tier, selects per-tier rating baselines
+
+	source      DemoSource // Platform the demo was recorded on, set or detected
+	sourceFixed bool       // Source set by the caller; detection is off
+
+	excluded       model.RoundExclusions // Rounds played but not counted, by reason
+	snapshots      []roundSnapshot       // Counted state at each live round start, for backup restores
+	lastScoreTotal int                   // Rounds won by both teams going into the last live round
+	sawLiveRound   bool                  // A live round has started
+	startRounds    int                   // Rounds won by both teams going into the first live round
+	duplicateFrom  int                   // Rounds starting with a score total in [duplicateFrom, duplicateTo)
+	duplicateTo    int                   // are counted from another demo and skipped here
 }
 
 // NewDemoParser creates a new DemoParser with logging disabled.
@@ -44,7 +61,7 @@ func NewDemoParserWithLogging(r io.Reader, enableLogging bool) *DemoParser {
 
 // NewDemoParserWithOptions creates a new DemoParser with configurable logging and KPR/DPR modifier.
 func NewDemoParserWithOptions(r io.Reader, enableLogging bool, kdprModifier bool) *DemoParser {
-	p := demoinfocs.NewParser(r)
+	p := newDemoinfocsParser(r)
 	state := NewMatchState()
 
 	dp := &DemoParser{
@@ -53,20 +70,51 @@ func NewDemoParserWithOptions(r io.Reader, enableLogging bool, kdprModifier bool
 		logger:       NewLogger(enableLogging),
 		collector:    probability.NewDataCollector(),
 		kdprModifier: kdprModifier,
+
+		exitFragPenalty: DefaultExitFragPenalty,
+		importance:      rating.FlatImportance{},
 	}
 
+	dp.SetStructuredLogger(slog.Default())
 	dp.registerHandlers()
 	return dp
 }
 
+// newDemoinfocsParser creates the demo library's parser for r. It skips the
+// rare malformed entity packet or unknown bombsite instead of failing the
+// whole demo; the library reports both as ParserWarn events.
+func newDemoinfocsParser(r io.Reader) demoinfocs.Parser {
+	config := demoinfocs.DefaultParserConfig
+	config.IgnorePacketEntitiesPanic = true
+	config.IgnoreErrBombsiteIndexNotFound = true
+	return demoinfocs.NewParserWithConfig(r, config)
+}
+
 // GetCollector returns the probability data collector for merging in cumulative mode.
 func (d *DemoParser) GetCollector() *probability.DataCollector {
 	return d.collector
 }
 
-// currentTime returns the current game time in seconds based on the current frame.
+// currentTick returns the server tick the demo has reached. Ticks, unlike
+// demo frames, advance at the server's tick rate however often the demo
+// recorded a snapshot.
+func (d *DemoParser) currentTick() int {
+	return d.parser.GameState().IngameTick()
+}
+
+// tickRate returns the server tick rate read from the demo, or
+// rating.TickRate while the demo hasn't reported one.
+func (d *DemoParser) tickRate() float64 {
+	if rate := d.parser.TickRate(); rate > 0 {
+		return rate
+	}
+	return rating.TickRate
+}
+
+// currentTime returns the current game time in seconds, from the current
+// tick at the demo's tick rate.
 func (d *DemoParser) currentTime() float64 {
-	return float64(d.parser.CurrentFrame()) / float64(rating.TickRate)
+	return float64(d.currentTick()) / d.tickRate()
 }
 
 // timeInRound returns the elapsed time since the round started.
@@ -74,11 +122,76 @@ func (d *DemoParser) timeInRound() float64 {
 	return d.currentTime() - d.state.RoundStartTime
 }
 
+// SetDuplicateRounds skips the rounds that start with from to to-1 rounds
+// won by both teams, because another demo of the same match already counts
+// them: the match was restored from a round backup and recorded again from
+// there. They are reported as duplicate excluded rounds.
+func (d *DemoParser) SetDuplicateRounds(from, to int) {
+	d.duplicateFrom = from
+	d.duplicateTo = to
+}
+
+// SetImportanceModel sets the model that weighs each round's swing and
+// clutch credit by the score going into it (flat by default).
+func (d *DemoParser) SetImportanceModel(m rating.ImportanceModel) {
+	d.importance = m
+}
+
+// SetTier sets the competitive tier the demo was played in, so ratings use
+// that tier's baselines.
+func (d *DemoParser) SetTier(tier string) {
+	d.tier = tier
+}
+
+// SetGrenadeLog enables or disables recording every grenade throw into the
+// players' stats. It's off by default; the throws are only needed for the
+// grenades export.
+func (d *DemoParser) SetGrenadeLog(enabled bool) {
+	d.state.GrenadeLog = nil
+	if enabled {
+		d.state.GrenadeLog = NewGrenadeLog()
+	}
+}
+
+// SetSwingAudit enables or disables recording every swing-affecting event.
+// It's off by default; the events are only needed for the swing audit
+// export.
+func (d *DemoParser) SetSwingAudit(enabled bool) {
+	d.state.SwingAudit = nil
+	if enabled {
+		d.state.SwingAudit = NewSwingAuditLog()
+	}
+}
+
 // SetLogging enables or disables detailed parsing logs.
 func (d *DemoParser) SetLogging(enabled bool) {
 	d.logger.SetEnabled(enabled)
 }
 
+// SetStructuredLogger sets the logger for warnings and debug records, such
+// as every kill's value and each player's rating components, and for the
+// detailed parsing log's lines. Records are tagged with the current map and
+// round; callers usually add the demo file, e.g. slog.With("demo", name).
+func (d *DemoParser) SetStructuredLogger(l *slog.Logger) {
+	d.structured = l
+	d.logger.SetOutput(logging.NewWriter(l, d.context))
+}
+
+// context returns the current map and round as record attributes.
+func (d *DemoParser) context() []any {
+	return []any{"map", d.state.MapName, "round", d.state.RoundNumber}
+}
+
+// logAt records msg at level with the current map and round, skipping the
+// work when level is disabled.
+func (d *DemoParser) logAt(level slog.Level, msg string, args ...any) {
+	ctx := context.Background()
+	if !d.structured.Enabled(ctx, level) {
+		return
+	}
+	d.structured.Log(ctx, level, msg, append(d.context(), args...)...)
+}
+
 // SetPlayerFilter limits logging to events involving the specified players.
 func (d *DemoParser) SetPlayerFilter(players []string) {
 	d.logger.SetPlayerFilter(players)
@@ -97,22 +210,52 @@ func (d *DemoParser) ClearPlayerFilter() {
 // Parse processes the entire demo file and computes all player statistics.
 // After parsing, it calculates derived metrics (ADR, KPR, ratings, etc.)
 // and the final eco-rating for each player.
-// Returns an error if parsing fails. Truncated demos (ErrUnexpectedEndOfDemo)
-// are handled gracefully — stats collected up to the truncation point are kept.
+// Returns a *DemoError if parsing fails. Truncated or corrupt demos with at
+// least one completed round are handled gracefully: stats collected up to the
+// failure are kept and the DemoError is marked Partial (see IsPartial).
 func (d *DemoParser) Parse() error {
-	if err := d.parser.ParseToEnd(); err != nil {
-		if errors.Is(err, demoinfocs.ErrUnexpectedEndOfDemo) {
-			log.Printf("Warning: demo truncated (unexpected EOF), using partial data")
-		} else {
-			return fmt.Errorf("failed to parse demo: %w", err)
-		}
+	err := d.parseToEnd()
+	if err == nil {
+		d.computeDerivedStats()
+		return nil
 	}
+	rounds := d.completedRounds()
+	if rounds == 0 {
+		return err
+	}
+	err.Partial = true
+	err.Rounds = rounds
+	d.logAt(slog.LevelWarn, "Demo parse failed, keeping partial stats", "kind", err.Kind, "rounds", rounds, "err", err.Err)
 	d.computeDerivedStats()
+	return err
+}
+
+// parseToEnd runs the demo library to the end of the demo, converting its
+// errors and panics into a DemoError.
+func (d *DemoParser) parseToEnd() (derr *DemoError) {
+	defer func() {
+		if r := recover(); r != nil {
+			derr = &DemoError{Kind: KindPanic, Err: fmt.Errorf("parser panic: %v", r)}
+		}
+	}()
+	if err := d.parser.ParseToEnd(); err != nil {
+		return classifyParseError(err)
+	}
 	return nil
 }
 
+// completedRounds returns the most rounds any player completed.
+func (d *DemoParser) completedRounds() int {
+	rounds := 0
+	for _, p := range d.state.Players {
+		rounds = max(rounds, p.RoundsPlayed)
+	}
+	return rounds
+}
+
 // computeDerivedStats calculates all derived metrics for each player after parsing.
 func (d *DemoParser) computeDerivedStats() {
+	d.flushGrenadeLog()
 
 	for _, p := range d.state.Players {
 		if p.RoundsPlayed > 0 {
@@ -137,9 +280,13 @@ func (d *DemoParser) computeDerivedStats() {
 
 			// Pistol round rating
 			if p.PistolRoundsPlayed > 0 {
-				p.PistolRoundRating = rating.ComputePistolRoundRating(
-					p.PistolRoundsPlayed, p.PistolRoundKills, p.PistolRoundDeaths,
-					p.PistolRoundSurvivals, p.PistolRoundMultiKills)
+				p.PistolRoundRating = rating.ComputePistolRating(rating.PistolInput{
+					RoundsPlayed:    p.PistolRoundsPlayed,
+					Kills:           p.PistolRoundKills,
+					Damage:          p.PistolRoundDamage,
+					Survivals:       p.PistolRoundSurvivals,
+					MultiKillRounds: p.PistolRoundMultiKills,
+				})
 			}
 
 			// Side-specific HLTV ratings
@@ -153,11 +300,19 @@ func (d *DemoParser) computeDerivedStats() {
 					p.CTRoundsPlayed, p.CTKills, p.CTDeaths, p.CTSurvivals, p.CTMultiKills)
 			}
 
+			if p.OTRoundsPlayed > 0 {
+				p.OTRating = rating.ComputeSideHLTVRating(
+					p.OTRoundsPlayed, p.OTKills, p.OTDeaths, p.OTSurvivals, p.OTMultiKills)
+			}
+
 			p.TimeAlivePerRound = p.TotalTimeAlive / rounds
 			p.EnemyFlashDurationPerRound = p.EnemyFlashDuration / rounds
 			p.TeamFlashDurationPerRound = p.TeamFlashDuration / rounds
 			p.RoundsWithKillPct = float64(p.RoundsWithKill) / rounds
 			p.RoundsWithMultiKillPct = float64(p.RoundsWithMultiKill) / rounds
+			if p.MultiKillPoints > 0 {
+				p.MultiKillContextWeight = p.WeightedMultiKills / float64(p.MultiKillPoints)
+			}
 			p.SavedByTeammatePerRound = float64(p.SavedByTeammate) / rounds
 			p.TradedDeathsPerRound = float64(p.TradedDeaths) / rounds
 			p.AssistsPerRound = float64(p.Assists) / rounds
@@ -177,17 +332,34 @@ func (d *DemoParser) computeDerivedStats() {
 			p.UtilityKillsPer100Rounds = float64(p.UtilityKills) * 100 / rounds
 			p.FlashesThrownPerRound = float64(p.FlashesThrown) / rounds
 			p.FlashAssistsPerRound = float64(p.FlashAssists) / rounds
+			p.TeamSwingWhileAlivePerRound = p.TeamSwingWhileAlive / rounds
 		}
 
 		if p.RoundsWon > 0 {
 			p.KillsPerRoundWin = float64(p.KillsInWonRounds) / float64(p.RoundsWon)
 			p.DamagePerRoundWin = float64(p.DamageInWonRounds) / float64(p.RoundsWon)
+			p.DeathsPerRoundWin = float64(p.DeathsInWonRounds) / float64(p.RoundsWon)
+			p.UtilDamagePerRoundWin = float64(p.UtilDamageInWonRounds) / float64(p.RoundsWon)
 		}
 
 		if p.RoundsLost > 0 {
+			p.KillsPerRoundLoss = float64(p.KillsInLostRounds) / float64(p.RoundsLost)
+			p.DeathsPerRoundLoss = float64(p.DeathsInLostRounds) / float64(p.RoundsLost)
+			p.DamagePerRoundLoss = float64(p.DamageInLostRounds) / float64(p.RoundsLost)
+			p.UtilDamagePerRoundLoss = float64(p.UtilDamageInLostRounds) / float64(p.RoundsLost)
 			p.SavesPerRoundLoss = float64(p.SavesOnLoss) / float64(p.RoundsLost)
 		}
 
+		if p.EcoRounds > 0 {
+			p.EcoRoundWinPct = float64(p.EcoRoundWins) / float64(p.EcoRounds)
+		}
+		if p.ForceBuyRounds > 0 {
+			p.ForceBuyWinPct = float64(p.ForceBuyWins) / float64(p.ForceBuyRounds)
+		}
+		if p.FullBuyRounds > 0 {
+			p.FullBuyWinPct = float64(p.FullBuyWins) / float64(p.FullBuyRounds)
+		}
+
 		if p.Deaths > 0 {
 			p.TradedDeathsPct = float64(p.TradedDeaths) / float64(p.Deaths)
 		}
@@ -203,6 +375,9 @@ func (d *DemoParser) computeDerivedStats() {
 			p.AWPKillsPct = float64(p.AWPKills) / float64(p.Kills)
 			p.LowBuyKillsPct = float64(p.LowBuyKills) / float64(p.Kills)
 			p.DisadvantagedBuyKillsPct = float64(p.DisadvantagedBuyKills) / float64(p.Kills)
+			p.KillsVsEcoPct = float64(p.KillsVsEco) / float64(p.Kills)
+			p.DecidedRoundKillsPct = float64(p.DecidedRoundKills) / float64(p.Kills)
+			p.StatPaddingIndex = float64(p.PaddingKills) / float64(p.Kills)
 			p.HeadshotPct = float64(p.Headshots) / float64(p.Kills)
 			p.ManAdvantageKillsPct = float64(p.ManAdvantageKills) / float64(p.Kills)
 		}
@@ -211,9 +386,22 @@ func (d *DemoParser) computeDerivedStats() {
 			p.ManDisadvantageDeathsPct = float64(p.ManDisadvantageDeaths) / float64(p.Deaths)
 		}
 
+		if n := p.CloseRangeKills + p.MidRangeKills + p.LongRangeKills; n > 0 {
+			p.AvgKillDistance = p.KillDistanceTotal / float64(n)
+		}
+		if n := p.CloseRangeDeaths + p.MidRangeDeaths + p.LongRangeDeaths; n > 0 {
+			p.AvgDeathDistance = p.DeathDistanceTotal / float64(n)
+		}
+
 		if p.KillsWithTTK > 0 {
 			p.AvgTimeToKill = p.TotalTimeToKill / float64(p.KillsWithTTK)
 		}
+		if p.KillsWithSightTime > 0 {
+			p.AvgSightToKill = p.TotalSightToKill / float64(p.KillsWithSightTime)
+		}
+		if p.Duels > 0 {
+			p.FirstDamagePct = float64(p.FirstDamageDuels) / float64(p.Duels)
+		}
 
 		if p.OpeningAttempts > 0 {
 			p.OpeningSuccessPct = float64(p.OpeningSuccesses) / float64(p.OpeningAttempts)
@@ -232,6 +420,14 @@ func (d *DemoParser) computeDerivedStats() {
 			p.AvgTimeToDeath = p.TotalDeathTime / float64(p.DeathTimeRounds)
 		}
 
+		if p.TPositionSamples > 0 {
+			p.AvgTTeammateDistance = p.TTeammateDistanceTotal / float64(p.TPositionSamples)
+		}
+		if p.CTPositionSamples > 0 {
+			p.AvgCTTeammateDistance = p.CTTeammateDistanceTotal / float64(p.CTPositionSamples)
+			p.CTBombZonePct = float64(p.CTBombZoneSamples) / float64(p.CTPositionSamples)
+		}
+
 		// Calculate DamagePerRound (same as ADR but explicit field)
 		if p.RoundsPlayed > 0 {
 			p.DamagePerRound = float64(p.Damage) / float64(p.RoundsPlayed)
@@ -247,6 +443,7 @@ func (d *DemoParser) computeDerivedStats() {
 		if p.RoundsPlayed > 0 {
 			rounds := float64(p.RoundsPlayed)
 			p.ProbabilitySwingPerRound = p.ProbabilitySwing / rounds
+			p.EconomySwingPerRound = p.EconomySwing / rounds
 			// DuelSwing: EcoKillValue - EcoDeathValue (net duel economy impact)
 			p.DuelSwing = p.EcoKillValue - p.EcoDeathValue
 			p.DuelSwingPerRound = p.DuelSwing / rounds
@@ -259,12 +456,15 @@ func (d *DemoParser) computeDerivedStats() {
 			}
 		}
 
-		p.FinalRating = rating.ComputeFinalRating(p, d.kdprModifier)
+		p.RatingBreakdown = rating.ComputeRatingBreakdown(p, d.state.MapName, d.tier, d.kdprModifier)
+		p.FinalRating = p.RatingBreakdown.FinalRating
+		d.computeRoundRatings(p)
+		d.computeGarbageTimeRating(p)
 
 		if p.TRoundsPlayed > 0 {
-			p.TEcoRating = rating.ComputeSideRating(
-				p.TRoundsPlayed, p.TKills, p.TDeaths, p.TDamage, p.TEcoKillValue,
-				p.TProbabilitySwing, p.TKAST, p.TMultiKills, p.TClutchRounds, p.TClutchWins, d.kdprModifier)
+			p.TEcoRating = rating.ComputeRoundsRating(
+				p.TRoundsPlayed, p.TKills, p.TDeaths, p.TDamage,
+				p.TKAST, p.TProbabilitySwing, d.state.MapName, d.tier, d.kdprModifier)
 		}
 		if p.TKills > 0 {
 			p.TManAdvantageKillsPct = float64(p.TManAdvantageKills) / float64(p.TKills)
@@ -273,9 +473,14 @@ func (d *DemoParser) computeDerivedStats() {
 			p.TManDisadvantageDeathsPct = float64(p.TManDisadvantageDeaths) / float64(p.TDeaths)
 		}
 		if p.CTRoundsPlayed > 0 {
-			p.CTEcoRating = rating.ComputeSideRating(
-				p.CTRoundsPlayed, p.CTKills, p.CTDeaths, p.CTDamage, p.CTEcoKillValue,
-				p.CTProbabilitySwing, p.CTKAST, p.CTMultiKills, p.CTClutchRounds, p.CTClutchWins, d.kdprModifier)
+			p.CTEcoRating = rating.ComputeRoundsRating(
+				p.CTRoundsPlayed, p.CTKills, p.CTDeaths, p.CTDamage,
+				p.CTKAST, p.CTProbabilitySwing, d.state.MapName, d.tier, d.kdprModifier)
+		}
+		if p.OTRoundsPlayed > 0 {
+			p.OTEcoRating = rating.ComputeRoundsRating(
+				p.OTRoundsPlayed, p.OTKills, p.OTDeaths, p.OTDamage,
+				p.OTKAST, p.OTProbabilitySwing, d.state.MapName, d.tier, d.kdprModifier)
 		}
 		if p.CTKills > 0 {
 			p.CTManAdvantageKillsPct = float64(p.CTManAdvantageKills) / float64(p.CTKills)
@@ -285,6 +490,12 @@ func (d *DemoParser) computeDerivedStats() {
 		}
 
 		d.logger.LogPlayerSummary(p.Name, p.Kills, p.Deaths, p.Damage, p.EcoKillValue, p.EcoDeathValue, p.FinalRating)
+		b := p.RatingBreakdown
+		d.logAt(slog.LevelDebug, "Player rating",
+			"player", p.Name, "steam_id", p.SteamID, "rounds", p.RoundsPlayed,
+			"rating", p.FinalRating, "unclamped", b.UnclampedRating,
+			"kpr_dpr", b.KPRDPR.Contribution, "adr", b.ADR.Contribution,
+			"kast", b.KAST.Contribution, "swing", b.ProbabilitySwing.Contribution)
 	}
 }
 
@@ -293,12 +504,78 @@ func (d *DemoParser) GetPlayers() map[uint64]*model.PlayerStats {
 	return d.state.Players
 }
 
+// GetSwingAudit returns every round's swing-affecting events, none unless
+// SetSwingAudit enabled them.
+func (d *DemoParser) GetSwingAudit() []model.SwingAuditRound {
+	return d.state.SwingAudit.Rounds()
+}
+
 // GetMapName returns the name of the map played (e.g., "de_dust2").
 func (d *DemoParser) GetMapName() string {
 	return d.state.MapName
 }
 
+// GetMatchInfo returns the demo's map, final score and team names, tick
+// rate, duration, source, excluded rounds and score progression. Call it
+// after Parse; MatchID and StartTime are left for the caller to fill in.
+func (d *DemoParser) GetMatchInfo() model.MatchInfo {
+	info := matchInfo(d.parser, d.state.MapName)
+	info.Source = string(d.Source())
+	info.Excluded = d.excluded
+	info.StartRounds = d.startRounds
+	info.Progression = string(d.state.Progression)
+	return info
+}
+
+// matchInfo reads the match metadata of the demo p has parsed so far.
+func matchInfo(p demoinfocs.Parser, mapName string) model.MatchInfo {
+	info := model.MatchInfo{
+		Map:             mapName,
+		TickRate:        p.TickRate(),
+		DurationSeconds: p.CurrentTime().Seconds(),
+	}
+	gs := p.GameState()
+	ct, t := gs.TeamCounterTerrorists(), gs.TeamTerrorists()
+	if ct == nil || t == nil {
+		return info
+	}
+	if t.Score() > ct.Score() {
+		ct, t = t, ct
+	}
+	info.Team1, info.Team1Score = ct.ClanName(), ct.Score()
+	info.Team2, info.Team2Score = t.ClanName(), t.Score()
+	return info
+}
+
 // GetLogs returns all captured log output from parsing.
 func (d *DemoParser) GetLogs() string {
 	return d.logger.GetOutput()
 }
+
+// computeRoundRatings fills in the per-round and running eco-rating of each
+// round breakdown, so a player's performance can be charted across the match.
+// Both are rated like FinalRating.
+func (d *DemoParser) computeRoundRatings(p *model.PlayerStats) {
+	var kills, deaths, damage, kast int
+	var swing float64
+	for i := range p.RoundBreakdowns {
+		rb := &p.RoundBreakdowns[i]
+		roundDeaths, roundKAST := 0, 0.0
+		if rb.Died {
+			roundDeaths = 1
+		}
+		if rb.KAST {
+			roundKAST = 1
+		}
+		rb.Rating = rating.ComputeRoundsRating(1, rb.Kills, roundDeaths, rb.Damage,
+			roundKAST, rb.ProbabilitySwing, d.state.MapName, d.tier, d.kdprModifier)
+
+		kills += rb.Kills
+		deaths += roundDeaths
+		damage += rb.Damage
+		kast += int(roundKAST)
+		swing += rb.ProbabilitySwing
+		rb.RunningRating = rating.ComputeRoundsRating(i+1, kills, deaths, damage,
+			float64(kast), swing, d.state.MapName, d.tier, d.kdprModifier)
+	}
+}
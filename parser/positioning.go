@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"math"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// positionSampleInterval is the seconds of match time between position
+// samples.
+const positionSampleInterval = 1.0
+
+// unknownZone is the zone name for positions the map gives no callout.
+const unknownZone = "Unknown"
+
+// PositionTracker paces the position samples that feed the positioning
+// stats: how far players play from their nearest teammate, and how much of
+// the CT side they spend on a bomb site.
+type PositionTracker struct {
+	lastSample float64 // Match time of the last sample
+}
+
+// NewPositionTracker creates a position tracker.
+func NewPositionTracker() *PositionTracker {
+	return &PositionTracker{}
+}
+
+// registerPositionHandler samples player positions as the demo plays.
+func (d *DemoParser) registerPositionHandler() {
+	d.parser.RegisterEventHandler(func(events.FrameDone) {
+		d.samplePositions()
+	})
+}
+
+// samplePositions records, for every living player, the named map zone they
+// stand in, the distance to their nearest living teammate and, on the CT
+// side, whether they stand in a bomb site and hold it (see sampleAnchor).
+// Samples are taken every positionSampleInterval seconds of live round
+// time; players with no teammate left alive are skipped for the distance
+// and bomb site samples.
+func (d *DemoParser) samplePositions() {
+	gs := d.parser.GameState()
+	if d.state.ShouldSkipEvent() || gs.IsWarmupPeriod() || gs.IsFreezetimePeriod() || d.state.RoundDecided {
+		return
+	}
+	pt := d.state.Positioning
+	now := d.currentTime()
+	if now-pt.lastSample < positionSampleInterval {
+		return
+	}
+	pt.lastSample = now
+
+	var alive []*common.Player
+	for _, p := range gs.Participants().Playing() {
+		if p.IsAlive() && (p.Team == common.TeamTerrorists || p.Team == common.TeamCounterTerrorists) {
+			alive = append(alive, p)
+		}
+	}
+	for _, p := range alive {
+		d.sampleZone(p)
+		if p.Team == common.TeamCounterTerrorists {
+			d.sampleAnchor(p, alive)
+		}
+		nearest := math.Inf(1)
+		pos := p.Position()
+		for _, mate := range alive {
+			if mate == p || mate.Team != p.Team {
+				continue
+			}
+			nearest = math.Min(nearest, pos.Distance(mate.Position()))
+		}
+		if math.IsInf(nearest, 1) {
+			continue
+		}
+		ps := d.state.ensurePlayer(p)
+		if p.Team == common.TeamTerrorists {
+			ps.TTeammateDistanceTotal += nearest
+			ps.TPositionSamples++
+			continue
+		}
+		ps.CTTeammateDistanceTotal += nearest
+		ps.CTPositionSamples++
+		if p.IsInBombZone() {
+			ps.CTBombZoneSamples++
+		}
+	}
+}
+
+// sampleZone adds a sample interval to the time a player has spent in their
+// current named zone (the map's callout, e.g. "BombsiteA"), on their side.
+// Players outside any named zone are counted under unknownZone.
+func (d *DemoParser) sampleZone(p *common.Player) {
+	zone := p.LastPlaceName()
+	if zone == "" {
+		zone = unknownZone
+	}
+	ps := d.state.ensurePlayer(p)
+	if p.Team == common.TeamTerrorists {
+		ps.TZoneTime[zone] += positionSampleInterval
+	} else {
+		ps.CTZoneTime[zone] += positionSampleInterval
+	}
+}
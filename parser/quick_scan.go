@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/msg"
+)
+
+// QuickScan reads a demo for its match summary only: the map from the
+// server info, and the teams, score and players at each round end. None of
+// the stat handlers of a full Parse run, which makes it suited to cataloging
+// or triaging a large demo dump before a full run.
+//
+// Like Parse, it returns a *DemoError when the demo can't be read to the
+// end; if at least one round was scored, the summary so far is returned
+// with a partial DemoError.
+func QuickScan(r io.Reader) (model.DemoSummary, error) {
+	var summary model.DemoSummary
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReaderSize(r, 1024*1024)
+	}
+	if err := ValidateDemo(br); err != nil {
+		return summary, err
+	}
+
+	p := newDemoinfocsParser(br)
+	var mapName string
+	p.RegisterNetMessageHandler(func(m *msg.CSVCMsg_ServerInfo) {
+		mapName = m.GetMapName()
+	})
+	// Players are collected at every scored round end, so substitutes count
+	// and warmup-only spectators don't.
+	players := make(map[uint64]model.SummaryPlayer)
+	p.RegisterEventHandler(func(events.RoundEnd) {
+		gs := p.GameState()
+		if !gs.IsMatchStarted() || gs.IsWarmupPeriod() {
+			return
+		}
+		for _, pl := range gs.Participants().Playing() {
+			if pl.Team != common.TeamTerrorists && pl.Team != common.TeamCounterTerrorists {
+				continue
+			}
+			player := model.SummaryPlayer{SteamID: strconv.FormatUint(pl.SteamID64, 10), Name: pl.Name}
+			if pl.TeamState != nil {
+				player.Team = pl.TeamState.ClanName()
+			}
+			players[pl.SteamID64] = player
+		}
+	})
+
+	derr := func() (derr *DemoError) {
+		defer func() {
+			if r := recover(); r != nil {
+				derr = &DemoError{Kind: KindPanic, Err: fmt.Errorf("parser panic: %v", r)}
+			}
+		}()
+		if err := p.ParseToEnd(); err != nil {
+			return classifyParseError(err)
+		}
+		return nil
+	}()
+
+	summary.Match = matchInfo(p, mapName)
+	for _, pl := range players {
+		summary.Players = append(summary.Players, pl)
+	}
+	sort.Slice(summary.Players, func(i, j int) bool {
+		a, b := summary.Players[i], summary.Players[j]
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+		return a.Name < b.Name
+	})
+
+	if derr == nil {
+		return summary, nil
+	}
+	if rounds := summary.Match.Team1Score + summary.Match.Team2Score; rounds > 0 {
+		derr.Partial = true
+		derr.Rounds = rounds
+	}
+	summary.Error = derr.Error()
+	return summary, derr
+}
@@ -23,9 +23,18 @@ type MatchState struct {
 	Round          map[uint64]*model.RoundStats
 	TradeDetector  *TradeDetector
 	SwingTracker   *SwingTracker
+	ThrowDetector  *ThrowDetector
+	GrenadeLog     *GrenadeLog // Nil unless the grenade log is enabled
+	EconomyTracker *EconomyTracker
+	RoundEvents    *RoundEventLog
+	Visibility     *VisibilityTracker
+	ZoneControl    *ZoneControlTracker
+	Positioning    *PositionTracker
+	SwingAudit     *SwingAuditLog
 	RoundHasKill   bool
 	MatchStarted   bool
 	IsKnifeRound   bool
+	SkipRound      bool // Round isn't counted: it started before the match did, or another demo of the match counts it
 	IsPistolRound  bool
 	RoundNumber    int
 	MapName        string
@@ -36,6 +45,13 @@ type MatchState struct {
 	RoundDecided   bool
 	RoundDecidedAt float64
 	BombPlanted    bool
+	BombPlantedAt  float64
+	GarbageTime    bool                // Round started with the match already decided
+	Importance     float64             // Round importance weight from the score going into the round
+	Leverage       float64             // Full-strength leverage weight from the score going into the round
+	LossStreak     map[common.Team]int // Consecutive round losses per side, for loss bonus
+
+	Progression []byte // Winning side of each counted round: 'T', 'C', or 'D' with no winner
 
 	// Round start state for swing calculation
 	RoundStartState *probability.RoundState
@@ -44,10 +60,19 @@ type MatchState struct {
 // NewMatchState creates a new MatchState with initialized maps.
 func NewMatchState() *MatchState {
 	return &MatchState{
-		Players:       make(map[uint64]*model.PlayerStats),
-		Round:         make(map[uint64]*model.RoundStats),
-		TradeDetector: NewTradeDetector(),
-		SwingTracker:  NewSwingTracker(),
+		Players:        make(map[uint64]*model.PlayerStats),
+		Round:          make(map[uint64]*model.RoundStats),
+		LossStreak:     make(map[common.Team]int),
+		TradeDetector:  NewTradeDetector(),
+		SwingTracker:   NewSwingTracker(),
+		ThrowDetector:  NewThrowDetector(),
+		EconomyTracker: NewEconomyTracker(),
+		RoundEvents:    NewRoundEventLog(),
+		Visibility:     NewVisibilityTracker(),
+		ZoneControl:    NewZoneControlTracker(),
+		Positioning:    NewPositionTracker(),
+		Importance:     1.0,
+		Leverage:       1.0,
 	}
 }
 
@@ -56,9 +81,12 @@ func (m *MatchState) ensurePlayer(p *common.Player) *model.PlayerStats {
 	id := p.SteamID64
 	if _, ok := m.Players[id]; !ok {
 		m.Players[id] = &model.PlayerStats{
-			SteamID:  fmt.Sprintf("%d", id),
-			Name:     p.Name,
-			TeamName: playerClanName(p),
+			SteamID:       fmt.Sprintf("%d", id),
+			Name:          p.Name,
+			TeamName:      playerClanName(p),
+			KillsByVictim: make(map[uint64]int),
+			TZoneTime:     make(map[string]float64),
+			CTZoneTime:    make(map[string]float64),
 		}
 	}
 	ps := m.Players[id]
@@ -87,9 +115,9 @@ func (m *MatchState) ensureRound(p *common.Player) *model.RoundStats {
 }
 
 // ShouldSkipEvent returns true if the current event should be skipped
-// (knife round or match not started).
+// (knife round, skipped round, or match not started).
 func (m *MatchState) ShouldSkipEvent() bool {
-	return m.IsKnifeRound || !m.MatchStarted
+	return m.IsKnifeRound || m.SkipRound || !m.MatchStarted
 }
 
 // CountAlivePlayers counts alive human players on each team from the given participants.
@@ -0,0 +1,51 @@
+package parser
+
+// roundKill is a kill buffered for the round end pass, with the bomb state
+// it happened in.
+type roundKill struct {
+	ctx           *killContext
+	bombPlanted   bool
+	bombPlantedAt float64
+}
+
+// RoundEventLog buffers the current round's events for the round end pass,
+// where classifications that depend on how the round played out are made
+// from the whole round instead of from what a handler has seen so far.
+type RoundEventLog struct {
+	kills []roundKill
+	// decidedAfter is how many buffered kills came before the round was
+	// decided, or -1 while it's undecided. Counting kills rather than
+	// comparing times keeps the deciding kill apart from a later kill on
+	// the same tick.
+	decidedAfter int
+}
+
+// NewRoundEventLog creates an empty RoundEventLog.
+func NewRoundEventLog() *RoundEventLog {
+	return &RoundEventLog{decidedAfter: -1}
+}
+
+// Reset clears the log for a new round.
+func (l *RoundEventLog) Reset() {
+	l.kills = l.kills[:0]
+	l.decidedAfter = -1
+}
+
+// recordKill buffers a kill.
+func (l *RoundEventLog) recordKill(ctx *killContext, bombPlanted bool, bombPlantedAt float64) {
+	l.kills = append(l.kills, roundKill{ctx: ctx, bombPlanted: bombPlanted, bombPlantedAt: bombPlantedAt})
+}
+
+// markDecided records that the round was decided after the kills buffered
+// so far. Only the first decision counts.
+func (l *RoundEventLog) markDecided() {
+	if l.decidedAfter < 0 {
+		l.decidedAfter = len(l.kills)
+	}
+}
+
+// decidedBefore reports whether the round was already decided when the
+// i-th buffered kill happened.
+func (l *RoundEventLog) decidedBefore(i int) bool {
+	return l.decidedAfter >= 0 && i >= l.decidedAfter
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"log/slog"
+	"maps"
+
+	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating/probability"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// roundSnapshot is the counted state at the start of a live round, kept so
+// the round can be taken back if a backup restore replays it.
+type roundSnapshot struct {
+	scoreTotal    int // Rounds won by both teams going into the round
+	roundNumber   int // Rounds counted before it
+	players       map[uint64]*model.PlayerStats
+	teamScore     int
+	enemyScore    int
+	lossStreak    map[common.Team]int
+	economyRounds int
+	auditRounds   int
+	grenades      int
+	progression   int
+	collector     probability.CollectorSnapshot
+}
+
+// snapshotRound records the counted state going into a live round with
+// scoreTotal rounds already won.
+func (d *DemoParser) snapshotRound(scoreTotal int) {
+	players := make(map[uint64]*model.PlayerStats, len(d.state.Players))
+	for id, ps := range d.state.Players {
+		players[id] = clonePlayerStats(ps)
+	}
+	var collector probability.CollectorSnapshot
+	if d.collector != nil {
+		collector = d.collector.Snapshot()
+	}
+	d.snapshots = append(d.snapshots, roundSnapshot{
+		scoreTotal:    scoreTotal,
+		roundNumber:   d.state.RoundNumber,
+		players:       players,
+		teamScore:     d.state.TeamScore,
+		enemyScore:    d.state.EnemyScore,
+		lossStreak:    maps.Clone(d.state.LossStreak),
+		economyRounds: len(d.state.EconomyTracker.rounds),
+		auditRounds:   d.state.SwingAudit.count(),
+		grenades:      d.state.GrenadeLog.count(),
+		progression:   len(d.state.Progression),
+		collector:     collector,
+	})
+}
+
+// restoreRound takes back every round counted since a live round last
+// started with scoreTotal rounds won. A round backup restore sends the
+// score back to a round already played, and the rounds from there are
+// played again; counting both plays would give players the replayed rounds
+// twice. A round that ended without a winner is taken back the same way.
+func (d *DemoParser) restoreRound(scoreTotal int) {
+	i := len(d.snapshots) - 1
+	for ; i >= 0 && d.snapshots[i].scoreTotal != scoreTotal; i-- {
+	}
+	if i < 0 {
+		return
+	}
+	snap := d.snapshots[i]
+	d.logAt(slog.LevelInfo, "Round backup restored, discarding replayed rounds",
+		"rounds", d.state.RoundNumber-snap.roundNumber, "restored_to", snap.roundNumber)
+	d.excluded.Replayed += d.state.RoundNumber - snap.roundNumber
+
+	d.state.Players = snap.players
+	d.state.RoundNumber = snap.roundNumber
+	d.state.TeamScore = snap.teamScore
+	d.state.EnemyScore = snap.enemyScore
+	d.state.LossStreak = snap.lossStreak
+	d.state.EconomyTracker.rounds = d.state.EconomyTracker.rounds[:snap.economyRounds]
+	if l := d.state.SwingAudit; l != nil {
+		l.rounds = l.rounds[:snap.auditRounds]
+	}
+	if gl := d.state.GrenadeLog; gl != nil {
+		gl.throws = gl.throws[:snap.grenades]
+		gl.throwers = gl.throwers[:snap.grenades]
+	}
+	d.state.Progression = d.state.Progression[:snap.progression]
+	if d.collector != nil {
+		d.collector.Restore(snap.collector)
+	}
+	d.snapshots = d.snapshots[:i]
+}
+
+// clonePlayerStats copies a player's stats deeply enough that counting more
+// rounds into the copy leaves ps unchanged. Slices are only appended to, so
+// the copy can share their backing arrays.
+func clonePlayerStats(ps *model.PlayerStats) *model.PlayerStats {
+	c := *ps
+	c.KillsByVictim = maps.Clone(ps.KillsByVictim)
+	c.TZoneTime = maps.Clone(ps.TZoneTime)
+	c.CTZoneTime = maps.Clone(ps.CTZoneTime)
+	c.OpeningWinsByMatchup = maps.Clone(ps.OpeningWinsByMatchup)
+	c.OpeningLossesByMatchup = maps.Clone(ps.OpeningLossesByMatchup)
+	return &c
+}
@@ -10,6 +10,7 @@ package parser
 
 import (
 	"github.com/ethsmith/eco-rating/model"
+	"github.com/ethsmith/eco-rating/rating"
 )
 
 // SideStatsUpdater handles updating side-specific statistics for a player.
@@ -114,10 +115,21 @@ func (u *SideStatsUpdater) UpdateCommonRoundStats() {
 	if u.roundStats.TeamWon {
 		u.player.KillsInWonRounds += u.roundStats.Kills
 		u.player.DamageInWonRounds += u.roundStats.Damage
+		u.player.UtilDamageInWonRounds += u.roundStats.UtilityDamage
+		if u.roundStats.DeathTime > 0 {
+			u.player.DeathsInWonRounds++
+		}
 
 		if u.roundStats.OpeningKill {
 			u.player.RoundsWonAfterOpening++
 		}
+	} else {
+		u.player.KillsInLostRounds += u.roundStats.Kills
+		u.player.DamageInLostRounds += u.roundStats.Damage
+		u.player.UtilDamageInLostRounds += u.roundStats.UtilityDamage
+		if u.roundStats.DeathTime > 0 {
+			u.player.DeathsInLostRounds++
+		}
 	}
 
 	u.updateAWPStats()
@@ -125,6 +137,7 @@ func (u *SideStatsUpdater) UpdateCommonRoundStats() {
 	u.updateUtilityStats()
 	u.updateTradeStats()
 	u.updatePistolStats()
+	u.updateOvertimeStats()
 }
 
 // updateAWPStats updates AWP-related statistics.
@@ -182,12 +195,12 @@ func (u *SideStatsUpdater) updateTradeStats() {
 
 	if u.roundStats.TradeKill {
 		u.player.TradeKills++
-		if u.roundStats.TradeSpeed > 0 && u.roundStats.TradeSpeed < 2.0 {
+		if u.roundStats.TradeSpeed > 0 && u.roundStats.TradeSpeed < rating.FastTradeSeconds {
 			u.player.FastTrades++
 		}
 	}
 
-	if u.roundStats.DeathTime > 0 && u.roundStats.DeathTime < 30.0 {
+	if u.roundStats.DeathTime > 0 && u.roundStats.DeathTime < rating.EarlyDeathSeconds {
 		u.player.EarlyDeaths++
 	}
 }
@@ -198,21 +211,83 @@ func (u *SideStatsUpdater) updatePistolStats() {
 		return
 	}
 
+	died := u.roundStats.DeathTime > 0
+	survived := !died && u.roundStats.Survived
+	multiKill := u.roundStats.Kills >= 2
+
 	u.player.PistolRoundsPlayed++
 	u.player.PistolRoundKills += u.roundStats.Kills
 	u.player.PistolRoundDamage += u.roundStats.Damage
-
-	if u.roundStats.DeathTime > 0 {
+	if died {
 		u.player.PistolRoundDeaths++
-	} else if u.roundStats.Survived {
+	}
+	if survived {
 		u.player.PistolRoundSurvivals++
 	}
-
 	if u.roundStats.TeamWon {
 		u.player.PistolRoundsWon++
 	}
+	if multiKill {
+		u.player.PistolRoundMultiKills++
+	}
 
+	// The side counters follow the same rules as the overall ones.
+	var played, kills, damage, survivals, multiKills, won *int
+	switch u.roundStats.PlayerSide {
+	case "T":
+		played, kills, damage = &u.player.TPistolRoundsPlayed, &u.player.TPistolRoundKills, &u.player.TPistolRoundDamage
+		survivals, multiKills, won = &u.player.TPistolRoundSurvivals, &u.player.TPistolRoundMultiKills, &u.player.TPistolRoundsWon
+	case "CT":
+		played, kills, damage = &u.player.CTPistolRoundsPlayed, &u.player.CTPistolRoundKills, &u.player.CTPistolRoundDamage
+		survivals, multiKills, won = &u.player.CTPistolRoundSurvivals, &u.player.CTPistolRoundMultiKills, &u.player.CTPistolRoundsWon
+	default:
+		return
+	}
+	*played++
+	*kills += u.roundStats.Kills
+	*damage += u.roundStats.Damage
+	if survived {
+		*survivals++
+	}
+	if multiKill {
+		*multiKills++
+	}
+	if u.roundStats.TeamWon {
+		*won++
+	}
+}
+
+// updateOvertimeStats updates overtime round statistics, kept apart from
+// regulation since overtime rounds are played under more pressure.
+func (u *SideStatsUpdater) updateOvertimeStats() {
+	if !u.roundStats.IsOvertime {
+		return
+	}
+
+	u.player.OTRoundsPlayed++
+	u.player.OTKills += u.roundStats.Kills
+	u.player.OTDamage += u.roundStats.Damage
+	u.player.OTEcoKillValue += u.roundStats.EconImpact
+
+	if u.roundStats.Survived {
+		u.player.OTSurvivals++
+	}
+	if u.roundStats.DeathTime > 0 {
+		u.player.OTDeaths++
+	}
 	if u.roundStats.Kills >= 2 {
-		u.player.PistolRoundMultiKills++
+		u.player.OTRoundsWithMultiKill++
+	}
+	if u.roundStats.Kills >= 0 && u.roundStats.Kills <= 5 {
+		u.player.OTMultiKills[u.roundStats.Kills]++
+	}
+	if u.roundStats.GotKill || u.roundStats.GotAssist || u.roundStats.Survived || u.roundStats.Traded {
+		u.player.OTKAST++
+	}
+	if u.roundStats.ClutchAttempt {
+		u.player.OTClutchRounds++
+		if u.roundStats.ClutchWon {
+			u.player.OTClutchWins++
+		}
 	}
 }
@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/rating/probability"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/msg"
+)
+
+// DemoSource is the platform a demo was recorded on. Platforms start
+// matches differently (knife rounds for sides, live-on-three restarts), so
+// each is normalized by its SourceProfile.
+type DemoSource string
+
+// Demo sources recognized by detectSource.
+const (
+	SourceUnknown DemoSource = "unknown"
+	SourceFaceit  DemoSource = "faceit"
+	SourceESEA    DemoSource = "esea"
+	SourceValveMM DemoSource = "valve"
+	SourceEBot    DemoSource = "ebot"
+)
+
+// SourceProfile is how rounds from one source are normalized.
+type SourceProfile struct {
+	// KnifeRounds is whether the source can play a knife round for sides.
+	// Sources that never do skip the check, so a broke team can't be
+	// mistaken for one.
+	KnifeRounds bool
+	// LiveRestarts is whether the source restarts the game when going live
+	// (live on three) or on an admin's command; rounds counted before a
+	// restart to 0-0 are discarded.
+	LiveRestarts bool
+}
+
+// sourceProfiles are the per-source normalization rules. Unknown sources
+// get every check, none of which changes a clean demo. Rounds before the
+// match starts are skipped for every source.
+var sourceProfiles = map[DemoSource]SourceProfile{
+	SourceUnknown: {KnifeRounds: true, LiveRestarts: true},
+	SourceFaceit:  {KnifeRounds: true, LiveRestarts: true},
+	SourceESEA:    {KnifeRounds: true, LiveRestarts: true},
+	SourceValveMM: {},
+	SourceEBot:    {KnifeRounds: true, LiveRestarts: true},
+}
+
+// sourceMarkers are the substrings, in lower case, that identify a source in
+// the server name, host name or server chat.
+var sourceMarkers = []struct {
+	marker string
+	source DemoSource
+}{
+	{"faceit", SourceFaceit},
+	{"esea", SourceESEA},
+	{"ebot", SourceEBot},
+	{"valve", SourceValveMM},
+}
+
+// ParseDemoSource returns the named demo source. "" selects detection from
+// the demo and returns SourceUnknown.
+func ParseDemoSource(name string) (DemoSource, error) {
+	if name == "" {
+		return SourceUnknown, nil
+	}
+	source := DemoSource(strings.ToLower(name))
+	if _, ok := sourceProfiles[source]; !ok {
+		return "", fmt.Errorf("unknown demo source %q (valid: %s, %s, %s, %s, %s)", name,
+			SourceFaceit, SourceESEA, SourceValveMM, SourceEBot, SourceUnknown)
+	}
+	return source, nil
+}
+
+// detectSource returns the source text such as a server name points to, or
+// SourceUnknown.
+func detectSource(text string) DemoSource {
+	text = strings.ToLower(text)
+	for _, m := range sourceMarkers {
+		if strings.Contains(text, m.marker) {
+			return m.source
+		}
+	}
+	return SourceUnknown
+}
+
+// SetSource fixes the demo's source instead of detecting it; "" keeps
+// detection on.
+func (d *DemoParser) SetSource(source DemoSource) {
+	if source == "" {
+		d.sourceFixed = false
+		return
+	}
+	d.source = source
+	d.sourceFixed = true
+}
+
+// Source returns the demo's source, as set or detected so far.
+func (d *DemoParser) Source() DemoSource {
+	if d.source == "" {
+		return SourceUnknown
+	}
+	return d.source
+}
+
+// sourceProfile returns the normalization rules for the demo's source.
+func (d *DemoParser) sourceProfile() SourceProfile {
+	return sourceProfiles[d.Source()]
+}
+
+// observeSource detects the source from text seen in the demo, keeping the
+// first match.
+func (d *DemoParser) observeSource(text string) {
+	if d.sourceFixed || d.Source() != SourceUnknown {
+		return
+	}
+	if source := detectSource(text); source != SourceUnknown {
+		d.source = source
+		d.logAt(slog.LevelDebug, "Demo source detected", "source", string(source))
+	}
+}
+
+// registerSourceHandlers detects the source from the demo header's server
+// name, the server's host name and server chat, where platforms such as eBot
+// announce themselves.
+func (d *DemoParser) registerSourceHandlers() {
+	d.parser.RegisterNetMessageHandler(func(m *msg.CDemoFileHeader) {
+		d.observeSource(m.GetServerName())
+	})
+	d.parser.RegisterNetMessageHandler(func(m *msg.CSVCMsg_ServerInfo) {
+		d.observeSource(m.GetHostName())
+	})
+	d.parser.RegisterEventHandler(func(e events.SayText) {
+		d.observeSource(e.Text)
+	})
+}
+
+// isKnifeRound reports whether the round starting is a knife round: every
+// player starts it with no money, where a live round starts everyone with at
+// least the pistol-round money.
+func isKnifeRound(participants []*common.Player) bool {
+	seen := false
+	for _, p := range participants {
+		if p.IsBot || (p.Team != common.TeamTerrorists && p.Team != common.TeamCounterTerrorists) {
+			continue
+		}
+		if p.Money()+p.MoneySpentThisRound() > 0 {
+			return false
+		}
+		seen = true
+	}
+	return seen
+}
+
+// isRestart reports whether the game was restarted since the last counted
+// round: rounds were counted but the score is back to 0-0.
+func (d *DemoParser) isRestart(tScore, ctScore int) bool {
+	return d.sourceProfile().LiveRestarts && d.state.RoundNumber > 0 && tScore == 0 && ctScore == 0
+}
+
+// resetMatch discards everything counted so far after a restart, keeping
+// the map, match start and trade settings, which the restart doesn't change.
+// Enabled grenade and swing audit logs start over empty.
+func (d *DemoParser) resetMatch() {
+	d.logAt(slog.LevelInfo, "Game restarted, discarding rounds counted before it", "rounds", d.state.RoundNumber)
+	d.excluded.Restarted += d.state.RoundNumber
+	d.snapshots = nil
+	old := d.state
+	d.state = NewMatchState()
+	d.state.MapName = old.MapName
+	d.state.MatchStarted = old.MatchStarted
+	old.TradeDetector.Reset()
+	d.state.TradeDetector = old.TradeDetector
+	if old.GrenadeLog != nil {
+		d.state.GrenadeLog = NewGrenadeLog()
+	}
+	if old.SwingAudit != nil {
+		d.state.SwingAudit = NewSwingAuditLog()
+	}
+	d.collector = probability.NewDataCollector()
+}
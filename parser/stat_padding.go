@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// isKillVsEco reports whether a kill on victim came against an eco or a
+// pistol buy: the victim's team was on an eco, or outside pistol rounds the
+// victim had nothing better than a pistol. Victims whose guns the demo no
+// longer shows aren't counted.
+func (d *DemoParser) isKillVsEco(victim *common.Player) bool {
+	if d.state.IsPistolRound {
+		return false
+	}
+	if d.state.EconomyTracker.BuyType(victim.Team) == BuyEco {
+		return true
+	}
+	gun := bestGun(victim)
+	if gun == nil {
+		gun = victim.ActiveWeapon()
+	}
+	return gun != nil && weaponCategory(gun) == weaponPistol
+}
+
+// tallyPaddingKills is the round end pass that counts each killer's kills
+// against ecos and in already decided rounds (exit frags, or any kill in
+// garbage time), for the stat-padding index. It runs after
+// classifyRoundKills, which marks the exit frags.
+func (d *DemoParser) tallyPaddingKills() {
+	for _, k := range d.state.RoundEvents.kills {
+		p := d.state.ensurePlayer(k.ctx.attacker)
+		decided := k.ctx.isExitFrag || d.state.GarbageTime
+		if k.ctx.vsEco {
+			p.KillsVsEco++
+		}
+		if decided {
+			p.DecidedRoundKills++
+		}
+		if k.ctx.vsEco || decided {
+			p.PaddingKills++
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// SwingAuditLog records every event that moved the round win probability,
+// with the probability before and after it and the swing credited for it.
+type SwingAuditLog struct {
+	rounds []model.SwingAuditRound
+	tProb  float64 // T-side win probability after the last event
+}
+
+// NewSwingAuditLog creates an empty swing audit log.
+func NewSwingAuditLog() *SwingAuditLog {
+	return &SwingAuditLog{}
+}
+
+// StartRound opens a round at the given T-side win probability.
+func (l *SwingAuditLog) StartRound(number int, tProb float64) {
+	if l == nil {
+		return
+	}
+	l.rounds = append(l.rounds, model.SwingAuditRound{
+		RoundNumber:       number,
+		StartTProbability: tProb,
+		EndTProbability:   tProb,
+		Events:            []model.SwingAuditEvent{},
+	})
+	l.tProb = tProb
+}
+
+// EndRound records the winner of the open round and scales its credits by
+// the round's importance, as the players' swing is scaled at round end.
+func (l *SwingAuditLog) EndRound(winner string, importance float64) {
+	if l == nil || len(l.rounds) == 0 {
+		return
+	}
+	round := &l.rounds[len(l.rounds)-1]
+	round.Winner = winner
+	for i := range round.Events {
+		round.Events[i].Credit *= importance
+		round.Events[i].OpponentCredit *= importance
+	}
+}
+
+// Rounds returns the recorded rounds, none for a disabled (nil) log.
+func (l *SwingAuditLog) Rounds() []model.SwingAuditRound {
+	if l == nil {
+		return nil
+	}
+	return l.rounds
+}
+
+// count returns the number of recorded rounds, 0 for a disabled (nil) log.
+func (l *SwingAuditLog) count() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.rounds)
+}
+
+// auditSwing records an event that moved the win probability. player made
+// the event and was credited credit; opponent, for kills, is the victim and
+// was charged opponentCredit.
+func (d *DemoParser) auditSwing(eventType string, player, opponent *common.Player, credit, opponentCredit float64) {
+	st := d.state.SwingTracker
+	l := d.state.SwingAudit
+	if l == nil || st == nil || !st.IsEnabled() || len(l.rounds) == 0 || player == nil {
+		return
+	}
+	tProb := st.GetCurrentWinProbability(common.TeamTerrorists)
+	before, after := l.tProb, tProb
+	if player.Team == common.TeamCounterTerrorists {
+		before, after = 1-before, 1-after
+	}
+	event := model.SwingAuditEvent{
+		Time:              d.timeInRound(),
+		Type:              eventType,
+		Player:            player.Name,
+		PlayerSteamID:     fmt.Sprintf("%d", player.SteamID64),
+		Side:              sideName(player.Team),
+		ProbabilityBefore: before,
+		ProbabilityAfter:  after,
+		Delta:             after - before,
+		Credit:            credit,
+		OpponentCredit:    opponentCredit,
+	}
+	if opponent != nil {
+		event.Opponent = opponent.Name
+		event.OpponentSteamID = fmt.Sprintf("%d", opponent.SteamID64)
+	}
+	round := &l.rounds[len(l.rounds)-1]
+	round.Events = append(round.Events, event)
+	round.EndTProbability = tProb
+	l.tProb = tProb
+}
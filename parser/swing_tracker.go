@@ -15,6 +15,21 @@ type SwingTracker struct {
 	roundState       *probability.RoundState
 	roundEvents      []swing.RoundEvent
 	enabled          bool
+	plantedAt        float64        // Time in round of the bomb plant
+	defuse           *defuseAttempt // Latest defuse this round, nil when none
+}
+
+// defuseDenialGraceSeconds is how long after letting go of the bomb a
+// defuser's death still counts as denying the defuse, for a defuser shot off
+// the bomb who lets go as they die. A starting estimate.
+const defuseDenialGraceSeconds = 0.5
+
+// defuseAttempt is a defuse started this round.
+type defuseAttempt struct {
+	defuserID uint64
+	startedAt float64 // Time in round
+	duration  float64 // Seconds to finish: 5 with a kit, 10 without
+	abortedAt float64 // Time in round the defuser let go, 0 while defusing
 }
 
 // NewSwingTracker creates a new swing tracker.
@@ -44,6 +59,7 @@ func (st *SwingTracker) ResetRound(tAlive, ctAlive int, mapName string) {
 	st.roundEvents = make([]swing.RoundEvent, 0)
 	st.damageTracker.Reset()
 	st.advantageTracker.Reset()
+	st.defuse = nil
 }
 
 // SetEconomy sets the economy categories for both teams.
@@ -116,6 +132,8 @@ func (st *SwingTracker) RecordKill(
 		FlashAssists:        st.damageTracker.GetFlashAssists(victimID),
 	}
 
+	st.advanceClock(timeInRound)
+
 	// Calculate economy-adjusted swing before updating state
 	swingResult := st.calculator.CalculateKillSwingWithEconomy(st.roundState, killEvent)
 
@@ -157,6 +175,22 @@ func (st *SwingTracker) RecordKill(
 	}
 }
 
+// advanceClock brings the round state's time remaining up to timeInRound.
+// Only the empirical win probability table is keyed by time. Without one
+// the round state keeps its starting time, and the full bomb timer once
+// planted, which the heuristic model's bomb timer adjustment never reaches,
+// so heuristic swing is unchanged.
+func (st *SwingTracker) advanceClock(timeInRound float64) {
+	if !st.calculator.GetProbabilityEngine().HasWinProbabilityTable() {
+		return
+	}
+	if st.roundState.BombPlanted {
+		st.roundState.TimeRemaining = max(0, probability.BombTimerSeconds-(timeInRound-st.plantedAt))
+	} else {
+		st.roundState.TimeRemaining = max(0, roundTimeSeconds-timeInRound)
+	}
+}
+
 // GetDamageToPlayer returns the total damage dealt to a player this round.
 // Used to estimate victim health at death time for death penalty reduction.
 func (st *SwingTracker) GetDamageToPlayer(playerID uint64) int {
@@ -172,6 +206,8 @@ func (st *SwingTracker) RecordBombPlant(planterID uint64, timeInRound float64) f
 		return 0
 	}
 
+	st.advanceClock(timeInRound)
+
 	// Calculate swing before updating state
 	engine := st.calculator.GetProbabilityEngine()
 	swingValue := engine.CalculateBombPlantSwing(st.roundState)
@@ -185,6 +221,7 @@ func (st *SwingTracker) RecordBombPlant(planterID uint64, timeInRound float64) f
 
 	// Update state
 	st.roundState.SetBombPlanted()
+	st.plantedAt = timeInRound
 
 	return swingValue
 }
@@ -195,6 +232,8 @@ func (st *SwingTracker) RecordBombDefuse(defuserID uint64, timeInRound float64)
 		return 0
 	}
 
+	st.advanceClock(timeInRound)
+
 	// Calculate swing before updating state
 	engine := st.calculator.GetProbabilityEngine()
 	swingValue := engine.CalculateBombDefuseSwing(st.roundState)
@@ -208,6 +247,61 @@ func (st *SwingTracker) RecordBombDefuse(defuserID uint64, timeInRound float64)
 
 	// Update state
 	st.roundState.SetBombDefused()
+	st.defuse = nil
+
+	return swingValue
+}
+
+// StartDefuse records a player starting to defuse the bomb.
+func (st *SwingTracker) StartDefuse(defuserID uint64, hasKit bool, timeInRound float64) {
+	duration := defuseSeconds
+	if hasKit {
+		duration = kitDefuseSeconds
+	}
+	st.defuse = &defuseAttempt{defuserID: defuserID, startedAt: timeInRound, duration: duration}
+}
+
+// AbortDefuse records the defuser letting go of the bomb.
+func (st *SwingTracker) AbortDefuse(defuserID uint64, timeInRound float64) {
+	if st.defuse != nil && st.defuse.defuserID == defuserID && st.defuse.abortedAt == 0 {
+		st.defuse.abortedAt = timeInRound
+	}
+}
+
+// RecordDefuseDenial records a kill of victimID, and returns the swing the
+// killer earns for denying a defuse when the victim was defusing, or had
+// just let go, with time left on the bomb to finish. Call it before
+// RecordKill, so the swing is measured with the defuser alive.
+func (st *SwingTracker) RecordDefuseDenial(killerID, victimID uint64, timeInRound float64) float64 {
+	if !st.enabled || st.roundState == nil || st.defuse == nil || st.defuse.defuserID != victimID {
+		return 0
+	}
+	attempt := st.defuse
+	st.defuse = nil
+
+	stoppedAt := timeInRound
+	if attempt.abortedAt > 0 {
+		if timeInRound-attempt.abortedAt > defuseDenialGraceSeconds {
+			return 0
+		}
+		stoppedAt = attempt.abortedAt
+	}
+	if attempt.startedAt+attempt.duration > st.plantedAt+probability.BombTimerSeconds {
+		return 0 // The bomb would have gone off first
+	}
+
+	st.advanceClock(timeInRound)
+
+	progress := (stoppedAt - attempt.startedAt) / attempt.duration
+	engine := st.calculator.GetProbabilityEngine()
+	swingValue := engine.CalculateDefuseDenialSwing(st.roundState, progress)
+
+	st.roundEvents = append(st.roundEvents, &swing.DefuseDenialEvent{
+		TimeInRound: timeInRound,
+		DenierID:    killerID,
+		DefuserID:   victimID,
+		Progress:    progress,
+	})
 
 	return swingValue
 }
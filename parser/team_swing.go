@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// processTeamSwingWhileAlive credits a change in the T side's round win
+// probability to every player alive for it: T players gain tDelta, CT
+// players lose it. players are the event's participants, credited even when
+// the event was their death. Summed over a match this is the team's swing
+// while the player was alive, a rough proxy for mid-round calling that
+// includes the player's own kills and deaths.
+func (d *DemoParser) processTeamSwingWhileAlive(tDelta float64, players map[common.Team]*common.Player) {
+	if tDelta == 0 {
+		return
+	}
+	credited := make(map[uint64]bool)
+	credit := func(p *common.Player) {
+		if p == nil || credited[p.SteamID64] {
+			return
+		}
+		switch p.Team {
+		case common.TeamTerrorists:
+			d.state.ensurePlayer(p).TeamSwingWhileAlive += tDelta
+		case common.TeamCounterTerrorists:
+			d.state.ensurePlayer(p).TeamSwingWhileAlive -= tDelta
+		default:
+			return
+		}
+		credited[p.SteamID64] = true
+	}
+	for _, p := range players {
+		credit(p)
+	}
+	for _, p := range d.parser.GameState().Participants().Playing() {
+		if p.IsAlive() {
+			credit(p)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+)
+
+// ThrowProbabilityThreshold is the win probability a team must reach in a
+// round for losing it to count as a throw.
+const ThrowProbabilityThreshold = 0.90
+
+// throwSide tracks one side's win probability over the round.
+type throwSide struct {
+	peak     float64
+	peakTime float64
+	collapse []model.ThrowEvent
+}
+
+// ThrowDetector follows both sides' win probability during a round and keeps
+// the events that lowered it after a side passed the throw threshold.
+type ThrowDetector struct {
+	tProb float64
+	sides map[common.Team]*throwSide
+}
+
+// NewThrowDetector creates a detector for the first round.
+func NewThrowDetector() *ThrowDetector {
+	td := &ThrowDetector{}
+	td.Reset(0.5)
+	return td
+}
+
+// Reset starts a new round at the given T-side win probability.
+func (td *ThrowDetector) Reset(tProb float64) {
+	td.tProb = tProb
+	td.sides = map[common.Team]*throwSide{
+		common.TeamTerrorists:        {peak: tProb},
+		common.TeamCounterTerrorists: {peak: 1 - tProb},
+	}
+}
+
+// Observe records the T-side win probability after an event. For a side
+// already past the threshold, an event that lowered its probability is kept
+// as part of the collapse. player is the throwing side's player involved (a
+// death victim) and opponent the other side's player.
+func (td *ThrowDetector) Observe(tProb, timeInRound float64, eventType string, players map[common.Team]*common.Player) {
+	for team, side := range td.sides {
+		before, after := td.tProb, tProb
+		if team == common.TeamCounterTerrorists {
+			before, after = 1-before, 1-after
+		}
+		if side.peak >= ThrowProbabilityThreshold && after < before {
+			event := model.ThrowEvent{Time: timeInRound, Type: eventType, Drop: before - after}
+			if p := players[team]; p != nil {
+				event.Player = p.Name
+			}
+			if p := players[opposingTeam(team)]; p != nil {
+				event.Opponent = p.Name
+			}
+			side.collapse = append(side.collapse, event)
+		}
+		if after > side.peak {
+			side.peak = after
+			side.peakTime = timeInRound
+		}
+	}
+	td.tProb = tProb
+}
+
+// Throw returns the throw descriptor for team if it lost the round after
+// passing the threshold.
+func (td *ThrowDetector) Throw(team, winner common.Team) (model.ThrowRound, bool) {
+	side := td.sides[team]
+	if side == nil || team == winner || side.peak < ThrowProbabilityThreshold {
+		return model.ThrowRound{}, false
+	}
+	return model.ThrowRound{
+		Side:            sideName(team),
+		PeakProbability: side.peak,
+		PeakTime:        side.peakTime,
+		Collapse:        append([]model.ThrowEvent(nil), side.collapse...),
+	}, true
+}
+
+// observeThrowProbability feeds the current win probability to the throw
+// detector after a kill or bomb event.
+func (d *DemoParser) observeThrowProbability(eventType string, players map[common.Team]*common.Player) {
+	if d.state.SwingTracker == nil || !d.state.SwingTracker.IsEnabled() {
+		return
+	}
+	tProb := d.state.SwingTracker.GetCurrentWinProbability(common.TeamTerrorists)
+	d.processTeamSwingWhileAlive(tProb-d.state.ThrowDetector.tProb, players)
+	d.state.ThrowDetector.Observe(tProb, d.timeInRound(), eventType, players)
+}
+
+// processThrows records the round as thrown for the losing side's players
+// when that side had passed the throw threshold. Players who died during the
+// collapse are also charged with the probability lost by their deaths.
+func (d *DemoParser) processThrows(ctx *roundEndContext) {
+	if ctx.winnerTeam != common.TeamTerrorists && ctx.winnerTeam != common.TeamCounterTerrorists {
+		return
+	}
+	loser := opposingTeam(ctx.winnerTeam)
+	throw, ok := d.state.ThrowDetector.Throw(loser, ctx.winnerTeam)
+	if !ok {
+		return
+	}
+	throw.RoundNumber = d.state.RoundNumber
+
+	drops := make(map[string]float64)
+	for _, e := range throw.Collapse {
+		if e.Type == "death" && e.Player != "" {
+			drops[e.Player] += e.Drop
+		}
+	}
+
+	var throwers []*common.Player
+	for _, p := range ctx.gs.Participants().Playing() {
+		if p.Team == loser && !p.IsBot {
+			throwers = append(throwers, p)
+			if throw.Team == "" {
+				throw.Team = d.state.ensurePlayer(p).TeamName
+			}
+		}
+	}
+	for _, p := range throwers {
+		ps := d.state.ensurePlayer(p)
+		d.state.ensureRound(p).ThrownRound = true
+		ps.RoundsThrown++
+		if drop, ok := drops[p.Name]; ok {
+			ps.ThrowDeaths++
+			ps.ThrowProbabilityLost += drop
+		}
+		ps.Throws = append(ps.Throws, throw)
+	}
+}
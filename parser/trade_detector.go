@@ -39,17 +39,50 @@ type TradeDetector struct {
 	recentKills      map[uint64]recentKill
 	recentTeamDeaths map[uint64]float64
 	pendingTrades    map[uint64][]pendingTrade
+
+	windowSeconds float64 // Trade window in seconds
+	proximity     float64 // Maximum teammate distance for a trade opportunity (units)
+	tickRate      float64 // Ticks per second, for converting the window to ticks
 }
 
-// NewTradeDetector creates a new TradeDetector with initialized maps.
+// NewTradeDetector creates a new TradeDetector with initialized maps and the
+// default trade window and proximity.
 func NewTradeDetector() *TradeDetector {
 	return &TradeDetector{
 		recentKills:      make(map[uint64]recentKill),
 		recentTeamDeaths: make(map[uint64]float64),
 		pendingTrades:    make(map[uint64][]pendingTrade),
+		windowSeconds:    rating.TradeWindowSeconds,
+		proximity:        rating.TradeProximityUnits,
+		tickRate:         rating.TickRate,
+	}
+}
+
+// SetWindow sets how many seconds after a death a kill on the killer still
+// counts as a trade.
+func (td *TradeDetector) SetWindow(seconds float64) {
+	td.windowSeconds = seconds
+}
+
+// SetProximity sets how close a teammate must be to a death, in units, for
+// it to count as a trade opportunity.
+func (td *TradeDetector) SetProximity(units float64) {
+	td.proximity = units
+}
+
+// SetTickRate sets the tick rate the trade window is converted to ticks at.
+// Non-positive rates are ignored.
+func (td *TradeDetector) SetTickRate(tickRate float64) {
+	if tickRate > 0 {
+		td.tickRate = tickRate
 	}
 }
 
+// windowTicks returns the trade window in ticks.
+func (td *TradeDetector) windowTicks() int {
+	return int(math.Round(td.windowSeconds * td.tickRate))
+}
+
 // Reset clears all trade detection state for a new round.
 func (td *TradeDetector) Reset() {
 	td.recentKills = make(map[uint64]recentKill)
@@ -57,6 +90,14 @@ func (td *TradeDetector) Reset() {
 	td.pendingTrades = make(map[uint64][]pendingTrade)
 }
 
+// SetTradeDefinition sets the trade window in seconds and the maximum
+// distance, in units, a teammate can be from a death to have a chance to
+// trade it. The window is converted to ticks at the demo's tick rate.
+func (d *DemoParser) SetTradeDefinition(windowSeconds, proximityUnits float64) {
+	d.state.TradeDetector.SetWindow(windowSeconds)
+	d.state.TradeDetector.SetProximity(proximityUnits)
+}
+
 // TradeResult contains the results of trade detection for a kill event.
 type TradeResult struct {
 	IsTrade          bool
@@ -91,7 +132,7 @@ func (td *TradeDetector) RecordDeath(
 				dy := victimPos.Y - teammatePos.Y
 				distance := math.Sqrt(dx*dx + dy*dy)
 
-				if distance < rating.TradeProximityUnits {
+				if distance < td.proximity {
 					pt := pendingTrade{
 						KillerID:           attacker.SteamID64,
 						KillerTeam:         attacker.Team,
@@ -125,7 +166,7 @@ func (td *TradeDetector) CheckForTrade(
 
 	// Check if this kill trades a recent teammate death
 	if recent, ok := td.recentKills[victim.SteamID64]; ok {
-		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= rating.TradeWindowTicks {
+		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= td.windowTicks() {
 			// This is a trade kill
 			if tradedRound, exists := rounds[recent.VictimID]; exists {
 				tradedRound.Traded = true
@@ -181,7 +222,7 @@ func (td *TradeDetector) CheckTradeKill(
 	}
 
 	if recent, ok := td.recentKills[victim.SteamID64]; ok {
-		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= rating.TradeWindowTicks {
+		if recent.VictimTeam == attacker.Team && currentTick-recent.Tick <= td.windowTicks() {
 			isTradeKill = true
 			if deathTime, exists := td.recentTeamDeaths[recent.VictimID]; exists {
 				tradeSpeed = timeInRound - deathTime
@@ -218,7 +259,7 @@ func (td *TradeDetector) ProcessExpiredTrades(
 		expiredCount := 0
 
 		for _, pt := range pendingList {
-			if currentTick-pt.DeathTick > rating.TradeWindowTicks {
+			if currentTick-pt.DeathTick > td.windowTicks() {
 				if roundStats, exists := rounds[pt.TeammateID]; exists {
 					roundStats.FailedTrades++
 				}
@@ -252,7 +293,7 @@ func (td *TradeDetector) ProcessRoundEndTrades(
 ) {
 	for _, pendingList := range td.pendingTrades {
 		for _, pt := range pendingList {
-			if currentTick-pt.DeathTick > rating.TradeWindowTicks {
+			if currentTick-pt.DeathTick > td.windowTicks() {
 				if roundStats, exists := rounds[pt.TeammateID]; exists {
 					roundStats.FailedTrades++
 				}
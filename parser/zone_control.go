@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"math"
+
+	"github.com/ethsmith/eco-rating/model"
+
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/events"
+)
+
+// Zone control geometry, in game units.
+const (
+	infernoFireRadius    = 60.0  // Horizontal reach of one burning fire cell
+	infernoHeightRange   = 100.0 // Vertical distance within which a player stands in the fire
+	infernoDenialRadius  = 400.0 // Distance from the fire within which an enemy is held off by it
+	smokeRadius          = 144.0 // Radius of a bloomed smoke cloud
+	zoneSampleInterval   = 0.25  // Seconds between molotov denial samples
+	maxExecuteSightlines = 512   // Sightlines kept per match, oldest dropped first
+)
+
+// ZoneControlTracker follows the smokes up in the current round and the
+// sightlines CTs have won duels on, so smokes up at the bomb plant can be
+// checked against them.
+type ZoneControlTracker struct {
+	smokes     map[int]activeSmoke // By grenade entity ID
+	sightlines []sightline         // CT kill lines this match
+	lastSample float64             // Match time of the last molotov denial sample
+}
+
+// activeSmoke is a bloomed smoke.
+type activeSmoke struct {
+	throwerID uint64
+	team      common.Team
+	pos       model.Position
+}
+
+// sightline is the line between a CT killer and their victim.
+type sightline struct {
+	from, to model.Position
+}
+
+// NewZoneControlTracker creates an empty zone control tracker.
+func NewZoneControlTracker() *ZoneControlTracker {
+	return &ZoneControlTracker{smokes: make(map[int]activeSmoke)}
+}
+
+// ResetRound clears the smokes of the previous round. Sightlines are kept
+// for the match.
+func (zc *ZoneControlTracker) ResetRound() {
+	zc.smokes = make(map[int]activeSmoke)
+}
+
+// recordSightline stores a CT kill line.
+func (zc *ZoneControlTracker) recordSightline(from, to model.Position) {
+	if len(zc.sightlines) == maxExecuteSightlines {
+		zc.sightlines = zc.sightlines[1:]
+	}
+	zc.sightlines = append(zc.sightlines, sightline{from: from, to: to})
+}
+
+// blockedSightlines counts the recorded sightlines passing through a smoke.
+func (zc *ZoneControlTracker) blockedSightlines(smoke model.Position) int {
+	n := 0
+	for _, s := range zc.sightlines {
+		if segmentDistance(smoke, s.from, s.to) <= smokeRadius {
+			n++
+		}
+	}
+	return n
+}
+
+// registerZoneControlHandlers tracks smokes, and samples molotov denial as
+// the demo plays.
+func (d *DemoParser) registerZoneControlHandlers() {
+	d.parser.RegisterEventHandler(func(e events.SmokeStart) {
+		if e.Thrower == nil || d.state.ShouldSkipEvent() {
+			return
+		}
+		d.state.ZoneControl.smokes[e.GrenadeEntityID] = activeSmoke{
+			throwerID: e.Thrower.SteamID64,
+			team:      e.Thrower.Team,
+			pos:       model.Position{X: e.Position.X, Y: e.Position.Y, Z: e.Position.Z},
+		}
+	})
+
+	d.parser.RegisterEventHandler(func(e events.SmokeExpired) {
+		delete(d.state.ZoneControl.smokes, e.GrenadeEntityID)
+	})
+
+	d.parser.RegisterEventHandler(func(events.FrameDone) {
+		d.sampleMolotovDenial()
+	})
+}
+
+// sampleMolotovDenial credits each burning molotov's thrower with the time
+// alive enemies spend near it without standing in it, i.e. held off it.
+// Samples are taken every zoneSampleInterval seconds of match time.
+func (d *DemoParser) sampleMolotovDenial() {
+	gs := d.parser.GameState()
+	if d.state.ShouldSkipEvent() || gs.IsWarmupPeriod() || d.state.RoundDecided {
+		return
+	}
+	zc := d.state.ZoneControl
+	now := d.currentTime()
+	if now-zc.lastSample < zoneSampleInterval {
+		return
+	}
+	zc.lastSample = now
+
+	for _, inferno := range gs.Infernos() {
+		thrower := inferno.Thrower()
+		if thrower == nil {
+			continue
+		}
+		fires := inferno.Fires().Active().List()
+		if len(fires) == 0 {
+			continue
+		}
+		var held int
+		for _, p := range gs.Participants().Playing() {
+			if !p.IsAlive() || p.Team != opposingTeam(thrower.Team) {
+				continue
+			}
+			pos := p.Position()
+			if dist := fireDistance(pos.X, pos.Y, pos.Z, fires); dist > infernoFireRadius && dist <= infernoDenialRadius {
+				held++
+			}
+		}
+		if held > 0 {
+			ps := d.state.ensurePlayer(thrower)
+			ps.MolotovDenialTime += float64(held) * zoneSampleInterval
+		}
+	}
+}
+
+// molotovZoneThrower returns the thrower of a burning molotov of the
+// attacker's team that victim stands in, or nil.
+func (d *DemoParser) molotovZoneThrower(attacker, victim *common.Player) *common.Player {
+	pos := victim.Position()
+	for _, inferno := range d.parser.GameState().Infernos() {
+		thrower := inferno.Thrower()
+		if thrower == nil || thrower.Team != attacker.Team {
+			continue
+		}
+		if fireDistance(pos.X, pos.Y, pos.Z, inferno.Fires().Active().List()) <= infernoFireRadius {
+			return thrower
+		}
+	}
+	return nil
+}
+
+// processMolotovZoneDamage credits enemy damage taken inside a teammate's
+// molotov to whoever threw it.
+func (d *DemoParser) processMolotovZoneDamage(e events.PlayerHurt, dmg int) {
+	if thrower := d.molotovZoneThrower(e.Attacker, e.Player); thrower != nil {
+		d.state.ensurePlayer(thrower).MolotovZoneDamage += dmg
+	}
+}
+
+// processZoneControlKill credits kills inside a molotov to its thrower, and
+// records CT kill lines as sightlines for execute smokes.
+func (d *DemoParser) processZoneControlKill(ctx *killContext) {
+	if thrower := d.molotovZoneThrower(ctx.attacker, ctx.victim); thrower != nil {
+		d.state.ensurePlayer(thrower).MolotovZoneKills++
+	}
+	weapon := ctx.event.Weapon
+	if ctx.attacker.Team == common.TeamCounterTerrorists && weapon != nil &&
+		weapon.Class() != common.EqClassGrenade && weapon.Class() != common.EqClassUnknown {
+		from, to := ctx.attacker.Position(), ctx.victim.Position()
+		d.state.ZoneControl.recordSightline(
+			model.Position{X: from.X, Y: from.Y, Z: from.Z},
+			model.Position{X: to.X, Y: to.Y, Z: to.Z},
+		)
+	}
+}
+
+// processExecuteSmokes credits the T smokes up at the bomb plant, and the CT
+// sightlines each one cuts.
+func (d *DemoParser) processExecuteSmokes() {
+	zc := d.state.ZoneControl
+	for _, smoke := range zc.smokes {
+		if smoke.team != common.TeamTerrorists {
+			continue
+		}
+		ps := d.state.Players[smoke.throwerID]
+		if ps == nil {
+			continue
+		}
+		ps.ExecuteSmokes++
+		ps.SmokeSightlinesBlocked += zc.blockedSightlines(smoke.pos)
+	}
+}
+
+// fireDistance returns the horizontal distance from a point to the nearest
+// fire cell within infernoHeightRange of it, or +Inf when there is none.
+func fireDistance(x, y, z float64, fires []common.Fire) float64 {
+	nearest := math.Inf(1)
+	for _, f := range fires {
+		if math.Abs(f.Z-z) > infernoHeightRange {
+			continue
+		}
+		nearest = math.Min(nearest, math.Hypot(f.X-x, f.Y-y))
+	}
+	return nearest
+}
+
+// segmentDistance returns the distance from p to the segment between a and b.
+func segmentDistance(p, a, b model.Position) float64 {
+	abx, aby, abz := b.X-a.X, b.Y-a.Y, b.Z-a.Z
+	apx, apy, apz := p.X-a.X, p.Y-a.Y, p.Z-a.Z
+	t := 0.0
+	if lenSq := abx*abx + aby*aby + abz*abz; lenSq > 0 {
+		t = math.Max(0, math.Min(1, (apx*abx+apy*aby+apz*abz)/lenSq))
+	}
+	dx, dy, dz := apx-t*abx, apy-t*aby, apz-t*abz
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
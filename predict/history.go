@@ -0,0 +1,227 @@
+package predict
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// calibrationBuckets is the number of equal-width probability buckets used
+// when reporting calibration.
+const calibrationBuckets = 10
+
+// PredictionRecord is a stored per-map prediction and, once the map has been
+// played, its result.
+type PredictionRecord struct {
+	TeamA        string    `json:"team_a"`
+	TeamB        string    `json:"team_b"`
+	TeamAPlayers []string  `json:"team_a_players"`
+	TeamBPlayers []string  `json:"team_b_players"`
+	Map          string    `json:"map"`
+	TeamAWinProb float64   `json:"team_a_win_prob"`
+	PredictedAt  time.Time `json:"predicted_at"`
+	Resolved     bool      `json:"resolved"`
+	TeamAWon     bool      `json:"team_a_won"`
+	ResolvedAt   time.Time `json:"resolved_at,omitzero"`
+}
+
+// History stores pick'em predictions across a season.
+type History struct {
+	Records []PredictionRecord `json:"records"`
+}
+
+// LoadHistory reads a prediction history file. A missing file returns an
+// empty history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, fmt.Errorf("failed to read prediction history: %w", err)
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("failed to parse prediction history: %w", err)
+	}
+	return h, nil
+}
+
+// Save writes the history to path as JSON.
+func (h *History) Save(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal prediction history: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Add stores every map of a prediction as an unresolved record.
+func (h *History) Add(f Fixture, pred Prediction) {
+	now := time.Now().UTC()
+	for _, mp := range pred.Maps {
+		h.Records = append(h.Records, PredictionRecord{
+			TeamA:        f.TeamA.Name,
+			TeamB:        f.TeamB.Name,
+			TeamAPlayers: f.TeamA.Players,
+			TeamBPlayers: f.TeamB.Players,
+			Map:          mp.Map,
+			TeamAWinProb: mp.TeamAWinProb,
+			PredictedAt:  now,
+		})
+	}
+}
+
+// ResolveFromPlayers marks unresolved predictions for this map as played,
+// using the parsed player stats to decide the winner. Teams are matched by
+// roster overlap first and by team name as a fallback. Only predictions made
+// before playedAt are resolved, so none is scored against a game its ratings
+// could already include; a game with no known time resolves none. Returns the
+// number of predictions resolved.
+func (h *History) ResolveFromPlayers(players map[uint64]*model.PlayerStats, mapName string, playedAt time.Time) int {
+	if playedAt.IsZero() {
+		return 0
+	}
+	// Group players by team and determine the winning team
+	teamPlayers := make(map[string]map[string]bool)
+	teamWon := make(map[string]bool)
+	for _, p := range players {
+		if p.TeamName == "" {
+			continue
+		}
+		if teamPlayers[p.TeamName] == nil {
+			teamPlayers[p.TeamName] = make(map[string]bool)
+		}
+		teamPlayers[p.TeamName][p.SteamID] = true
+		if p.RoundsWon > p.RoundsLost {
+			teamWon[p.TeamName] = true
+		}
+	}
+	if len(teamPlayers) != 2 || len(teamWon) != 1 {
+		return 0
+	}
+
+	resolved := 0
+	now := time.Now().UTC()
+	for i := range h.Records {
+		rec := &h.Records[i]
+		if rec.Resolved || rec.Map != mapName || !rec.PredictedAt.Before(playedAt) {
+			continue
+		}
+		teamA := matchTeam(rec.TeamA, rec.TeamAPlayers, teamPlayers)
+		teamB := matchTeam(rec.TeamB, rec.TeamBPlayers, teamPlayers)
+		if teamA == "" || teamB == "" || teamA == teamB {
+			continue
+		}
+		rec.Resolved = true
+		rec.TeamAWon = teamWon[teamA]
+		rec.ResolvedAt = now
+		resolved++
+	}
+	return resolved
+}
+
+// matchTeam finds the parsed team that best matches a predicted roster.
+// A team matches when at least three roster players appear on it, or when the
+// team names match case-insensitively.
+func matchTeam(name string, roster []string, teams map[string]map[string]bool) string {
+	best, bestOverlap := "", 0
+	for team, members := range teams {
+		overlap := 0
+		for _, id := range roster {
+			if members[id] {
+				overlap++
+			}
+		}
+		if overlap > bestOverlap {
+			best, bestOverlap = team, overlap
+		}
+	}
+	if bestOverlap >= 3 {
+		return best
+	}
+	for team := range teams {
+		if name != "" && strings.EqualFold(team, name) {
+			return team
+		}
+	}
+	return ""
+}
+
+// CalibrationBucket summarizes predictions whose probability for team A fell
+// within [Low, High).
+type CalibrationBucket struct {
+	Low          float64 `json:"low"`
+	High         float64 `json:"high"`
+	Count        int     `json:"count"`
+	AvgPredicted float64 `json:"avg_predicted"`
+	ActualRate   float64 `json:"actual_rate"`
+}
+
+// Accuracy summarizes how well stored predictions matched results.
+type Accuracy struct {
+	Predictions int                 `json:"predictions"`
+	Resolved    int                 `json:"resolved"`
+	Correct     int                 `json:"correct"`
+	Accuracy    float64             `json:"accuracy"`
+	BrierScore  float64             `json:"brier_score"`
+	LogLoss     float64             `json:"log_loss"`
+	Calibration []CalibrationBucket `json:"calibration"`
+}
+
+// Evaluate scores all resolved predictions. Calibration buckets are keyed on
+// the probability given to team A, so a perfectly calibrated model has
+// ActualRate close to AvgPredicted in every bucket.
+func (h *History) Evaluate() Accuracy {
+	acc := Accuracy{Predictions: len(h.Records)}
+	buckets := make([]CalibrationBucket, calibrationBuckets)
+	wins := make([]int, calibrationBuckets)
+	for i := range buckets {
+		buckets[i].Low = float64(i) / calibrationBuckets
+		buckets[i].High = float64(i+1) / calibrationBuckets
+	}
+
+	for _, rec := range h.Records {
+		if !rec.Resolved {
+			continue
+		}
+		acc.Resolved++
+		outcome := 0.0
+		if rec.TeamAWon {
+			outcome = 1
+		}
+		if (rec.TeamAWinProb >= 0.5) == rec.TeamAWon {
+			acc.Correct++
+		}
+		acc.BrierScore += (rec.TeamAWinProb - outcome) * (rec.TeamAWinProb - outcome)
+		p := math.Min(math.Max(rec.TeamAWinProb, 1e-6), 1-1e-6)
+		acc.LogLoss -= outcome*math.Log(p) + (1-outcome)*math.Log(1-p)
+
+		idx := min(int(rec.TeamAWinProb*calibrationBuckets), calibrationBuckets-1)
+		buckets[idx].Count++
+		buckets[idx].AvgPredicted += rec.TeamAWinProb
+		if rec.TeamAWon {
+			wins[idx]++
+		}
+	}
+
+	if acc.Resolved > 0 {
+		n := float64(acc.Resolved)
+		acc.Accuracy = float64(acc.Correct) / n
+		acc.BrierScore /= n
+		acc.LogLoss /= n
+	}
+	for i := range buckets {
+		if buckets[i].Count > 0 {
+			buckets[i].AvgPredicted /= float64(buckets[i].Count)
+			buckets[i].ActualRate = float64(wins[i]) / float64(buckets[i].Count)
+		}
+	}
+	acc.Calibration = buckets
+	return acc
+}
@@ -0,0 +1,204 @@
+// Package predict estimates per-map win probabilities for upcoming fixtures.
+// Team strength is built from the aggregated player ratings exported by
+// cumulative mode, adjusted for map ratings and T/CT side splits, and can be
+// blended with an externally supplied team Elo.
+package predict
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ethsmith/eco-rating/rating/probability"
+)
+
+// Model constants.
+const (
+	// RatingToRoundLogit converts a team average rating difference into a
+	// per-round log-odds advantage. A 0.10 rating edge is worth ~5% per round.
+	RatingToRoundLogit = 2.0
+
+	// RoundsToWin is the number of rounds needed to win a regulation map (MR12).
+	RoundsToWin = 13
+
+	// DefaultEloWeight is how much of the final probability comes from Elo
+	// when both teams supply one.
+	DefaultEloWeight = 0.30
+
+	// DefaultPlayerRating is used for roster players with no rating history.
+	DefaultPlayerRating = 1.0
+)
+
+// Roster describes one team in a fixture.
+type Roster struct {
+	Name    string   `json:"name"`
+	Players []string `json:"players"`       // Steam IDs
+	Elo     float64  `json:"elo,omitempty"` // Optional team Elo (0 = not used)
+}
+
+// Fixture is an upcoming match between two rosters on one or more maps.
+type Fixture struct {
+	TeamA Roster   `json:"team_a"`
+	TeamB Roster   `json:"team_b"`
+	Maps  []string `json:"maps"`
+}
+
+// MapPrediction is the predicted outcome of a single map.
+type MapPrediction struct {
+	Map            string  `json:"map"`
+	TeamAStrength  float64 `json:"team_a_strength"`
+	TeamBStrength  float64 `json:"team_b_strength"`
+	TeamATRoundWin float64 `json:"team_a_t_round_win"`  // Round win probability with team A on T
+	TeamACTRound   float64 `json:"team_a_ct_round_win"` // Round win probability with team A on CT
+	RatingWinProb  float64 `json:"rating_win_prob"`
+	EloWinProb     float64 `json:"elo_win_prob,omitempty"`
+	TeamAWinProb   float64 `json:"team_a_win_prob"`
+	TeamBWinProb   float64 `json:"team_b_win_prob"`
+}
+
+// Prediction is the full prediction for a fixture.
+type Prediction struct {
+	TeamA          string          `json:"team_a"`
+	TeamB          string          `json:"team_b"`
+	Maps           []MapPrediction `json:"maps"`
+	MissingPlayers []string        `json:"missing_players,omitempty"`
+}
+
+// Predictor produces fixture predictions from a set of player ratings.
+type Predictor struct {
+	ratings   map[string]PlayerRating
+	tables    *probability.ProbabilityTables
+	EloWeight float64
+}
+
+// NewPredictor creates a Predictor using the default probability tables for
+// map T/CT balance.
+func NewPredictor(ratings map[string]PlayerRating) *Predictor {
+	return &Predictor{
+		ratings:   ratings,
+		tables:    probability.DefaultTables(),
+		EloWeight: DefaultEloWeight,
+	}
+}
+
+// Predict returns per-map win probabilities for the fixture.
+func (p *Predictor) Predict(f Fixture) Prediction {
+	pred := Prediction{TeamA: f.TeamA.Name, TeamB: f.TeamB.Name}
+	missing := make(map[string]bool)
+
+	for _, mapName := range f.Maps {
+		aT, aCT, aOverall := p.teamStrength(f.TeamA, mapName, missing)
+		bT, bCT, bOverall := p.teamStrength(f.TeamB, mapName, missing)
+
+		mapTWin := p.tables.GetMapAdjustment(mapName)
+		mapBias := logit(mapTWin)
+
+		// Team A on T faces team B on CT, and vice versa
+		aTRound := sigmoid(mapBias + RatingToRoundLogit*(aT-bCT))
+		aCTRound := 1 - sigmoid(mapBias+RatingToRoundLogit*(bT-aCT))
+
+		ratingProb := mapWinProbability((aTRound + aCTRound) / 2)
+		mp := MapPrediction{
+			Map:            mapName,
+			TeamAStrength:  aOverall,
+			TeamBStrength:  bOverall,
+			TeamATRoundWin: aTRound,
+			TeamACTRound:   aCTRound,
+			RatingWinProb:  ratingProb,
+			TeamAWinProb:   ratingProb,
+		}
+
+		if f.TeamA.Elo > 0 && f.TeamB.Elo > 0 {
+			mp.EloWinProb = 1 / (1 + math.Pow(10, (f.TeamB.Elo-f.TeamA.Elo)/400))
+			mp.TeamAWinProb = (1-p.EloWeight)*ratingProb + p.EloWeight*mp.EloWinProb
+		}
+		mp.TeamBWinProb = 1 - mp.TeamAWinProb
+		pred.Maps = append(pred.Maps, mp)
+	}
+
+	for id := range missing {
+		pred.MissingPlayers = append(pred.MissingPlayers, id)
+	}
+	sort.Strings(pred.MissingPlayers)
+	return pred
+}
+
+// teamStrength returns a roster's average T rating, CT rating and map rating.
+// Side ratings are scaled by how the player performs on this map relative to
+// their overall rating, so map pool strength carries into both halves.
+func (p *Predictor) teamStrength(r Roster, mapName string, missing map[string]bool) (tRating, ctRating, overall float64) {
+	if len(r.Players) == 0 {
+		return DefaultPlayerRating, DefaultPlayerRating, DefaultPlayerRating
+	}
+	for _, id := range r.Players {
+		pr, ok := p.ratings[id]
+		if !ok {
+			missing[id] = true
+			tRating += DefaultPlayerRating
+			ctRating += DefaultPlayerRating
+			overall += DefaultPlayerRating
+			continue
+		}
+
+		mapRating := pr.Rating
+		if mr, ok := pr.MapRatings[mapName]; ok && mr > 0 {
+			mapRating = mr
+		}
+		mapFactor := 1.0
+		if pr.Rating > 0 {
+			mapFactor = mapRating / pr.Rating
+		}
+
+		t, ct := pr.TRating, pr.CTRating
+		if t <= 0 {
+			t = pr.Rating
+		}
+		if ct <= 0 {
+			ct = pr.Rating
+		}
+		tRating += t * mapFactor
+		ctRating += ct * mapFactor
+		overall += mapRating
+	}
+	n := float64(len(r.Players))
+	return tRating / n, ctRating / n, overall / n
+}
+
+// mapWinProbability converts a per-round win probability into the probability
+// of winning an MR12 map. Overtime is approximated as a win-by-two race.
+func mapWinProbability(roundWin float64) float64 {
+	q := 1 - roundWin
+	// prob[a][b] = probability of reaching score a-b
+	var prob [RoundsToWin][RoundsToWin]float64
+	prob[0][0] = 1
+	win := 0.0
+	for a := 0; a < RoundsToWin; a++ {
+		for b := 0; b < RoundsToWin; b++ {
+			if a == RoundsToWin-1 && b == RoundsToWin-1 {
+				continue
+			}
+			cur := prob[a][b]
+			if cur == 0 {
+				continue
+			}
+			if a+1 == RoundsToWin {
+				win += cur * roundWin
+			} else {
+				prob[a+1][b] += cur * roundWin
+			}
+			if b+1 < RoundsToWin {
+				prob[a][b+1] += cur * q
+			}
+		}
+	}
+	tied := prob[RoundsToWin-1][RoundsToWin-1]
+	overtime := roundWin * roundWin / (roundWin*roundWin + q*q)
+	return win + tied*overtime
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func logit(p float64) float64 {
+	return math.Log(p / (1 - p))
+}
@@ -0,0 +1,102 @@
+package predict
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethsmith/eco-rating/output"
+)
+
+// PlayerRating is the subset of aggregated stats used for predictions.
+type PlayerRating struct {
+	SteamID      string             `json:"steam_id"`
+	Name         string             `json:"name"`
+	RoundsPlayed int                `json:"rounds_played"`
+	Rating       float64            `json:"rating"`
+	TRating      float64            `json:"t_rating"`
+	CTRating     float64            `json:"ct_rating"`
+	MapRatings   map[string]float64 `json:"map_ratings"`
+}
+
+// LoadRatingsCSV reads player ratings from an aggregated stats CSV written by
+// cumulative mode. When a player has rows for several tiers, the row with the
+// most rounds played is kept.
+func LoadRatingsCSV(path string) (map[string]PlayerRating, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ratings file: %w", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ratings file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("ratings file %s is empty", path)
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, required := range []string{"Steam ID", "Final Rating"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("ratings file %s is missing column %q", path, required)
+		}
+	}
+
+	// The export writes a "<Map> Rating" column (e.g. "Dust2 Rating") for
+	// each map in the active duty pool; other rating columns aren't maps
+	mapColumns := make(map[string]int)
+	for _, mapName := range output.DefaultActiveDutyMaps {
+		name := strings.TrimPrefix(mapName, "de_")
+		if idx, ok := columns[strings.ToUpper(name[:1])+name[1:]+" Rating"]; ok {
+			mapColumns[mapName] = idx
+		}
+	}
+
+	ratings := make(map[string]PlayerRating)
+	for _, row := range records[1:] {
+		pr := PlayerRating{
+			SteamID:      field(row, columns, "Steam ID"),
+			Name:         field(row, columns, "Name"),
+			RoundsPlayed: int(parseFloat(field(row, columns, "Rounds Played"))),
+			Rating:       parseFloat(field(row, columns, "Final Rating")),
+			TRating:      parseFloat(field(row, columns, "T Eco Rating")),
+			CTRating:     parseFloat(field(row, columns, "CT Eco Rating")),
+			MapRatings:   make(map[string]float64),
+		}
+		for mapName, idx := range mapColumns {
+			if idx < len(row) && row[idx] != "" {
+				pr.MapRatings[mapName] = parseFloat(row[idx])
+			}
+		}
+		if existing, ok := ratings[pr.SteamID]; ok && existing.RoundsPlayed >= pr.RoundsPlayed {
+			continue
+		}
+		ratings[pr.SteamID] = pr
+	}
+	return ratings, nil
+}
+
+// field returns the named column from a CSV row, or "" if absent.
+func field(row []string, columns map[string]int, name string) string {
+	idx, ok := columns[name]
+	if !ok || idx >= len(row) {
+		return ""
+	}
+	return row[idx]
+}
+
+// parseFloat parses a CSV cell, treating blanks and bad values as 0.
+func parseFloat(s string) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
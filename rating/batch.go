@@ -0,0 +1,73 @@
+package rating
+
+import "math"
+
+// ComponentBatch holds many games' rating components as columns, for tools
+// that rate the same samples over and over with different weights
+// (calibration, optimization). Weights.RateBatch rates a whole batch in one
+// pass over flat arrays, without building a breakdown for each.
+type ComponentBatch struct {
+	Rounds        []float64
+	KPR           []float64
+	DPR           []float64
+	ADR           []float64
+	KAST          []float64
+	SwingPerRound []float64
+}
+
+// NewComponentBatch lays out components as a batch, in the same order.
+func NewComponentBatch(components []Components) *ComponentBatch {
+	n := len(components)
+	b := &ComponentBatch{
+		Rounds:        make([]float64, n),
+		KPR:           make([]float64, n),
+		DPR:           make([]float64, n),
+		ADR:           make([]float64, n),
+		KAST:          make([]float64, n),
+		SwingPerRound: make([]float64, n),
+	}
+	for i, c := range components {
+		b.Rounds[i] = float64(c.Rounds)
+		if c.Rounds > 0 {
+			b.KPR[i] = float64(c.Kills) / float64(c.Rounds)
+			b.DPR[i] = float64(c.Deaths) / float64(c.Rounds)
+		}
+		b.ADR[i] = c.ADR
+		b.KAST[i] = c.KAST
+		b.SwingPerRound[i] = c.SwingPerRound
+	}
+	return b
+}
+
+// Len returns the number of samples in the batch.
+func (b *ComponentBatch) Len() int {
+	return len(b.Rounds)
+}
+
+// RateBatch applies the final rating formula with these weights to every
+// sample in b, writing the ratings to out (allocated when too short) and
+// returning it. Each rating equals Rate on the same components.
+func (w Weights) RateBatch(b *ComponentBatch, kdprModifier bool, out []float64) []float64 {
+	n := b.Len()
+	if cap(out) < n {
+		out = make([]float64, n)
+	}
+	out = out[:n]
+
+	for i := 0; i < n; i++ {
+		if b.Rounds[i] == 0 {
+			out[i] = 0
+			continue
+		}
+		r := RatingBaseline +
+			computeContribution(b.ADR[i], w.BaselineADR, w.ADRContribAbove, w.ADRContribBelow) +
+			computeContribution(b.KAST[i], w.BaselineKAST, w.KASTContribAbove, w.KASTContribBelow) +
+			b.SwingPerRound[i]*w.ProbSwingContribMultiplier
+		if kdprModifier {
+			r += exponentialAdjustment(b.KPR[i]-w.BaselineKPR, 0.1, 5) +
+				exponentialAdjustment(w.BaselineDPR-b.DPR[i], 0.1, 5)
+		}
+		out[i] = math.Max(MinRating, math.Min(MaxRating, r))
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package rating
+
+// Entry score baselines, starting estimates: each round has one opening duel
+// among ten players, an even duel is won half the time, a quarter of opening
+// deaths are traded, and a team up 5v4 wins most rounds.
+const (
+	EntryBaselineOpeningAttempts = 0.20 // Opening duels taken per round
+	EntryBaselineOpeningSuccess  = 0.50 // Share of opening duels won
+	EntryBaselineOpeningTraded   = 0.25 // Share of opening deaths traded
+	EntryBaselineConversion      = 0.70 // Share of opening-kill rounds won
+)
+
+// EntryInput contains the opening duel statistics the entry score is
+// built from.
+type EntryInput struct {
+	RoundsPlayed          int
+	OpeningAttempts       int
+	OpeningSuccesses      int
+	OpeningKills          int
+	OpeningDeaths         int
+	OpeningDeathsTraded   int
+	RoundsWonAfterOpening int
+}
+
+// ComputeEntryScore scores entry play on its own, without the final rating:
+// the average of opening attempts per round, opening success, the share of
+// opening deaths traded and the share of opening-kill rounds converted into
+// a win, each relative to its baseline and capped at ScoreComponentCap. A
+// player at every baseline scores 1.0, and a share with nothing to measure,
+// such as traded opening deaths for a player who never died first, counts
+// as at baseline. Attempts reward taking the duels, traded opening deaths
+// dying where the team can answer, and conversion the 5v4 the opening kill
+// hands the team.
+func ComputeEntryScore(input EntryInput) float64 {
+	if input.RoundsPlayed == 0 {
+		return 0
+	}
+	component := func(count, total int, baseline float64) float64 {
+		if total == 0 {
+			return 1 // Neutral, not a penalty
+		}
+		return min(float64(count)/float64(total)/baseline, ScoreComponentCap)
+	}
+	return (component(input.OpeningAttempts, input.RoundsPlayed, EntryBaselineOpeningAttempts) +
+		component(input.OpeningSuccesses, input.OpeningAttempts, EntryBaselineOpeningSuccess) +
+		component(input.OpeningDeathsTraded, input.OpeningDeaths, EntryBaselineOpeningTraded) +
+		component(input.RoundsWonAfterOpening, input.OpeningKills, EntryBaselineConversion)) / 4
+}
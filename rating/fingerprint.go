@@ -0,0 +1,37 @@
+package rating
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/ethsmith/eco-rating/rating/probability"
+)
+
+// CurrentVersion is the registered rating version the parser's final rating
+// follows.
+const CurrentVersion = "eco-3.0"
+
+// BaselinesHash fingerprints the weights, the per-map and per-tier baseline
+// tables and the empirical win probability table in effect, so two
+// deployments can tell whether they rate games the same way. It changes
+// whenever any weight, baseline or table cell does.
+func BaselinesHash() string {
+	mapBaselinesMu.RLock()
+	tierBaselinesMu.RLock()
+	data, err := json.Marshal(struct {
+		Weights Weights              `json:"weights"`
+		Maps    map[string]Baselines `json:"maps"`
+		Tiers   map[string]Baselines `json:"tiers"`
+
+		WinTable *probability.WinProbabilityTable `json:"win_table,omitempty"`
+	}{DefaultWeights(), mapBaselines, tierBaselines, probability.ActiveWinProbabilityTable()})
+	tierBaselinesMu.RUnlock()
+	mapBaselinesMu.RUnlock()
+	if err != nil {
+		// Only plain numbers are marshaled, so this cannot happen.
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
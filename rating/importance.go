@@ -0,0 +1,147 @@
+package rating
+
+import (
+	"fmt"
+	"math"
+)
+
+// ImportanceModel weighs a round by how much it matters to the match, from
+// the score going into it. A player's round swing and clutch credit are
+// scaled by the weight, so 1.0 leaves them as they are.
+type ImportanceModel interface {
+	RoundImportance(scoreA, scoreB int) float64
+}
+
+// Importance model names, as selected in config.
+const (
+	ImportanceFlat     = "flat"
+	ImportanceLeverage = "leverage"
+)
+
+// Leverage importance settings.
+const (
+	DefaultLeverageStrength = 0.25
+	MinRoundImportance      = 0.75 // Floor for lopsided rounds like 11-3
+	MaxRoundImportance      = 1.5  // Cap for rounds like 11-11
+)
+
+// FlatImportance weighs every round 1.0, as ratings did before round
+// importance existed.
+type FlatImportance struct{}
+
+// RoundImportance implements ImportanceModel.
+func (FlatImportance) RoundImportance(scoreA, scoreB int) float64 {
+	return 1.0
+}
+
+// LeverageImportance weighs a round by its leverage: how much winning it
+// rather than losing it changes a team's chance of winning the match, with
+// every round a coin flip. Leverage is taken relative to the first round's,
+// so 11-11 weighs more than 0-0 and 11-3 much less.
+type LeverageImportance struct {
+	// Strength is how far the weight follows relative leverage: 0 is flat,
+	// 1 is fully proportional. The weight is clamped to
+	// [MinRoundImportance, MaxRoundImportance] either way.
+	Strength float64
+}
+
+// RoundImportance implements ImportanceModel.
+func (m LeverageImportance) RoundImportance(scoreA, scoreB int) float64 {
+	toWin := RegulationRounds/2 + 1
+	relative := roundLeverage(scoreA, scoreB) / leverage(toWin, toWin)
+	weight := 1 + m.Strength*(relative-1)
+	return math.Max(MinRoundImportance, math.Min(MaxRoundImportance, weight))
+}
+
+// RoundLeverage returns the leverage model's weight at full strength: a
+// round's leverage relative to the first round's, clamped to
+// [MinRoundImportance, MaxRoundImportance]. Unlike the configured model it
+// is always on, for stats that report leverage alongside raw counts.
+func RoundLeverage(scoreA, scoreB int) float64 {
+	return LeverageImportance{Strength: 1}.RoundImportance(scoreA, scoreB)
+}
+
+// NewImportanceModel returns the importance model named name; an empty name
+// is flat. strength only applies to the leverage model.
+func NewImportanceModel(name string, strength float64) (ImportanceModel, error) {
+	switch name {
+	case "", ImportanceFlat:
+		return FlatImportance{}, nil
+	case ImportanceLeverage:
+		return LeverageImportance{Strength: strength}, nil
+	}
+	return nil, fmt.Errorf("unknown round importance model %q (valid: %s, %s)", name, ImportanceFlat, ImportanceLeverage)
+}
+
+// roundLeverage returns the leverage of the next round at a score.
+func roundLeverage(scoreA, scoreB int) float64 {
+	a, b := roundsToWin(scoreA, scoreB)
+	return leverage(a, b)
+}
+
+// roundsToWin returns how many more rounds each team needs to take the
+// match, or the current overtime. Each overtime is first to
+// OvertimeLength/2+1 of its rounds, and a tie starts a new one.
+func roundsToWin(scoreA, scoreB int) (int, int) {
+	half := RegulationRounds / 2
+	if scoreA < half || scoreB < half {
+		return clampRoundsToWin(half+1-scoreA, half+1), clampRoundsToWin(half+1-scoreB, half+1)
+	}
+	otHalf := OvertimeLength / 2
+	x, y := scoreA-half, scoreB-half
+	played := min(x, y) / otHalf * otHalf
+	return clampRoundsToWin(otHalf+1-(x-played), otHalf+1), clampRoundsToWin(otHalf+1-(y-played), otHalf+1)
+}
+
+// clampRoundsToWin keeps a rounds-needed count within 1..limit, for scores
+// past the end of a match.
+func clampRoundsToWin(n, limit int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > limit {
+		return limit
+	}
+	return n
+}
+
+// leverage is the difference between winning the match after taking the
+// next round and after losing it, for teams needing a and b rounds.
+func leverage(a, b int) float64 {
+	return matchWinProbability(a-1, b) - matchWinProbability(a, b-1)
+}
+
+// matchWinProbability returns the chance that a team needing a rounds gets
+// there before one needing b, each round a coin flip.
+func matchWinProbability(a, b int) float64 {
+	if a <= 0 {
+		return 1
+	}
+	if b <= 0 {
+		return 0
+	}
+	if a < len(winProbabilities) && b < len(winProbabilities) {
+		return winProbabilities[a][b]
+	}
+	return 0.5*matchWinProbability(a-1, b) + 0.5*matchWinProbability(a, b-1)
+}
+
+// winProbabilities caches matchWinProbability for a regulation match.
+var winProbabilities = func() [][]float64 {
+	n := RegulationRounds/2 + 2
+	table := make([][]float64, n)
+	for a := range table {
+		table[a] = make([]float64, n)
+		for b := range table[a] {
+			switch {
+			case a == 0:
+				table[a][b] = 1
+			case b == 0:
+				table[a][b] = 0
+			default:
+				table[a][b] = 0.5*table[a-1][b] + 0.5*table[a][b-1]
+			}
+		}
+	}
+	return table
+}()
@@ -0,0 +1,56 @@
+package rating
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Baselines are the league averages a rating is measured against. A player
+// at every baseline with zero probability swing rates 1.0.
+type Baselines struct {
+	KPR  float64 `json:"kpr"`
+	DPR  float64 `json:"dpr"`
+	ADR  float64 `json:"adr"`
+	KAST float64 `json:"kast"` // Fraction of rounds, 0-1
+}
+
+var (
+	mapBaselinesMu sync.RWMutex
+	mapBaselines   map[string]Baselines
+)
+
+// SetMapBaselines replaces the per-map baseline table. Maps missing from the
+// table are rated against the global baselines in weights.go.
+func SetMapBaselines(table map[string]Baselines) {
+	mapBaselinesMu.Lock()
+	defer mapBaselinesMu.Unlock()
+	mapBaselines = make(map[string]Baselines, len(table))
+	for m, b := range table {
+		mapBaselines[m] = b
+	}
+}
+
+// MapBaselines returns the baselines for mapName and whether the map has its
+// own entry.
+func MapBaselines(mapName string) (Baselines, bool) {
+	mapBaselinesMu.RLock()
+	defer mapBaselinesMu.RUnlock()
+	b, ok := mapBaselines[mapName]
+	return b, ok
+}
+
+// LoadMapBaselines reads a per-map baseline table, keyed by map name, from a
+// JSON file such as the one written by -calibrate-maps.
+func LoadMapBaselines(path string) (map[string]Baselines, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read map baselines: %w", err)
+	}
+	var table map[string]Baselines
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse map baselines: %w", err)
+	}
+	return table, nil
+}
@@ -0,0 +1,52 @@
+package rating
+
+// Pistol round baselines. Pistol rounds are played on 800 dollars with
+// little or no armor, so kills, damage and survival land far from gun-round
+// averages; measuring them against the standard baselines says more about
+// the round type than the player. These are starting estimates for MR12
+// league play and should be recalibrated as pistol data accumulates.
+const (
+	PistolBaselineKPR       = 0.70 // Average kills per pistol round
+	PistolBaselineADR       = 60.0 // Average damage per pistol round
+	PistolBaselineSPR       = 0.28 // Share of pistol rounds survived
+	PistolBaselineMultiKill = 0.14 // Share of pistol rounds with two or more kills
+)
+
+// Pistol round component weights. They sum to 1, so a player at every
+// pistol baseline rates 1.0.
+const (
+	PistolKillWeight      = 0.35
+	PistolDamageWeight    = 0.30
+	PistolSurvivalWeight  = 0.20
+	PistolMultiKillWeight = 0.15
+)
+
+// PistolInput contains the pistol round statistics needed to compute a
+// pistol round rating.
+type PistolInput struct {
+	RoundsPlayed    int
+	Kills           int
+	Damage          int
+	Survivals       int
+	MultiKillRounds int // Pistol rounds with two or more kills
+}
+
+// ComputePistolRating calculates the rating for pistol rounds only. Each
+// component is measured against its pistol baseline rather than the
+// gun-round ones, then weighted.
+func ComputePistolRating(input PistolInput) float64 {
+	if input.RoundsPlayed == 0 {
+		return 0
+	}
+
+	rounds := float64(input.RoundsPlayed)
+	kpr := float64(input.Kills) / rounds
+	adr := float64(input.Damage) / rounds
+	spr := float64(input.Survivals) / rounds
+	mkr := float64(input.MultiKillRounds) / rounds
+
+	return PistolKillWeight*kpr/PistolBaselineKPR +
+		PistolDamageWeight*adr/PistolBaselineADR +
+		PistolSurvivalWeight*spr/PistolBaselineSPR +
+		PistolMultiKillWeight*mkr/PistolBaselineMultiKill
+}
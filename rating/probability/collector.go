@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
@@ -15,6 +16,7 @@ type DataCollector struct {
 	mu            sync.Mutex
 	data          *CollectedData
 	pendingStates []string // State keys captured during round, attributed at round end
+	pendingTimed  []string // Time-bucketed keys of the same snapshots
 }
 
 // CollectedData holds all collected probability data.
@@ -24,6 +26,10 @@ type CollectedData struct {
 	MapData       map[string]*MapData          `json:"map_data"`
 	TotalRounds   int                          `json:"total_rounds"`
 	TotalKills    int                          `json:"total_kills"`
+
+	// TimedStateOutcomes splits StateOutcomes by time remaining, for the
+	// empirical win probability table. Keys look like "5v4_none_t60".
+	TimedStateOutcomes map[string]*StateOutcomeData `json:"timed_state_outcomes"`
 }
 
 // StateOutcomeData tracks win/loss for a specific game state.
@@ -48,9 +54,10 @@ type MapData struct {
 func NewDataCollector() *DataCollector {
 	return &DataCollector{
 		data: &CollectedData{
-			StateOutcomes: make(map[string]*StateOutcomeData),
-			DuelOutcomes:  make(map[string]*DuelOutcomeData),
-			MapData:       make(map[string]*MapData),
+			StateOutcomes:      make(map[string]*StateOutcomeData),
+			TimedStateOutcomes: make(map[string]*StateOutcomeData),
+			DuelOutcomes:       make(map[string]*DuelOutcomeData),
+			MapData:            make(map[string]*MapData),
 		},
 	}
 }
@@ -77,16 +84,19 @@ func (dc *DataCollector) RecordRoundStart(tAlive, ctAlive int, bombPlanted bool,
 	defer dc.mu.Unlock()
 
 	dc.pendingStates = nil // Reset for new round
+	dc.pendingTimed = nil
 }
 
 // RecordStateSnapshot captures the current game state for later attribution.
 // Call this before each significant event (kill, bomb plant, etc).
-func (dc *DataCollector) RecordStateSnapshot(tAlive, ctAlive int, bombPlanted bool) {
+// timeRemaining is the round timer before the plant and the bomb timer after.
+func (dc *DataCollector) RecordStateSnapshot(tAlive, ctAlive int, bombPlanted bool, timeRemaining float64) {
 	dc.mu.Lock()
 	defer dc.mu.Unlock()
 
 	key := stateKey(tAlive, ctAlive, bombPlanted)
 	dc.pendingStates = append(dc.pendingStates, key)
+	dc.pendingTimed = append(dc.pendingTimed, timedStateKey(tAlive, ctAlive, bombPlanted, timeRemaining))
 }
 
 // RecordRoundEnd records the outcome of a round.
@@ -103,17 +113,10 @@ func (dc *DataCollector) RecordRoundEnd(
 	dc.data.TotalRounds++
 
 	// Attribute all pending state snapshots to the winner
-	for _, key := range dc.pendingStates {
-		if dc.data.StateOutcomes[key] == nil {
-			dc.data.StateOutcomes[key] = &StateOutcomeData{}
-		}
-		if winner == common.TeamTerrorists {
-			dc.data.StateOutcomes[key].TWins++
-		} else {
-			dc.data.StateOutcomes[key].CTWins++
-		}
-	}
+	attributeStates(dc.data.StateOutcomes, dc.pendingStates, winner)
+	attributeStates(dc.data.TimedStateOutcomes, dc.pendingTimed, winner)
 	dc.pendingStates = nil // Clear for next round
+	dc.pendingTimed = nil
 
 	// Record map data
 	if dc.data.MapData[mapName] == nil {
@@ -126,6 +129,20 @@ func (dc *DataCollector) RecordRoundEnd(
 	}
 }
 
+// attributeStates credits each state key's outcome with the round winner.
+func attributeStates(outcomes map[string]*StateOutcomeData, keys []string, winner common.Team) {
+	for _, key := range keys {
+		if outcomes[key] == nil {
+			outcomes[key] = &StateOutcomeData{}
+		}
+		if winner == common.TeamTerrorists {
+			outcomes[key].TWins++
+		} else {
+			outcomes[key].CTWins++
+		}
+	}
+}
+
 // RecordKill records the outcome of a kill/duel.
 // Records bidirectional data: attacker won in A_V key, defender lost in V_A key.
 // This allows computing win rates for any equipment matchup.
@@ -159,6 +176,38 @@ func (dc *DataCollector) RecordKill(
 	}
 }
 
+// CollectorSnapshot is a copy of a collector's data and pending states,
+// taken by Snapshot and put back by Restore.
+type CollectorSnapshot struct {
+	data          *CollectedData
+	pendingStates []string
+	pendingTimed  []string
+}
+
+// Snapshot copies the collected data and the state snapshots not yet
+// attributed to a round outcome.
+func (dc *DataCollector) Snapshot() CollectorSnapshot {
+	c := NewDataCollector()
+	c.Merge(dc)
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return CollectorSnapshot{
+		data:          c.data,
+		pendingStates: slices.Clone(dc.pendingStates),
+		pendingTimed:  slices.Clone(dc.pendingTimed),
+	}
+}
+
+// Restore puts back what Snapshot copied, discarding everything recorded
+// since. A snapshot can be restored only once.
+func (dc *DataCollector) Restore(s CollectorSnapshot) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	dc.data = s.data
+	dc.pendingStates = s.pendingStates
+	dc.pendingTimed = s.pendingTimed
+}
+
 // Merge combines data from another collector.
 func (dc *DataCollector) Merge(other *DataCollector) {
 	dc.mu.Lock()
@@ -177,6 +226,14 @@ func (dc *DataCollector) Merge(other *DataCollector) {
 		dc.data.StateOutcomes[key].CTWins += outcome.CTWins
 	}
 
+	for key, outcome := range other.data.TimedStateOutcomes {
+		if dc.data.TimedStateOutcomes[key] == nil {
+			dc.data.TimedStateOutcomes[key] = &StateOutcomeData{}
+		}
+		dc.data.TimedStateOutcomes[key].TWins += outcome.TWins
+		dc.data.TimedStateOutcomes[key].CTWins += outcome.CTWins
+	}
+
 	for key, outcome := range other.data.DuelOutcomes {
 		if dc.data.DuelOutcomes[key] == nil {
 			dc.data.DuelOutcomes[key] = &DuelOutcomeData{}
@@ -220,7 +277,14 @@ func (dc *DataCollector) LoadFromFile(filepath string) error {
 		return err
 	}
 
-	return json.Unmarshal(data, dc.data)
+	if err := json.Unmarshal(data, dc.data); err != nil {
+		return err
+	}
+	if dc.data.TimedStateOutcomes == nil {
+		// Saved before time remaining was collected
+		dc.data.TimedStateOutcomes = make(map[string]*StateOutcomeData)
+	}
+	return nil
 }
 
 // GetData returns the collected data (for building tables).
@@ -0,0 +1,132 @@
+package probability
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+)
+
+// Empirical win probability table settings.
+const (
+	// TimeBucketSeconds is the width of the time remaining buckets. The round
+	// timer is counted before the plant and the bomb timer after it.
+	TimeBucketSeconds = 10.0
+	// DefaultMinTableSamples is the number of rounds a state must have been
+	// seen in for its cell to be used; rarer states fall back to the
+	// heuristic model. A starting estimate, to be raised as the corpus grows.
+	DefaultMinTableSamples = 30
+	// BombTimerSeconds is the time remaining when the bomb is planted.
+	BombTimerSeconds = 40.0
+)
+
+// WinProbabilityTable is an empirical round win probability table, keyed by
+// players alive on each side, bomb state and time remaining, trained from
+// the round states of parsed demos and their outcomes.
+type WinProbabilityTable struct {
+	BucketSeconds float64              `json:"bucket_seconds"`
+	MinSamples    int                  `json:"min_samples"`
+	Rounds        int                  `json:"rounds"` // Rounds the table was trained on
+	Cells         map[string]TableCell `json:"cells"`  // By timedStateKey
+}
+
+// TableCell is one state's T-side win rate and the number of times the
+// state was seen.
+type TableCell struct {
+	TWinProbability float64 `json:"t_win_probability"`
+	Samples         int     `json:"samples"`
+}
+
+// timedStateKey extends stateKey with the time remaining bucket, e.g.
+// "5v4_none_t60" for 60 to 70 seconds left.
+func timedStateKey(tAlive, ctAlive int, bombPlanted bool, timeRemaining float64) string {
+	bucket := int(math.Max(0, timeRemaining) / TimeBucketSeconds)
+	return fmt.Sprintf("%s_t%d", stateKey(tAlive, ctAlive, bombPlanted), bucket*int(TimeBucketSeconds))
+}
+
+// Lookup returns the T-side win probability for a state and whether the
+// table has enough samples of it.
+func (t *WinProbabilityTable) Lookup(tAlive, ctAlive int, bombPlanted bool, timeRemaining float64) (float64, bool) {
+	cell, ok := t.Cells[timedStateKey(tAlive, ctAlive, bombPlanted, timeRemaining)]
+	if !ok || cell.Samples < t.MinSamples {
+		return 0, false
+	}
+	return cell.TWinProbability, true
+}
+
+// BuildWinProbabilityTable trains a table from the time-keyed state outcomes
+// collected so far. Every cell is kept with its sample count; minSamples
+// only decides which cells Lookup uses.
+func (dc *DataCollector) BuildWinProbabilityTable(minSamples int) *WinProbabilityTable {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	table := &WinProbabilityTable{
+		BucketSeconds: TimeBucketSeconds,
+		MinSamples:    minSamples,
+		Rounds:        dc.data.TotalRounds,
+		Cells:         make(map[string]TableCell, len(dc.data.TimedStateOutcomes)),
+	}
+	for key, outcome := range dc.data.TimedStateOutcomes {
+		total := outcome.TWins + outcome.CTWins
+		if total == 0 {
+			continue
+		}
+		table.Cells[key] = TableCell{
+			TWinProbability: float64(outcome.TWins) / float64(total),
+			Samples:         total,
+		}
+	}
+	return table
+}
+
+// SaveWinProbabilityTable writes a table as JSON.
+func SaveWinProbabilityTable(path string, table *WinProbabilityTable) error {
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal win probability table: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write win probability table: %w", err)
+	}
+	return nil
+}
+
+// LoadWinProbabilityTable reads a table written by SaveWinProbabilityTable.
+func LoadWinProbabilityTable(path string) (*WinProbabilityTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read win probability table: %w", err)
+	}
+	var table WinProbabilityTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("failed to parse win probability table: %w", err)
+	}
+	if table.BucketSeconds != TimeBucketSeconds {
+		return nil, fmt.Errorf("win probability table uses %g-second buckets, expected %g", table.BucketSeconds, TimeBucketSeconds)
+	}
+	return &table, nil
+}
+
+var (
+	winTableMu sync.RWMutex
+	winTable   *WinProbabilityTable
+)
+
+// SetWinProbabilityTable makes engines created by NewDefaultEngine from now
+// on look states up in table before falling back to the heuristic model.
+// nil goes back to the heuristic model alone.
+func SetWinProbabilityTable(table *WinProbabilityTable) {
+	winTableMu.Lock()
+	defer winTableMu.Unlock()
+	winTable = table
+}
+
+// ActiveWinProbabilityTable returns the table set by SetWinProbabilityTable,
+// or nil.
+func ActiveWinProbabilityTable() *WinProbabilityTable {
+	winTableMu.RLock()
+	defer winTableMu.RUnlock()
+	return winTable
+}
@@ -4,7 +4,8 @@ import "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
 
 // Engine calculates win probabilities based on game state.
 type Engine struct {
-	tables *ProbabilityTables
+	tables    *ProbabilityTables
+	empirical *WinProbabilityTable // nil = heuristic model only
 }
 
 // NewEngine creates a new probability engine with the given tables.
@@ -12,15 +13,36 @@ func NewEngine(tables *ProbabilityTables) *Engine {
 	return &Engine{tables: tables}
 }
 
-// NewDefaultEngine creates a probability engine with default tables.
+// NewDefaultEngine creates a probability engine with default tables and the
+// empirical win probability table set by SetWinProbabilityTable, if any.
 func NewDefaultEngine() *Engine {
-	return NewEngine(DefaultTables())
+	e := NewEngine(DefaultTables())
+	e.empirical = ActiveWinProbabilityTable()
+	return e
+}
+
+// WithWinProbabilityTable makes the engine look states up in table before
+// falling back to the heuristic model, and returns the engine.
+func (e *Engine) WithWinProbabilityTable(table *WinProbabilityTable) *Engine {
+	e.empirical = table
+	return e
+}
+
+// HasWinProbabilityTable reports whether the engine uses an empirical table.
+func (e *Engine) HasWinProbabilityTable() bool {
+	return e.empirical != nil
 }
 
 // GetWinProbability returns the probability that the specified side wins the round.
+// States the empirical table has enough samples of take its win rate, which
+// already accounts for time remaining; the economy and map adjustments apply
+// either way, as the table is trained across economies and maps.
 func (e *Engine) GetWinProbability(state *RoundState, side common.Team) float64 {
 	// Get base probability (T-side win rate)
-	tWinProb := e.getBaseProbability(state)
+	tWinProb, empirical := e.getEmpiricalProbability(state)
+	if !empirical {
+		tWinProb = e.getBaseProbability(state)
+	}
 
 	// Apply economy adjustment
 	tWinProb = e.applyEconomyAdjustment(tWinProb, state)
@@ -29,7 +51,14 @@ func (e *Engine) GetWinProbability(state *RoundState, side common.Team) float64
 	tWinProb = e.applyMapAdjustment(tWinProb, state.Map)
 
 	// Apply time adjustment for bomb planted scenarios
-	tWinProb = e.applyTimeAdjustment(tWinProb, state)
+	if !empirical {
+		tWinProb = e.applyTimeAdjustment(tWinProb, state)
+	}
+
+	// A defused bomb decides the round for CT
+	if state.BombDefused {
+		tWinProb = 0
+	}
 
 	// Clamp to valid range
 	tWinProb = clamp(tWinProb, 0.01, 0.99)
@@ -45,6 +74,15 @@ func (e *Engine) getBaseProbability(state *RoundState) float64 {
 	return e.tables.GetBaseWinProbability(state.TAlive, state.CTAlive, state.BombPlanted)
 }
 
+// getEmpiricalProbability returns the T-side win probability from the
+// empirical table, and whether it had the state.
+func (e *Engine) getEmpiricalProbability(state *RoundState) (float64, bool) {
+	if e.empirical == nil {
+		return 0, false
+	}
+	return e.empirical.Lookup(state.TAlive, state.CTAlive, state.BombPlanted, state.TimeRemaining)
+}
+
 // applyEconomyAdjustment modifies probability based on economy differential.
 func (e *Engine) applyEconomyAdjustment(baseProb float64, state *RoundState) float64 {
 	// Calculate economy differential
@@ -130,6 +168,8 @@ func (e *Engine) CalculateKillSwing(stateBefore, stateAfter *RoundState, killerS
 }
 
 // CalculateBombPlantSwing calculates the probability swing from a bomb plant.
+// Like a kill, a plant is never credited below zero, even in the few states
+// where the planted win rate is lower than the unplanted one.
 func (e *Engine) CalculateBombPlantSwing(stateBefore *RoundState) float64 {
 	stateAfter := stateBefore.Clone()
 	stateAfter.SetBombPlanted()
@@ -137,10 +177,11 @@ func (e *Engine) CalculateBombPlantSwing(stateBefore *RoundState) float64 {
 	probBefore := e.GetWinProbability(stateBefore, common.TeamTerrorists)
 	probAfter := e.GetWinProbability(stateAfter, common.TeamTerrorists)
 
-	return probAfter - probBefore
+	return max(0, probAfter-probBefore)
 }
 
-// CalculateBombDefuseSwing calculates the probability swing from a bomb defuse.
+// CalculateBombDefuseSwing calculates the probability swing from a bomb defuse:
+// everything CT still had to win, since a defuse decides the round.
 func (e *Engine) CalculateBombDefuseSwing(stateBefore *RoundState) float64 {
 	stateAfter := stateBefore.Clone()
 	stateAfter.SetBombDefused()
@@ -151,6 +192,14 @@ func (e *Engine) CalculateBombDefuseSwing(stateBefore *RoundState) float64 {
 	return probAfter - probBefore
 }
 
+// CalculateDefuseDenialSwing calculates the probability swing for T from
+// stopping a defuse that was progress (0 to 1) of the way done. The states
+// don't model a defuse underway, so its value is taken as the share of the
+// defuse's swing it had already earned.
+func (e *Engine) CalculateDefuseDenialSwing(state *RoundState, progress float64) float64 {
+	return e.CalculateBombDefuseSwing(state) * clamp(progress, 0, 1)
+}
+
 // GetEconomyAdjustedKillValue returns a multiplier for kill value based on economy.
 // Kills against better-equipped opponents are worth more (>1.0).
 // Kills against worse-equipped opponents are worth less (<1.0).
@@ -0,0 +1,77 @@
+package probability
+
+import "github.com/markus-wa/demoinfocs-golang/v5/pkg/demoinfocs/common"
+
+// Round income used to project the next round's economy (CS2 competitive).
+const (
+	RoundWinIncome    = 3250 // Reward for winning a round by elimination
+	LossBonusBase     = 1400 // Loss bonus after the first consecutive loss
+	LossBonusStep     = 500  // Added per further consecutive loss
+	LossBonusMax      = 3400 // Loss bonus cap
+	MaxLoadoutValue   = 6000 // Most a player is assumed to spend on one loadout
+	ForecastTeamAlive = 5    // Players per side assumed at the start of the next round
+)
+
+// ForecastPlayer is one player's economy at the end of a round.
+type ForecastPlayer struct {
+	Money     int     // Cash on hand
+	Equipment float64 // Value of the equipment they're carrying
+	Alive     bool    // Survivors keep their equipment into the next round
+}
+
+// RoundIncome returns a team's income for the next round. lossStreak is the
+// number of consecutive rounds the team has lost, including this one.
+func RoundIncome(won bool, lossStreak int) int {
+	if won {
+		return RoundWinIncome
+	}
+	if lossStreak < 1 {
+		lossStreak = 1
+	}
+	bonus := LossBonusBase + LossBonusStep*(lossStreak-1)
+	if bonus > LossBonusMax {
+		bonus = LossBonusMax
+	}
+	return bonus
+}
+
+// ProjectTeamEquipment estimates a team's average equipment value at the
+// start of the next round. Survivors keep what they carry and top up with
+// cash; dead players rebuy from cash alone. Each loadout is capped at
+// MaxLoadoutValue so a rich team isn't credited for money it can't spend.
+func ProjectTeamEquipment(players []ForecastPlayer, income int) float64 {
+	if len(players) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, p := range players {
+		kept := 0.0
+		if p.Alive {
+			kept = p.Equipment
+		}
+		limit := float64(MaxLoadoutValue)
+		if kept > limit {
+			limit = kept
+		}
+		value := kept + float64(p.Money+income)
+		if value > limit {
+			value = limit
+		}
+		total += value
+	}
+	return total / float64(len(players))
+}
+
+// NextRoundWinProbability returns side's probability of winning a full 5v5
+// next round given both teams' projected average equipment values.
+func (e *Engine) NextRoundWinProbability(side common.Team, teamEquip, enemyEquip float64, mapName string) float64 {
+	state := NewRoundState(ForecastTeamAlive, ForecastTeamAlive, mapName)
+	if side == common.TeamTerrorists {
+		state.TEconomy = CategorizeEquipment(teamEquip)
+		state.CTEconomy = CategorizeEquipment(enemyEquip)
+	} else {
+		state.TEconomy = CategorizeEquipment(enemyEquip)
+		state.CTEconomy = CategorizeEquipment(teamEquip)
+	}
+	return e.GetWinProbability(state, side)
+}
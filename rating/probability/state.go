@@ -104,9 +104,10 @@ func (s *RoundState) RecordDeath(side common.Team) {
 	}
 }
 
-// SetBombPlanted marks the bomb as planted.
+// SetBombPlanted marks the bomb as planted and starts the bomb timer.
 func (s *RoundState) SetBombPlanted() {
 	s.BombPlanted = true
+	s.TimeRemaining = BombTimerSeconds
 }
 
 // SetBombDefused marks the bomb as defused.
@@ -26,14 +26,6 @@ func exponentialAdjustment(diff float64, maxAdj float64, k float64) float64 {
 	return math.Max(-maxAdj, math.Min(maxAdj, adj))
 }
 
-// computeKPRDPRAdjustment calculates the combined KPR/DPR adjustment.
-// Each is calculated independently with exponential scaling, range -0.2 to +0.2 total.
-func computeKPRDPRAdjustment(kpr, dpr float64) float64 {
-	kprAdj := exponentialAdjustment(kpr-BaselineKPR, 0.1, 5)
-	dprAdj := exponentialAdjustment(BaselineDPR-dpr, 0.1, 5)
-	return kprAdj + dprAdj
-}
-
 // computeContribution calculates a contribution based on value vs baseline with different multipliers.
 func computeContribution(value, baseline, aboveMultiplier, belowMultiplier float64) float64 {
 	if value >= baseline {
@@ -49,60 +41,71 @@ func computeContribution(value, baseline, aboveMultiplier, belowMultiplier float
 // - KAST: Rewards round involvement (kill/assist/survive/trade)
 //
 // Kills/deaths are captured entirely through ProbabilitySwing to avoid double-counting.
-// Returns a value typically between 0.20 and 3.00.
-func ComputeFinalRating(p *model.PlayerStats, kdprModifier bool) float64 {
-	rounds := float64(p.RoundsPlayed)
-	if rounds == 0 {
+// ADR, KAST and KPR/DPR are measured against the baselines for mapName and
+// tier (see Weights.ForGame), and the global baselines when neither has an
+// entry. Returns a value typically between 0.20 and 3.00.
+func ComputeFinalRating(p *model.PlayerStats, mapName, tier string, kdprModifier bool) float64 {
+	if p.RoundsPlayed == 0 {
 		return 0
 	}
+	return GameWeights(mapName, tier).Rate(PlayerComponents(p, mapName, tier), kdprModifier)
+}
 
-	adr := float64(p.Damage) / rounds
-	kast := p.KAST
-	probSwingPerRound := p.ProbabilitySwingPerRound
+// GameWeights returns the default weights with the baselines of a game on
+// mapName in tier.
+func GameWeights(mapName, tier string) Weights {
+	return DefaultWeights().ForGame(mapName, tier)
+}
 
-	var kprDprAdjustment float64
-	if kdprModifier {
-		kprDprAdjustment = computeKPRDPRAdjustment(p.KPR, p.DPR)
+// ComputeRatingBreakdown is ComputeFinalRating with each component's
+// contribution to the result.
+func ComputeRatingBreakdown(p *model.PlayerStats, mapName, tier string, kdprModifier bool) model.RatingBreakdown {
+	if p.RoundsPlayed == 0 {
+		return model.RatingBreakdown{}
 	}
-
-	adrContrib := computeContribution(adr, BaselineADR, ADRContribAbove, ADRContribBelow)
-	kastContrib := computeContribution(kast, BaselineKAST, KASTContribAbove, KASTContribBelow)
-	probSwingContrib := probSwingPerRound * ProbSwingContribMultiplier
-
-	rating := RatingBaseline + adrContrib + kastContrib + probSwingContrib + kprDprAdjustment
-	return math.Max(MinRating, math.Min(MaxRating, rating))
+	return GameWeights(mapName, tier).Breakdown(PlayerComponents(p, mapName, tier), kdprModifier)
 }
 
-// ComputeSideRating calculates a rating for a specific side (T or CT).
-// Pure probability-based rating matching ComputeFinalRating:
-// - ProbabilitySwing: Core metric measuring win probability impact
-// - ADR: Rewards chip damage that didn't result in kills
-// - KAST: Rewards round involvement
-//
-// Kills/deaths are captured entirely through swing to avoid double-counting.
-func ComputeSideRating(rounds int, kills int, deaths int, damage int, ecoKillValue float64,
-	probabilitySwing float64, kast float64, multiKills [6]int, clutchRounds int, clutchWins int, kdprModifier bool) float64 {
-
-	roundsF := float64(rounds)
-	if roundsF == 0 {
-		return 0
+// PlayerComponents returns the rating inputs of a player's game on mapName
+// in tier. The player must have played at least one round.
+func PlayerComponents(p *model.PlayerStats, mapName, tier string) Components {
+	return Components{
+		Rounds:        p.RoundsPlayed,
+		Kills:         p.Kills,
+		Deaths:        p.Deaths,
+		ADR:           float64(p.Damage) / float64(p.RoundsPlayed),
+		KAST:          p.KAST,
+		SwingPerRound: p.ProbabilitySwingPerRound,
+		Map:           mapName,
+		Tier:          tier,
 	}
+}
 
-	adr := float64(damage) / roundsF
-	kastPct := kast / roundsF
-	probSwingPerRound := probabilitySwing / roundsF
+// ComputeComponentRating applies the final rating formula to a game's
+// components, against the baselines of their map and tier. It is
+// ComputeFinalRating without the PlayerStats, so stored box scores can be
+// re-rated.
+func ComputeComponentRating(c Components, kdprModifier bool) float64 {
+	return GameWeights(c.Map, c.Tier).Rate(c, kdprModifier)
+}
 
-	var kprDprAdjustment float64
-	if kdprModifier {
-		kpr := float64(kills) / roundsF
-		dpr := float64(deaths) / roundsF
-		kprDprAdjustment = computeKPRDPRAdjustment(kpr, dpr)
+// ComputeRoundsRating applies the final rating formula to some of a player's
+// rounds in a game on mapName in tier, such as the rounds outside garbage
+// time, against the same baselines as ComputeFinalRating so the two compare
+// directly. kast is the number of KAST rounds.
+func ComputeRoundsRating(rounds, kills, deaths, damage int, kast, probabilitySwing float64, mapName, tier string, kdprModifier bool) float64 {
+	if rounds == 0 {
+		return 0
 	}
-
-	adrContrib := computeContribution(adr, BaselineADR, ADRContribAbove, ADRContribBelow)
-	kastContrib := computeContribution(kastPct, BaselineKAST, KASTContribAbove, KASTContribBelow)
-	probSwingContrib := probSwingPerRound * ProbSwingContribMultiplier
-
-	rating := RatingBaseline + adrContrib + kastContrib + probSwingContrib + kprDprAdjustment
-	return math.Max(MinRating, math.Min(MaxRating, rating))
+	roundsF := float64(rounds)
+	return ComputeComponentRating(Components{
+		Rounds:        rounds,
+		Kills:         kills,
+		Deaths:        deaths,
+		ADR:           float64(damage) / roundsF,
+		KAST:          kast / roundsF,
+		SwingPerRound: probabilitySwing / roundsF,
+		Map:           mapName,
+		Tier:          tier,
+	}, kdprModifier)
 }
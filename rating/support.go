@@ -0,0 +1,116 @@
+package rating
+
+// Support profile baselines, per round. Like the pistol baselines these are
+// starting estimates for MR12 league play, to be recalibrated from the
+// archive as support data accumulates.
+const (
+	SupportBaselineUtilityDamage = 6.0  // Grenade damage per round
+	SupportBaselineFlashAssists  = 0.05 // Flash assists per round
+	SupportBaselineTrades        = 0.20 // Trade kills plus traded deaths per round
+)
+
+// Support profile weights: the rating change per unit above or below each
+// baseline. SupportKillDiscount takes back part of what kills above
+// BaselineKPR earned through swing, so a support player isn't ranked by
+// fragging they aren't asked to do.
+const (
+	SupportUtilityDamageWeight = 0.01 // Per point of utility damage per round
+	SupportFlashAssistWeight   = 1.0  // Per flash assist per round
+	SupportTradeWeight         = 0.5  // Per trade kill or traded death per round
+	SupportKillDiscount        = 0.25 // Per kill per round above BaselineKPR
+)
+
+// SupportDetectionRatio is how far above the support baselines, on average,
+// a player's utility damage, flash assists and trades must be for the
+// support profile to be picked for them, with KPR below BaselineKPR.
+const SupportDetectionRatio = 1.25
+
+// Support score baselines, per round, for the components the support
+// profile doesn't already have a baseline for. Starting estimates like the
+// ones above: trades split about evenly between trade kills and traded
+// deaths, and a team's opening death is traded in roughly a quarter of
+// rounds, shared among four teammates.
+const (
+	SupportBaselineTradedDeaths   = 0.10 // Deaths a teammate traded per round
+	SupportBaselineSavedTeammates = 0.10 // Kills avenging a teammate per round
+	SupportBaselineEntryTrades    = 0.03 // Kills trading a teammate's opening death per round
+)
+
+// ScoreComponentCap caps each component of the support and entry scores at
+// this many times its baseline, so one outlying number can't carry a score.
+const ScoreComponentCap = 3.0
+
+// SupportInput contains the statistics the support profile re-weights.
+type SupportInput struct {
+	Rating         float64 // Final rating the support profile starts from
+	RoundsPlayed   int
+	Kills          int
+	UtilityDamage  int
+	FlashAssists   int
+	TradeKills     int
+	TradedDeaths   int
+	SavedTeammates int // Support score only
+	EntryTrades    int // Support score only
+}
+
+// ComputeSupportRating re-weights a final rating for a support player:
+// utility damage, flash assists and trade participation above their
+// baselines add to it and kills above BaselineKPR count for less. A player
+// at every support baseline and BaselineKPR keeps their final rating.
+func ComputeSupportRating(input SupportInput) float64 {
+	if input.RoundsPlayed == 0 {
+		return 0
+	}
+	rounds := float64(input.RoundsPlayed)
+	kpr := float64(input.Kills) / rounds
+	udr := float64(input.UtilityDamage) / rounds
+	fapr := float64(input.FlashAssists) / rounds
+	tpr := float64(input.TradeKills+input.TradedDeaths) / rounds
+
+	r := input.Rating +
+		SupportUtilityDamageWeight*(udr-SupportBaselineUtilityDamage) +
+		SupportFlashAssistWeight*(fapr-SupportBaselineFlashAssists) +
+		SupportTradeWeight*(tpr-SupportBaselineTrades)
+	if kpr > BaselineKPR {
+		r -= SupportKillDiscount * (kpr - BaselineKPR)
+	}
+	return max(MinRating, min(MaxRating, r))
+}
+
+// IsSupportProfile reports whether a player's numbers fit the support
+// profile: utility damage, flash assists and trades averaging at least
+// SupportDetectionRatio times their baselines, on a KPR below BaselineKPR.
+func IsSupportProfile(input SupportInput) bool {
+	if input.RoundsPlayed == 0 {
+		return false
+	}
+	rounds := float64(input.RoundsPlayed)
+	if float64(input.Kills)/rounds >= BaselineKPR {
+		return false
+	}
+	ratio := (float64(input.UtilityDamage)/rounds/SupportBaselineUtilityDamage +
+		float64(input.FlashAssists)/rounds/SupportBaselineFlashAssists +
+		float64(input.TradeKills+input.TradedDeaths)/rounds/SupportBaselineTrades) / 3
+	return ratio >= SupportDetectionRatio
+}
+
+// ComputeSupportScore scores support play on its own, without the final
+// rating: the average of utility damage, flash assists, traded deaths,
+// saved teammates and entry trades per round, each relative to its baseline
+// and capped at ScoreComponentCap. A player at every baseline scores
+// 1.0. Traded deaths stand for playing close enough to be traded, saved
+// teammates and entry trades for doing the trading.
+func ComputeSupportScore(input SupportInput) float64 {
+	if input.RoundsPlayed == 0 {
+		return 0
+	}
+	rounds := float64(input.RoundsPlayed)
+	component := func(count, baseline float64) float64 {
+		return min(count/rounds/baseline, ScoreComponentCap)
+	}
+	return (component(float64(input.UtilityDamage), SupportBaselineUtilityDamage) +
+		component(float64(input.FlashAssists), SupportBaselineFlashAssists) +
+		component(float64(input.TradedDeaths), SupportBaselineTradedDeaths) +
+		component(float64(input.SavedTeammates), SupportBaselineSavedTeammates) +
+		component(float64(input.EntryTrades), SupportBaselineEntryTrades)) / 5
+}
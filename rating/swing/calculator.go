@@ -50,6 +50,8 @@ func (c *Calculator) CalculateRoundSwing(
 			c.processBombPlant(playerSwing, state, e)
 		case *BombDefuseEvent:
 			c.processBombDefuse(playerSwing, state, e)
+		case *DefuseDenialEvent:
+			c.processDefuseDenial(playerSwing, state, e)
 		case *BombExplodeEvent:
 			c.processBombExplode(playerSwing, state)
 		}
@@ -162,6 +164,18 @@ func (c *Calculator) processBombDefuse(
 	playerSwing[defuse.DefuserID] += delta * DefuseCreditShare
 }
 
+// processDefuseDenial handles a defuser killed mid-defuse. The denier gets
+// the defuser's share of the swing the defuse had earned; the kill itself is
+// processed separately.
+func (c *Calculator) processDefuseDenial(
+	playerSwing map[uint64]float64,
+	state *probability.RoundState,
+	denial *DefuseDenialEvent,
+) {
+	delta := c.probEngine.CalculateDefuseDenialSwing(state, denial.Progress)
+	playerSwing[denial.DenierID] += delta * DefuseCreditShare
+}
+
 // processBombExplode handles bomb explosion (no individual credit, T team wins).
 func (c *Calculator) processBombExplode(
 	playerSwing map[uint64]float64,
@@ -16,6 +16,7 @@ const (
 	EventBombPlant
 	EventBombDefuse
 	EventBombExplode
+	EventDefuseDenial
 )
 
 // KillEvent represents a kill during the round.
@@ -68,6 +69,18 @@ type BombDefuseEvent struct {
 func (e *BombDefuseEvent) GetTimeInRound() float64 { return e.TimeInRound }
 func (e *BombDefuseEvent) GetType() EventType      { return EventBombDefuse }
 
+// DefuseDenialEvent represents a defuser killed before the defuse finished,
+// with time left for it to finish.
+type DefuseDenialEvent struct {
+	TimeInRound float64
+	DenierID    uint64
+	DefuserID   uint64
+	Progress    float64 // Share of the defuse done when it was stopped, 0 to 1
+}
+
+func (e *DefuseDenialEvent) GetTimeInRound() float64 { return e.TimeInRound }
+func (e *DefuseDenialEvent) GetType() EventType      { return EventDefuseDenial }
+
 // BombExplodeEvent represents a bomb explosion.
 type BombExplodeEvent struct {
 	TimeInRound float64
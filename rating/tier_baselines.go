@@ -0,0 +1,68 @@
+package rating
+
+import "sync"
+
+var (
+	tierBaselinesMu sync.RWMutex
+	tierBaselines   map[string]Baselines
+)
+
+// SetTierBaselines replaces the per-tier baseline table, so each tier's
+// ratings center around 1.00 within that tier. Tiers missing from the table
+// are rated against the global baselines.
+func SetTierBaselines(table map[string]Baselines) {
+	tierBaselinesMu.Lock()
+	defer tierBaselinesMu.Unlock()
+	tierBaselines = make(map[string]Baselines, len(table))
+	for t, b := range table {
+		tierBaselines[t] = b
+	}
+}
+
+// TierBaselines returns the baselines for tier and whether the tier has its
+// own entry.
+func TierBaselines(tier string) (Baselines, bool) {
+	tierBaselinesMu.RLock()
+	defer tierBaselinesMu.RUnlock()
+	b, ok := tierBaselines[tier]
+	return b, ok
+}
+
+// ForGame returns the weights with their baselines set for a game on mapName
+// in tier. A tier entry replaces the global baselines and a map entry
+// replaces them for that map; with both, the map's baselines are scaled by
+// the tier's ratio to the global ones, so the map effect carries over into
+// every tier.
+func (w Weights) ForGame(mapName, tier string) Weights {
+	global := Baselines{KPR: w.BaselineKPR, DPR: w.BaselineDPR, ADR: w.BaselineADR, KAST: w.BaselineKAST}
+	b := global
+	m, hasMap := MapBaselines(mapName)
+	if hasMap {
+		b = m
+	}
+	if t, ok := TierBaselines(tier); ok {
+		if hasMap {
+			b = Baselines{
+				KPR:  scaleBaseline(m.KPR, t.KPR, global.KPR),
+				DPR:  scaleBaseline(m.DPR, t.DPR, global.DPR),
+				ADR:  scaleBaseline(m.ADR, t.ADR, global.ADR),
+				KAST: scaleBaseline(m.KAST, t.KAST, global.KAST),
+			}
+		} else {
+			b = t
+		}
+	}
+	w.BaselineKPR = b.KPR
+	w.BaselineDPR = b.DPR
+	w.BaselineADR = b.ADR
+	w.BaselineKAST = b.KAST
+	return w
+}
+
+// scaleBaseline scales a map baseline by a tier's ratio to the global one.
+func scaleBaseline(mapValue, tierValue, globalValue float64) float64 {
+	if globalValue == 0 {
+		return tierValue
+	}
+	return mapValue * tierValue / globalValue
+}
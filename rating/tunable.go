@@ -0,0 +1,123 @@
+package rating
+
+import (
+	"math"
+
+	"github.com/ethsmith/eco-rating/model"
+)
+
+// Weights holds the tunable constants of the final rating formula, so
+// alternative values can be tried without editing weights.go. DefaultWeights
+// returns the values the parser uses.
+type Weights struct {
+	BaselineADR                float64 `json:"baseline_adr"`
+	ADRContribAbove            float64 `json:"adr_contrib_above"`
+	ADRContribBelow            float64 `json:"adr_contrib_below"`
+	BaselineKAST               float64 `json:"baseline_kast"`
+	KASTContribAbove           float64 `json:"kast_contrib_above"`
+	KASTContribBelow           float64 `json:"kast_contrib_below"`
+	ProbSwingContribMultiplier float64 `json:"prob_swing_contrib_multiplier"`
+	BaselineKPR                float64 `json:"baseline_kpr"` // Only used with the KPR/DPR modifier
+	BaselineDPR                float64 `json:"baseline_dpr"` // Only used with the KPR/DPR modifier
+}
+
+// DefaultWeights returns the constants from weights.go.
+func DefaultWeights() Weights {
+	return Weights{
+		BaselineADR:                BaselineADR,
+		ADRContribAbove:            ADRContribAbove,
+		ADRContribBelow:            ADRContribBelow,
+		BaselineKAST:               BaselineKAST,
+		KASTContribAbove:           KASTContribAbove,
+		KASTContribBelow:           KASTContribBelow,
+		ProbSwingContribMultiplier: ProbSwingContribMultiplier,
+		BaselineKPR:                BaselineKPR,
+		BaselineDPR:                BaselineDPR,
+	}
+}
+
+// WeightField is a named, addressable weight.
+type WeightField struct {
+	Name  string
+	Value *float64
+}
+
+// Fields returns every weight by name, for code that varies them one at a
+// time.
+func (w *Weights) Fields() []WeightField {
+	return []WeightField{
+		{"BaselineADR", &w.BaselineADR},
+		{"ADRContribAbove", &w.ADRContribAbove},
+		{"ADRContribBelow", &w.ADRContribBelow},
+		{"BaselineKAST", &w.BaselineKAST},
+		{"KASTContribAbove", &w.KASTContribAbove},
+		{"KASTContribBelow", &w.KASTContribBelow},
+		{"ProbSwingContribMultiplier", &w.ProbSwingContribMultiplier},
+		{"BaselineKPR", &w.BaselineKPR},
+		{"BaselineDPR", &w.BaselineDPR},
+	}
+}
+
+// Rate applies the final rating formula with these weights.
+func (w Weights) Rate(c Components, kdprModifier bool) float64 {
+	return w.Breakdown(c, kdprModifier).FinalRating
+}
+
+// Breakdown applies the final rating formula with these weights and returns
+// each component's contribution alongside the result. The baselines are used
+// as given; c's map and tier don't select any (see ForGame).
+func (w Weights) Breakdown(c Components, kdprModifier bool) model.RatingBreakdown {
+	if c.Rounds == 0 {
+		return model.RatingBreakdown{}
+	}
+	rounds := float64(c.Rounds)
+
+	b := model.RatingBreakdown{
+		Baseline: RatingBaseline,
+		KPRDPR:   model.RatingComponent{Metric: "kpr_dpr", Notes: "KPR/DPR modifier disabled"},
+		ADR: model.RatingComponent{
+			Metric:       "adr",
+			Value:        c.ADR,
+			Baseline:     w.BaselineADR,
+			Multiplier:   contributionMultiplier(c.ADR, w.BaselineADR, w.ADRContribAbove, w.ADRContribBelow),
+			Contribution: computeContribution(c.ADR, w.BaselineADR, w.ADRContribAbove, w.ADRContribBelow),
+		},
+		KAST: model.RatingComponent{
+			Metric:       "kast",
+			Value:        c.KAST,
+			Baseline:     w.BaselineKAST,
+			Multiplier:   contributionMultiplier(c.KAST, w.BaselineKAST, w.KASTContribAbove, w.KASTContribBelow),
+			Contribution: computeContribution(c.KAST, w.BaselineKAST, w.KASTContribAbove, w.KASTContribBelow),
+		},
+		ProbabilitySwing: model.RatingComponent{
+			Metric:       "probability_swing_per_round",
+			Value:        c.SwingPerRound,
+			Multiplier:   w.ProbSwingContribMultiplier,
+			Contribution: c.SwingPerRound * w.ProbSwingContribMultiplier,
+		},
+		Formula: "baseline + adr + kast + probability_swing + kpr_dpr, clamped",
+	}
+	if kdprModifier {
+		kprAdj := exponentialAdjustment(float64(c.Kills)/rounds-w.BaselineKPR, 0.1, 5)
+		dprAdj := exponentialAdjustment(w.BaselineDPR-float64(c.Deaths)/rounds, 0.1, 5)
+		b.KPRDPR = model.RatingComponent{
+			Metric:       "kpr_dpr",
+			Value:        float64(c.Kills-c.Deaths) / rounds,
+			Contribution: kprAdj + dprAdj,
+			Notes:        "Capped exponential adjustments for KPR and DPR against their baselines",
+		}
+	}
+
+	b.UnclampedRating = RatingBaseline + b.ADR.Contribution + b.KAST.Contribution + b.ProbabilitySwing.Contribution + b.KPRDPR.Contribution
+	b.FinalRating = math.Max(MinRating, math.Min(MaxRating, b.UnclampedRating))
+	return b
+}
+
+// contributionMultiplier returns the multiplier computeContribution applies
+// to value.
+func contributionMultiplier(value, baseline, aboveMultiplier, belowMultiplier float64) float64 {
+	if value >= baseline {
+		return aboveMultiplier
+	}
+	return belowMultiplier
+}
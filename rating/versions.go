@@ -0,0 +1,68 @@
+package rating
+
+import "fmt"
+
+// Components are the per-game inputs the rating formulas use. They are what
+// the game archive stores for each player, so any registered version can be
+// replayed over past seasons.
+type Components struct {
+	Rounds        int
+	Kills         int
+	Deaths        int
+	ADR           float64
+	KAST          float64 // Fraction of rounds, 0-1
+	SwingPerRound float64 // Probability swing per round
+	Map           string  // Map played; selects per-map baselines in ComputeComponentRating
+	Tier          string  // Competitive tier; selects per-tier baselines in ComputeComponentRating
+}
+
+// Version is a named rating formula.
+type Version struct {
+	Name        string
+	Description string
+	Compute     func(Components) float64
+}
+
+var versions []Version
+
+// RegisterVersion adds a rating formula to the registry. Versions are listed
+// in registration order; registering a name twice panics.
+func RegisterVersion(v Version) {
+	for _, existing := range versions {
+		if existing.Name == v.Name {
+			panic(fmt.Sprintf("rating version %q registered twice", v.Name))
+		}
+	}
+	versions = append(versions, v)
+}
+
+// Versions returns every registered rating formula.
+func Versions() []Version {
+	return append([]Version(nil), versions...)
+}
+
+// VersionNames returns the names of every registered rating formula.
+func VersionNames() []string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func init() {
+	RegisterVersion(Version{
+		Name:        CurrentVersion,
+		Description: "Probability swing, ADR and KAST",
+		Compute: func(c Components) float64 {
+			return ComputeComponentRating(c, false)
+		},
+	})
+	RegisterVersion(Version{
+		Name:        "eco-3.0-kdpr",
+		Description: "eco-3.0 with the KPR/DPR adjustment",
+		Compute: func(c Components) float64 {
+			return ComputeComponentRating(c, true)
+		},
+	})
+}
@@ -100,15 +100,17 @@ const (
 	MultiKillContrib        = 0.005 // Multi-kill bonus contribution multiplier
 )
 
-// Trade detection constants - used in handlers.go for trade calculations.
+// Default trade detection parameters - the parser can override both per run.
 const (
-	TradeWindowTicks    = 320    // Trade window in ticks (5 seconds at 64 tick)
+	TradeWindowSeconds  = 5.0    // Trade window in seconds, converted to ticks at the demo's tick rate
 	TradeProximityUnits = 1200.0 // Maximum distance for trade opportunity (units)
+	FastTradeSeconds    = 2.0    // Trade kills faster than this count as fast trades
 )
 
 // Round context constants - used for round importance calculations.
 const (
 	LateRoundTimeThreshold = 30.0 // Time threshold for late bomb plant (seconds)
+	EarlyDeathSeconds      = 30.0 // Deaths before this many seconds into the round count as early deaths
 	ClutchDefuseThreshold  = 10.0 // Time threshold for clutch defuse (seconds)
 )
 
@@ -119,9 +121,14 @@ const (
 	RoundsPerHalf         = 12 // Rounds per half in regulation
 	RegulationRounds      = 24 // Total regulation rounds (MR12)
 	OvertimeLength        = 6  // Rounds per overtime (MR3)
-	TickRate              = 64 // Server tick rate for time calculations
+	TickRate              = 64 // Fallback tick rate for demos that don't report theirs
 )
 
+// IsOvertimeRound reports whether a round number falls after regulation.
+func IsOvertimeRound(roundNumber int) bool {
+	return roundNumber > RegulationRounds
+}
+
 // IsPistolRound determines if a round number is a pistol round.
 // Handles regulation and overtime pistol rounds for MR12 format.
 func IsPistolRound(roundNumber int) bool {
@@ -0,0 +1,191 @@
+// Package server exposes eco-rating data over a small REST API.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/ethsmith/eco-rating/archive"
+	"github.com/ethsmith/eco-rating/metrics"
+	"github.com/ethsmith/eco-rating/predict"
+	"github.com/ethsmith/eco-rating/rating"
+)
+
+// Server serves REST endpoints backed by a loaded set of player ratings and,
+// optionally, the demo index.
+type Server struct {
+	predictor *predict.Predictor
+	demos     *archive.DemoIndex
+	mux       *http.ServeMux
+}
+
+// NewServer creates a Server and registers its routes.
+func NewServer(predictor *predict.Predictor) *Server {
+	return NewServerWithOptions(predictor, nil)
+}
+
+// NewServerWithOptions creates a Server with optional backends. Routes are
+// only registered for the backends that are non-nil; GET /metrics, /healthz,
+// /readyz and /version are always served.
+func NewServerWithOptions(predictor *predict.Predictor, demos *archive.DemoIndex) *Server {
+	s := &Server{
+		predictor: predictor,
+		demos:     demos,
+		mux:       http.NewServeMux(),
+	}
+	s.mux.Handle("GET /metrics", metrics.Default.Handler())
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /readyz", s.handleReady)
+	s.mux.HandleFunc("GET /version", s.handleVersion)
+	if predictor != nil {
+		s.mux.HandleFunc("POST /predict", s.handlePredict)
+	}
+	if demos != nil {
+		s.mux.HandleFunc("GET /demos", s.handleListDemos)
+		s.mux.HandleFunc("GET /demos/{id...}", s.handleGetDemo)
+	}
+	return s
+}
+
+// Handler returns the HTTP handler for the server's routes.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe starts serving on addr and blocks until the server stops.
+func (s *Server) ListenAndServe(addr string) error {
+	log.Printf("Listening on %s", addr)
+	return http.ListenAndServe(addr, s.mux)
+}
+
+// handleHealth reports that the process is up and serving requests.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readiness is the body of a GET /readyz response.
+type Readiness struct {
+	Ready    bool            `json:"ready"`
+	Backends map[string]bool `json:"backends"` // Whether each data backend is loaded
+}
+
+// handleReady reports whether the server has data to serve: 200 when at least
+// one backend (ratings for /predict, the demo index for /demos) is loaded,
+// 503 otherwise.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ready := Readiness{
+		Ready: s.predictor != nil || s.demos != nil,
+		Backends: map[string]bool{
+			"predict": s.predictor != nil,
+			"demos":   s.demos != nil,
+		},
+	}
+	status := http.StatusOK
+	if !ready.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, ready)
+}
+
+// VersionInfo is the body of a GET /version response.
+type VersionInfo struct {
+	Version        string   `json:"version"`            // Module version, "(devel)" for local builds
+	Revision       string   `json:"revision,omitempty"` // VCS revision the binary was built from
+	Modified       bool     `json:"modified,omitempty"` // Built from a working tree with uncommitted changes
+	GoVersion      string   `json:"go_version"`         // Go toolchain the binary was built with
+	RatingVersion  string   `json:"rating_version"`     // Rating formula the parser's final rating follows
+	RatingVersions []string `json:"rating_versions"`    // Every registered rating formula
+	BaselinesHash  string   `json:"baselines_hash"`     // Fingerprint of the weights and baselines in effect
+}
+
+// handleVersion reports the build and the rating formula it serves.
+func (s *Server) handleVersion(w http.ResponseWriter, r *http.Request) {
+	info := VersionInfo{
+		RatingVersion:  rating.CurrentVersion,
+		RatingVersions: rating.VersionNames(),
+		BaselinesHash:  rating.BaselinesHash(),
+	}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Version = build.Main.Version
+		info.GoVersion = build.GoVersion
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Revision = setting.Value
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// handlePredict accepts a predict.Fixture as JSON and returns a predict.Prediction.
+func (s *Server) handlePredict(w http.ResponseWriter, r *http.Request) {
+	var fixture predict.Fixture
+	if err := json.NewDecoder(r.Body).Decode(&fixture); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid fixture: %w", err))
+		return
+	}
+	if len(fixture.Maps) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("fixture must list at least one map"))
+		return
+	}
+	writeJSON(w, http.StatusOK, s.predictor.Predict(fixture))
+}
+
+// handleListDemos returns demo index entries filtered by the q, map, team,
+// tier, since, and until (RFC 3339) query parameters.
+func (s *Server) handleListDemos(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := archive.DemoQuery{
+		Text: params.Get("q"),
+		Map:  params.Get("map"),
+		Team: params.Get("team"),
+		Tier: params.Get("tier"),
+	}
+	for name, dst := range map[string]*time.Time{"since": &q.Since, "until": &q.Until} {
+		if v := params.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s: %w", name, err))
+				return
+			}
+			*dst = t
+		}
+	}
+	demos := s.demos.Query(q)
+	if demos == nil {
+		demos = []archive.DemoEntry{}
+	}
+	writeJSON(w, http.StatusOK, demos)
+}
+
+// handleGetDemo returns the demo index entry for a match ID (the bucket key).
+func (s *Server) handleGetDemo(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	demo, ok := s.demos.Get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("demo %q not found", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, demo)
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// writeError writes an error as a JSON response.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
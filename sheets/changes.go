@@ -0,0 +1,171 @@
+package sheets
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Thresholds for flagging a changed cell. A change is flagged only when it is
+// both a large factor and far outside how the rest of its column moved, so a
+// column that shifts as a whole, such as after a rating recalibration, isn't
+// flagged cell by cell.
+const (
+	alertMinFactor = 2.0 // New value at least this many times the old, or at most its inverse
+	alertMinScore  = 4.0 // Robust z-score of the log change within its column
+	alertMinRows   = 5   // Changed rows a column needs before its spread means anything
+	alertMinSpread = 0.1 // Floor on a column's log-change spread, for columns that barely move
+)
+
+// ChangeAlert is a cell whose new value jumped improbably far from the one
+// uploaded before, the kind of jump a parsing regression causes.
+type ChangeAlert struct {
+	Sheet  string
+	Key    string // The row's key values, joined with " / "
+	Column string
+	Old    string
+	New    string
+	Score  float64 // Robust z-score of the change within its column
+}
+
+// DetectChanges compares table with what the tab named sheet currently holds
+// and returns improbable jumps, matching rows by key and columns by header.
+// A missing or empty tab has nothing to compare against.
+func DetectChanges(svc Service, sheet string, table Table) ([]ChangeAlert, error) {
+	found, err := hasSheet(svc, sheet)
+	if err != nil || !found {
+		return nil, err
+	}
+	existing, err := svc.Values(quoteSheet(sheet))
+	if err != nil {
+		return nil, err
+	}
+	return changeAlerts(sheet, existing, table)
+}
+
+// changeAlerts flags the rate columns of table whose values moved improbably
+// far from existing. Only columns whose new values are decimals are compared:
+// counts are written as integers and grow with every game played.
+func changeAlerts(sheet string, existing [][]string, table Table) ([]ChangeAlert, error) {
+	if len(existing) < 2 {
+		return nil, nil
+	}
+	newKey, err := keyFunc(table.Header, table.Keys)
+	if err != nil {
+		return nil, err
+	}
+	oldKey, err := keyFunc(existing[0], table.Keys)
+	if err != nil {
+		// The tab was last written with other columns; nothing lines up
+		return nil, nil
+	}
+	rowOf := make(map[string]int, len(existing))
+	for r := 1; r < len(existing); r++ {
+		if key := oldKey(existing[r]); key != "" {
+			rowOf[key] = r
+		}
+	}
+	columnOf := make(map[string]int, len(existing[0]))
+	for c, h := range existing[0] {
+		columnOf[h] = c
+	}
+	isKey := make(map[string]bool, len(table.Keys))
+	for _, k := range table.Keys {
+		isKey[k] = true
+	}
+
+	var alerts []ChangeAlert
+	for c, name := range table.Header {
+		oldColumn, ok := columnOf[name]
+		if !ok || isKey[name] || !decimalColumn(table.Rows, c) {
+			continue
+		}
+
+		type change struct {
+			row      []string
+			old, new string
+			log      float64
+		}
+		var changes []change
+		for _, row := range table.Rows {
+			r, ok := rowOf[newKey(row)]
+			if !ok {
+				continue
+			}
+			old, cur := cellAt(existing[r], oldColumn), cellAt(row, c)
+			a, errA := strconv.ParseFloat(old, 64)
+			b, errB := strconv.ParseFloat(cur, 64)
+			if errA != nil || errB != nil || a <= 0 || b <= 0 || a == b {
+				continue
+			}
+			changes = append(changes, change{row: row, old: old, new: cur, log: math.Log(b / a)})
+		}
+		if len(changes) < alertMinRows {
+			continue
+		}
+
+		logs := make([]float64, len(changes))
+		for i, ch := range changes {
+			logs[i] = ch.log
+		}
+		center := median(logs)
+		for i := range logs {
+			logs[i] = math.Abs(logs[i] - center)
+		}
+		spread := math.Max(1.4826*median(logs), alertMinSpread)
+
+		for _, ch := range changes {
+			score := math.Abs(ch.log-center) / spread
+			if score < alertMinScore || math.Abs(ch.log) < math.Log(alertMinFactor) {
+				continue
+			}
+			alerts = append(alerts, ChangeAlert{
+				Sheet:  sheet,
+				Key:    displayKey(table, ch.row),
+				Column: name,
+				Old:    ch.old,
+				New:    ch.new,
+				Score:  score,
+			})
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool { return alerts[i].Score > alerts[j].Score })
+	return alerts, nil
+}
+
+// decimalColumn reports whether any row's value in column c is a decimal.
+func decimalColumn(rows [][]string, c int) bool {
+	for _, row := range rows {
+		if strings.Contains(cellAt(row, c), ".") {
+			if _, err := strconv.ParseFloat(cellAt(row, c), 64); err == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// displayKey joins row's key values for reading, e.g. "76561198012345678 / contender".
+func displayKey(table Table, row []string) string {
+	parts := make([]string, 0, len(table.Keys))
+	for _, k := range table.Keys {
+		for i, h := range table.Header {
+			if h == k {
+				parts = append(parts, cellAt(row, i))
+				break
+			}
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+// median returns the median of values, reordering them.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,472 @@
+// Package sheets uploads stats tables to spreadsheets. Uploads go through
+// the Service interface; Client implements it for Google Sheets through the
+// Sheets REST API, authenticating with a service account key.
+package sheets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiBase is the Sheets API endpoint for spreadsheets.
+const apiBase = "https://sheets.googleapis.com/v4/spreadsheets/"
+
+// Client reads and writes one Google spreadsheet. It implements Service.
+type Client struct {
+	SpreadsheetID string
+	http          *http.Client
+	scheduler     *scheduler
+}
+
+// ClientOptions configures a Client.
+type ClientOptions struct {
+	// Credentials are service account key files, each with edit access to
+	// the spreadsheet. Keys from separate Google Cloud projects each bring
+	// their own quota; requests are spread across them round-robin.
+	Credentials []string
+	// RequestsPerMinute paces each credential's requests (0 = no pacing).
+	RequestsPerMinute int
+}
+
+// NewClient creates a client for spreadsheetID using the service account
+// key at credentialsPath, paced to the API's default quota. The service
+// account needs edit access to the spreadsheet.
+func NewClient(credentialsPath, spreadsheetID string) (*Client, error) {
+	return NewClientWithOptions(spreadsheetID, ClientOptions{
+		Credentials:       []string{credentialsPath},
+		RequestsPerMinute: DefaultRequestsPerMinute,
+	})
+}
+
+// NewClientWithOptions creates a client for spreadsheetID spreading requests
+// over several service accounts.
+func NewClientWithOptions(spreadsheetID string, opts ClientOptions) (*Client, error) {
+	if len(opts.Credentials) == 0 {
+		return nil, fmt.Errorf("no spreadsheet credentials configured")
+	}
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	projects := make([]*project, 0, len(opts.Credentials))
+	for _, path := range opts.Credentials {
+		tokens, err := newTokenSource(path, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, &project{tokens: tokens})
+	}
+	return &Client{
+		SpreadsheetID: spreadsheetID,
+		http:          httpClient,
+		scheduler:     newScheduler(projects, opts.RequestsPerMinute),
+	}, nil
+}
+
+// ValueRange is a block of cell values written starting at Range's top-left
+// cell, in A1 notation.
+type ValueRange struct {
+	Range  string     `json:"range"`
+	Values [][]string `json:"values"`
+}
+
+// sheetProperties are a tab's ID, title and grid size.
+type sheetProperties struct {
+	SheetID        int    `json:"sheetId"`
+	Title          string `json:"title"`
+	GridProperties struct {
+		RowCount    int `json:"rowCount"`
+		ColumnCount int `json:"columnCount"`
+	} `json:"gridProperties"`
+}
+
+// properties returns every tab's properties, in tab order.
+func (c *Client) properties() ([]sheetProperties, error) {
+	var resp struct {
+		Sheets []struct {
+			Properties sheetProperties `json:"properties"`
+		} `json:"sheets"`
+	}
+	query := url.Values{"fields": {"sheets.properties(sheetId,title,gridProperties)"}}
+	if err := c.do(http.MethodGet, "", query, nil, &resp); err != nil {
+		return nil, err
+	}
+	props := make([]sheetProperties, 0, len(resp.Sheets))
+	for _, s := range resp.Sheets {
+		props = append(props, s.Properties)
+	}
+	return props, nil
+}
+
+// property returns the properties of the tab named title.
+func (c *Client) property(title string) (sheetProperties, error) {
+	props, err := c.properties()
+	if err != nil {
+		return sheetProperties{}, err
+	}
+	for _, p := range props {
+		if p.Title == title {
+			return p, nil
+		}
+	}
+	return sheetProperties{}, fmt.Errorf("no sheet %q", title)
+}
+
+// SheetTitles returns the titles of the spreadsheet's tabs.
+func (c *Client) SheetTitles() ([]string, error) {
+	props, err := c.properties()
+	if err != nil {
+		return nil, err
+	}
+	titles := make([]string, 0, len(props))
+	for _, p := range props {
+		titles = append(titles, p.Title)
+	}
+	return titles, nil
+}
+
+// AddSheet adds an empty tab named title.
+func (c *Client) AddSheet(title string) error {
+	req := map[string]any{"requests": []any{
+		map[string]any{"addSheet": map[string]any{"properties": map[string]any{"title": title}}},
+	}}
+	return c.do(http.MethodPost, ":batchUpdate", nil, req, nil)
+}
+
+// DeleteSheet removes the tab named title.
+func (c *Client) DeleteSheet(title string) error {
+	p, err := c.property(title)
+	if err != nil {
+		return err
+	}
+	req := map[string]any{"requests": []any{
+		map[string]any{"deleteSheet": map[string]any{"sheetId": p.SheetID}},
+	}}
+	return c.do(http.MethodPost, ":batchUpdate", nil, req, nil)
+}
+
+// CopySheet replaces the cells of tab to with those of tab from in a single
+// batch update, which the API applies atomically: to is grown to fit, its
+// values cleared and from's values and formulas pasted in. Formatting of to
+// is kept.
+func (c *Client) CopySheet(from, to string) error {
+	src, err := c.property(from)
+	if err != nil {
+		return err
+	}
+	dst, err := c.property(to)
+	if err != nil {
+		return err
+	}
+	grid := dst.GridProperties
+	grid.RowCount = max(grid.RowCount, src.GridProperties.RowCount)
+	grid.ColumnCount = max(grid.ColumnCount, src.GridProperties.ColumnCount)
+	req := map[string]any{"requests": []any{
+		map[string]any{"updateSheetProperties": map[string]any{
+			"properties": map[string]any{"sheetId": dst.SheetID, "gridProperties": grid},
+			"fields":     "gridProperties(rowCount,columnCount)",
+		}},
+		map[string]any{"updateCells": map[string]any{
+			"range":  map[string]any{"sheetId": dst.SheetID},
+			"fields": "userEnteredValue",
+		}},
+		map[string]any{"copyPaste": map[string]any{
+			"source": map[string]any{"sheetId": src.SheetID},
+			"destination": map[string]any{
+				"sheetId":          dst.SheetID,
+				"startRowIndex":    0,
+				"endRowIndex":      1,
+				"startColumnIndex": 0,
+				"endColumnIndex":   1,
+			},
+			"pasteType": "PASTE_FORMULA",
+		}},
+	}}
+	return c.do(http.MethodPost, ":batchUpdate", nil, req, nil)
+}
+
+// Format freezes f.FrozenRows, sets two-decimal number formats, adds a
+// red-white-green color scale per column and the row colors, sets the header
+// notes and groups columns, in one batch update. The color scales and row
+// colors an earlier Format added, told apart from users' rules by their
+// shape and colors, and the tab's column groups are removed first so
+// repeated uploads don't stack them.
+func (c *Client) Format(sheet string, f Format) error {
+	var resp struct {
+		Sheets []struct {
+			Properties         sheetProperties   `json:"properties"`
+			ConditionalFormats []conditionalRule `json:"conditionalFormats"`
+			ColumnGroups       []dimensionGroup  `json:"columnGroups"`
+		} `json:"sheets"`
+	}
+	query := url.Values{"fields": {"sheets(properties(sheetId,title),conditionalFormats,columnGroups)"}}
+	if err := c.do(http.MethodGet, "", query, nil, &resp); err != nil {
+		return err
+	}
+	sheetID, found := 0, false
+	var rules []conditionalRule
+	var groups []dimensionGroup
+	for _, s := range resp.Sheets {
+		if s.Properties.Title == sheet {
+			sheetID, found, rules, groups = s.Properties.SheetID, true, s.ConditionalFormats, s.ColumnGroups
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no sheet %q", sheet)
+	}
+
+	column := func(c int) map[string]any {
+		return map[string]any{"sheetId": sheetID, "startRowIndex": f.FrozenRows, "startColumnIndex": c, "endColumnIndex": c + 1}
+	}
+	requests := []any{
+		map[string]any{"updateSheetProperties": map[string]any{
+			"properties": map[string]any{"sheetId": sheetID, "gridProperties": map[string]any{"frozenRowCount": f.FrozenRows}},
+			"fields":     "gridProperties.frozenRowCount",
+		}},
+	}
+	for _, c := range f.DecimalColumns {
+		requests = append(requests, map[string]any{"repeatCell": map[string]any{
+			"range":  column(c),
+			"cell":   map[string]any{"userEnteredFormat": map[string]any{"numberFormat": map[string]any{"type": "NUMBER", "pattern": "0.00"}}},
+			"fields": "userEnteredFormat.numberFormat",
+		}})
+	}
+	// Delete from the end so earlier indices stay valid
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].isColumnScale(f.FrozenRows) || rules[i].isRowColor(f.FrozenRows) {
+			requests = append(requests, map[string]any{"deleteConditionalFormatRule": map[string]any{"sheetId": sheetID, "index": i}})
+		}
+	}
+	// Each rule goes in first, so add the row colors last to first and the
+	// color scales after them, ahead of every row color
+	for i := len(f.RowColors) - 1; i >= 0; i-- {
+		rc := f.RowColors[i]
+		requests = append(requests, map[string]any{"addConditionalFormatRule": map[string]any{
+			"index": 0,
+			"rule": map[string]any{
+				"ranges": []any{map[string]any{"sheetId": sheetID, "startRowIndex": f.FrozenRows}},
+				"booleanRule": map[string]any{
+					"condition": map[string]any{
+						"type":   "CUSTOM_FORMULA",
+						"values": []any{map[string]any{"userEnteredValue": rc.formula(f.FrozenRows)}},
+					},
+					"format": map[string]any{"backgroundColor": hexColor(rc.Color)},
+				},
+			},
+		}})
+	}
+	for _, c := range f.ColorScaleColumns {
+		requests = append(requests, map[string]any{"addConditionalFormatRule": map[string]any{
+			"index": 0,
+			"rule": map[string]any{
+				"ranges": []any{column(c)},
+				"gradientRule": map[string]any{
+					"minpoint": map[string]any{"type": "MIN", "color": scaleLow},
+					"midpoint": map[string]any{"type": "NUMBER", "value": strconv.FormatFloat(f.ColorScaleMidpoint, 'f', -1, 64), "color": scaleMid},
+					"maxpoint": map[string]any{"type": "MAX", "color": scaleHigh},
+				},
+			},
+		}})
+	}
+	if len(f.HeaderNotes) > 0 {
+		cells := make([]any, len(f.HeaderNotes))
+		for i, note := range f.HeaderNotes {
+			cells[i] = map[string]any{"note": note}
+		}
+		requests = append(requests, map[string]any{"updateCells": map[string]any{
+			"range":  map[string]any{"sheetId": sheetID, "startRowIndex": 0, "endRowIndex": 1, "startColumnIndex": 0, "endColumnIndex": len(cells)},
+			"rows":   []any{map[string]any{"values": cells}},
+			"fields": "note",
+		}})
+	}
+	// Each delete lowers the group depth over its range by one, so deleting
+	// every listed group clears nested groups too
+	for _, g := range groups {
+		requests = append(requests, map[string]any{"deleteDimensionGroup": map[string]any{"range": g.Range}})
+	}
+	for _, g := range f.ColumnGroups {
+		requests = append(requests, map[string]any{"addDimensionGroup": map[string]any{
+			"range": map[string]any{"sheetId": sheetID, "dimension": "COLUMNS", "startIndex": g.Start, "endIndex": g.End},
+		}})
+	}
+	return c.do(http.MethodPost, ":batchUpdate", nil, map[string]any{"requests": requests}, nil)
+}
+
+// DeleteRows removes the rows, in ascending order, from the tab named sheet in
+// one batch update. Rows are deleted bottom up so the earlier indices stay
+// valid.
+func (c *Client) DeleteRows(sheet string, rows []int) error {
+	p, err := c.property(sheet)
+	if err != nil {
+		return err
+	}
+	requests := make([]any, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		requests = append(requests, map[string]any{"deleteDimension": map[string]any{
+			"range": map[string]any{"sheetId": p.SheetID, "dimension": "ROWS", "startIndex": rows[i], "endIndex": rows[i] + 1},
+		}})
+	}
+	return c.do(http.MethodPost, ":batchUpdate", nil, map[string]any{"requests": requests}, nil)
+}
+
+// Values returns the cells in rng. Formula cells are returned as their
+// formula and numbers unformatted, with every digit the sheet holds, so they
+// compare cleanly with the values an upload writes. Trailing empty cells and
+// rows are omitted.
+func (c *Client) Values(rng string) ([][]string, error) {
+	var resp struct {
+		Values [][]any `json:"values"`
+	}
+	query := url.Values{"valueRenderOption": {"FORMULA"}}
+	if err := c.do(http.MethodGet, "/values/"+url.PathEscape(rng), query, nil, &resp); err != nil {
+		return nil, err
+	}
+	rows := make([][]string, len(resp.Values))
+	for i, row := range resp.Values {
+		rows[i] = make([]string, len(row))
+		for j, v := range row {
+			rows[i][j] = cellString(v)
+		}
+	}
+	return rows, nil
+}
+
+// Clear empties every cell in rng, keeping formatting.
+func (c *Client) Clear(rng string) error {
+	return c.do(http.MethodPost, "/values/"+url.PathEscape(rng)+":clear", nil, map[string]any{}, nil)
+}
+
+// UpdateValues writes every range in one request. Values are entered as if
+// typed into the sheet, so numbers stay numeric and the exports' HYPERLINK
+// formulas evaluate, but every other cell is quoted as text (see
+// enteredCell).
+func (c *Client) UpdateValues(ranges []ValueRange) error {
+	if len(ranges) == 0 {
+		return nil
+	}
+	data := make([]ValueRange, len(ranges))
+	for i, r := range ranges {
+		values := make([][]string, len(r.Values))
+		for j, row := range r.Values {
+			values[j] = make([]string, len(row))
+			for k, cell := range row {
+				values[j][k] = enteredCell(cell)
+			}
+		}
+		data[i] = ValueRange{Range: r.Range, Values: values}
+	}
+	req := map[string]any{"valueInputOption": "USER_ENTERED", "data": data}
+	return c.do(http.MethodPost, "/values:batchUpdate", nil, req, nil)
+}
+
+// hyperlinkFormula matches the HYPERLINK formulas the exports write, with
+// the quotes in the URL and label doubled.
+var hyperlinkFormula = regexp.MustCompile(`^=HYPERLINK\("(?:[^"]|"")*","(?:[^"]|"")*"\)$`)
+
+// enteredCell returns how value is typed into the sheet. Numbers the sheet
+// holds exactly, booleans and HYPERLINK formulas are entered as they are.
+// Anything else is prefixed with a quote, which keeps it text: a Steam ID
+// isn't rounded to a number, a date isn't reformatted, and a player name
+// starting with "=", "+", "-" or "@" isn't evaluated as a formula.
+func enteredCell(value string) string {
+	if _, ok := plainNumber(value); ok {
+		return value
+	}
+	switch {
+	case value == "", value == "TRUE", value == "FALSE", hyperlinkFormula.MatchString(value):
+		return value
+	}
+	return "'" + value
+}
+
+// do sends an authenticated request for the spreadsheet and decodes the
+// JSON response into out when out is non-nil. Requests rejected for quota
+// (429) are retried, on another credential when one is free.
+func (c *Client) do(method, path string, query url.Values, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+	u := apiBase + url.PathEscape(c.SpreadsheetID) + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		p := c.scheduler.acquire()
+		token, err := p.tokens.Token()
+		if err != nil {
+			return err
+		}
+		var reader io.Reader
+		if data != nil {
+			reader = bytes.NewReader(data)
+		}
+		req, err := http.NewRequest(method, u, reader)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return fmt.Errorf("sheets request failed: %w", err)
+		}
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < maxQuotaRetries {
+			c.scheduler.backOff(p, quotaWait(resp, attempt))
+			resp.Body.Close()
+			continue
+		}
+		return decodeResponse(resp, out)
+	}
+}
+
+// decodeResponse checks a response's status and decodes its JSON body into
+// out when out is non-nil, closing the body.
+func decodeResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sheets request returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	// Numbers decoded into untyped cells keep every digit (see cellString)
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("failed to decode sheets response: %w", err)
+	}
+	return nil
+}
+
+// cellString converts a cell value from a values response to a string.
+func cellString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,177 @@
+package sheets
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CSVDir is a Service that keeps each tab as a CSV file in a directory, for
+// previewing uploads without a Google spreadsheet. Tabs are loaded on first
+// use and written back after every change.
+type CSVDir struct {
+	Dir    string
+	memory *Memory
+}
+
+// NewCSVDir creates a CSV-backed spreadsheet in dir.
+func NewCSVDir(dir string) *CSVDir {
+	return &CSVDir{Dir: dir}
+}
+
+// SheetTitles returns the tab titles, one per CSV file.
+func (c *CSVDir) SheetTitles() ([]string, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c.memory.SheetTitles()
+}
+
+// AddSheet adds an empty tab and its file.
+func (c *CSVDir) AddSheet(title string) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.AddSheet(title); err != nil {
+		return err
+	}
+	return c.save(title)
+}
+
+// Values returns the cells in rng.
+func (c *CSVDir) Values(rng string) ([][]string, error) {
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c.memory.Values(rng)
+}
+
+// Clear empties every cell in rng.
+func (c *CSVDir) Clear(rng string) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.Clear(rng); err != nil {
+		return err
+	}
+	g, _ := parseRange(rng)
+	return c.save(g.Sheet)
+}
+
+// UpdateValues writes each range's values and saves the changed tabs.
+func (c *CSVDir) UpdateValues(ranges []ValueRange) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.UpdateValues(ranges); err != nil {
+		return err
+	}
+	saved := make(map[string]bool)
+	for _, vr := range ranges {
+		g, _ := parseRange(vr.Range)
+		if saved[g.Sheet] {
+			continue
+		}
+		saved[g.Sheet] = true
+		if err := c.save(g.Sheet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteSheet removes a tab and its file.
+func (c *CSVDir) DeleteSheet(title string) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.DeleteSheet(title); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(c.Dir, title+".csv")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete sheet: %w", err)
+	}
+	return nil
+}
+
+// DeleteRows removes the rows from a tab and saves it.
+func (c *CSVDir) DeleteRows(sheet string, rows []int) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.DeleteRows(sheet, rows); err != nil {
+		return err
+	}
+	return c.save(sheet)
+}
+
+// CopySheet replaces tab to's cells with tab from's and saves it.
+func (c *CSVDir) CopySheet(from, to string) error {
+	if err := c.load(); err != nil {
+		return err
+	}
+	if err := c.memory.CopySheet(from, to); err != nil {
+		return err
+	}
+	return c.save(to)
+}
+
+// load reads every CSV file in the directory the first time it's called.
+func (c *CSVDir) load() error {
+	if c.memory != nil {
+		return nil
+	}
+	memory := NewMemory()
+	paths, err := filepath.Glob(filepath.Join(c.Dir, "*.csv"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open sheet: %w", err)
+		}
+		r := csv.NewReader(file)
+		r.FieldsPerRecord = -1
+		grid, err := r.ReadAll()
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read sheet %s: %w", path, err)
+		}
+		title := strings.TrimSuffix(filepath.Base(path), ".csv")
+		memory.AddSheet(title)
+		memory.tabs[title] = grid
+	}
+	c.memory = memory
+	return nil
+}
+
+// save writes a tab back to its CSV file.
+func (c *CSVDir) save(title string) error {
+	if strings.ContainsAny(title, `/\`) {
+		return fmt.Errorf("sheet title %q can't be used as a file name", title)
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sheet directory: %w", err)
+	}
+	// Write to a temporary file and rename it so a crash never leaves a
+	// half-written tab
+	path := filepath.Join(c.Dir, title+".csv")
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	w := csv.NewWriter(file)
+	if err := w.WriteAll(c.memory.Sheet(title)); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	return os.Rename(path+".tmp", path)
+}
@@ -0,0 +1,167 @@
+package sheets
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format is the presentation applied to a tab after an upload, so the sheet
+// reads well without formatting it by hand.
+type Format struct {
+	FrozenRows         int        // Header rows kept in view while scrolling
+	DecimalColumns     []int      // 0-based columns shown with two decimals below the header
+	ColorScaleColumns  []int      // 0-based columns colored red (low) through green (high)
+	ColorScaleMidpoint float64    // Value colored white on the color scale
+	HeaderNotes        []string   // Note attached to each header cell, "" for none
+	ColumnGroups       []Span     // Collapsible column groups; must not touch each other
+	RowColors          []RowColor // Row background colors below the header; the first match wins
+}
+
+// RowColor colors the rows whose cell in Column equals Value. An empty Value
+// matches every row with anything in Column, for a default color listed
+// last.
+type RowColor struct {
+	Column int
+	Value  string
+	Color  string // Hex RGB, e.g. "#F4CCCC"
+}
+
+// formula returns the custom formula matching r's rows, written for the
+// first row below frozenRows header rows: =$C2="premier".
+func (r RowColor) formula(frozenRows int) string {
+	cell := "$" + ColumnLetter(r.Column) + strconv.Itoa(frozenRows+1)
+	if r.Value == "" {
+		return "=" + cell + `<>""`
+	}
+	return "=" + cell + `="` + strings.ReplaceAll(r.Value, `"`, `""`) + `"`
+}
+
+// rowColorFormula matches the formulas RowColor.formula writes.
+var rowColorFormula = regexp.MustCompile(`^=\$[A-Z]+[0-9]+(=".*"|<>"")$`)
+
+// hexColor converts "#RRGGBB" to the API's color components; anything else
+// is white.
+func hexColor(hex string) color {
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(hex) != 7 {
+		return color{Red: 1, Green: 1, Blue: 1}
+	}
+	return color{
+		Red:   float64(v>>16&0xFF) / 255,
+		Green: float64(v>>8&0xFF) / 255,
+		Blue:  float64(v&0xFF) / 255,
+	}
+}
+
+// Span is a run of 0-based columns, End exclusive.
+type Span struct {
+	Start, End int
+}
+
+// Formatter is implemented by backends that can format tabs.
+type Formatter interface {
+	// Format applies f to the tab named sheet, replacing the formatting a
+	// previous Format call applied.
+	Format(sheet string, f Format) error
+}
+
+// ApplyFormat formats the tab named sheet when svc can; backends holding only
+// values, such as CSVDir, are left as they are.
+func ApplyFormat(svc Service, sheet string, f Format) error {
+	formatter, ok := svc.(Formatter)
+	if !ok {
+		return nil
+	}
+	return formatter.Format(sheet, f)
+}
+
+// Colors of the rating color scale, matching the Sheets preset red-white-green
+// scale.
+var (
+	scaleLow  = color{Red: 0.902, Green: 0.486, Blue: 0.451}
+	scaleMid  = color{Red: 1, Green: 1, Blue: 1}
+	scaleHigh = color{Red: 0.341, Green: 0.733, Blue: 0.541}
+)
+
+// color is an RGB color in the Sheets API's 0-1 components.
+type color struct {
+	Red   float64 `json:"red"`
+	Green float64 `json:"green"`
+	Blue  float64 `json:"blue"`
+}
+
+// dimensionGroup is a row or column group of a tab, as the API returns it.
+type dimensionGroup struct {
+	Range struct {
+		SheetID    int    `json:"sheetId"`
+		Dimension  string `json:"dimension"`
+		StartIndex int    `json:"startIndex"`
+		EndIndex   int    `json:"endIndex"`
+	} `json:"range"`
+}
+
+// conditionalRule is the part of a tab's conditional format rule needed to
+// recognize the color scales Format adds.
+type conditionalRule struct {
+	Ranges []struct {
+		StartRowIndex    int `json:"startRowIndex"`
+		StartColumnIndex int `json:"startColumnIndex"`
+		EndColumnIndex   int `json:"endColumnIndex"`
+	} `json:"ranges"`
+	GradientRule *struct {
+		Minpoint interpolationPoint `json:"minpoint"`
+		Midpoint interpolationPoint `json:"midpoint"`
+		Maxpoint interpolationPoint `json:"maxpoint"`
+	} `json:"gradientRule"`
+	BooleanRule *struct {
+		Condition struct {
+			Type   string `json:"type"`
+			Values []struct {
+				UserEnteredValue string `json:"userEnteredValue"`
+			} `json:"values"`
+		} `json:"condition"`
+	} `json:"booleanRule"`
+}
+
+// interpolationPoint is one end or the middle of a color scale.
+type interpolationPoint struct {
+	Type  string `json:"type"`
+	Color color  `json:"color"`
+}
+
+// is reports whether p has the given type and, to the 8-bit precision the
+// API may round to, color.
+func (p interpolationPoint) is(kind string, c color) bool {
+	const tolerance = 1.0 / 255
+	return p.Type == kind &&
+		math.Abs(p.Color.Red-c.Red) <= tolerance &&
+		math.Abs(p.Color.Green-c.Green) <= tolerance &&
+		math.Abs(p.Color.Blue-c.Blue) <= tolerance
+}
+
+// isColumnScale reports whether r is a color scale Format added: one column
+// below frozenRows header rows, scaled from the minimum through a number to
+// the maximum in the scale colors. Color scales users add by hand are kept.
+func (r conditionalRule) isColumnScale(frozenRows int) bool {
+	if r.GradientRule == nil || len(r.Ranges) != 1 {
+		return false
+	}
+	rng := r.Ranges[0]
+	if rng.StartRowIndex != frozenRows || rng.EndColumnIndex-rng.StartColumnIndex != 1 {
+		return false
+	}
+	g := r.GradientRule
+	return g.Minpoint.is("MIN", scaleLow) && g.Midpoint.is("NUMBER", scaleMid) && g.Maxpoint.is("MAX", scaleHigh)
+}
+
+// isRowColor reports whether r colors whole rows below frozenRows header
+// rows by a formula RowColor writes, the shape of the row colors Format adds.
+func (r conditionalRule) isRowColor(frozenRows int) bool {
+	if r.BooleanRule == nil || len(r.Ranges) != 1 || r.Ranges[0].StartRowIndex != frozenRows {
+		return false
+	}
+	cond := r.BooleanRule.Condition
+	return cond.Type == "CUSTOM_FORMULA" && len(cond.Values) == 1 && rowColorFormula.MatchString(cond.Values[0].UserEnteredValue)
+}
@@ -0,0 +1,184 @@
+package sheets
+
+import "fmt"
+
+// Memory is an in-memory Service, holding each tab as a grid of cells that
+// Sheet returns. CSVDir keeps its tabs in one between file writes.
+type Memory struct {
+	tabs    map[string][][]string
+	order   []string
+	formats map[string]Format
+}
+
+// NewMemory creates an empty in-memory spreadsheet.
+func NewMemory() *Memory {
+	return &Memory{tabs: make(map[string][][]string), formats: make(map[string]Format)}
+}
+
+// Sheet returns a copy of the tab's cells, or nil if there is no such tab.
+func (m *Memory) Sheet(title string) [][]string {
+	grid, ok := m.tabs[title]
+	if !ok {
+		return nil
+	}
+	out := make([][]string, len(grid))
+	for i, row := range grid {
+		out[i] = append([]string(nil), row...)
+	}
+	return out
+}
+
+// Formatting returns the format last applied to the tab.
+func (m *Memory) Formatting(title string) Format {
+	return m.formats[title]
+}
+
+// Format records f as the tab's format.
+func (m *Memory) Format(sheet string, f Format) error {
+	if _, ok := m.tabs[sheet]; !ok {
+		return fmt.Errorf("no sheet %q", sheet)
+	}
+	m.formats[sheet] = f
+	return nil
+}
+
+// SheetTitles returns the tab titles in the order they were added.
+func (m *Memory) SheetTitles() ([]string, error) {
+	return append([]string(nil), m.order...), nil
+}
+
+// AddSheet adds an empty tab named title.
+func (m *Memory) AddSheet(title string) error {
+	if _, ok := m.tabs[title]; ok {
+		return fmt.Errorf("sheet %q already exists", title)
+	}
+	m.tabs[title] = nil
+	m.order = append(m.order, title)
+	return nil
+}
+
+// DeleteSheet removes the tab named title.
+func (m *Memory) DeleteSheet(title string) error {
+	if _, ok := m.tabs[title]; !ok {
+		return fmt.Errorf("no sheet %q", title)
+	}
+	delete(m.tabs, title)
+	delete(m.formats, title)
+	for i, t := range m.order {
+		if t == title {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// DeleteRows removes the rows, in ascending order, from the tab.
+func (m *Memory) DeleteRows(sheet string, rows []int) error {
+	grid, ok := m.tabs[sheet]
+	if !ok {
+		return fmt.Errorf("no sheet %q", sheet)
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if r := rows[i]; r < len(grid) {
+			grid = append(grid[:r], grid[r+1:]...)
+		}
+	}
+	m.tabs[sheet] = grid
+	return nil
+}
+
+// CopySheet replaces tab to's cells with a copy of tab from's.
+func (m *Memory) CopySheet(from, to string) error {
+	if _, ok := m.tabs[from]; !ok {
+		return fmt.Errorf("no sheet %q", from)
+	}
+	if _, ok := m.tabs[to]; !ok {
+		return fmt.Errorf("no sheet %q", to)
+	}
+	m.tabs[to] = m.Sheet(from)
+	return nil
+}
+
+// Values returns the cells in rng with trailing empty cells and rows
+// trimmed, as the Sheets API does.
+func (m *Memory) Values(rng string) ([][]string, error) {
+	g, grid, err := m.lookup(rng)
+	if err != nil {
+		return nil, err
+	}
+	endRow := len(grid)
+	if g.EndRow >= 0 && g.EndRow < endRow {
+		endRow = g.EndRow
+	}
+	var rows [][]string
+	for r := g.StartRow; r < endRow; r++ {
+		row := grid[r]
+		endColumn := len(row)
+		if g.EndColumn >= 0 && g.EndColumn < endColumn {
+			endColumn = g.EndColumn
+		}
+		var cells []string
+		if g.StartColumn < endColumn {
+			cells = append(cells, row[g.StartColumn:endColumn]...)
+		}
+		for len(cells) > 0 && cells[len(cells)-1] == "" {
+			cells = cells[:len(cells)-1]
+		}
+		rows = append(rows, cells)
+	}
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+// Clear empties every cell in rng.
+func (m *Memory) Clear(rng string) error {
+	g, grid, err := m.lookup(rng)
+	if err != nil {
+		return err
+	}
+	for r := g.StartRow; r < len(grid) && (g.EndRow < 0 || r < g.EndRow); r++ {
+		for c := g.StartColumn; c < len(grid[r]) && (g.EndColumn < 0 || c < g.EndColumn); c++ {
+			grid[r][c] = ""
+		}
+	}
+	return nil
+}
+
+// UpdateValues writes each range's values starting at its top-left cell,
+// growing the tab as needed.
+func (m *Memory) UpdateValues(ranges []ValueRange) error {
+	for _, vr := range ranges {
+		g, grid, err := m.lookup(vr.Range)
+		if err != nil {
+			return err
+		}
+		for i, values := range vr.Values {
+			r := g.StartRow + i
+			for len(grid) <= r {
+				grid = append(grid, nil)
+			}
+			for len(grid[r]) < g.StartColumn+len(values) {
+				grid[r] = append(grid[r], "")
+			}
+			copy(grid[r][g.StartColumn:], values)
+		}
+		m.tabs[g.Sheet] = grid
+	}
+	return nil
+}
+
+// lookup parses rng and returns its tab's grid.
+func (m *Memory) lookup(rng string) (gridRange, [][]string, error) {
+	g, err := parseRange(rng)
+	if err != nil {
+		return g, nil, err
+	}
+	grid, ok := m.tabs[g.Sheet]
+	if !ok {
+		return g, nil, fmt.Errorf("no sheet %q", g.Sheet)
+	}
+	return g, grid, nil
+}
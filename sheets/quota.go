@@ -0,0 +1,90 @@
+package sheets
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultRequestsPerMinute matches the Sheets API's default per-user,
+// per-project quota for reads and for writes.
+const DefaultRequestsPerMinute = 60
+
+// maxQuotaRetries is how many times a request rejected for quota is retried.
+const maxQuotaRetries = 6
+
+// project is one service account, usually in its own Google Cloud project
+// with its own quota, and when it may next send a request.
+type project struct {
+	tokens *tokenSource
+	next   time.Time
+}
+
+// scheduler spreads requests over projects round-robin, pacing each to its
+// per-minute quota and backing a project off when the API reports it has
+// run out.
+type scheduler struct {
+	mu       sync.Mutex
+	projects []*project
+	interval time.Duration // Minimum gap between one project's requests
+	turn     int
+	now      func() time.Time
+	sleep    func(time.Duration)
+}
+
+// newScheduler paces each project to requestsPerMinute; 0 disables pacing.
+func newScheduler(projects []*project, requestsPerMinute int) *scheduler {
+	s := &scheduler{projects: projects, now: time.Now, sleep: time.Sleep}
+	if requestsPerMinute > 0 {
+		s.interval = time.Minute / time.Duration(requestsPerMinute)
+	}
+	return s
+}
+
+// acquire waits for the project that can send soonest, taking projects in
+// turn when several are free, and reserves its next slot.
+func (s *scheduler) acquire() *project {
+	s.mu.Lock()
+	best := -1
+	for i := range s.projects {
+		idx := (s.turn + i) % len(s.projects)
+		if best < 0 || s.projects[idx].next.Before(s.projects[best].next) {
+			best = idx
+		}
+	}
+	p := s.projects[best]
+	s.turn = best + 1
+	now := s.now()
+	start := p.next
+	if start.Before(now) {
+		start = now
+	}
+	p.next = start.Add(s.interval)
+	s.mu.Unlock()
+
+	if wait := start.Sub(now); wait > 0 {
+		s.sleep(wait)
+	}
+	return p
+}
+
+// backOff keeps p idle for wait after the API rejected its request for quota,
+// so the next requests go to other projects.
+func (s *scheduler) backOff(p *project, wait time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if until := s.now().Add(wait); until.After(p.next) {
+		p.next = until
+	}
+}
+
+// quotaWait returns how long to hold off after a 429 response: the
+// Retry-After header when the API sends one, otherwise an exponential
+// backoff from one second.
+func quotaWait(resp *http.Response, attempt int) time.Duration {
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	return time.Second << attempt
+}
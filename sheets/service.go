@@ -0,0 +1,123 @@
+package sheets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Service is a spreadsheet backend an upload writes to. Ranges are in A1
+// notation with a quoted tab title, e.g. 'Stats'!B3. Client implements it
+// for Google Sheets, Memory in memory and CSVDir as one CSV file per tab.
+type Service interface {
+	// SheetTitles returns the titles of the spreadsheet's tabs.
+	SheetTitles() ([]string, error)
+	// AddSheet adds an empty tab named title.
+	AddSheet(title string) error
+	// Values returns the cells in rng as strings, formulas as written.
+	// Trailing empty cells and rows may be omitted.
+	Values(rng string) ([][]string, error)
+	// Clear empties every cell in rng.
+	Clear(rng string) error
+	// UpdateValues writes each range's values starting at its top-left cell.
+	UpdateValues(ranges []ValueRange) error
+	// DeleteSheet removes the tab named title.
+	DeleteSheet(title string) error
+	// CopySheet replaces every cell of tab to with tab from's, all at once.
+	CopySheet(from, to string) error
+	// DeleteRows removes the 0-based rows of tab sheet, given in ascending
+	// order, moving the rows below them up.
+	DeleteRows(sheet string, rows []int) error
+}
+
+// EnsureSheet adds a tab named title to svc unless it already has one.
+func EnsureSheet(svc Service, title string) error {
+	found, err := hasSheet(svc, title)
+	if err != nil || found {
+		return err
+	}
+	return svc.AddSheet(title)
+}
+
+// hasSheet reports whether svc has a tab named title.
+func hasSheet(svc Service, title string) (bool, error) {
+	titles, err := svc.SheetTitles()
+	if err != nil {
+		return false, err
+	}
+	for _, t := range titles {
+		if t == title {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// gridRange is a parsed A1 range. Rows and columns are 0-based and the ends
+// exclusive; an end of -1 means the range runs to the edge of the sheet.
+type gridRange struct {
+	Sheet                  string
+	StartRow, EndRow       int
+	StartColumn, EndColumn int
+}
+
+// parseRange parses the A1 forms uploads use: 'Tab', 'Tab'!B3, 'Tab'!A:HO
+// and 'Tab'!A1:C4.
+func parseRange(rng string) (gridRange, error) {
+	sheet, cells, _ := strings.Cut(rng, "!")
+	if strings.HasPrefix(rng, "'") {
+		// Quoted titles may contain '!', so split after the closing quote
+		end := strings.LastIndex(rng, "'")
+		sheet = strings.ReplaceAll(rng[1:end], "''", "'")
+		cells = strings.TrimPrefix(rng[end+1:], "!")
+	}
+	g := gridRange{Sheet: sheet, EndRow: -1, EndColumn: -1}
+	if cells == "" {
+		return g, nil
+	}
+
+	start, end, hasEnd := strings.Cut(cells, ":")
+	row, column, err := parseCell(start)
+	if err != nil {
+		return g, fmt.Errorf("invalid range %q: %w", rng, err)
+	}
+	g.StartColumn = column
+	if row >= 0 {
+		g.StartRow = row
+	}
+	if !hasEnd {
+		// A single cell is where a write starts; it runs as far as its values
+		return g, nil
+	}
+	row, column, err = parseCell(end)
+	if err != nil {
+		return g, fmt.Errorf("invalid range %q: %w", rng, err)
+	}
+	g.EndColumn = column + 1
+	if row >= 0 {
+		g.EndRow = row + 1
+	}
+	return g, nil
+}
+
+// parseCell parses a cell like "B3" or a bare column like "B" into 0-based
+// row and column indices; a bare column has row -1.
+func parseCell(cell string) (row, column int, err error) {
+	i := 0
+	column = 0
+	for i < len(cell) && cell[i] >= 'A' && cell[i] <= 'Z' {
+		column = column*26 + int(cell[i]-'A'+1)
+		i++
+	}
+	if i == 0 {
+		return 0, 0, fmt.Errorf("cell %q has no column", cell)
+	}
+	if i == len(cell) {
+		return -1, column - 1, nil
+	}
+	n, err := strconv.Atoi(cell[i:])
+	if err != nil || n < 1 {
+		return 0, 0, fmt.Errorf("cell %q has an invalid row", cell)
+	}
+	return n - 1, column - 1, nil
+}
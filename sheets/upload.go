@@ -0,0 +1,293 @@
+package sheets
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// batchRows is the most rows written in one request.
+const batchRows = 1000
+
+// stagingSuffix names the tab a replace upload is written to before it is
+// copied over the real tab.
+const stagingSuffix = " (staging)"
+
+// Mode selects how an upload writes a table into a sheet.
+type Mode string
+
+const (
+	// ModeReplace rewrites the whole sheet with the table, via a staging tab.
+	ModeReplace Mode = "replace"
+	// ModeUpsert matches rows by key, rewrites only cells whose values
+	// changed, appends new rows, deletes rows no longer in the table, and
+	// leaves everything to the right of the exported columns alone.
+	ModeUpsert Mode = "upsert"
+)
+
+// ValidMode reports whether mode is a known upload mode.
+func ValidMode(mode string) bool {
+	return Mode(mode) == ModeReplace || Mode(mode) == ModeUpsert
+}
+
+// Table is a header row and its data rows. Keys name the header columns that
+// identify a row across uploads, e.g. Steam ID and Tier.
+type Table struct {
+	Header []string
+	Rows   [][]string
+	Keys   []string
+}
+
+// UploadSummary counts what an upload wrote.
+type UploadSummary struct {
+	UpdatedCells int // Cells rewritten in rows already in the sheet
+	AppendedRows int // Rows added below the existing ones
+	RemovedRows  int // Rows deleted because the table no longer has them
+}
+
+// Upload writes table to the tab named sheet of svc, creating the tab if
+// needed.
+func Upload(svc Service, sheet string, table Table, mode Mode) (UploadSummary, error) {
+	if err := EnsureSheet(svc, sheet); err != nil {
+		return UploadSummary{}, err
+	}
+
+	if mode == ModeReplace {
+		if err := replaceViaStaging(svc, sheet, table); err != nil {
+			return UploadSummary{}, err
+		}
+		return UploadSummary{AppendedRows: len(table.Rows)}, nil
+	}
+
+	lastColumn := ColumnLetter(len(table.Header) - 1)
+	existing, err := svc.Values(quoteSheet(sheet) + "!A:" + lastColumn)
+	if err != nil {
+		return UploadSummary{}, err
+	}
+	updates, stale, summary, err := planUpsert(sheet, existing, table)
+	if err != nil {
+		return UploadSummary{}, err
+	}
+	if err := updateInBatches(svc, updates); err != nil {
+		return UploadSummary{}, err
+	}
+	if len(stale) > 0 {
+		if err := svc.DeleteRows(sheet, stale); err != nil {
+			return UploadSummary{}, err
+		}
+	}
+	return summary, nil
+}
+
+// replaceViaStaging writes table to a staging tab in batches and, once every
+// batch has landed, copies it over sheet in one atomic step. A failure part
+// way through leaves sheet as it was; the next upload starts the staging
+// tab over.
+func replaceViaStaging(svc Service, sheet string, table Table) error {
+	staging := sheet + stagingSuffix
+	if err := EnsureSheet(svc, staging); err != nil {
+		return err
+	}
+	if err := svc.Clear(quoteSheet(staging)); err != nil {
+		return err
+	}
+
+	values := append([][]string{table.Header}, table.Rows...)
+	var ranges []ValueRange
+	for start := 0; start < len(values); start += batchRows {
+		end := min(start+batchRows, len(values))
+		ranges = append(ranges, ValueRange{Range: cellRange(staging, start, 0), Values: values[start:end]})
+	}
+	if err := updateInBatches(svc, ranges); err != nil {
+		return fmt.Errorf("staging upload of %s failed, sheet left unchanged: %w", sheet, err)
+	}
+
+	if err := svc.CopySheet(staging, sheet); err != nil {
+		return err
+	}
+	return svc.DeleteSheet(staging)
+}
+
+// updateInBatches writes ranges in requests of at most batchRows rows, so a
+// large upload never exceeds the API's request size.
+func updateInBatches(svc Service, ranges []ValueRange) error {
+	var batch []ValueRange
+	rows := 0
+	for _, r := range ranges {
+		if rows+len(r.Values) > batchRows && len(batch) > 0 {
+			if err := svc.UpdateValues(batch); err != nil {
+				return err
+			}
+			batch, rows = nil, 0
+		}
+		batch = append(batch, r)
+		rows += len(r.Values)
+	}
+	return svc.UpdateValues(batch)
+}
+
+// planUpsert compares table with the sheet's existing exported columns and
+// returns the ranges to write: changed cells of rows already in the sheet,
+// the header if it changed, and new rows appended below the last row. Rows
+// keep their position, so notes users add beside a row stay with it. It also
+// returns the rows whose key the table no longer has, to delete once the
+// ranges are written. A sheet whose header lacks a key column, such as one
+// written before the column was added, can't be matched: all its rows are
+// replaced.
+func planUpsert(sheet string, existing [][]string, table Table) ([]ValueRange, []int, UploadSummary, error) {
+	var summary UploadSummary
+	if len(existing) == 0 {
+		values := append([][]string{table.Header}, table.Rows...)
+		summary.AppendedRows = len(table.Rows)
+		return []ValueRange{{Range: cellRange(sheet, 0, 0), Values: values}}, nil, summary, nil
+	}
+
+	newKey, err := keyFunc(table.Header, table.Keys)
+	if err != nil {
+		return nil, nil, summary, err
+	}
+	current := make(map[string]bool, len(table.Rows))
+	for _, row := range table.Rows {
+		current[newKey(row)] = true
+	}
+	rowOf := make(map[string]int, len(existing))
+	var stale []int
+	if oldKey, err := keyFunc(existing[0], table.Keys); err == nil {
+		for r := 1; r < len(existing); r++ {
+			key := oldKey(existing[r])
+			if key == "" {
+				continue
+			}
+			if !current[key] {
+				stale = append(stale, r)
+			} else if _, dup := rowOf[key]; !dup {
+				rowOf[key] = r
+			}
+		}
+	} else {
+		for r := 1; r < len(existing); r++ {
+			stale = append(stale, r)
+		}
+	}
+	summary.RemovedRows = len(stale)
+
+	var updates []ValueRange
+	changed := func(r int, row []string) {
+		old := existing[r]
+		for c := 0; c < len(row); {
+			if sameCell(cellAt(old, c), row[c]) {
+				c++
+				continue
+			}
+			start := c
+			for c < len(row) && !sameCell(cellAt(old, c), row[c]) {
+				c++
+			}
+			updates = append(updates, ValueRange{Range: cellRange(sheet, r, start), Values: [][]string{row[start:c]}})
+			summary.UpdatedCells += c - start
+		}
+	}
+
+	changed(0, table.Header)
+	var appended [][]string
+	for _, row := range table.Rows {
+		if r, ok := rowOf[newKey(row)]; ok {
+			changed(r, row)
+			continue
+		}
+		appended = append(appended, row)
+	}
+	if len(appended) > 0 {
+		updates = append(updates, ValueRange{Range: cellRange(sheet, len(existing), 0), Values: appended})
+		summary.AppendedRows = len(appended)
+	}
+	return updates, stale, summary, nil
+}
+
+// keyFunc returns a function building a row's key from the key columns of
+// header. Every key column must be in header.
+func keyFunc(header, keys []string) (func([]string) string, error) {
+	indices := make([]int, 0, len(keys))
+	for _, k := range keys {
+		idx := -1
+		for i, h := range header {
+			if h == k {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("key column %q not in header", k)
+		}
+		indices = append(indices, idx)
+	}
+	return func(row []string) string {
+		parts := make([]string, len(indices))
+		for i, idx := range indices {
+			parts[i] = cellAt(row, idx)
+		}
+		return strings.Join(parts, "\x00")
+	}, nil
+}
+
+// cellAt returns row[i], or "" past the end of a row the API trimmed.
+func cellAt(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+// sameCell reports whether a sheet cell already holds value. Numbers compare
+// by value, since the sheet returns "1.23" for a written "1.230". Longer
+// digit strings, such as Steam IDs, must match exactly.
+func sameCell(cell, value string) bool {
+	if cell == value {
+		return true
+	}
+	a, okA := plainNumber(cell)
+	b, okB := plainNumber(value)
+	return okA && okB && math.Abs(a-b) < 1e-9
+}
+
+// maxExactDigits is the most significant digits a sheet number holds
+// exactly, as a float64.
+const maxExactDigits = 15
+
+// plainNumber parses a decimal number of at most maxExactDigits significant
+// digits, such as "-0.25" or "42". Exponents, hex and longer numbers aren't
+// plain: the sheet would round them or enter them differently.
+func plainNumber(s string) (float64, bool) {
+	digits := strings.TrimPrefix(s, "-")
+	intPart, frac, _ := strings.Cut(digits, ".")
+	if intPart == "" || strings.Trim(intPart+frac, "0123456789") != "" {
+		return 0, false
+	}
+	if significant := strings.TrimLeft(intPart+frac, "0"); len(significant) > maxExactDigits {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+// ColumnLetter returns the A1 letters of the 0-based column index: 0 is A,
+// 26 is AA.
+func ColumnLetter(index int) string {
+	letters := ""
+	for index >= 0 {
+		letters = string(rune('A'+index%26)) + letters
+		index = index/26 - 1
+	}
+	return letters
+}
+
+// quoteSheet quotes a tab title for use in A1 notation.
+func quoteSheet(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// cellRange returns the A1 reference of the 0-based row and column in sheet.
+func cellRange(sheet string, row, column int) string {
+	return quoteSheet(sheet) + "!" + ColumnLetter(column) + strconv.Itoa(row+1)
+}